@@ -178,9 +178,15 @@ func createPrecompileHook(abifilename string, template string) bind.BindHook {
 	}
 }
 
+// allowListEnabled returns true if [funcs] contains readAllowList and the setter of each role.
+// The setters with an expiry are optional, so that existing ABIs are still detected.
 func allowListEnabled(funcs map[string]*bind.TmplMethod) bool {
-	for key := range allowlist.AllowListABI.Methods {
-		if _, ok := funcs[key]; !ok {
+	if _, ok := funcs["readAllowList"]; !ok {
+		return false
+	}
+	for _, role := range []allowlist.Role{allowlist.NoRole, allowlist.EnabledRole, allowlist.ManagerRole, allowlist.AdminRole} {
+		setter, _ := role.GetSetterFunctionName()
+		if _, ok := funcs[setter]; !ok {
 			return false
 		}
 	}
@@ -475,7 +475,7 @@ var bindTests = []struct {
 			wrappedStateDB := extstate.New(statedb)
 			address := common.BigToAddress(big.NewInt(1))
 			SetHelloWorldAllowListStatus(wrappedStateDB, address, allowlist.EnabledRole)
-			role := GetHelloWorldAllowListStatus(wrappedStateDB, address)
+			role := GetHelloWorldAllowListStatus(wrappedStateDB, address, false, 0)
 			require.Equal(t, role, allowlist.EnabledRole)
 		`,
 		"",
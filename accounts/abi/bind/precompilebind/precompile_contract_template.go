@@ -124,9 +124,9 @@ type {{capitalise .Normalized.Name}}Output struct{
 {{- end}}
 
 {{if .Contract.AllowList}}
-// Get{{.Contract.Type}}AllowListStatus returns the role of [address] for the {{.Contract.Type}} list.
-func Get{{.Contract.Type}}AllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// Get{{.Contract.Type}}AllowListStatus returns the role of [address] for the {{.Contract.Type}} list at [timestamp].
+func Get{{.Contract.Type}}AllowListStatus(stateDB contract.StateDB, address common.Address, isHelicon bool, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, isHelicon, timestamp)
 }
 
 // Set{{.Contract.Type}}AllowListStatus sets the permissions of [address] to [role] for the
@@ -255,7 +255,7 @@ func {{decapitalise .Normalized.Name}}(accessibleState contract.AccessibleState,
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannot{{.Normalized.Name}}, caller)
 	}
@@ -306,7 +306,7 @@ func {{decapitalise $contract.Type}}Fallback (accessibleState contract.Accessibl
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", Err{{$contract.Type}}CannotFallback, caller)
 	}
@@ -6,6 +6,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,25 +30,59 @@ const (
 	BatchSizeKey      = "batch-size"
 	MetricsPortKey    = "metrics-port"
 	MetricsOutputKey  = "metrics-output"
+	WorkloadKey       = "workload"
+	StorageSlotsKey   = "storage-slots"
 )
 
 var (
 	ErrNoEndpoints = errors.New("must specify at least one endpoint")
 	ErrNoWorkers   = errors.New("must specify non-zero number of workers")
 	ErrNoTxs       = errors.New("must specify non-zero number of txs-per-worker")
+	ErrNoWorkload  = errors.New("must specify at least one workload")
 )
 
 type Config struct {
-	Endpoints     []string      `json:"endpoints"`
-	MaxFeeCap     int64         `json:"max-fee-cap"`
-	MaxTipCap     int64         `json:"max-tip-cap"`
-	Workers       int           `json:"workers"`
-	TxsPerWorker  uint64        `json:"txs-per-worker"`
-	KeyDir        string        `json:"key-dir"`
-	Timeout       time.Duration `json:"timeout"`
-	BatchSize     uint64        `json:"batch-size"`
-	MetricsPort   uint64        `json:"metrics-port"`
-	MetricsOutput string        `json:"metrics-output"`
+	Endpoints     []string        `json:"endpoints"`
+	MaxFeeCap     int64           `json:"max-fee-cap"`
+	MaxTipCap     int64           `json:"max-tip-cap"`
+	Workers       int             `json:"workers"`
+	TxsPerWorker  uint64          `json:"txs-per-worker"`
+	KeyDir        string          `json:"key-dir"`
+	Timeout       time.Duration   `json:"timeout"`
+	BatchSize     uint64          `json:"batch-size"`
+	MetricsPort   uint64          `json:"metrics-port"`
+	MetricsOutput string          `json:"metrics-output"`
+	Workload      []WorkloadShare `json:"workload"`
+	StorageSlots  uint64          `json:"storage-slots"`
+}
+
+// WorkloadShare is the weight of a type of workload in the transactions of
+// each worker.
+type WorkloadShare struct {
+	Name   string
+	Weight uint64
+}
+
+// ParseWorkload parses workload types, each optionally followed by "=weight",
+// e.g. "transfer=3". Types without a weight have a weight of 1.
+func ParseWorkload(shares []string) ([]WorkloadShare, error) {
+	if len(shares) == 0 {
+		return nil, ErrNoWorkload
+	}
+	workload := make([]WorkloadShare, 0, len(shares))
+	for _, share := range shares {
+		name, weightStr, hasWeight := strings.Cut(strings.TrimSpace(share), "=")
+		weight := uint64(1)
+		if hasWeight {
+			var err error
+			weight, err = strconv.ParseUint(weightStr, 10, 64)
+			if err != nil || weight == 0 {
+				return nil, fmt.Errorf("invalid weight %q of workload %q: must be a positive integer", weightStr, name)
+			}
+		}
+		workload = append(workload, WorkloadShare{Name: name, Weight: weight})
+	}
+	return workload, nil
 }
 
 func BuildConfig(v *viper.Viper) (Config, error) {
@@ -62,7 +97,13 @@ func BuildConfig(v *viper.Viper) (Config, error) {
 		BatchSize:     v.GetUint64(BatchSizeKey),
 		MetricsPort:   v.GetUint64(MetricsPortKey),
 		MetricsOutput: v.GetString(MetricsOutputKey),
+		StorageSlots:  v.GetUint64(StorageSlotsKey),
+	}
+	workload, err := ParseWorkload(v.GetStringSlice(WorkloadKey))
+	if err != nil {
+		return c, err
 	}
+	c.Workload = workload
 	if len(c.Endpoints) == 0 {
 		return c, ErrNoEndpoints
 	}
@@ -126,4 +167,6 @@ func addSimulatorFlags(fs *pflag.FlagSet) {
 	fs.Uint64(BatchSizeKey, 100, "Specify the batchsize for the worker to issue and confirm txs")
 	fs.Uint64(MetricsPortKey, 8082, "Specify the port to use for the metrics server")
 	fs.String(MetricsOutputKey, "", "Specify the file to write metrics in json format, or empty to write to stdout (defaults to stdout)")
+	fs.StringSlice(WorkloadKey, []string{"transfer"}, "Specify a comma separated list of workload types, each optionally followed by =weight for its share of the transactions (transfer, erc20, deploy, storage, native-minter, fee-manager, warp)")
+	fs.Uint64(StorageSlotsKey, 10, "Specify the number of new storage slots written by each transaction of the storage workload")
 }
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorkload(t *testing.T) {
+	tests := []struct {
+		name    string
+		shares  []string
+		want    []WorkloadShare
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			wantErr: ErrNoWorkload,
+		},
+		{
+			name:   "default weight",
+			shares: []string{"transfer"},
+			want:   []WorkloadShare{{Name: "transfer", Weight: 1}},
+		},
+		{
+			name:   "weights",
+			shares: []string{"transfer=3", " erc20 ", "warp=2"},
+			want: []WorkloadShare{
+				{Name: "transfer", Weight: 3},
+				{Name: "erc20", Weight: 1},
+				{Name: "warp", Weight: 2},
+			},
+		},
+		{
+			name:   "zero weight",
+			shares: []string{"transfer=0"},
+		},
+		{
+			name:   "negative weight",
+			shares: []string{"transfer=-1"},
+		},
+		{
+			name:   "missing weight",
+			shares: []string{"transfer="},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseWorkload(test.shares)
+			switch {
+			case test.wantErr != nil:
+				require.ErrorIs(t, err, test.wantErr)
+			case test.want == nil:
+				require.ErrorContains(t, err, "must be a positive integer")
+			default:
+				require.NoError(t, err)
+				require.Equal(t, test.want, got)
+			}
+		})
+	}
+}
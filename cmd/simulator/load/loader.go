@@ -6,10 +6,13 @@ package load
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"syscall"
 	"time"
@@ -27,13 +30,14 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 
 	ethcrypto "github.com/ava-labs/libevm/crypto"
-	ethparams "github.com/ava-labs/libevm/params"
 )
 
 const (
 	MetricsEndpoint = "/metrics" // Endpoint for the Prometheus Metrics Server
 )
 
+var errFailedTxs = errors.New("transactions of workloads failed")
+
 // Loader executes a series of worker/tx sequence pairs.
 // Each worker/txSequence pair issues [batchSize] transactions, confirms all
 // of them as accepted, and then moves to the next batch until the txSequence
@@ -183,10 +187,20 @@ func ExecuteLoader(ctx context.Context, config config.Config) error {
 		}
 	}
 
-	// Each address needs: params.GWei * MaxFeeCap * ethparams.TxGas * TxsPerWorker total wei
-	// to fund gas for all of their transactions.
+	mix, err := newWorkloadMix(config.Workload, config.StorageSlots)
+	if err != nil {
+		return err
+	}
+	deployments := mix.deployments()
+	setupGas := uint64(0)
+	for range deployments {
+		setupGas += deployGas
+	}
+
+	// Each address needs: params.GWei * MaxFeeCap * (maxGas * TxsPerWorker + setupGas)
+	// total wei to fund gas for all of their transactions.
 	maxFeeCap := new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(config.MaxFeeCap))
-	minFundsPerAddr := new(big.Int).Mul(maxFeeCap, big.NewInt(int64(config.TxsPerWorker*ethparams.TxGas)))
+	minFundsPerAddr := new(big.Int).Mul(maxFeeCap, new(big.Int).SetUint64(config.TxsPerWorker*mix.maxGas()+setupGas))
 	fundStart := time.Now()
 	log.Info("Distributing funds", "numTxsPerWorker", config.TxsPerWorker, "minFunds", minFundsPerAddr)
 	keys, err = DistributeFunds(ctx, clients[0], keys, config.Workers, minFundsPerAddr, m)
@@ -210,19 +224,81 @@ func ExecuteLoader(ctx context.Context, config config.Config) error {
 	}
 	signer := types.LatestSignerForChainID(chainID)
 
+	workers := make([]txs.Worker[*types.Transaction], 0, len(clients))
+	for i, client := range clients {
+		workers = append(workers, NewSingleAddressTxWorker(client, ethcrypto.PubkeyToAddress(pks[i].PublicKey)))
+	}
+
+	// Each worker deploys the contracts called by its workloads.
+	contracts := make(map[common.Address]map[*workload]common.Address)
+	if len(deployments) > 0 {
+		log.Info("Deploying workload contracts...", "numContracts", len(deployments))
+		deployStart := time.Now()
+		deployGenerator := func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+			addr := ethcrypto.PubkeyToAddress(key.PublicKey)
+			if contracts[addr] == nil {
+				contracts[addr] = make(map[*workload]common.Address)
+			}
+			w := deployments[len(contracts[addr])]
+			contracts[addr][w] = ethcrypto.CreateAddress(addr, nonce)
+			return types.SignNewTx(key, signer, &types.DynamicFeeTx{
+				ChainID:   chainID,
+				Nonce:     nonce,
+				GasTipCap: gasTipCap,
+				GasFeeCap: gasFeeCap,
+				Gas:       deployGas,
+				To:        nil,
+				Data:      w.initCode,
+				Value:     common.Big0,
+			})
+		}
+		deploySequences, err := txs.GenerateTxSequences(ctx, deployGenerator, clients[0], pks, uint64(len(deployments)), false)
+		if err != nil {
+			return err
+		}
+		// The deployments are not part of the load, so their times are not
+		// reported.
+		deployMetrics := metrics.NewDefaultMetrics()
+		if err := New(workers, deploySequences, config.BatchSize, deployMetrics).Execute(ctx); err != nil {
+			return err
+		}
+		for addr, deployed := range contracts {
+			for w, contract := range deployed {
+				code, err := client.CodeAt(ctx, contract, nil)
+				if err != nil {
+					return fmt.Errorf("failed to fetch code of %s contract %s: %w", w.name, contract, err)
+				}
+				if len(code) == 0 {
+					return fmt.Errorf("failed to deploy %s contract of %s", w.name, addr)
+				}
+			}
+		}
+		log.Info("Deployed workload contracts successfully", "time", time.Since(deployStart))
+	}
+
 	log.Info("Creating transaction sequences...")
 	txGenerator := func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
 		addr := ethcrypto.PubkeyToAddress(key.PublicKey)
-		return types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		w := mix.next(nonce)
+		to, data, err := w.call(contracts[addr][w], addr, nonce)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s tx: %w", w.name, err)
+		}
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
 			ChainID:   chainID,
 			Nonce:     nonce,
 			GasTipCap: gasTipCap,
 			GasFeeCap: gasFeeCap,
-			Gas:       ethparams.TxGas,
-			To:        &addr,
-			Data:      nil,
+			Gas:       w.gas,
+			To:        to,
+			Data:      data,
 			Value:     common.Big0,
 		})
+		if err != nil {
+			return nil, err
+		}
+		m.SetTxType(tx.Hash(), w.name)
+		return tx, nil
 	}
 	txSequenceStart := time.Now()
 	txSequences, err := txs.GenerateTxSequences(ctx, txGenerator, clients[0], pks, config.TxsPerWorker, false)
@@ -231,15 +307,27 @@ func ExecuteLoader(ctx context.Context, config config.Config) error {
 	}
 	log.Info("Created transaction sequences successfully", "time", time.Since(txSequenceStart))
 
-	workers := make([]txs.Worker[*types.Transaction], 0, len(clients))
-	for i, client := range clients {
-		workers = append(workers, NewSingleAddressTxWorker(client, ethcrypto.PubkeyToAddress(pks[i].PublicKey)))
-	}
 	loader := New(workers, txSequences, config.BatchSize, m)
 	err = loader.Execute(ctx)
 	prerr := m.Print(config.MetricsOutput) // Print regardless of execution error
 	if prerr != nil {
 		log.Warn("Failed to print metrics", "error", prerr)
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	return checkFailedTxs(m.FailedTxs())
+}
+
+// checkFailedTxs returns an error if any tx of the load failed, for example
+// because a precompile is not active or the workers are not in its allow list.
+func checkFailedTxs(failed map[string]uint64) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	txTypes := slices.Sorted(maps.Keys(failed))
+	for _, txType := range txTypes {
+		log.Error("Transactions failed", "type", txType, "failed", failed[txType])
+	}
+	return fmt.Errorf("%w: %v", errFailedTxs, txTypes)
 }
@@ -86,10 +86,25 @@ func (tw *ethereumTxWorker) confirmTxByNonce(ctx context.Context, tx *types.Tran
 }
 
 func (tw *ethereumTxWorker) confirmTxByReceipt(ctx context.Context, tx *types.Transaction) error {
+	_, err := tw.awaitReceipt(ctx, tx)
+	return err
+}
+
+// TxSucceeded reports whether the confirmed [tx] succeeded.
+func (tw *ethereumTxWorker) TxSucceeded(ctx context.Context, tx *types.Transaction) (bool, error) {
+	receipt, err := tw.awaitReceipt(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	return receipt.Status == types.ReceiptStatusSuccessful, nil
+}
+
+// awaitReceipt returns the receipt of [tx] once it is available.
+func (tw *ethereumTxWorker) awaitReceipt(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
 	for {
-		_, err := tw.client.TransactionReceipt(ctx, tx.Hash())
+		receipt, err := tw.client.TransactionReceipt(ctx, tx.Hash())
 		if err == nil {
-			return nil
+			return receipt, nil
 		}
 		log.Debug("no tx receipt", "txHash", tx.Hash(), "nonce", tx.Nonce(), "err", err)
 
@@ -97,7 +112,7 @@ func (tw *ethereumTxWorker) confirmTxByReceipt(ctx context.Context, tx *types.Tr
 		case <-tw.newHeads:
 		case <-time.After(time.Second):
 		case <-ctx.Done():
-			return fmt.Errorf("failed to await tx %s nonce %d: %w", tx.Hash(), tx.Nonce(), ctx.Err())
+			return nil, fmt.Errorf("failed to await tx %s nonce %d: %w", tx.Hash(), tx.Nonce(), ctx.Err())
 		}
 	}
 }
@@ -0,0 +1,234 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/libevm/common"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/contracts/bindings"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+
+	ethcrypto "github.com/ava-labs/libevm/crypto"
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+// Workload types
+const (
+	TransferWorkload     = "transfer"
+	ERC20Workload        = "erc20"
+	DeployWorkload       = "deploy"
+	StorageWorkload      = "storage"
+	NativeMinterWorkload = "native-minter"
+	FeeManagerWorkload   = "fee-manager"
+	WarpWorkload         = "warp"
+)
+
+const (
+	erc20TransferGas = 100_000
+	deployGas        = 3_000_000
+	storageBaseGas   = 30_000
+	storageSlotGas   = 25_000 // Cold SSTORE of a new slot and loop overhead
+	precompileGas    = 100_000
+)
+
+var (
+	// storageInitCode deploys a contract that stores 1 in the n slots starting
+	// at base, where n and base are the first two words of the calldata.
+	storageInitCode = common.FromHex(
+		"601e80600b6000396000f3" + // Return the runtime code
+			"600035602035" + // Load n and base
+			"5b8115601c57" + // Loop: stop if n == 0
+			"60018155" + // sstore(base, 1)
+			"6001019060019003906006565b00", // base++, n--, jump to loop
+	)
+
+	erc20InitSupply = new(big.Int).Lsh(common.Big1, 128)
+)
+
+// workload creates the transactions of a type of workload.
+type workload struct {
+	name string
+	gas  uint64
+	// initCode of the contract deployed by each worker before the load, or
+	// nil if the workload needs no contract.
+	initCode []byte
+	// call returns the recipient and data of the tx of [from] with [nonce],
+	// given the worker's [contract].
+	call func(contract common.Address, from common.Address, nonce uint64) (*common.Address, []byte, error)
+}
+
+// newWorkload returns the workload named [name]. Each tx of the storage
+// workload writes [storageSlots] new slots.
+func newWorkload(name string, storageSlots uint64) (*workload, error) {
+	switch name {
+	case TransferWorkload:
+		return &workload{
+			name: name,
+			gas:  ethparams.TxGas,
+			call: func(_ common.Address, from common.Address, _ uint64) (*common.Address, []byte, error) {
+				return &from, nil, nil
+			},
+		}, nil
+	case ERC20Workload:
+		initCode, err := erc20InitCode()
+		if err != nil {
+			return nil, err
+		}
+		abi, err := bindings.ERC20NativeMinterMetaData.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		return &workload{
+			name:     name,
+			gas:      erc20TransferGas,
+			initCode: initCode,
+			call: func(contract common.Address, from common.Address, nonce uint64) (*common.Address, []byte, error) {
+				data, err := abi.Pack("transfer", common.BytesToAddress(seed(from, nonce)), common.Big1)
+				return &contract, data, err
+			},
+		}, nil
+	case DeployWorkload:
+		initCode, err := erc20InitCode()
+		if err != nil {
+			return nil, err
+		}
+		return &workload{
+			name: name,
+			gas:  deployGas,
+			call: func(common.Address, common.Address, uint64) (*common.Address, []byte, error) {
+				return nil, initCode, nil
+			},
+		}, nil
+	case StorageWorkload:
+		if storageSlots == 0 {
+			return nil, fmt.Errorf("%s workload must write a non-zero number of storage slots", name)
+		}
+		return &workload{
+			name:     name,
+			gas:      storageBaseGas + storageSlots*storageSlotGas,
+			initCode: storageInitCode,
+			call: func(contract common.Address, from common.Address, nonce uint64) (*common.Address, []byte, error) {
+				// Each tx writes to new slots starting at a unique base.
+				data := common.LeftPadBytes(new(big.Int).SetUint64(storageSlots).Bytes(), common.HashLength)
+				return &contract, append(data, seed(from, nonce)...), nil
+			},
+		}, nil
+	case NativeMinterWorkload:
+		// The txs revert unless the workers are enabled in the allow list of
+		// the native minter.
+		return &workload{
+			name: name,
+			gas:  precompileGas,
+			call: func(_ common.Address, from common.Address, _ uint64) (*common.Address, []byte, error) {
+				data, err := nativeminter.PackMintNativeCoin(from, common.Big1)
+				return &nativeminter.ContractAddress, data, err
+			},
+		}, nil
+	case FeeManagerWorkload:
+		return &workload{
+			name: name,
+			gas:  precompileGas,
+			call: func(common.Address, common.Address, uint64) (*common.Address, []byte, error) {
+				data, err := feemanager.PackGetFeeConfig()
+				return &feemanager.ContractAddress, data, err
+			},
+		}, nil
+	case WarpWorkload:
+		return &workload{
+			name: name,
+			gas:  precompileGas,
+			call: func(_ common.Address, from common.Address, nonce uint64) (*common.Address, []byte, error) {
+				data, err := warp.PackSendWarpMessage(seed(from, nonce))
+				return &warp.ContractAddress, data, err
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown workload %q", name)
+	}
+}
+
+// erc20InitCode returns the code deploying an ERC20 token, minting its initial
+// supply to the deployer.
+func erc20InitCode() ([]byte, error) {
+	abi, err := bindings.ERC20NativeMinterMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	args, err := abi.Pack("", erc20InitSupply)
+	if err != nil {
+		return nil, err
+	}
+	return append(common.FromHex(bindings.ERC20NativeMinterMetaData.Bin), args...), nil
+}
+
+// seed returns a value unique to the tx of [from] with [nonce].
+func seed(from common.Address, nonce uint64) []byte {
+	return ethcrypto.Keccak256(from.Bytes(), binary.BigEndian.AppendUint64(nil, nonce))
+}
+
+// workloadMix interleaves the txs of several workloads according to their
+// weights.
+type workloadMix struct {
+	workloads []*workload
+	// cumulative holds the sum of the weights of each workload and those
+	// before it.
+	cumulative []uint64
+}
+
+func newWorkloadMix(shares []config.WorkloadShare, storageSlots uint64) (*workloadMix, error) {
+	if len(shares) == 0 {
+		return nil, config.ErrNoWorkload
+	}
+	var (
+		mix   = &workloadMix{}
+		total uint64
+	)
+	for _, share := range shares {
+		w, err := newWorkload(share.Name, storageSlots)
+		if err != nil {
+			return nil, err
+		}
+		total += share.Weight
+		mix.workloads = append(mix.workloads, w)
+		mix.cumulative = append(mix.cumulative, total)
+	}
+	return mix, nil
+}
+
+// next returns the workload of the tx with [nonce].
+func (m *workloadMix) next(nonce uint64) *workload {
+	r := nonce % m.cumulative[len(m.cumulative)-1]
+	return m.workloads[sort.Search(len(m.cumulative), func(i int) bool {
+		return r < m.cumulative[i]
+	})]
+}
+
+// maxGas returns the highest gas limit of the txs of the mix.
+func (m *workloadMix) maxGas() uint64 {
+	var gas uint64
+	for _, w := range m.workloads {
+		gas = max(gas, w.gas)
+	}
+	return gas
+}
+
+// deployments returns the workloads whose contract each worker deploys before
+// the load.
+func (m *workloadMix) deployments() []*workload {
+	var deployments []*workload
+	for _, w := range m.workloads {
+		if w.initCode != nil {
+			deployments = append(deployments, w)
+		}
+	}
+	return deployments
+}
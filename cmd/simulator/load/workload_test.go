@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+)
+
+func TestWorkloadMix(t *testing.T) {
+	require := require.New(t)
+
+	_, err := newWorkloadMix(nil, 0)
+	require.ErrorIs(err, config.ErrNoWorkload)
+	_, err = newWorkloadMix([]config.WorkloadShare{{Name: "unknown", Weight: 1}}, 0)
+	require.ErrorContains(err, "unknown workload")
+	_, err = newWorkloadMix([]config.WorkloadShare{{Name: StorageWorkload, Weight: 1}}, 0)
+	require.ErrorContains(err, "non-zero number of storage slots")
+
+	mix, err := newWorkloadMix([]config.WorkloadShare{
+		{Name: TransferWorkload, Weight: 3},
+		{Name: ERC20Workload, Weight: 1},
+		{Name: StorageWorkload, Weight: 2},
+	}, 4)
+	require.NoError(err)
+
+	// Each run of 6 nonces has 3 transfers, 1 ERC20 transfer and 2 storage
+	// writes, in the order of the workloads.
+	want := []string{
+		TransferWorkload, TransferWorkload, TransferWorkload,
+		ERC20Workload,
+		StorageWorkload, StorageWorkload,
+	}
+	for nonce := uint64(0); nonce < 3*uint64(len(want)); nonce++ {
+		require.Equal(want[nonce%uint64(len(want))], mix.next(nonce).name, "nonce %d", nonce)
+	}
+
+	require.Equal(uint64(storageBaseGas+4*storageSlotGas), mix.maxGas())
+	deployments := mix.deployments()
+	require.Len(deployments, 2)
+	require.Equal(ERC20Workload, deployments[0].name)
+	require.Equal(StorageWorkload, deployments[1].name)
+}
@@ -8,9 +8,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"os"
+	"sync"
 
+	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -26,6 +29,17 @@ type Metrics struct {
 	ConfirmationTxTimes prometheus.Summary
 	// Summary of the quantiles of Individual Issuance To Confirmation Tx Times
 	IssuanceToConfirmationTxTimes prometheus.Summary
+	// Summary of the quantiles of Individual Issuance Tx Times by workload type
+	IssuanceTxTypeTimes *prometheus.SummaryVec
+	// Summary of the quantiles of Individual Issuance To Confirmation Tx Times by workload type
+	IssuanceToConfirmationTxTypeTimes *prometheus.SummaryVec
+	// Count of the confirmed txs that failed by workload type
+	FailedTxTypes *prometheus.CounterVec
+
+	// Workload type of the txs being issued and confirmed
+	lock    sync.Mutex
+	txTypes map[common.Hash]string
+	failed  map[string]uint64
 }
 
 func NewDefaultMetrics() *Metrics {
@@ -52,13 +66,75 @@ func NewMetrics(reg *prometheus.Registry) *Metrics {
 			Help:       "Individual Tx Issuance To Confirmation Times for a Load Test",
 			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 		}),
+		IssuanceTxTypeTimes: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       "tx_type_issuance_time",
+			Help:       "Individual Tx Issuance Times by Workload Type for a Load Test",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}, []string{"type"}),
+		IssuanceToConfirmationTxTypeTimes: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       "tx_type_issuance_to_confirmation_time",
+			Help:       "Individual Tx Issuance To Confirmation Times by Workload Type for a Load Test",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}, []string{"type"}),
+		FailedTxTypes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tx_type_failed",
+			Help: "Confirmed Txs that Failed by Workload Type for a Load Test",
+		}, []string{"type"}),
+		txTypes: make(map[common.Hash]string),
+		failed:  make(map[string]uint64),
 	}
 	reg.MustRegister(m.IssuanceTxTimes)
 	reg.MustRegister(m.ConfirmationTxTimes)
 	reg.MustRegister(m.IssuanceToConfirmationTxTimes)
+	reg.MustRegister(m.IssuanceTxTypeTimes)
+	reg.MustRegister(m.IssuanceToConfirmationTxTypeTimes)
+	reg.MustRegister(m.FailedTxTypes)
 	return m
 }
 
+// SetTxType records the workload type of the tx with [hash], so its times are
+// also reported by type.
+func (m *Metrics) SetTxType(hash common.Hash, txType string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.txTypes[hash] = txType
+}
+
+// TxType returns the workload type of the tx with [hash], if it has one.
+func (m *Metrics) TxType(hash common.Hash) (string, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	txType, ok := m.txTypes[hash]
+	return txType, ok
+}
+
+// ForgetTxType removes the workload type of the tx with [hash] once confirmed.
+func (m *Metrics) ForgetTxType(hash common.Hash) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.txTypes, hash)
+}
+
+// RecordFailedTx records that a confirmed tx of workload [txType] failed.
+func (m *Metrics) RecordFailedTx(txType string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.failed[txType]++
+	m.FailedTxTypes.WithLabelValues(txType).Inc()
+}
+
+// FailedTxs returns the number of confirmed txs that failed by workload type.
+func (m *Metrics) FailedTxs() map[string]uint64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return maps.Clone(m.failed)
+}
+
 type MetricsServer struct {
 	cancel context.CancelFunc
 	stopCh chan struct{}
@@ -35,6 +35,12 @@ type Worker[T THash] interface {
 	LatestHeight(ctx context.Context) (uint64, error)
 }
 
+// StatusWorker is a Worker that reports whether a confirmed transaction
+// succeeded.
+type StatusWorker[T THash] interface {
+	TxSucceeded(ctx context.Context, tx T) (bool, error)
+}
+
 // Execute the work of the given agent.
 type Agent[T THash] interface {
 	Execute(ctx context.Context) error
@@ -102,6 +108,9 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 				}
 				issuanceIndividualDuration := time.Since(issuanceIndividualStart)
 				m.IssuanceTxTimes.Observe(issuanceIndividualDuration.Seconds())
+				if txType, ok := m.TxType(tx.Hash()); ok {
+					m.IssuanceTxTypeTimes.WithLabelValues(txType).Observe(issuanceIndividualDuration.Seconds())
+				}
 				txs = append(txs, tx)
 			}
 		}
@@ -121,6 +130,13 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 			issuanceToConfirmationIndividualDuration := time.Since(txMap[tx.Hash()])
 			m.ConfirmationTxTimes.Observe(confirmationIndividualDuration.Seconds())
 			m.IssuanceToConfirmationTxTimes.Observe(issuanceToConfirmationIndividualDuration.Seconds())
+			if txType, ok := m.TxType(tx.Hash()); ok {
+				m.IssuanceToConfirmationTxTypeTimes.WithLabelValues(txType).Observe(issuanceToConfirmationIndividualDuration.Seconds())
+				m.ForgetTxType(tx.Hash())
+				if err := a.checkTxStatus(ctx, tx, txType); err != nil {
+					return fmt.Errorf("failed to check status of transaction %d: %w", i, err)
+				}
+			}
 			delete(txMap, tx.Hash())
 			confirmedCount++
 		}
@@ -141,3 +157,21 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 		batchI++
 	}
 }
+
+// checkTxStatus records the confirmed [tx] of workload [txType] as failed if
+// the worker reports that it did not succeed.
+func (a issueNAgent[T]) checkTxStatus(ctx context.Context, tx T, txType string) error {
+	worker, ok := a.worker.(StatusWorker[T])
+	if !ok {
+		return nil
+	}
+	succeeded, err := worker.TxSucceeded(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if !succeeded {
+		log.Debug("transaction failed", "txHash", tx.Hash(), "type", txType)
+		a.metrics.RecordFailedTx(txType)
+	}
+	return nil
+}
@@ -56,8 +56,9 @@ type ChainHeaderReader interface {
 	// GetHeaderByHash retrieves a block header from the database by its hash.
 	GetHeaderByHash(hash common.Hash) *types.Header
 
-	// GetFeeConfigAt retrieves the fee config and last changed block number at block header.
-	GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error)
+	// GetFeeConfigAt retrieves the fee config and last changed block number of a block with
+	// [timestamp] built on [parent].
+	GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error)
 
 	// GetCoinbaseAt retrieves the configured coinbase address at [parent].
 	// If fee recipients are allowed, returns true in the second return value and a predefined address in the first value.
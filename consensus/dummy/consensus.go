@@ -15,11 +15,13 @@ import (
 	"github.com/ava-labs/libevm/trie"
 
 	"github.com/ava-labs/subnet-evm/consensus"
+	"github.com/ava-labs/subnet-evm/core/extstate"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
 	"github.com/ava-labs/subnet-evm/plugin/evm/vmerrors"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 	"github.com/ava-labs/subnet-evm/utils"
 )
 
@@ -106,7 +108,7 @@ func verifyHeaderGasFields(config *extras.ChainConfig, header *types.Header, par
 	// Fee config might depend on the state when precompile is activated
 	// but we don't know the final state while forming the block.
 	// See worker package for more details.
-	feeConfig, _, err := chain.GetFeeConfigAt(parent)
+	feeConfig, _, err := chain.GetFeeConfigAt(parent, header.Time)
 	if err != nil {
 		return err
 	}
@@ -207,12 +209,12 @@ func (*DummyEngine) Prepare(_ consensus.ChainHeaderReader, header *types.Header)
 	return nil
 }
 
-func (eng *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *types.Block, parent *types.Header, _ *state.StateDB, receipts []*types.Receipt) error {
+func (eng *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *types.Block, parent *types.Header, state *state.StateDB, receipts []*types.Receipt) error {
 	config := params.GetExtra(chain.Config())
 	timestamp := block.Time()
 	// we use the parent to determine the fee config
 	// since the current block has not been finalized yet.
-	feeConfig, _, err := chain.GetFeeConfigAt(parent)
+	feeConfig, _, err := chain.GetFeeConfigAt(parent, timestamp)
 	if err != nil {
 		return err
 	}
@@ -241,6 +243,7 @@ func (eng *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *types
 			}
 		}
 	}
+	distributeRewardSplit(config, block.Header(), state)
 
 	return nil
 }
@@ -250,7 +253,7 @@ func (eng *DummyEngine) FinalizeAndAssemble(chain consensus.ChainHeaderReader, h
 ) (*types.Block, error) {
 	// we use the parent to determine the fee config
 	// since the current block has not been finalized yet.
-	feeConfig, _, err := chain.GetFeeConfigAt(parent)
+	feeConfig, _, err := chain.GetFeeConfigAt(parent, header.Time)
 	if err != nil {
 		return nil, err
 	}
@@ -278,6 +281,7 @@ func (eng *DummyEngine) FinalizeAndAssemble(chain consensus.ChainHeaderReader, h
 			}
 		}
 	}
+	distributeRewardSplit(configExtra, header, state)
 
 	// finalize the header.Extra
 	extraPrefix, err := customheader.ExtraPrefix(configExtra, parent, header)
@@ -307,6 +311,15 @@ func (eng *DummyEngine) FinalizeAndAssemble(chain consensus.ChainHeaderReader, h
 	), nil
 }
 
+// distributeRewardSplit distributes the fees of [header] to the recipients of the reward
+// split if they were collected by the reward manager after Helicon.
+func distributeRewardSplit(config *extras.ChainConfig, header *types.Header, state *state.StateDB) {
+	if !config.IsHelicon(header.Time) || header.Coinbase != rewardmanager.ContractAddress {
+		return
+	}
+	rewardmanager.DistributeRewardSplit(extstate.New(state))
+}
+
 //nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
 func (*DummyEngine) CalcDifficulty(chain consensus.ChainHeaderReader, time uint64, parent *types.Header) *big.Int {
 	return big.NewInt(1)
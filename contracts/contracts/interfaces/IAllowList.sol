@@ -3,6 +3,8 @@ pragma solidity ^0.8.24;
 
 interface IAllowList {
   event RoleSet(uint256 indexed role, address indexed account, address indexed sender, uint256 oldRole);
+  // Emitted instead of the above when a role is set with an expiry.
+  event RoleSet(uint256 indexed role, address indexed account, address indexed sender, uint256 oldRole, uint256 expiry);
 
   // Set [addr] to have the admin role over the precompile contract.
   function setAdmin(address addr) external;
@@ -16,6 +18,31 @@ interface IAllowList {
   // Set [addr] to have no role for the precompile contract.
   function setNone(address addr) external;
 
-  // Read the status of [addr].
+  // Set [addr] to have the admin role over the precompile contract until [expiry].
+  // Available after the Helicon upgrade.
+  function setAdminUntil(address addr, uint256 expiry) external;
+
+  // Set [addr] to be enabled on the precompile contract until [expiry].
+  // Available after the Helicon upgrade.
+  function setEnabledUntil(address addr, uint256 expiry) external;
+
+  // Set [addr] to have the manager role over the precompile contract until [expiry].
+  // Available after the Helicon upgrade.
+  function setManagerUntil(address addr, uint256 expiry) external;
+
+  // Read the status of [addr]. An expired role is read as no role.
+  // After Helicon, the role is followed by its expiry (0 if it does not expire)
+  // in the returned data.
   function readAllowList(address addr) external view returns (uint256 role);
+
+  // Get the addresses with an unexpired admin role. Available after the Helicon upgrade.
+  function getAdmins() external view returns (address[] memory admins);
+
+  // Get the number of addresses with an unexpired enabled role. Available after the Helicon upgrade.
+  function getEnabledCount() external view returns (uint256 count);
+
+  // Get the addresses with [role] among up to [limit] indexed addresses, starting at [offset].
+  // Expired roles are omitted, so fewer than [limit] addresses may be returned.
+  // Available after the Helicon upgrade.
+  function list(uint256 role, uint256 offset, uint256 limit) external view returns (address[] memory addrs);
 }
@@ -0,0 +1,26 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+import "./IAllowList.sol";
+
+interface ICallAllowList is IAllowList {
+  // CallPermissionSet is the event logged whenever the permission of an account to call a contract is modified
+  event CallPermissionSet(address indexed sender, address indexed account, address indexed destination, bool allowed);
+
+  // RoleCallPermissionSet is the event logged whenever the permission of a role to call a contract is modified
+  event RoleCallPermissionSet(address indexed sender, uint256 indexed role, address indexed destination, bool allowed);
+
+  // setCallAllowed sets whether [account] may send transactions calling [destination].
+  // Can only be called by admins and managers.
+  function setCallAllowed(address account, address destination, bool allowed) external;
+
+  // setRoleCallAllowed sets whether addresses with [role] may send transactions calling [destination].
+  // Can only be called by admins and managers.
+  function setRoleCallAllowed(uint256 role, address destination, bool allowed) external;
+
+  // isCallAllowed returns true if [account] may send transactions calling [destination].
+  // Admins may call any contract.
+  function isCallAllowed(address account, address destination) external view returns (bool allowed);
+
+  // isRoleCallAllowed returns true if addresses with [role] may send transactions calling [destination].
+  function isRoleCallAllowed(uint256 role, address destination) external view returns (bool allowed);
+}
@@ -14,6 +14,8 @@ interface IFeeManager is IAllowList {
     uint256 blockGasCostStep;
   }
   event FeeConfigChanged(address indexed sender, FeeConfig oldFeeConfig, FeeConfig newFeeConfig);
+  event FeeConfigScheduled(address indexed sender, uint256 indexed activationTimestamp, FeeConfig feeConfig);
+  event ScheduledFeeConfigCancelled(address indexed sender, uint256 indexed activationTimestamp);
 
   // Set fee config fields to contract storage
   function setFeeConfig(
@@ -44,4 +46,40 @@ interface IFeeManager is IAllowList {
 
   // Get the last block number changed the fee config from the contract storage
   function getFeeConfigLastChangedAt() external view returns (uint256 blockNumber);
+
+  // Schedule fee config fields to be applied at the first block with a timestamp
+  // at or after activationTimestamp. Replaces any previously scheduled fee config.
+  // Available after the Helicon upgrade.
+  function scheduleFeeConfig(
+    uint256 gasLimit,
+    uint256 targetBlockRate,
+    uint256 minBaseFee,
+    uint256 targetGas,
+    uint256 baseFeeChangeDenominator,
+    uint256 minBlockGasCost,
+    uint256 maxBlockGasCost,
+    uint256 blockGasCostStep,
+    uint256 activationTimestamp
+  ) external;
+
+  // Cancel the scheduled fee config. Available after the Helicon upgrade.
+  function cancelScheduledFeeConfig() external;
+
+  // Get the scheduled fee config from the contract storage.
+  // activationTimestamp is 0 if no fee config is scheduled.
+  // Available after the Helicon upgrade.
+  function getScheduledFeeConfig()
+    external
+    view
+    returns (
+      uint256 gasLimit,
+      uint256 targetBlockRate,
+      uint256 minBaseFee,
+      uint256 targetGas,
+      uint256 baseFeeChangeDenominator,
+      uint256 minBlockGasCost,
+      uint256 maxBlockGasCost,
+      uint256 blockGasCostStep,
+      uint256 activationTimestamp
+    );
 }
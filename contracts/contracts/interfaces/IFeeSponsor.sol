@@ -0,0 +1,20 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+import "./IAllowList.sol";
+
+// Enabled addresses of the allow list are sponsors, which may pay for the gas of the
+// transactions of other senders. A transaction is sponsored by including the sponsor's
+// signed authorization in its access list, under the address of this precompile.
+interface IFeeSponsor is IAllowList {
+  // SpendingLimitSet is the event logged whenever the spending limit of a sponsor is set
+  event SpendingLimitSet(address indexed sender, address indexed sponsor, uint256 limit);
+
+  // setSpendingLimit sets the maximum amount [sponsor] may pay for sponsored transactions
+  // and resets the amount it has spent. A limit of 0 removes the limit.
+  // Can only be called by admins and managers.
+  function setSpendingLimit(address sponsor, uint256 limit) external;
+
+  // getSponsorship returns the spending limit of [sponsor] and the amount it has spent
+  // since the limit was set.
+  function getSponsorship(address sponsor) external view returns (uint256 limit, uint256 spent);
+}
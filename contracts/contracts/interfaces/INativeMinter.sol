@@ -4,6 +4,29 @@ import "./IAllowList.sol";
 
 interface INativeMinter is IAllowList {
   event NativeCoinMinted(address indexed sender, address indexed recipient, uint256 amount);
+  event MintAllowanceUpdated(address indexed minter, uint256 remainingQuota, uint256 remainingSupply);
+  event MintQuotaSet(address indexed sender, address indexed minter, uint256 amount, uint256 window);
+  event NativeCoinBurned(address indexed sender, uint256 amount);
   // Mint [amount] number of native coins and send to [addr]
+  // After Helicon, reverts if the mint exceeds the quota of the caller or the max supply.
   function mintNativeCoin(address addr, uint256 amount) external;
+
+  // Set the quota of [minter] to [amount] per [window] seconds. Zero [amount] and [window] removes the quota.
+  // Can only be called by admins. Available after the Helicon upgrade.
+  function setMintQuota(address minter, uint256 amount, uint256 window) external;
+
+  // Get the quota of [minter] and the amount it can still mint in the current window.
+  // [remaining] is the max uint256 if [minter] has no quota. Available after the Helicon upgrade.
+  function getMintQuota(address minter) external view returns (uint256 amount, uint256 window, uint256 remaining);
+
+  // Get the max supply and the amount minted since it was configured.
+  // [maxSupply] is 0 if there is no max supply. Available after the Helicon upgrade.
+  function getSupplyCap() external view returns (uint256 maxSupply, uint256 totalMinted);
+
+  // Burn [amount] number of native coins from the balance of the caller.
+  // Can be called by any address. Available after the Helicon upgrade.
+  function burnNativeCoin(uint256 amount) external;
+
+  // Get the total amount minted and burned through the precompile since Helicon. Available after the Helicon upgrade.
+  function getSupplyCounters() external view returns (uint256 totalMinted, uint256 totalBurned);
 }
@@ -16,6 +16,9 @@ interface IRewardManager is IAllowList {
   // RewardsDisabled is the event logged whenever rewards are disabled
   event RewardsDisabled(address indexed sender);
 
+  // RewardSplitChanged is the event logged whenever the reward split is modified
+  event RewardSplitChanged(address indexed sender, address[] recipients, uint256[] weights);
+
   // setRewardAddress sets the reward address to the given address
   function setRewardAddress(address addr) external;
 
@@ -30,4 +33,13 @@ interface IRewardManager is IAllowList {
 
   // areFeeRecipientsAllowed returns true if fee recipients are allowed
   function areFeeRecipientsAllowed() external view returns (bool isAllowed);
+
+  // setRewardSplit splits the fees of each block between [recipients], weighted in basis points.
+  // [weights] must add up to 10000. The reward address becomes this precompile, which distributes
+  // the fees at the end of each block. Available after the Helicon upgrade.
+  function setRewardSplit(address[] calldata recipients, uint256[] calldata weights) external;
+
+  // currentRewardSplit returns the recipients of the reward split and their weights, which are
+  // empty if fees are not split. Available after the Helicon upgrade.
+  function currentRewardSplit() external view returns (address[] memory recipients, uint256[] memory weights);
 }
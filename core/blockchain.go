@@ -34,6 +34,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
@@ -173,13 +174,20 @@ const (
 	// trieCleanCacheStatsNamespace is the namespace to surface stats from the trie
 	// clean cache's underlying fastcache.
 	trieCleanCacheStatsNamespace = "hashdb/memcache/clean/fastcache"
+
+	// pathStateAncientDir is the directory in [CacheConfig.ChainDataDir] of
+	// the freezer storing the state histories of the path scheme.
+	pathStateAncientDir = "ancient"
 )
 
 // cacheableFeeConfig encapsulates fee configuration itself and the block number that it has changed at,
-// in order to cache them together.
+// in order to cache them together, along with the scheduled fee config and its activation timestamp if any.
 type cacheableFeeConfig struct {
 	feeConfig     commontype.FeeConfig
 	lastChangedAt *big.Int
+
+	scheduledFeeConfig  *commontype.FeeConfig
+	scheduledActivation uint64
 }
 
 // cacheableCoinbaseConfig encapsulates coinbase address itself and allowFeeRecipient flag,
@@ -212,7 +220,7 @@ type CacheConfig struct {
 	StateHistory                    uint64  // Number of blocks from head whose state histories are reserved.
 	StateScheme                     string  // Scheme used to store ethereum states and merkle tree nodes on top
 
-	ChainDataDir    string // Directory to store chain data in (used by Firewood)
+	ChainDataDir    string // Directory to store chain data in (used by Firewood and the path scheme freezer)
 	SnapshotNoBuild bool   // Whether the background generation is allowed
 	SnapshotWait    bool   // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
 }
@@ -228,8 +236,16 @@ func (c *CacheConfig) triedbConfig() *triedb.Config {
 		}.BackendConstructor
 	}
 	if c.StateScheme == rawdb.PathScheme {
+		// Diff layers are flattened on acceptance by the [pathTrieWriter], and
+		// the state histories of the last [CacheConfig.StateHistory] flattened
+		// layers are kept in the freezer to roll the state back.
+		var ancientDir string
+		if c.ChainDataDir != "" {
+			ancientDir = filepath.Join(c.ChainDataDir, pathStateAncientDir)
+		}
 		config.DBOverride = pathdb.Config{
 			StateHistory:   c.StateHistory,
+			AncientDir:     ancientDir,
 			CleanCacheSize: c.TrieCleanLimit * 1024 * 1024,
 			DirtyCacheSize: c.TrieDirtyLimit * 1024 * 1024,
 		}.BackendConstructor
@@ -240,18 +256,23 @@ func (c *CacheConfig) triedbConfig() *triedb.Config {
 			log.Crit("Chain data directory must be specified for Firewood")
 		}
 
-		config.DBOverride = firewood.Config{
-			ChainDataDir:         c.ChainDataDir,
-			CleanCacheSize:       c.TrieCleanLimit * 1024 * 1024,
-			FreeListCacheEntries: firewood.Defaults.FreeListCacheEntries,
-			Revisions:            uint(c.StateHistory), // must be at least 2
-			ReadCacheStrategy:    ffi.CacheAllReads,
-			ArchiveMode:          !c.Pruning,
-		}.BackendConstructor
+		config.DBOverride = c.FirewoodConfig().BackendConstructor
 	}
 	return config
 }
 
+// FirewoodConfig derives the configuration of the Firewood database.
+func (c *CacheConfig) FirewoodConfig() firewood.Config {
+	return firewood.Config{
+		ChainDataDir:         c.ChainDataDir,
+		CleanCacheSize:       c.TrieCleanLimit * 1024 * 1024,
+		FreeListCacheEntries: firewood.Defaults.FreeListCacheEntries,
+		Revisions:            uint(c.StateHistory), // must be at least 2
+		ReadCacheStrategy:    ffi.CacheAllReads,
+		ArchiveMode:          !c.Pruning,
+	}
+}
+
 // DefaultCacheConfig are the default caching values if none are specified by the
 // user (also used during testing).
 var DefaultCacheConfig = &CacheConfig{
@@ -324,6 +345,7 @@ type BlockChain struct {
 	txAcceptedFeed    event.Feed
 	scope             event.SubscriptionScope
 	genesisBlock      *types.Block
+	genesisSupply     *big.Int // Sum of the balances allocated by the genesis, nil if unknown
 
 	// This mutex synchronizes chain write operations.
 	// Readers don't need to take it, they can just read the database.
@@ -456,6 +478,15 @@ func NewBlockChain(
 	if bc.genesisBlock == nil {
 		return nil, ErrNoGenesis
 	}
+	if genesis != nil {
+		// The airdrop data is only required to create the genesis block, so the
+		// genesis supply may be unknown after a restart.
+		if supply, err := genesis.Supply(); err != nil {
+			log.Warn("Unable to compute genesis supply", "err", err)
+		} else {
+			bc.genesisSupply = supply
+		}
+	}
 
 	bc.currentBlock.Store(nil)
 
@@ -689,6 +720,12 @@ func (bc *BlockChain) DrainAcceptorQueue() {
 	bc.acceptorWg.Wait()
 }
 
+// AcceptorQueueLen returns the number of accepted blocks waiting to be
+// processed by the Acceptor and the capacity of the [acceptorQueue].
+func (bc *BlockChain) AcceptorQueueLen() (int, int) {
+	return len(bc.acceptorQueue), cap(bc.acceptorQueue)
+}
+
 // stopAcceptor sends a signal to the Acceptor to stop processing accepted
 // blocks. The Acceptor will exit once all items in [acceptorQueue] have been
 // processed.
@@ -1268,12 +1305,6 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, parentRoot common.
 	if err != nil {
 		return err
 	}
-	// If node is running in path mode, skip explicit gc operation
-	// which is unnecessary in this mode.
-	if bc.triedb.Scheme() == rawdb.PathScheme {
-		return nil
-	}
-
 	// Note: if InsertTrie must be the last step in verification that can return an error.
 	// This allows [stateManager] to assume that if it inserts a trie without returning an
 	// error then the block has passed verification and either AcceptTrie/RejectTrie will
@@ -1892,6 +1923,16 @@ func (bc *BlockChain) reprocessState(current *types.Block, reexec uint64) error
 			hasState = true
 			break
 		}
+		// With the path scheme, the disk layer may have been written past
+		// the acceptor tip, so roll it back with the state histories.
+		if recoverable, _ := bc.triedb.Recoverable(current.Root()); recoverable {
+			log.Info("Rolling back state", "number", current.NumberU64(), "root", current.Root())
+			if err := bc.triedb.Recover(current.Root()); err != nil {
+				return fmt.Errorf("failed to roll back state to %s: %w", current.Root(), err)
+			}
+			hasState = true
+			break
+		}
 
 		if current.NumberU64() == 0 {
 			return errors.New("genesis state is missing")
@@ -2201,6 +2242,14 @@ func (bc *BlockChain) ResetToStateSyncedBlock(block *types.Block) error {
 	bc.currentBlock.Store(block.Header())
 	bc.hc.SetCurrentHeader(block.Header())
 
+	// The synced trie nodes were written to disk directly, so the layers of
+	// the path database must be reset to the synced root.
+	if bc.triedb.Scheme() == rawdb.PathScheme {
+		if err := bc.triedb.Enable(block.Root()); err != nil {
+			return err
+		}
+	}
+
 	lastAcceptedHash := block.Hash()
 	bc.stateCache = extstate.NewDatabaseWithNodeDB(bc.db, bc.triedb)
 
@@ -1462,20 +1462,20 @@ func StatefulPrecompiles(t *testing.T, create createFunc) {
 				gen.AddTx(signedTx)
 			},
 			verifyState: func(sdb *state.StateDB) error {
-				res := deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr1)
+				res := deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr1, false, 0)
 				if allowlist.AdminRole != res {
 					return fmt.Errorf("unexpected allow list status for addr1 %s, expected %s", res, allowlist.AdminRole)
 				}
-				res = deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr2)
+				res = deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr2, false, 0)
 				if allowlist.AdminRole != res {
 					return fmt.Errorf("unexpected allow list status for addr2 %s, expected %s", res, allowlist.AdminRole)
 				}
 				return nil
 			},
 			verifyGenesis: func(sdb *state.StateDB) {
-				res := deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr1)
+				res := deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr1, false, 0)
 				require.Equal(t, allowlist.AdminRole, res, "unexpected allow list status for addr1 %s, expected %s", res, allowlist.AdminRole)
-				res = deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr2)
+				res = deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr2, false, 0)
 				require.Equal(t, allowlist.NoRole, res, "unexpected allow list status for addr2 %s, expected %s", res, allowlist.NoRole)
 			},
 		},
@@ -1500,22 +1500,22 @@ func StatefulPrecompiles(t *testing.T, create createFunc) {
 				gen.AddTx(signedTx)
 			},
 			verifyState: func(sdb *state.StateDB) error {
-				res := feemanager.GetFeeManagerStatus(sdb, addr1)
+				res := feemanager.GetFeeManagerStatus(sdb, addr1, false, 0)
 				assert.Equal(allowlist.AdminRole, res)
 
 				storedConfig := feemanager.GetStoredFeeConfig(sdb)
 				assert.Equal(testFeeConfig, storedConfig)
 
-				feeConfig, _, err := blockchain.GetFeeConfigAt(blockchain.CurrentHeader())
+				feeConfig, _, err := blockchain.GetFeeConfigAt(blockchain.CurrentHeader(), blockchain.CurrentHeader().Time)
 				require.NoError(t, err)
 				assert.Equal(testFeeConfig, feeConfig)
 				return nil
 			},
 			verifyGenesis: func(sdb *state.StateDB) {
-				res := feemanager.GetFeeManagerStatus(sdb, addr1)
+				res := feemanager.GetFeeManagerStatus(sdb, addr1, false, 0)
 				assert.Equal(allowlist.AdminRole, res)
 
-				feeConfig, _, err := blockchain.GetFeeConfigAt(blockchain.Genesis().Header())
+				feeConfig, _, err := blockchain.GetFeeConfigAt(blockchain.Genesis().Header(), blockchain.Genesis().Time())
 				require.NoError(t, err)
 				assert.Equal(params.GetExtra(&config).FeeConfig, feeConfig)
 			},
@@ -300,6 +300,12 @@ func (bc *BlockChain) Genesis() *types.Block {
 	return bc.genesisBlock
 }
 
+// GenesisSupply returns the sum of the balances allocated by the genesis, or
+// nil if the chain was not created with a genesis specification.
+func (bc *BlockChain) GenesisSupply() *big.Int {
+	return bc.genesisSupply
+}
+
 // GetVMConfig returns the block chain VM config.
 func (bc *BlockChain) GetVMConfig() *vm.Config {
 	return &bc.vmConfig
@@ -361,12 +367,15 @@ func (bc *BlockChain) SubscribeAcceptedTransactionEvent(ch chan<- NewTxsEvent) e
 	return bc.scope.Track(bc.txAcceptedFeed.Subscribe(ch))
 }
 
-// GetFeeConfigAt returns the fee configuration and the last changed block number at [parent].
+// GetFeeConfigAt returns the fee configuration and the last changed block number of a block
+// with [timestamp] built on [parent].
 // If Subnet-EVM is not activated, returns default fee config and nil block number.
-// If FeeManager is activated at [parent], returns the fee config in the precompile contract state.
+// If FeeManager is activated at [parent], returns the fee config in the precompile contract state,
+// or the fee config scheduled in the precompile contract state if it activates at or before
+// [timestamp], as the block applies it before its transactions.
 // Otherwise returns the fee config in the chain config.
 // Assumes that a valid configuration is stored when the precompile is activated.
-func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
+func (bc *BlockChain) GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error) {
 	config := params.GetExtra(bc.Config())
 	if !config.IsSubnetEVM(parent.Time) {
 		return params.DefaultFeeConfig, nil, nil
@@ -376,28 +385,38 @@ func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 	}
 
 	// try to return it from the cache
-	if cached, hit := bc.feeConfigCache.Get(parent.Root); hit {
-		return cached.feeConfig, cached.lastChangedAt, nil
-	}
+	cached, hit := bc.feeConfigCache.Get(parent.Root)
+	if !hit {
+		stateDB, err := bc.StateAt(parent.Root)
+		if err != nil {
+			return commontype.EmptyFeeConfig, nil, err
+		}
 
-	stateDB, err := bc.StateAt(parent.Root)
-	if err != nil {
-		return commontype.EmptyFeeConfig, nil, err
+		storedFeeConfig := feemanager.GetStoredFeeConfig(stateDB)
+		// this should not return an invalid fee config since it's assumed that
+		// StoreFeeConfig returns an error when an invalid fee config is attempted to be stored.
+		// However an external stateDB call can modify the contract state.
+		// This check is added to add a defense in-depth.
+		if err := storedFeeConfig.Verify(); err != nil {
+			return commontype.EmptyFeeConfig, nil, err
+		}
+		lastChangedAt := feemanager.GetFeeConfigLastChangedAt(stateDB)
+		cached = &cacheableFeeConfig{feeConfig: storedFeeConfig, lastChangedAt: lastChangedAt}
+		if scheduledFeeConfig, activation, ok := feemanager.GetScheduledFeeConfig(stateDB); ok {
+			if err := scheduledFeeConfig.Verify(); err != nil {
+				return commontype.EmptyFeeConfig, nil, err
+			}
+			cached.scheduledFeeConfig = &scheduledFeeConfig
+			cached.scheduledActivation = activation
+		}
+		// add it to the cache
+		bc.feeConfigCache.Add(parent.Root, cached)
 	}
 
-	storedFeeConfig := feemanager.GetStoredFeeConfig(stateDB)
-	// this should not return an invalid fee config since it's assumed that
-	// StoreFeeConfig returns an error when an invalid fee config is attempted to be stored.
-	// However an external stateDB call can modify the contract state.
-	// This check is added to add a defense in-depth.
-	if err := storedFeeConfig.Verify(); err != nil {
-		return commontype.EmptyFeeConfig, nil, err
+	if cached.scheduledFeeConfig != nil && cached.scheduledActivation <= timestamp && config.IsPrecompileEnabled(feemanager.ContractAddress, timestamp) {
+		return *cached.scheduledFeeConfig, new(big.Int).Add(parent.Number, common.Big1), nil
 	}
-	lastChangedAt := feemanager.GetFeeConfigLastChangedAt(stateDB)
-	cacheable := &cacheableFeeConfig{feeConfig: storedFeeConfig, lastChangedAt: lastChangedAt}
-	// add it to the cache
-	bc.feeConfigCache.Add(parent.Root, cacheable)
-	return storedFeeConfig, lastChangedAt, nil
+	return cached.feeConfig, cached.lastChangedAt, nil
 }
 
 // GetCoinbaseAt returns the configured coinbase address at [parent].
@@ -41,6 +41,7 @@ import (
 	"github.com/ava-labs/libevm/crypto"
 	"github.com/ava-labs/libevm/eth/tracers/logger"
 	"github.com/ava-labs/libevm/ethdb"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/core/state/pruner"
@@ -76,7 +77,11 @@ var (
 	}
 
 	// Firewood should only be included for snapshot disabled tests.
-	schemes = []string{rawdb.HashScheme, customrawdb.FirewoodScheme}
+	schemes = []string{rawdb.HashScheme, rawdb.PathScheme, customrawdb.FirewoodScheme}
+
+	// The path scheme only keeps the state of recent blocks, so it is excluded
+	// from archive tests.
+	archiveSchemes = []string{rawdb.HashScheme, customrawdb.FirewoodScheme}
 )
 
 func newGwei(n int64) *big.Int {
@@ -114,7 +119,7 @@ func TestArchiveBlockChain(t *testing.T) {
 }
 
 func TestArchiveBlockChainSnapsDisabled(t *testing.T) {
-	for _, scheme := range schemes {
+	for _, scheme := range archiveSchemes {
 		t.Run(scheme, func(t *testing.T) {
 			testArchiveBlockChainSnapsDisabled(t, scheme)
 		})
@@ -374,7 +379,7 @@ func TestBlockChainOfflinePruningUngracefulShutdown(t *testing.T) {
 // TestPruningToNonPruning tests that opening a previously pruned database as a
 // non-pruned database is successful.
 func TestPruningToNonPruning(t *testing.T) {
-	for _, scheme := range schemes {
+	for _, scheme := range archiveSchemes {
 		t.Run(scheme, func(t *testing.T) {
 			testPruningToNonPruning(t, scheme)
 		})
@@ -1263,3 +1268,93 @@ func TestEIP3651(t *testing.T) {
 		t.Fatalf("sender balance incorrect: expected %d, got %d", expected, actual)
 	}
 }
+
+// TestPathSchemeStateHistory tests that the path scheme keeps the state of the
+// last [StateHistory] accepted blocks, and that the state of the last accepted
+// block is available after an unclean shutdown.
+func TestPathSchemeStateHistory(t *testing.T) {
+	var (
+		require      = require.New(t)
+		key1, _      = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _      = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr1        = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2        = crypto.PubkeyToAddress(key2.PublicKey)
+		chainDB      = rawdb.NewMemoryDatabase()
+		stateHistory = 4
+	)
+	gspec := &Genesis{
+		Config: &params.ChainConfig{HomesteadBlock: new(big.Int)},
+		Alloc:  types.GenesisAlloc{addr1: {Balance: big.NewInt(1000000)}},
+	}
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.PathScheme)
+	cacheConfig.SnapshotLimit = 0
+	cacheConfig.CommitInterval = 8
+	cacheConfig.StateHistory = uint64(stateHistory)
+	cacheConfig.ChainDataDir = t.TempDir()
+
+	blockchain, err := createBlockChain(chainDB, cacheConfig, gspec, common.Hash{})
+	require.NoError(err)
+
+	signer := types.HomesteadSigner{}
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, blockchain.engine, 20, 10, func(_ int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), addr2, big.NewInt(10000), ethparams.TxGas, nil, nil), signer, key1)
+		gen.AddTx(tx)
+	})
+	require.NoError(err)
+	_, forkBlocks, _, err := GenerateChainWithGenesis(gspec, blockchain.engine, 20, 10, func(_ int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), addr2, big.NewInt(5000), ethparams.TxGas, nil, nil), signer, key1)
+		gen.AddTx(tx)
+	})
+	require.NoError(err)
+
+	// Unaccepted blocks are never flattened into the disk layer, so a fork
+	// longer than the state history can be inserted.
+	_, err = blockchain.InsertChain(blocks)
+	require.NoError(err)
+	_, err = blockchain.InsertChain(forkBlocks)
+	require.NoError(err)
+	for _, block := range blocks {
+		require.NoError(blockchain.Accept(block))
+	}
+	for _, block := range forkBlocks {
+		require.NoError(blockchain.Reject(block))
+	}
+	blockchain.DrainAcceptorQueue()
+
+	// The state of the last [stateHistory] accepted blocks is kept as diff
+	// layers on top of the disk layer.
+	for i, block := range blocks {
+		require.Equal(i >= len(blocks)-stateHistory-1, blockchain.HasState(block.Root()), "block %d", block.NumberU64())
+	}
+
+	// After a clean shutdown, the diff layers are restored from the journal.
+	lastAccepted := blockchain.LastConsensusAcceptedBlock()
+	blockchain.Stop()
+	blockchain, err = createBlockChain(chainDB, cacheConfig, gspec, lastAccepted.Hash())
+	require.NoError(err)
+	require.True(blockchain.HasState(lastAccepted.Root()))
+	require.True(blockchain.HasState(blocks[len(blocks)-2].Root()))
+
+	// After an unclean shutdown, the blocks accepted after the last commit
+	// are re-processed.
+	rawdb.DeleteTrieJournal(chainDB)
+	require.NoError(blockchain.triedb.Close())
+	blockchain.stopWithoutSaving()
+	blockchain, err = createBlockChain(chainDB, cacheConfig, gspec, lastAccepted.Hash())
+	require.NoError(err)
+	require.True(blockchain.HasState(lastAccepted.Root()))
+
+	// If the state on disk is ahead of the acceptor tip after an unclean
+	// shutdown, it is rolled back with the state histories to re-process the
+	// blocks after the acceptor tip.
+	acceptorTip := blocks[len(blocks)-stateHistory]
+	require.NoError(blockchain.triedb.Commit(lastAccepted.Root(), false))
+	require.NoError(customrawdb.WriteAcceptorTip(chainDB, acceptorTip.Hash()))
+	rawdb.DeleteTrieJournal(chainDB)
+	require.NoError(blockchain.triedb.Close())
+	blockchain.stopWithoutSaving()
+	blockchain, err = createBlockChain(chainDB, cacheConfig, gspec, lastAccepted.Hash())
+	require.NoError(err)
+	defer blockchain.Stop()
+	require.True(blockchain.HasState(lastAccepted.Root()))
+}
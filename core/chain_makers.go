@@ -380,7 +380,7 @@ func (cm *chainMaker) makeHeader(parent *types.Block, gap uint64, state *state.S
 	time := parent.Time() + gap // block time is fixed at [gap] seconds
 	timeMS := customtypes.HeaderTimeMilliseconds(parent.Header()) + gap*1000
 
-	feeConfig, _, err := cm.GetFeeConfigAt(parent.Header())
+	feeConfig, _, err := cm.GetFeeConfigAt(parent.Header(), time)
 	if err != nil {
 		panic(err)
 	}
@@ -507,7 +507,7 @@ func (cm *chainMaker) GetBlock(hash common.Hash, number uint64) *types.Block {
 	return cm.blockByNumber(number)
 }
 
-func (cm *chainMaker) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
+func (cm *chainMaker) GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error) {
 	return params.GetExtra(cm.config).FeeConfig, nil, nil
 }
 
@@ -385,6 +385,35 @@ func (g *Genesis) toBlock(db ethdb.Database, triedb *triedb.Database) *types.Blo
 	return block
 }
 
+// Supply returns the sum of the balances allocated by the genesis, including
+// the airdrop.
+func (g *Genesis) Supply() (*big.Int, error) {
+	balances := make(map[common.Address]*big.Int, len(g.Alloc))
+	for addr, account := range g.Alloc {
+		if account.Balance != nil {
+			balances[addr] = account.Balance
+		}
+	}
+	if g.AirdropHash != (common.Hash{}) {
+		if len(g.AirdropData) == 0 {
+			return nil, errors.New("airdrop data not provided")
+		}
+		airdrop := []*Airdrop{}
+		if err := json.Unmarshal(g.AirdropData, &airdrop); err != nil {
+			return nil, fmt.Errorf("failed to parse airdrop data: %w", err)
+		}
+		// The airdrop overwrites the balances allocated in [g.Alloc].
+		for _, alloc := range airdrop {
+			balances[alloc.Address] = g.AirdropAmount
+		}
+	}
+	supply := new(big.Int)
+	for _, balance := range balances {
+		supply.Add(supply, balance)
+	}
+	return supply, nil
+}
+
 // Commit writes the block and state of a genesis specification to the database.
 // The block is committed as the canonical head block.
 func (g *Genesis) Commit(db ethdb.Database, triedb *triedb.Database) (*types.Block, error) {
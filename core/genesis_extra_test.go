@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/rawdb"
 	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
 	"github.com/ava-labs/libevm/triedb"
 	"github.com/stretchr/testify/require"
 
@@ -106,3 +107,68 @@ func TestGenesisToBlockDecoding(t *testing.T) {
 		})
 	}
 }
+
+func TestGenesisSupply(t *testing.T) {
+	var (
+		addr1 = common.Address{1}
+		addr2 = common.Address{2}
+		addr3 = common.Address{3}
+	)
+	airdropData := []byte(`[{"address":"0x0200000000000000000000000000000000000000"},{"address":"0x0300000000000000000000000000000000000000"}]`)
+
+	tests := []struct {
+		name    string
+		genesis *Genesis
+		want    *big.Int
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			genesis: &Genesis{},
+			want:    big.NewInt(0),
+		},
+		{
+			name: "alloc",
+			genesis: &Genesis{
+				Alloc: types.GenesisAlloc{
+					addr1: {Balance: big.NewInt(10)},
+					addr2: {Balance: big.NewInt(5)},
+					addr3: {Code: []byte{1}},
+				},
+			},
+			want: big.NewInt(15),
+		},
+		{
+			name: "airdrop overwrites alloc",
+			genesis: &Genesis{
+				Alloc: types.GenesisAlloc{
+					addr1: {Balance: big.NewInt(10)},
+					addr2: {Balance: big.NewInt(5)},
+				},
+				AirdropHash:   crypto.Keccak256Hash(airdropData),
+				AirdropAmount: big.NewInt(100),
+				AirdropData:   airdropData,
+			},
+			want: big.NewInt(210),
+		},
+		{
+			name: "missing airdrop data",
+			genesis: &Genesis{
+				AirdropHash:   crypto.Keccak256Hash(airdropData),
+				AirdropAmount: big.NewInt(100),
+			},
+			wantErr: "airdrop data not provided",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			supply, err := test.genesis.Supply()
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Zero(t, test.want.Cmp(supply), "expected %s, got %s", test.want, supply)
+		})
+	}
+}
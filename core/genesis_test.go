@@ -231,7 +231,7 @@ func TestStatefulPrecompilesConfigure(t *testing.T) {
 				return &config
 			},
 			assertState: func(t *testing.T, sdb *state.StateDB) {
-				assert.Equal(t, allowlist.AdminRole, deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr), "unexpected allow list status for modified address")
+				assert.Equal(t, allowlist.AdminRole, deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr, false, 0), "unexpected allow list status for modified address")
 				assert.Equal(t, uint64(1), sdb.GetNonce(deployerallowlist.ContractAddress))
 			},
 		},
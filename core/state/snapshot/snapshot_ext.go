@@ -27,6 +27,12 @@ func (t *Tree) DiskStorageIterator(account common.Hash, seek common.Hash) Storag
 	return it
 }
 
+// Generating reports whether the disk layer of the snapshot is still being
+// generated in the background.
+func (t *Tree) Generating() (bool, error) {
+	return t.generating()
+}
+
 type SnapshotIterable interface {
 	Snapshot
 
@@ -31,8 +31,10 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/ethdb"
+	"github.com/ava-labs/libevm/triedb"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
 )
 
@@ -44,6 +46,11 @@ import (
 // [commitInterval].
 const flushWindow = 768
 
+// maxPathDiffLayers is the maximum number of accepted states kept in memory as
+// diff layers with the path scheme. The older states are only available to be
+// rolled back to with the state histories.
+const maxPathDiffLayers = 128
+
 type TrieWriter interface {
 	InsertTrie(block *types.Block) error // Handle inserted trie reference of [root]
 	AcceptTrie(block *types.Block) error // Mark [root] as part of an accepted block
@@ -59,6 +66,13 @@ type TrieDB interface {
 }
 
 func NewTrieWriter(db TrieDB, config *CacheConfig) TrieWriter {
+	if config.StateScheme == rawdb.PathScheme {
+		return &pathTrieWriter{
+			db:             db.(*triedb.Database).Backend().(PathTrieDB),
+			diffLayers:     int(min(config.StateHistory, maxPathDiffLayers)),
+			commitInterval: config.CommitInterval,
+		}
+	}
 	// Firewood should only be used in pruning mode, but we shouldn't explicitly manage this.
 	if config.Pruning && config.StateScheme != customrawdb.FirewoodScheme {
 		cm := &cappedMemoryTrieWriter{
@@ -190,3 +204,45 @@ func (cm *cappedMemoryTrieWriter) Shutdown() error {
 	// re-processing the state on the next startup.
 	return cm.TrieDB.Commit(last, true)
 }
+
+// PathTrieDB is the path-based trie database managed by a [pathTrieWriter].
+type PathTrieDB interface {
+	Cap(root common.Hash, layers int, flush bool) error
+}
+
+// pathTrieWriter is the TrieWriter of the path-based state scheme. The trie
+// database keeps the state of each inserted block as a diff layer in memory,
+// and the diff layers more than [diffLayers] below the last accepted block
+// are flattened into the disk layer on acceptance, storing their state
+// histories. The disk layer is written to disk every [commitInterval] blocks
+// to bound the number of blocks re-processed after an unclean shutdown. If
+// [commitInterval] is 0, each accepted state is flattened and written to disk.
+type pathTrieWriter struct {
+	db             PathTrieDB
+	diffLayers     int
+	commitInterval uint64
+}
+
+func (*pathTrieWriter) InsertTrie(*types.Block) error { return nil }
+
+func (p *pathTrieWriter) AcceptTrie(block *types.Block) error {
+	layers, flush := p.diffLayers, true
+	if p.commitInterval != 0 {
+		flush = block.NumberU64()%p.commitInterval == 0
+	} else {
+		// Like the [noPruningTrieWriter], write each accepted state to disk.
+		layers = 0
+	}
+	if err := p.db.Cap(block.Root(), layers, flush); err != nil {
+		return fmt.Errorf("failed to cap trie for block %s: %w", block.Hash().Hex(), err)
+	}
+	return nil
+}
+
+// RejectTrie is a no-op, as the diff layers of rejected blocks are discarded
+// once their parent is flattened into the disk layer.
+func (*pathTrieWriter) RejectTrie(*types.Block) error { return nil }
+
+// Shutdown is a no-op, as the diff layers are journaled when the blockchain
+// is stopped.
+func (*pathTrieWriter) Shutdown() error { return nil }
@@ -105,3 +105,64 @@ func TestNoPruningTrieWriter(t *testing.T) {
 		m.LastDereference = common.Hash{}
 	}
 }
+
+type MockPathTrieDB struct {
+	LastCap    common.Hash
+	LastLayers int
+	LastFlush  bool
+}
+
+func (t *MockPathTrieDB) Cap(root common.Hash, layers int, flush bool) error {
+	t.LastCap = root
+	t.LastLayers = layers
+	t.LastFlush = flush
+	return nil
+}
+
+func TestPathTrieWriter(t *testing.T) {
+	m := &MockPathTrieDB{}
+	w := &pathTrieWriter{db: m, diffLayers: tipBufferSize, commitInterval: 16}
+	for i := 0; i < 2*int(w.commitInterval)+1; i++ {
+		bigI := big.NewInt(int64(i))
+		block := types.NewBlock(
+			&types.Header{
+				Root:   common.BigToHash(bigI),
+				Number: bigI,
+			},
+			nil, nil, nil, nil,
+		)
+
+		require.NoError(t, w.InsertTrie(block))
+		require.Zero(t, m.LastCap, "should not have capped block on insert")
+
+		require.NoError(t, w.AcceptTrie(block))
+		require.Equal(t, block.Root(), m.LastCap, "should have capped block on accept")
+		require.Equal(t, tipBufferSize, m.LastLayers)
+		require.Equal(t, uint64(i)%w.commitInterval == 0, m.LastFlush, "should have flushed block after CommitInterval")
+		*m = MockPathTrieDB{}
+
+		require.NoError(t, w.RejectTrie(block))
+		require.Zero(t, m.LastCap, "should not have capped block on reject")
+	}
+}
+
+func TestPathTrieWriterNoCommitInterval(t *testing.T) {
+	m := &MockPathTrieDB{}
+	w := &pathTrieWriter{db: m, diffLayers: tipBufferSize}
+	for i := 0; i < 3; i++ {
+		bigI := big.NewInt(int64(i))
+		block := types.NewBlock(
+			&types.Header{
+				Root:   common.BigToHash(bigI),
+				Number: bigI,
+			},
+			nil, nil, nil, nil,
+		)
+
+		require.NoError(t, w.AcceptTrie(block))
+		require.Equal(t, block.Root(), m.LastCap, "should have capped block on accept")
+		require.Zero(t, m.LastLayers, "should not have kept diff layers")
+		require.True(t, m.LastFlush, "should have flushed every block")
+		*m = MockPathTrieDB{}
+	}
+}
@@ -8,12 +8,16 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/log"
 
 	"github.com/ava-labs/subnet-evm/core/extstate"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/stateupgrade"
 )
@@ -85,9 +89,56 @@ func applyStateUpgrades(c *params.ChainConfig, parentTimestamp *uint64, blockCon
 	return nil
 }
 
+// applyScheduledFeeConfig applies the fee config scheduled in the fee manager precompile
+// if the precompile is enabled and the scheduled activation timestamp has been reached
+// by the timestamp set in [blockContext].
+func applyScheduledFeeConfig(c *params.ChainConfig, blockContext contract.ConfigurationBlockContext, statedb *state.StateDB) error {
+	if !params.GetExtra(c).IsPrecompileEnabled(feemanager.ContractAddress, blockContext.Timestamp()) {
+		return nil
+	}
+	applied, err := feemanager.ApplyScheduledFeeConfig(extstate.New(statedb), blockContext)
+	if err != nil {
+		return err
+	}
+	if applied {
+		log.Info("Applied scheduled fee config", "blockNumber", blockContext.Number(), "timestamp", blockContext.Timestamp())
+	}
+	return nil
+}
+
+// applyHeliconAllowListIndexes enables the index of the allow list of each allow list precompile
+// enabled at the Helicon transition from [parentTimestamp] to the timestamp set in [blockContext].
+// The addresses given a role by the configs of the precompile are indexed with their stored role.
+// Storage keys are hashed in the state, so the addresses given a role by transactions before
+// Helicon cannot be enumerated. They are indexed the next time their role is set, even to the
+// same role.
+func applyHeliconAllowListIndexes(c *params.ChainConfig, parentTimestamp *uint64, blockContext contract.ConfigurationBlockContext, statedb *state.StateDB) {
+	extra := params.GetExtra(c)
+	blockTimestamp := blockContext.Timestamp()
+	if !extras.IsForkTransition(extra.HeliconTimestamp, parentTimestamp, blockTimestamp) {
+		return
+	}
+	wrappedStateDB := extstate.New(statedb)
+	for _, module := range modules.RegisteredModules() {
+		activeConfig, ok := extra.GetActivePrecompileConfig(module.Address, blockTimestamp).(allowlist.ConfigWithAllowList)
+		if !ok || activeConfig.IsDisabled() {
+			continue
+		}
+		var addresses []common.Address
+		for _, config := range extra.GetActivatingPrecompileConfigs(module.Address, nil, blockTimestamp, extra.PrecompileUpgrades) {
+			if config, ok := config.(allowlist.ConfigWithAllowList); ok {
+				addresses = append(addresses, config.AllowListAddresses()...)
+			}
+		}
+		log.Info("Enabling allow list index", "name", module.ConfigKey, "addresses", len(addresses))
+		allowlist.EnableIndex(wrappedStateDB, module.Address, addresses)
+	}
+}
+
 // ApplyUpgrades checks if any of the precompile or state upgrades specified by the chain config are activated by the block
 // transition from [parentTimestamp] to the timestamp set in [header]. If this is the case, it calls [Configure]
-// to apply the necessary state transitions for the upgrade.
+// to apply the necessary state transitions for the upgrade. It also enables the allow list indexes at the
+// Helicon transition and applies any scheduled fee config that becomes active at the timestamp set in [header].
 // This function is called:
 // - in block processing to update the state when processing a block.
 // - in the miner to apply the state upgrades when producing a block.
@@ -95,7 +146,11 @@ func ApplyUpgrades(c *params.ChainConfig, parentTimestamp *uint64, blockContext
 	if err := ApplyPrecompileActivations(c, parentTimestamp, blockContext, statedb); err != nil {
 		return err
 	}
-	return applyStateUpgrades(c, parentTimestamp, blockContext, statedb)
+	if err := applyStateUpgrades(c, parentTimestamp, blockContext, statedb); err != nil {
+		return err
+	}
+	applyHeliconAllowListIndexes(c, parentTimestamp, blockContext, statedb)
+	return applyScheduledFeeConfig(c, blockContext, statedb)
 }
 
 // BlockContext implements [contract.ConfigurationBlockContext].
@@ -4,20 +4,32 @@
 package core
 
 import (
+	"crypto/ecdsa"
+	"math"
 	"math/big"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/evm/predicate"
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/core/vm"
 	"github.com/ava-labs/libevm/crypto"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core/extstate"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/plugin/evm/vmerrors"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/callallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feesponsor"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/utils"
 
@@ -103,3 +115,456 @@ func TestBadTxAllowListBlock(t *testing.T) {
 		require.ErrorIs(t, err, tt.want, "test %d", i)
 	}
 }
+
+func TestApplyHeliconAllowListIndexes(t *testing.T) {
+	var (
+		admin   = common.Address{1}
+		enabled = common.Address{2}
+		granted = common.Address{3}
+		config  = params.WithExtra(
+			&params.ChainConfig{ChainID: big.NewInt(1)},
+			&extras.ChainConfig{
+				FeeConfig: params.DefaultFeeConfig,
+				NetworkUpgrades: extras.NetworkUpgrades{
+					SubnetEVMTimestamp: utils.NewUint64(0),
+					HeliconTimestamp:   utils.NewUint64(10),
+				},
+				GenesisPrecompiles: extras.Precompiles{
+					txallowlist.ConfigKey: txallowlist.NewConfig(utils.NewUint64(0), []common.Address{admin}, []common.Address{enabled}, nil),
+				},
+			},
+		)
+	)
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	require.NoError(t, ApplyPrecompileActivations(config, nil, NewBlockContext(big.NewInt(0), 0), statedb))
+	stateDB := extstate.New(statedb)
+	require.False(t, allowlist.IsIndexEnabled(stateDB, txallowlist.ContractAddress))
+
+	// A role granted by a transaction before Helicon is not known to the index.
+	txallowlist.SetTxAllowListStatus(stateDB, granted, allowlist.EnabledRole)
+
+	parent := uint64(5)
+	require.NoError(t, ApplyUpgrades(config, &parent, NewBlockContext(big.NewInt(1), 9), statedb))
+	require.False(t, allowlist.IsIndexEnabled(stateDB, txallowlist.ContractAddress))
+
+	parent = 9
+	require.NoError(t, ApplyUpgrades(config, &parent, NewBlockContext(big.NewInt(2), 10), statedb))
+	require.True(t, allowlist.IsIndexEnabled(stateDB, txallowlist.ContractAddress))
+	require.Equal(t, []common.Address{admin}, allowlist.GetRoleMembers(stateDB, txallowlist.ContractAddress, allowlist.AdminRole, 0, 10))
+	require.Equal(t, []common.Address{enabled}, allowlist.GetRoleMembers(stateDB, txallowlist.ContractAddress, allowlist.EnabledRole, 0, 10))
+
+	// Setting the role granted before Helicon again indexes it.
+	txallowlist.SetTxAllowListStatus(stateDB, granted, allowlist.EnabledRole)
+	require.Equal(t, []common.Address{enabled, granted}, allowlist.GetRoleMembers(stateDB, txallowlist.ContractAddress, allowlist.EnabledRole, 0, 10))
+}
+
+// TestCallAllowList tests that transactions calling contracts are rejected
+// unless the sender is allowed to call the contract by the call allow list.
+func TestCallAllowList(t *testing.T) {
+	var (
+		adminKey, _   = crypto.GenerateKey()
+		allowedKey, _ = crypto.GenerateKey()
+		deniedKey, _  = crypto.GenerateKey()
+		adminAddr     = crypto.PubkeyToAddress(adminKey.PublicKey)
+		allowedAddr   = crypto.PubkeyToAddress(allowedKey.PublicKey)
+		deniedAddr    = crypto.PubkeyToAddress(deniedKey.PublicKey)
+		target        = common.HexToAddress("0x0123")
+		eoa           = common.HexToAddress("0x0456")
+
+		config = params.Copy(params.TestChainConfig)
+		signer = types.LatestSigner(&config)
+	)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		callallowlist.ConfigKey: callallowlist.NewConfig(utils.NewUint64(0), []common.Address{adminAddr}, nil, nil),
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			adminAddr:   {Balance: big.NewInt(params.Ether)},
+			allowedAddr: {Balance: big.NewInt(params.Ether)},
+			deniedAddr:  {Balance: big.NewInt(params.Ether)},
+			target:      {Code: []byte{0x00}}, // STOP
+		},
+	}
+	mkTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, data []byte, baseFee *big.Int) *types.Transaction {
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: nonce, To: &to, Gas: 100_000, GasPrice: baseFee, Data: data}), signer, key)
+		require.NoError(t, err)
+		return tx
+	}
+
+	db, blocks, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 2, 10, func(i int, b *BlockGen) {
+		switch i {
+		case 0:
+			// Admins can call any contract, and other senders can transfer to addresses without code.
+			b.AddTx(mkTx(adminKey, 0, target, nil, b.BaseFee()))
+			b.AddTx(mkTx(deniedKey, 0, eoa, nil, b.BaseFee()))
+			data, err := callallowlist.PackSetCallAllowed(allowedAddr, target, true)
+			require.NoError(t, err)
+			b.AddTx(mkTx(adminKey, 1, callallowlist.ContractAddress, data, b.BaseFee()))
+		case 1:
+			b.AddTx(mkTx(allowedKey, 0, target, nil, b.BaseFee()))
+		}
+	})
+	require.NoError(t, err)
+	for _, blockReceipts := range receipts {
+		for _, receipt := range blockReceipts {
+			require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		}
+	}
+
+	blockchain, err := NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, blocks[0].ParentHash(), false)
+	require.NoError(t, err)
+	defer blockchain.Stop()
+	_, err = blockchain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	block := GenerateBadBlock(blocks[1], dummy.NewCoinbaseFaker(), types.Transactions{mkTx(deniedKey, 1, target, nil, blocks[1].BaseFee())}, gspec.Config)
+	_, err = blockchain.InsertChain(types.Blocks{block})
+	require.ErrorIs(t, err, vmerrors.ErrCallNotAllowListed)
+}
+
+// TestCallAllowListContractCreation tests that contract creations are rejected
+// unless the sender is allowed to call [callallowlist.CreationAddress].
+func TestCallAllowListContractCreation(t *testing.T) {
+	var (
+		adminKey, _   = crypto.GenerateKey()
+		allowedKey, _ = crypto.GenerateKey()
+		deniedKey, _  = crypto.GenerateKey()
+		adminAddr     = crypto.PubkeyToAddress(adminKey.PublicKey)
+		allowedAddr   = crypto.PubkeyToAddress(allowedKey.PublicKey)
+		deniedAddr    = crypto.PubkeyToAddress(deniedKey.PublicKey)
+
+		config = params.Copy(params.TestChainConfig)
+		signer = types.LatestSigner(&config)
+	)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		callallowlist.ConfigKey: callallowlist.NewConfig(utils.NewUint64(0), []common.Address{adminAddr}, nil, nil),
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			adminAddr:   {Balance: big.NewInt(params.Ether)},
+			allowedAddr: {Balance: big.NewInt(params.Ether)},
+			deniedAddr:  {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	mkTx := func(key *ecdsa.PrivateKey, nonce uint64, to *common.Address, data []byte, baseFee *big.Int) *types.Transaction {
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: nonce, To: to, Gas: 100_000, GasPrice: baseFee, Data: data}), signer, key)
+		require.NoError(t, err)
+		return tx
+	}
+
+	db, blocks, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 2, 10, func(i int, b *BlockGen) {
+		switch i {
+		case 0:
+			// Admins can create contracts.
+			b.AddTx(mkTx(adminKey, 0, nil, nil, b.BaseFee()))
+			data, err := callallowlist.PackSetCallAllowed(allowedAddr, callallowlist.CreationAddress, true)
+			require.NoError(t, err)
+			b.AddTx(mkTx(adminKey, 1, &callallowlist.ContractAddress, data, b.BaseFee()))
+		case 1:
+			b.AddTx(mkTx(allowedKey, 0, nil, nil, b.BaseFee()))
+		}
+	})
+	require.NoError(t, err)
+	for _, blockReceipts := range receipts {
+		for _, receipt := range blockReceipts {
+			require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		}
+	}
+
+	blockchain, err := NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, blocks[0].ParentHash(), false)
+	require.NoError(t, err)
+	defer blockchain.Stop()
+	_, err = blockchain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	block := GenerateBadBlock(blocks[1], dummy.NewCoinbaseFaker(), types.Transactions{mkTx(deniedKey, 0, nil, nil, blocks[1].BaseFee())}, gspec.Config)
+	_, err = blockchain.InsertChain(types.Blocks{block})
+	require.ErrorIs(t, err, vmerrors.ErrCallNotAllowListed)
+}
+
+// TestCallAllowListPrecompiles tests that precompiles can be called by any
+// sender while the call allow list is enabled.
+func TestCallAllowListPrecompiles(t *testing.T) {
+	var (
+		managerKey, _ = crypto.GenerateKey()
+		deniedKey, _  = crypto.GenerateKey()
+		managerAddr   = crypto.PubkeyToAddress(managerKey.PublicKey)
+		deniedAddr    = crypto.PubkeyToAddress(deniedKey.PublicKey)
+		target        = common.HexToAddress("0x0123")
+
+		config = params.Copy(params.TestChainConfig)
+		signer = types.LatestSigner(&config)
+	)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		callallowlist.ConfigKey: callallowlist.NewConfig(utils.NewUint64(0), nil, nil, []common.Address{managerAddr}),
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			managerAddr: {Balance: big.NewInt(params.Ether)},
+			deniedAddr:  {Balance: big.NewInt(params.Ether)},
+			target:      {Code: []byte{0x00}}, // STOP
+		},
+	}
+	mkTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, data []byte, baseFee *big.Int) *types.Transaction {
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: nonce, To: &to, Gas: 100_000, GasPrice: baseFee, Data: data}), signer, key)
+		require.NoError(t, err)
+		return tx
+	}
+
+	db, blocks, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 1, 10, func(_ int, b *BlockGen) {
+		// Managers may only call contracts they are allowed to, but can call
+		// the call allow list to allow themselves.
+		data, err := callallowlist.PackSetCallAllowed(managerAddr, target, true)
+		require.NoError(t, err)
+		b.AddTx(mkTx(managerKey, 0, callallowlist.ContractAddress, data, b.BaseFee()))
+		b.AddTx(mkTx(managerKey, 1, target, nil, b.BaseFee()))
+		// Senders without a role can call stateful and native precompiles.
+		data, err = allowlist.PackReadAllowList(deniedAddr)
+		require.NoError(t, err)
+		b.AddTx(mkTx(deniedKey, 0, callallowlist.ContractAddress, data, b.BaseFee()))
+		b.AddTx(mkTx(deniedKey, 1, common.BytesToAddress([]byte{0x02}), []byte("data"), b.BaseFee())) // SHA256
+	})
+	require.NoError(t, err)
+	require.Len(t, receipts[0], 4)
+	for _, receipt := range receipts[0] {
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	}
+
+	blockchain, err := NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, blocks[0].ParentHash(), false)
+	require.NoError(t, err)
+	defer blockchain.Stop()
+	_, err = blockchain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	block := GenerateBadBlock(blocks[0], dummy.NewCoinbaseFaker(), types.Transactions{mkTx(deniedKey, 2, target, nil, blocks[0].BaseFee())}, gspec.Config)
+	_, err = blockchain.InsertChain(types.Blocks{block})
+	require.ErrorIs(t, err, vmerrors.ErrCallNotAllowListed)
+}
+
+// TestRewardSplit tests that the fees collected by the reward manager are
+// distributed to the recipients of the reward split at the end of each block.
+func TestRewardSplit(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		split  = []rewardmanager.RewardRecipient{
+			{Address: common.HexToAddress("0x0a"), Weight: 7_000},
+			{Address: common.HexToAddress("0x0b"), Weight: 3_000},
+		}
+
+		config = params.Copy(params.TestChainConfig)
+		signer = types.LatestSigner(&config)
+	)
+	params.GetExtra(&config).HeliconTimestamp = utils.NewUint64(0)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		rewardmanager.ConfigKey: rewardmanager.NewConfig(utils.NewUint64(0), nil, nil, nil, &rewardmanager.InitialRewardConfig{
+			RewardSplit: split,
+		}),
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+	}
+
+	fees := new(big.Int)
+	db, blocks, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 2, 10, func(i int, b *BlockGen) {
+		b.SetCoinbase(rewardmanager.ContractAddress)
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &common.Address{1}, Gas: ethparams.TxGas, GasPrice: b.BaseFee()}), signer, key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+		fees.Add(fees, new(big.Int).Mul(b.BaseFee(), new(big.Int).SetUint64(ethparams.TxGas)))
+	})
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+
+	blockchain, err := NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, blocks[0].ParentHash(), false)
+	require.NoError(t, err)
+	defer blockchain.Stop()
+	_, err = blockchain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	statedb, err := blockchain.State()
+	require.NoError(t, err)
+	require.True(t, statedb.GetBalance(rewardmanager.ContractAddress).IsZero())
+	// The fees of each block are a multiple of TxGas, so there is no remainder.
+	first := statedb.GetBalance(split[0].Address).ToBig()
+	second := statedb.GetBalance(split[1].Address).ToBig()
+	require.Equal(t, fees, new(big.Int).Add(first, second))
+	require.Positive(t, second.Sign())
+	require.Equal(t, new(big.Int).Div(new(big.Int).Mul(fees, big.NewInt(3_000)), big.NewInt(10_000)), second)
+}
+
+// TestFeeSponsor tests that the gas of a transaction carrying a fee
+// sponsorship authorization is paid by the sponsor, up to its spending limit.
+func TestFeeSponsor(t *testing.T) {
+	var (
+		adminKey, _   = crypto.GenerateKey()
+		sponsorKey, _ = crypto.GenerateKey()
+		senderKey, _  = crypto.GenerateKey()
+		adminAddr     = crypto.PubkeyToAddress(adminKey.PublicKey)
+		sponsorAddr   = crypto.PubkeyToAddress(sponsorKey.PublicKey)
+		senderAddr    = crypto.PubkeyToAddress(senderKey.PublicKey)
+		to            = common.HexToAddress("0x0123")
+
+		config = params.Copy(params.TestChainConfig)
+		signer = types.LatestSigner(&config)
+	)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		feesponsor.ConfigKey: feesponsor.NewConfig(utils.NewUint64(0), []common.Address{adminAddr}, []common.Address{sponsorAddr}, nil),
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			adminAddr:   {Balance: big.NewInt(params.Ether)},
+			sponsorAddr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	// The sender has no balance, so each of its transactions must be sponsored.
+	mkSponsoredTx := func(nonce uint64, baseFee *big.Int) *types.Transaction {
+		auth := &feesponsor.Authorization{
+			Sponsor: sponsorAddr,
+			Sender:  senderAddr,
+			Nonce:   nonce,
+			Expiry:  math.MaxUint64,
+			MaxFee:  uint256.NewInt(params.Ether),
+		}
+		require.NoError(t, auth.Sign(ids.Empty, sponsorKey))
+		tx, err := types.SignTx(types.NewTx(&types.AccessListTx{
+			ChainID:  config.ChainID,
+			Nonce:    nonce,
+			To:       &to,
+			Gas:      100_000,
+			GasPrice: baseFee,
+			AccessList: types.AccessList{{
+				Address:     feesponsor.ContractAddress,
+				StorageKeys: auth.Predicate(),
+			}},
+		}), signer, senderKey)
+		require.NoError(t, err)
+		return tx
+	}
+
+	var fee *big.Int
+	db, blocks, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 2, 10, func(i int, b *BlockGen) {
+		switch i {
+		case 0:
+			tx := mkSponsoredTx(0, b.BaseFee())
+			b.AddTx(tx)
+			fee = new(big.Int).Mul(b.BaseFee(), new(big.Int).SetUint64(b.receipts[0].GasUsed))
+		case 1:
+			// Limiting the sponsor to a single wei prevents it from sponsoring further transactions.
+			data, err := feesponsor.PackSetSpendingLimit(sponsorAddr, big.NewInt(1))
+			require.NoError(t, err)
+			tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: 0, To: &feesponsor.ContractAddress, Gas: 100_000, GasPrice: b.BaseFee(), Data: data}), signer, adminKey)
+			require.NoError(t, err)
+			b.AddTx(tx)
+		}
+	})
+	require.NoError(t, err)
+	for _, blockReceipts := range receipts {
+		for _, receipt := range blockReceipts {
+			require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		}
+	}
+
+	blockchain, err := NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, blocks[0].ParentHash(), false)
+	require.NoError(t, err)
+	defer blockchain.Stop()
+	_, err = blockchain.InsertChain(blocks[:1])
+	require.NoError(t, err)
+
+	statedb, err := blockchain.State()
+	require.NoError(t, err)
+	require.True(t, statedb.GetBalance(senderAddr).IsZero())
+	require.Equal(t, uint64(1), statedb.GetNonce(senderAddr))
+	require.Equal(t, new(big.Int).Sub(big.NewInt(params.Ether), fee), statedb.GetBalance(sponsorAddr).ToBig())
+	require.Equal(t, fee, feesponsor.GetSpent(extstate.New(statedb), sponsorAddr).ToBig())
+
+	_, err = blockchain.InsertChain(blocks[1:])
+	require.NoError(t, err)
+
+	block := GenerateBadBlock(blocks[1], dummy.NewCoinbaseFaker(), types.Transactions{mkSponsoredTx(1, blocks[1].BaseFee())}, gspec.Config)
+	_, err = blockchain.InsertChain(types.Blocks{block})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+// TestFeeSponsorFailedPredicate tests that the sender pays for gas when the
+// fee sponsorship authorization of its transaction failed verification.
+func TestFeeSponsorFailedPredicate(t *testing.T) {
+	var (
+		sponsorKey, _ = crypto.GenerateKey()
+		senderKey, _  = crypto.GenerateKey()
+		sponsorAddr   = crypto.PubkeyToAddress(sponsorKey.PublicKey)
+		senderAddr    = crypto.PubkeyToAddress(senderKey.PublicKey)
+		to            = common.HexToAddress("0x0123")
+		baseFee       = big.NewInt(ethparams.InitialBaseFee)
+
+		config = params.Copy(params.TestChainConfig)
+		signer = types.LatestSigner(&config)
+	)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		feesponsor.ConfigKey: feesponsor.NewConfig(utils.NewUint64(0), nil, []common.Address{sponsorAddr}, nil),
+	}
+	auth := &feesponsor.Authorization{
+		Sponsor: sponsorAddr,
+		Sender:  senderAddr,
+		Expiry:  math.MaxUint64,
+		MaxFee:  uint256.NewInt(params.Ether),
+	}
+	require.NoError(t, auth.Sign(ids.Empty, sponsorKey))
+	tx, err := types.SignTx(types.NewTx(&types.AccessListTx{
+		ChainID:    config.ChainID,
+		To:         &to,
+		Gas:        100_000,
+		GasPrice:   baseFee,
+		AccessList: types.AccessList{{Address: feesponsor.ContractAddress, StorageKeys: auth.Predicate()}},
+	}), signer, senderKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		results predicate.PrecompileResults
+		wantErr error
+	}{
+		{
+			name:    "verified",
+			results: predicate.PrecompileResults{feesponsor.ContractAddress: set.NewBits()},
+		},
+		{
+			name:    "failed",
+			results: predicate.PrecompileResults{feesponsor.ContractAddress: set.NewBits(0)},
+			wantErr: ErrInsufficientFunds,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(t, err)
+			statedb.SetBalance(sponsorAddr, uint256.NewInt(params.Ether))
+			feesponsor.SetFeeSponsorStatus(extstate.New(statedb), sponsorAddr, allowlist.EnabledRole)
+			statedb.SetTxContext(tx.Hash(), 0)
+
+			var results predicate.BlockResults
+			results.Set(tx.Hash(), test.results)
+			resultsBytes, err := results.Bytes()
+			require.NoError(t, err)
+			header := &types.Header{
+				Number:     big.NewInt(1),
+				Difficulty: big.NewInt(1),
+				GasLimit:   8_000_000,
+				BaseFee:    baseFee,
+			}
+			blockContext := NewEVMBlockContextWithPredicateResults(header, nil, &common.Address{}, resultsBytes)
+			msg, err := TransactionToMessage(tx, signer, baseFee)
+			require.NoError(t, err)
+			evm := vm.NewEVM(blockContext, NewEVMTxContext(msg), statedb, &config, vm.Config{})
+
+			_, err = ApplyMessage(evm, msg, new(GasPool).AddGas(header.GasLimit))
+			require.ErrorIs(t, err, test.wantErr)
+		})
+	}
+}
@@ -358,13 +358,13 @@ func TestStateProcessorErrors(t *testing.T) {
 // - valid pow (fake), ancestry, difficulty, gaslimit etc
 func GenerateBadBlock(parent *types.Block, engine consensus.Engine, txs types.Transactions, config *params.ChainConfig) *types.Block {
 	fakeChainReader := newChainMaker(nil, config, engine)
-	feeConfig, _, err := fakeChainReader.GetFeeConfigAt(parent.Header())
+	gap := uint64(10) // 10 seconds
+	time := parent.Time() + gap
+	feeConfig, _, err := fakeChainReader.GetFeeConfigAt(parent.Header(), time)
 	if err != nil {
 		panic(err)
 	}
 	configExtra := params.GetExtra(config)
-	gap := uint64(10) // 10 seconds
-	time := parent.Time() + gap
 	timeMS := customtypes.HeaderTimeMilliseconds(parent.Header()) + gap*1000
 	gasLimit, _ := customheader.GasLimit(configExtra, feeConfig, parent.Header(), timeMS)
 	baseFee, _ := customheader.BaseFee(configExtra, feeConfig, parent.Header(), timeMS)
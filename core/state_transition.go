@@ -31,6 +31,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
 
 	"github.com/ava-labs/avalanchego/vms/evm/predicate"
 	"github.com/ava-labs/libevm/common"
@@ -41,8 +42,13 @@ import (
 	"github.com/ava-labs/libevm/log"
 	ethparams "github.com/ava-labs/libevm/params"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
 	"github.com/ava-labs/subnet-evm/plugin/evm/vmerrors"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/callallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feesponsor"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/holiman/uint256"
 )
 
@@ -275,6 +281,11 @@ type StateTransition struct {
 	initialGas   uint64
 	state        vm.StateDB
 	evm          *vm.EVM
+
+	// feePayer is the account charged for gas. It is the sender of the
+	// message, unless the gas is paid by a fee sponsor.
+	feePayer  common.Address
+	sponsored bool
 }
 
 // NewStateTransition initialises and returns a new state transition object.
@@ -302,7 +313,6 @@ func (st *StateTransition) buyGas() error {
 	if st.msg.GasFeeCap != nil {
 		balanceCheck.SetUint64(st.msg.GasLimit)
 		balanceCheck = balanceCheck.Mul(balanceCheck, st.msg.GasFeeCap)
-		balanceCheck.Add(balanceCheck, st.msg.Value)
 	}
 	if st.evm.ChainConfig().IsCancun(st.evm.Context.BlockNumber, st.evm.Context.Time) {
 		if blobGas := st.blobGasUsed(); blobGas > 0 {
@@ -316,6 +326,16 @@ func (st *StateTransition) buyGas() error {
 			mgval.Add(mgval, blobFee)
 		}
 	}
+	st.feePayer = st.msg.From
+	if sponsor, ok := st.feeSponsor(balanceCheck, mgval); ok {
+		// The sponsor pays for gas, so the sender only needs to cover the value transferred.
+		st.feePayer = sponsor
+		st.sponsored = true
+		balanceCheck = new(big.Int)
+	}
+	if st.msg.GasFeeCap != nil {
+		balanceCheck.Add(balanceCheck, st.msg.Value)
+	}
 	balanceCheckU256, overflow := uint256.FromBig(balanceCheck)
 	if overflow {
 		return fmt.Errorf("%w: address %v required balance exceeds 256 bits", ErrInsufficientFunds, st.msg.From.Hex())
@@ -330,7 +350,100 @@ func (st *StateTransition) buyGas() error {
 
 	st.initialGas = st.msg.GasLimit
 	mgvalU256, _ := uint256.FromBig(mgval)
-	st.state.SubBalance(st.msg.From, mgvalU256)
+	st.state.SubBalance(st.feePayer, mgvalU256)
+	return nil
+}
+
+// feeSponsor returns the sponsor paying for the gas of the message, if the
+// message carries a fee sponsorship authorization that was verified as a
+// predicate and that the sponsor can honour. The sponsor must be able to
+// cover [gasCost], the maximum gas cost of the message, and is charged [charge].
+//
+// Messages that are not transactions, such as eth_call, are never sponsored
+// as their predicates are not verified.
+func (st *StateTransition) feeSponsor(gasCost *big.Int, charge *big.Int) (common.Address, bool) {
+	msg := st.msg
+	if msg.SkipAccountChecks || !params.GetExtra(st.evm.ChainConfig()).IsPrecompileEnabled(feesponsor.ContractAddress, st.evm.Context.Time) {
+		return common.Address{}, false
+	}
+	auth, ok := feesponsor.AuthorizationFromAccessList(msg.AccessList)
+	if !ok {
+		return common.Address{}, false
+	}
+	stateDB, ok := st.state.(contract.StateDB)
+	if !ok {
+		return common.Address{}, false
+	}
+	// The authorization is the first predicate of the fee sponsor precompile,
+	// and a set bit marks a predicate that failed verification.
+	predicateResults := st.predicateResults()
+	if predicateResults.Get(stateDB.TxHash(), feesponsor.ContractAddress).Contains(0) {
+		return common.Address{}, false
+	}
+	gasCostU256, overflow := uint256.FromBig(gasCost)
+	if overflow || !auth.Covers(msg.From, msg.Nonce, gasCostU256, st.evm.Context.Time) {
+		return common.Address{}, false
+	}
+	chargeU256, overflow := uint256.FromBig(charge)
+	isHelicon := params.GetExtra(st.evm.ChainConfig()).IsHelicon(st.evm.Context.Time)
+	if overflow || !feesponsor.CanSponsor(stateDB, auth.Sponsor, chargeU256, isHelicon, st.evm.Context.Time) {
+		return common.Address{}, false
+	}
+	if st.state.GetBalance(auth.Sponsor).Cmp(gasCostU256) < 0 {
+		return common.Address{}, false
+	}
+	return auth.Sponsor, true
+}
+
+// predicateResults returns the predicate results of the block being processed.
+func (st *StateTransition) predicateResults() predicate.BlockResults {
+	header := st.evm.Context.Header
+	if header == nil {
+		return nil
+	}
+	predicateBytes := customheader.PredicateBytesFromExtra(header.Extra)
+	if len(predicateBytes) == 0 {
+		return nil
+	}
+	results, err := predicate.ParseBlockResults(predicateBytes)
+	if err != nil {
+		// The predicate results are verified before the block is processed.
+		return nil
+	}
+	return results
+}
+
+// callAllowListState is the state read to check the call allow list.
+type callAllowListState interface {
+	contract.StateReader
+	GetCodeSize(common.Address) int
+}
+
+// CheckCallAllowList returns an error if the call allow list is enabled by
+// [rules] and [from] may not send a transaction calling [to]. Only calls to
+// contracts are restricted: precompiles, including the call allow list itself,
+// may always be called.
+// Constructors may call any contract, so a contract creation, with a nil [to],
+// is checked as a call to [callallowlist.CreationAddress].
+func CheckCallAllowList(rules params.Rules, stateDB callAllowListState, from common.Address, to *common.Address) error {
+	rulesExtra := params.GetRulesExtra(rules)
+	if !rulesExtra.IsPrecompileEnabled(callallowlist.ContractAddress) {
+		return nil
+	}
+	if to == nil {
+		if !callallowlist.IsCallAllowed(stateDB, from, callallowlist.CreationAddress, rulesExtra.IsHeliconActivated(), rulesExtra.Timestamp) {
+			return fmt.Errorf("%w: contract creation from %s", vmerrors.ErrCallNotAllowListed, from)
+		}
+		return nil
+	}
+	// Stateful precompiles have placeholder code, so they are exempted
+	// explicitly.
+	if _, ok := modules.GetPrecompileModuleByAddress(*to); ok || slices.Contains(vm.ActivePrecompiles(rules), *to) {
+		return nil
+	}
+	if stateDB.GetCodeSize(*to) > 0 && !callallowlist.IsCallAllowed(stateDB, from, *to, rulesExtra.IsHeliconActivated(), rulesExtra.Timestamp) {
+		return fmt.Errorf("%w: %s from %s", vmerrors.ErrCallNotAllowListed, to, from)
+	}
 	return nil
 }
 
@@ -358,12 +471,19 @@ func (st *StateTransition) preCheck() error {
 		}
 
 		// Check that the sender is on the tx allow list if enabled
-		if params.GetExtra(st.evm.ChainConfig()).IsPrecompileEnabled(txallowlist.ContractAddress, st.evm.Context.Time) {
-			txAllowListRole := txallowlist.GetTxAllowListStatus(st.state, msg.From)
+		if extra := params.GetExtra(st.evm.ChainConfig()); extra.IsPrecompileEnabled(txallowlist.ContractAddress, st.evm.Context.Time) {
+			txAllowListRole := txallowlist.GetTxAllowListStatus(st.state, msg.From, extra.IsHelicon(st.evm.Context.Time), st.evm.Context.Time)
 			if !txAllowListRole.IsEnabled() {
 				return fmt.Errorf("%w: %s", vmerrors.ErrSenderAddressNotAllowListed, msg.From)
 			}
 		}
+
+		// Check that the sender may call the destination contract, or create a contract,
+		// if the call allow list is enabled
+		rules := st.evm.ChainConfig().Rules(st.evm.Context.BlockNumber, params.IsMergeTODO, st.evm.Context.Time)
+		if err := CheckCallAllowList(rules, st.state, msg.From, msg.To); err != nil {
+			return err
+		}
 	}
 	// Make sure that transaction gasFeeCap is greater than the baseFee (post london)
 	if st.evm.ChainConfig().IsLondon(st.evm.Context.BlockNumber) {
@@ -551,7 +671,14 @@ func (st *StateTransition) refundGas(subnetEVM bool) uint64 {
 	// Return ETH for remaining gas, exchanged at the original rate.
 	remaining := uint256.NewInt(st.gasRemaining)
 	remaining = remaining.Mul(remaining, uint256.MustFromBig(st.msg.GasPrice))
-	st.state.AddBalance(st.msg.From, remaining)
+	st.state.AddBalance(st.feePayer, remaining)
+
+	// Record what the sponsor paid against its spending limit.
+	if st.sponsored {
+		paid := uint256.NewInt(st.gasUsed())
+		paid.Mul(paid, uint256.MustFromBig(st.msg.GasPrice))
+		feesponsor.AddSpent(st.state.(contract.StateDB), st.feePayer, paid)
+	}
 
 	// Also return remaining gas to the block gas counter so it is
 	// available for the next transaction.
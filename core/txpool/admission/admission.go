@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package admission implements operator configured rules restricting which
+// transactions the transaction pool admits.
+package admission
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+)
+
+const (
+	// SelectorLength is the length of a function selector in calldata.
+	SelectorLength = 4
+
+	// sweepInterval is the minimum interval between drops of elapsed windows.
+	sweepInterval = time.Minute
+)
+
+var (
+	ErrDeniedAddress    = errors.New("address denied")
+	ErrDeniedSelector   = errors.New("function selector denied")
+	ErrRateLimited      = errors.New("sender rate limit exceeded")
+	ErrGasQuotaExceeded = errors.New("contract gas quota exceeded")
+)
+
+// RateLimit is a maximum number of transactions per window.
+type RateLimit struct {
+	Transactions uint64
+	Window       time.Duration
+}
+
+// GasQuota is a maximum amount of gas per window.
+type GasQuota struct {
+	Gas    uint64
+	Window time.Duration
+}
+
+// Config holds the rules of a [Policy].
+type Config struct {
+	// DeniedAddresses rejects transactions sent by or to any of the addresses.
+	DeniedAddresses []common.Address
+	// DeniedSelectors rejects calls to any of the function selectors.
+	DeniedSelectors [][SelectorLength]byte
+	// SenderRateLimit limits the transactions admitted from each sender, unless
+	// overridden in SenderRateLimits.
+	SenderRateLimit *RateLimit
+	// SenderRateLimits limits the transactions admitted from specific senders.
+	SenderRateLimits map[common.Address]RateLimit
+	// ContractGasQuotas limits the gas limit of the transactions admitted to
+	// specific recipients.
+	ContractGasQuotas map[common.Address]GasQuota
+}
+
+// Policy enforces a [Config]. Admitted transactions count against the rate
+// limits and gas quotas in fixed windows, starting at the first transaction
+// admitted for a sender or contract.
+type Policy struct {
+	deniedAddresses   map[common.Address]struct{}
+	deniedSelectors   map[[SelectorLength]byte]struct{}
+	senderRateLimit   *RateLimit
+	senderRateLimits  map[common.Address]RateLimit
+	contractGasQuotas map[common.Address]GasQuota
+
+	lock      sync.Mutex
+	now       func() time.Time
+	senders   windows
+	contracts windows
+}
+
+// window tracks the usage of a limit until end.
+type window struct {
+	end  time.Time
+	used uint64
+}
+
+// windows tracks the current window of each address.
+type windows struct {
+	entries   map[common.Address]*window
+	nextSweep time.Time
+}
+
+// get returns the window of addr at now, starting a new one of the given length
+// if the previous window has elapsed.
+func (ws *windows) get(addr common.Address, length time.Duration, now time.Time) *window {
+	// Periodically drop elapsed windows to bound the memory of the policy.
+	if !now.Before(ws.nextSweep) {
+		for other, w := range ws.entries {
+			if !now.Before(w.end) {
+				delete(ws.entries, other)
+			}
+		}
+		ws.nextSweep = now.Add(sweepInterval)
+	}
+	if w, ok := ws.entries[addr]; ok && now.Before(w.end) {
+		return w
+	}
+	w := &window{end: now.Add(length)}
+	ws.entries[addr] = w
+	return w
+}
+
+// New returns a policy enforcing config.
+func New(config Config) *Policy {
+	p := &Policy{
+		deniedAddresses:   make(map[common.Address]struct{}, len(config.DeniedAddresses)),
+		deniedSelectors:   make(map[[SelectorLength]byte]struct{}, len(config.DeniedSelectors)),
+		senderRateLimit:   config.SenderRateLimit,
+		senderRateLimits:  config.SenderRateLimits,
+		contractGasQuotas: config.ContractGasQuotas,
+		now:               time.Now,
+		senders:           windows{entries: make(map[common.Address]*window)},
+		contracts:         windows{entries: make(map[common.Address]*window)},
+	}
+	for _, addr := range config.DeniedAddresses {
+		p.deniedAddresses[addr] = struct{}{}
+	}
+	for _, selector := range config.DeniedSelectors {
+		p.deniedSelectors[selector] = struct{}{}
+	}
+	return p
+}
+
+// Admit returns the reason tx sent by from is rejected, or nil if it is
+// admitted, in which case it is counted against the rate limit of the sender
+// and the gas quota of the recipient.
+func (p *Policy) Admit(tx *types.Transaction, from common.Address) error {
+	if err := p.Check(tx, from); err != nil {
+		return err
+	}
+	to := tx.To()
+	if to == nil {
+		return p.admitSender(from, p.now())
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	quota, hasQuota := p.contractGasQuotas[*to]
+	var contract *window
+	if hasQuota {
+		contract = p.contracts.get(*to, quota.Window, now)
+		if tx.Gas() > quota.Gas-min(contract.used, quota.Gas) {
+			return fmt.Errorf("%w: contract %s used %d of %d gas in %s", ErrGasQuotaExceeded, *to, contract.used, quota.Gas, quota.Window)
+		}
+	}
+	if err := p.admitSenderLocked(from, now); err != nil {
+		return err
+	}
+	if contract != nil {
+		contract.used += tx.Gas()
+	}
+	return nil
+}
+
+// Check returns the reason tx sent by from is denied by the denied addresses
+// or selectors, or nil if it is not. It does not count against the rate limits
+// and gas quotas.
+func (p *Policy) Check(tx *types.Transaction, from common.Address) error {
+	if _, denied := p.deniedAddresses[from]; denied {
+		return fmt.Errorf("%w: sender %s", ErrDeniedAddress, from)
+	}
+	to := tx.To()
+	if to == nil {
+		return nil
+	}
+	if _, denied := p.deniedAddresses[*to]; denied {
+		return fmt.Errorf("%w: recipient %s", ErrDeniedAddress, *to)
+	}
+	if data := tx.Data(); len(data) >= SelectorLength {
+		selector := [SelectorLength]byte(data[:SelectorLength])
+		if _, denied := p.deniedSelectors[selector]; denied {
+			return fmt.Errorf("%w: %#x", ErrDeniedSelector, selector)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) admitSender(from common.Address, now time.Time) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.admitSenderLocked(from, now)
+}
+
+// admitSenderLocked counts a transaction against the rate limit of from, if
+// any. The policy lock must be held.
+func (p *Policy) admitSenderLocked(from common.Address, now time.Time) error {
+	limit, ok := p.senderRateLimits[from]
+	if !ok {
+		if p.senderRateLimit == nil {
+			return nil
+		}
+		limit = *p.senderRateLimit
+	}
+	w := p.senders.get(from, limit.Window, now)
+	if w.used >= limit.Transactions {
+		return fmt.Errorf("%w: sender %s sent %d transactions in %s", ErrRateLimited, from, w.used, limit.Window)
+	}
+	w.used++
+	return nil
+}
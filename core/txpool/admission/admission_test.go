@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admission
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sender    = common.Address{0x01}
+	other     = common.Address{0x02}
+	contract  = common.Address{0x03}
+	transfer  = []byte(nil)
+	approve   = common.FromHex("0x095ea7b3")
+	emptyCall = []byte{}
+)
+
+func newTx(to *common.Address, gas uint64, data []byte) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{To: to, Gas: gas, GasPrice: big.NewInt(1), Data: data})
+}
+
+// newTestPolicy returns a policy enforcing config with a clock set by the
+// returned function.
+func newTestPolicy(config Config) (*Policy, func(time.Duration)) {
+	var (
+		p   = New(config)
+		now = time.Unix(0, 0)
+	)
+	p.now = func() time.Time { return now }
+	return p, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestDenied(t *testing.T) {
+	p := New(Config{
+		DeniedAddresses: []common.Address{other},
+		DeniedSelectors: [][SelectorLength]byte{[SelectorLength]byte(approve)},
+	})
+	tests := []struct {
+		name string
+		from common.Address
+		tx   *types.Transaction
+		want error
+	}{
+		{
+			name: "denied sender",
+			from: other,
+			tx:   newTx(&contract, 21_000, transfer),
+			want: ErrDeniedAddress,
+		},
+		{
+			name: "denied recipient",
+			from: sender,
+			tx:   newTx(&other, 21_000, transfer),
+			want: ErrDeniedAddress,
+		},
+		{
+			name: "denied selector",
+			from: sender,
+			tx:   newTx(&contract, 50_000, append(approve, 0x01)),
+			want: ErrDeniedSelector,
+		},
+		{
+			name: "denied selector in contract creation",
+			from: sender,
+			tx:   newTx(nil, 100_000, approve),
+		},
+		{
+			name: "allowed call",
+			from: sender,
+			tx:   newTx(&contract, 50_000, emptyCall),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, p.Check(test.tx, test.from), test.want)
+			require.ErrorIs(t, p.Admit(test.tx, test.from), test.want)
+		})
+	}
+}
+
+func TestSenderRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	p, advance := newTestPolicy(Config{
+		SenderRateLimit: &RateLimit{Transactions: 2, Window: time.Minute},
+		SenderRateLimits: map[common.Address]RateLimit{
+			other: {Transactions: 1, Window: time.Hour},
+		},
+	})
+	tx := newTx(&contract, 21_000, transfer)
+	require.NoError(p.Admit(tx, sender))
+	require.NoError(p.Admit(tx, sender))
+	require.ErrorIs(p.Admit(tx, sender), ErrRateLimited)
+	require.NoError(p.Admit(tx, other))
+	require.ErrorIs(p.Admit(tx, other), ErrRateLimited)
+
+	// The default limit restarts after its window, the override does not.
+	advance(time.Minute)
+	require.NoError(p.Admit(tx, sender))
+	require.ErrorIs(p.Admit(tx, other), ErrRateLimited)
+	advance(time.Hour)
+	require.NoError(p.Admit(tx, other))
+}
+
+func TestContractGasQuota(t *testing.T) {
+	require := require.New(t)
+
+	p, advance := newTestPolicy(Config{
+		SenderRateLimits: map[common.Address]RateLimit{
+			other: {Transactions: 0, Window: time.Minute},
+		},
+		ContractGasQuotas: map[common.Address]GasQuota{
+			contract: {Gas: 100_000, Window: time.Minute},
+		},
+	})
+	require.NoError(p.Admit(newTx(&contract, 60_000, emptyCall), sender))
+	require.ErrorIs(p.Admit(newTx(&contract, 50_000, emptyCall), sender), ErrGasQuotaExceeded)
+	require.NoError(p.Admit(newTx(&other, 50_000, emptyCall), sender))
+
+	// Transactions rejected by the rate limit do not use the gas quota.
+	require.ErrorIs(p.Admit(newTx(&contract, 40_000, emptyCall), other), ErrRateLimited)
+	require.NoError(p.Admit(newTx(&contract, 40_000, emptyCall), sender))
+	require.ErrorIs(p.Admit(newTx(&contract, 1, emptyCall), sender), ErrGasQuotaExceeded)
+
+	advance(time.Minute)
+	require.NoError(p.Admit(newTx(&contract, 100_000, emptyCall), sender))
+}
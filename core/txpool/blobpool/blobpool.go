@@ -412,7 +412,8 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserve txpool.Addres
 	for addr := range p.index {
 		p.recheck(addr, nil)
 	}
-	feeConfig, _, err := p.chain.GetFeeConfigAt(p.head)
+	now := time.Now()
+	feeConfig, _, err := p.chain.GetFeeConfigAt(p.head, uint64(now.Unix()))
 	if err != nil {
 		p.Close()
 		return err
@@ -421,7 +422,7 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserve txpool.Addres
 		params.GetExtra(p.chain.Config()),
 		feeConfig,
 		p.head,
-		uint64(time.Now().UnixMilli()),
+		uint64(now.UnixMilli()),
 	)
 	if err != nil {
 		p.Close()
@@ -848,7 +849,8 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 	if p.chain.Config().IsCancun(p.head.Number, p.head.Time) {
 		p.limbo.finalize(p.chain.CurrentFinalBlock())
 	}
-	feeConfig, _, err := p.chain.GetFeeConfigAt(p.head)
+	now := time.Now()
+	feeConfig, _, err := p.chain.GetFeeConfigAt(p.head, uint64(now.Unix()))
 	if err != nil {
 		log.Error("Failed to get fee config to reset blobpool fees", "err", err)
 		return
@@ -857,7 +859,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 		params.GetExtra(p.chain.Config()),
 		feeConfig,
 		p.head,
-		uint64(time.Now().UnixMilli()),
+		uint64(now.UnixMilli()),
 	)
 	if err != nil {
 		log.Error("Failed to estimate next base fee to reset blobpool fees", "err", err)
@@ -189,7 +189,7 @@ func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
 	return bc.statedb, nil
 }
 
-func (bc *testBlockChain) GetFeeConfigAt(header *types.Header) (commontype.FeeConfig, *big.Int, error) {
+func (bc *testBlockChain) GetFeeConfigAt(header *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error) {
 	return params.GetExtra(bc.config).FeeConfig, nil, nil
 }
 
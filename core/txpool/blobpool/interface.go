@@ -56,5 +56,5 @@ type BlockChain interface {
 	// StateAt returns a state database for a given root hash (generally the head).
 	StateAt(root common.Hash) (*state.StateDB, error)
 
-	GetFeeConfigAt(header *types.Header) (commontype.FeeConfig, *big.Int, error)
+	GetFeeConfigAt(header *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error)
 }
@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bundlepool
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/ava-labs/libevm/rlp"
+
+	"github.com/ava-labs/subnet-evm/core/txpool"
+)
+
+// MaxBundleTxs is the maximum number of transactions in a bundle.
+const MaxBundleTxs = 64
+
+var (
+	ErrEmptyBundle      = errors.New("bundle has no transactions")
+	ErrBundleTooLarge   = errors.New("bundle has too many transactions")
+	ErrBlobTxInBundle   = errors.New("blob transactions are not supported in bundles")
+	ErrUnboundedBundle  = errors.New("bundle has no maximum block number or timestamp")
+	ErrInvalidBundle    = errors.New("invalid bundle")
+	ErrInvalidSignature = errors.New("invalid bundle signature")
+)
+
+// Bundle is an ordered list of transactions included contiguously in a single
+// block, either all together or not at all, within a range of block numbers
+// and timestamps.
+type Bundle struct {
+	Txs            []*types.Transaction
+	BlockNumberMin *big.Int
+	BlockNumberMax *big.Int
+	TimestampMin   *uint64
+	TimestampMax   *uint64
+
+	// Signature is an optional signature of [Bundle.SigningHash] for the chain
+	// by an operator, in the [R || S || V] format.
+	Signature []byte
+}
+
+// Hash returns the hash of the transaction hashes of the bundle.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+// SigningHash returns the hash signed by the operator of the bundle on the
+// chain with chainID, covering its transactions and range.
+func (b *Bundle) SigningHash(chainID *big.Int) common.Hash {
+	enc, _ := rlp.EncodeToBytes([]any{chainID, b.Hash(), b.BlockNumberMin, b.BlockNumberMax, b.TimestampMin, b.TimestampMax})
+	return crypto.Keccak256Hash(enc)
+}
+
+// Sign sets the signature of the bundle by the operator key for the chain with
+// chainID.
+func (b *Bundle) Sign(key *ecdsa.PrivateKey, chainID *big.Int) error {
+	sig, err := crypto.Sign(b.SigningHash(chainID).Bytes(), key)
+	if err != nil {
+		return err
+	}
+	b.Signature = sig
+	return nil
+}
+
+// Operator returns the address of the operator that signed the bundle for the
+// chain with chainID, or the zero address if the bundle is not signed.
+func (b *Bundle) Operator(chainID *big.Int) (common.Address, error) {
+	if len(b.Signature) == 0 {
+		return common.Address{}, nil
+	}
+	if err := b.checkSignatureLength(); err != nil {
+		return common.Address{}, err
+	}
+	// Accept the recovery id of wallets, which is offset by 27.
+	sig := bytes.Clone(b.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(b.SigningHash(chainID).Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+func (b *Bundle) checkSignatureLength() error {
+	if len(b.Signature) != 0 && len(b.Signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: length %d, want %d", ErrInvalidSignature, len(b.Signature), crypto.SignatureLength)
+	}
+	return nil
+}
+
+// Validate performs sanity checks on the bundle that do not depend on the
+// chain.
+func (b *Bundle) Validate() error {
+	switch {
+	case len(b.Txs) == 0:
+		return ErrEmptyBundle
+	case len(b.Txs) > MaxBundleTxs:
+		return fmt.Errorf("%w: %d, limit %d", ErrBundleTooLarge, len(b.Txs), MaxBundleTxs)
+	case b.BlockNumberMax == nil && b.TimestampMax == nil:
+		return ErrUnboundedBundle
+	}
+	seen := make(map[common.Hash]struct{}, len(b.Txs))
+	for _, tx := range b.Txs {
+		if tx.Type() == types.BlobTxType {
+			return fmt.Errorf("%w: %s", ErrBlobTxInBundle, tx.Hash())
+		}
+		if _, ok := seen[tx.Hash()]; ok {
+			return fmt.Errorf("%w: duplicate transaction %s", ErrInvalidBundle, tx.Hash())
+		}
+		seen[tx.Hash()] = struct{}{}
+	}
+	if err := b.bounds().Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	return b.checkSignatureLength()
+}
+
+// CheckHeader checks the block number and timestamp range of the bundle
+// against the header of the block it would be included in.
+func (b *Bundle) CheckHeader(header *types.Header) error {
+	return b.bounds().CheckHeader(header)
+}
+
+// Expired reports whether the bundle can no longer be included in any block
+// built on top of head.
+func (b *Bundle) Expired(head *types.Header) bool {
+	return b.bounds().Expired(head)
+}
+
+// expiresWithin reports whether the bundle can no longer be included once
+// [blocks] blocks or [seconds] seconds have passed since head.
+func (b *Bundle) expiresWithin(head *types.Header, blocks uint64, seconds uint64) bool {
+	if b.BlockNumberMax != nil && b.BlockNumberMax.Cmp(new(big.Int).Add(head.Number, new(big.Int).SetUint64(blocks))) <= 0 {
+		return true
+	}
+	return b.TimestampMax != nil && *b.TimestampMax <= head.Time+seconds
+}
+
+// bounds returns the block number and timestamp range of the bundle as a
+// transaction conditional, which enforces the same bounds on transactions.
+func (b *Bundle) bounds() *txpool.TransactionConditional {
+	return &txpool.TransactionConditional{
+		BlockNumberMin: b.BlockNumberMin,
+		BlockNumberMax: b.BlockNumberMax,
+		TimestampMin:   b.TimestampMin,
+		TimestampMax:   b.TimestampMax,
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package bundlepool holds transaction bundles waiting to be included in a
+// block by the miner.
+package bundlepool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/lru"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/event"
+	"github.com/ava-labs/libevm/log"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/params"
+)
+
+const (
+	// maxPendingBundles is the maximum number of bundles waiting to be included.
+	maxPendingBundles = 256
+
+	// maxRangeBlocks and maxRangeSeconds bound how long a bundle may wait to be
+	// included: it must expire within either of them after the current head.
+	maxRangeBlocks  = 128
+	maxRangeSeconds = 300
+
+	// resultRetention is the number of results of bundles no longer pending
+	// kept to answer status queries.
+	resultRetention = 4096
+)
+
+var (
+	ErrPoolFull       = errors.New("bundle pool is full")
+	ErrBundleExpired  = errors.New("bundle range has passed")
+	ErrRangeTooLong   = errors.New("bundle range is too long")
+	ErrUnknownBundle  = errors.New("unknown bundle")
+	ErrPoolClosed     = errors.New("bundle pool closed")
+	errPartialInclude = errors.New("transactions of the bundle were included separately")
+	errNonceUsed      = errors.New("nonce of a transaction of the bundle was used")
+)
+
+// Status is the inclusion status of a bundle.
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // Waiting to be included
+	StatusIncluded Status = "included" // Included in an accepted block
+	StatusExpired  Status = "expired"  // Range passed without inclusion
+	StatusFailed   Status = "failed"   // Can no longer be included
+)
+
+// Result is the status of a bundle and, once included, the block including it.
+type Result struct {
+	Status      Status
+	BlockHash   common.Hash
+	BlockNumber uint64
+	Err         error
+}
+
+// NewBundleEvent is posted when a bundle is added to the pool.
+type NewBundleEvent struct{ Bundle *Bundle }
+
+// BlockChain defines the methods of the chain used by the bundle pool.
+type BlockChain interface {
+	Config() *params.ChainConfig
+	CurrentBlock() *types.Header
+	StateAt(root common.Hash) (*state.StateDB, error)
+	SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription
+}
+
+// BundlePool holds the bundles waiting to be included until they are included
+// in an accepted block or can no longer be.
+type BundlePool struct {
+	chain  BlockChain
+	signer types.Signer
+
+	mu      sync.RWMutex
+	pending []*Bundle
+	hashes  map[common.Hash]struct{}
+	results *lru.Cache[common.Hash, Result]
+	closed  bool
+
+	bundleFeed event.Feed
+	scope      event.SubscriptionScope
+
+	sub  event.Subscription
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a bundle pool tracking the accepted blocks of chain.
+func New(chain BlockChain) *BundlePool {
+	p := &BundlePool{
+		chain:   chain,
+		signer:  types.LatestSigner(chain.Config()),
+		hashes:  make(map[common.Hash]struct{}),
+		results: lru.NewCache[common.Hash, Result](resultRetention),
+		quit:    make(chan struct{}),
+	}
+	accepted := make(chan core.ChainEvent, 16)
+	p.sub = chain.SubscribeChainAcceptedEvent(accepted)
+
+	p.wg.Add(1)
+	go p.loop(accepted)
+	return p
+}
+
+func (p *BundlePool) loop(accepted <-chan core.ChainEvent) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case ev := <-accepted:
+			p.accept(ev.Block)
+		case <-p.sub.Err():
+			return
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// Close stops tracking the chain.
+func (p *BundlePool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	p.sub.Unsubscribe()
+	close(p.quit)
+	p.wg.Wait()
+	p.scope.Close()
+}
+
+// Add adds a bundle to the pool. The caller is expected to have simulated the
+// bundle on the pending state.
+func (p *BundlePool) Add(bundle *Bundle) error {
+	if err := bundle.Validate(); err != nil {
+		return err
+	}
+	if _, err := bundle.Operator(p.chain.Config().ChainID); err != nil {
+		return err
+	}
+	hash := bundle.Hash()
+	head := p.chain.CurrentBlock()
+
+	p.mu.Lock()
+	switch {
+	case p.closed:
+		p.mu.Unlock()
+		return ErrPoolClosed
+	case p.known(hash):
+		p.mu.Unlock()
+		return fmt.Errorf("%w: bundle %s", txpool.ErrAlreadyKnown, hash)
+	case len(p.pending) >= maxPendingBundles:
+		p.mu.Unlock()
+		return fmt.Errorf("%w: %d bundles", ErrPoolFull, len(p.pending))
+	case bundle.Expired(head):
+		p.mu.Unlock()
+		return ErrBundleExpired
+	case !bundle.expiresWithin(head, maxRangeBlocks, maxRangeSeconds):
+		p.mu.Unlock()
+		return fmt.Errorf("%w: must expire within %d blocks or %d seconds", ErrRangeTooLong, maxRangeBlocks, maxRangeSeconds)
+	}
+	p.pending = append(p.pending, bundle)
+	p.hashes[hash] = struct{}{}
+	p.mu.Unlock()
+
+	log.Debug("Added bundle", "hash", hash, "txs", len(bundle.Txs))
+	p.bundleFeed.Send(NewBundleEvent{Bundle: bundle})
+	return nil
+}
+
+// known reports whether the pool holds, or has a result for, the bundle with
+// hash. The pool lock must be held.
+func (p *BundlePool) known(hash common.Hash) bool {
+	if _, ok := p.hashes[hash]; ok {
+		return true
+	}
+	return p.results.Contains(hash)
+}
+
+// Pending returns the bundles that may be included in the block of header, in
+// the order they were added.
+func (p *BundlePool) Pending(header *types.Header) []*Bundle {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var bundles []*Bundle
+	for _, bundle := range p.pending {
+		if bundle.CheckHeader(header) == nil {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// Status returns the status of the bundle with hash.
+func (p *BundlePool) Status(hash common.Hash) (Result, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if _, ok := p.hashes[hash]; ok {
+		return Result{Status: StatusPending}, nil
+	}
+	if result, ok := p.results.Get(hash); ok {
+		return result, nil
+	}
+	return Result{}, fmt.Errorf("%w: %s", ErrUnknownBundle, hash)
+}
+
+// SubscribeNewBundles registers a subscription for bundles added to the pool.
+func (p *BundlePool) SubscribeNewBundles(ch chan<- NewBundleEvent) event.Subscription {
+	return p.scope.Track(p.bundleFeed.Subscribe(ch))
+}
+
+// accept resolves the pending bundles included in block, or that can no
+// longer be included after it.
+func (p *BundlePool) accept(block *types.Block) {
+	included := make(map[common.Hash]struct{}, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		included[tx.Hash()] = struct{}{}
+	}
+	statedb, err := p.chain.StateAt(block.Root())
+	if err != nil {
+		log.Warn("Failed to get accepted state to recheck bundles", "block", block.NumberU64(), "err", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	remaining := p.pending[:0]
+	for _, bundle := range p.pending {
+		var (
+			hash  = bundle.Hash()
+			count = 0
+		)
+		for _, tx := range bundle.Txs {
+			if _, ok := included[tx.Hash()]; ok {
+				count++
+			}
+		}
+		var result Result
+		switch {
+		case count == len(bundle.Txs):
+			result = Result{Status: StatusIncluded, BlockHash: block.Hash(), BlockNumber: block.NumberU64()}
+		case count > 0:
+			result = Result{Status: StatusFailed, Err: errPartialInclude}
+		case bundle.Expired(block.Header()):
+			result = Result{Status: StatusExpired}
+		case statedb != nil && p.nonceUsed(bundle, statedb):
+			result = Result{Status: StatusFailed, Err: errNonceUsed}
+		default:
+			remaining = append(remaining, bundle)
+			continue
+		}
+		log.Debug("Resolved bundle", "hash", hash, "status", result.Status, "block", block.NumberU64())
+		delete(p.hashes, hash)
+		p.results.Add(hash, result)
+	}
+	clear(p.pending[len(remaining):])
+	p.pending = remaining
+}
+
+// nonceUsed reports whether the nonce of a transaction of bundle was used in
+// statedb, so that the bundle can no longer be included.
+func (p *BundlePool) nonceUsed(bundle *Bundle, statedb *state.StateDB) bool {
+	for _, tx := range bundle.Txs {
+		from, err := types.Sender(p.signer, tx)
+		if err != nil {
+			// Already recovered during the simulation of the bundle.
+			continue
+		}
+		if tx.Nonce() < statedb.GetNonce(from) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bundlepool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/ava-labs/libevm/event"
+	"github.com/ava-labs/libevm/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/params"
+)
+
+type testChain struct {
+	head  *types.Header
+	state *state.StateDB
+	feed  event.Feed
+}
+
+func newTestChain(t *testing.T, head *types.Header) *testChain {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	return &testChain{head: head, state: statedb}
+}
+
+func (*testChain) Config() *params.ChainConfig { return params.TestChainConfig }
+
+func (c *testChain) CurrentBlock() *types.Header { return c.head }
+
+func (c *testChain) StateAt(common.Hash) (*state.StateDB, error) { return c.state, nil }
+
+func (c *testChain) SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func newTx(nonce uint64) *types.Transaction {
+	return types.NewTransaction(nonce, common.Address{}, common.Big0, 21_000, common.Big1, nil)
+}
+
+func newBlock(number int64, txs ...*types.Transaction) *types.Block {
+	header := &types.Header{Number: big.NewInt(number)}
+	return types.NewBlock(header, txs, nil, nil, trie.NewStackTrie(nil))
+}
+
+func TestValidate(t *testing.T) {
+	maxBlock := big.NewInt(10)
+	tooMany := make([]*types.Transaction, MaxBundleTxs+1)
+	for i := range tooMany {
+		tooMany[i] = newTx(uint64(i))
+	}
+	tests := []struct {
+		name   string
+		bundle *Bundle
+		want   error
+	}{
+		{
+			name:   "empty",
+			bundle: &Bundle{BlockNumberMax: maxBlock},
+			want:   ErrEmptyBundle,
+		},
+		{
+			name:   "too many transactions",
+			bundle: &Bundle{Txs: tooMany, BlockNumberMax: maxBlock},
+			want:   ErrBundleTooLarge,
+		},
+		{
+			name:   "unbounded",
+			bundle: &Bundle{Txs: []*types.Transaction{newTx(0)}},
+			want:   ErrUnboundedBundle,
+		},
+		{
+			name:   "duplicate transaction",
+			bundle: &Bundle{Txs: []*types.Transaction{newTx(0), newTx(0)}, BlockNumberMax: maxBlock},
+			want:   ErrInvalidBundle,
+		},
+		{
+			name:   "inverted range",
+			bundle: &Bundle{Txs: []*types.Transaction{newTx(0)}, BlockNumberMin: big.NewInt(11), BlockNumberMax: maxBlock},
+			want:   ErrInvalidBundle,
+		},
+		{
+			name:   "valid",
+			bundle: &Bundle{Txs: []*types.Transaction{newTx(0), newTx(1)}, BlockNumberMax: maxBlock},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.bundle.Validate(), test.want)
+		})
+	}
+}
+
+func TestOperator(t *testing.T) {
+	require := require.New(t)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	operator := crypto.PubkeyToAddress(key.PublicKey)
+
+	chainID := params.TestChainConfig.ChainID
+	bundle := &Bundle{Txs: []*types.Transaction{newTx(0)}, BlockNumberMax: big.NewInt(10)}
+	have, err := bundle.Operator(chainID)
+	require.NoError(err)
+	require.Zero(have)
+
+	require.NoError(bundle.Sign(key, chainID))
+	require.NoError(bundle.Validate())
+	have, err = bundle.Operator(chainID)
+	require.NoError(err)
+	require.Equal(operator, have)
+
+	// Signatures with the recovery id of wallets are accepted.
+	bundle.Signature[crypto.RecoveryIDOffset] += 27
+	have, err = bundle.Operator(chainID)
+	require.NoError(err)
+	require.Equal(operator, have)
+
+	// The signature covers the chain ID.
+	have, err = bundle.Operator(new(big.Int).Add(chainID, common.Big1))
+	require.NoError(err)
+	require.NotEqual(operator, have)
+
+	// The signature covers the range of the bundle.
+	bundle.BlockNumberMax = big.NewInt(11)
+	have, err = bundle.Operator(chainID)
+	require.NoError(err)
+	require.NotEqual(operator, have)
+
+	bundle.Signature = bundle.Signature[:crypto.SignatureLength-1]
+	require.ErrorIs(bundle.Validate(), ErrInvalidSignature)
+}
+
+func TestBundlePool(t *testing.T) {
+	require := require.New(t)
+
+	chain := newTestChain(t, &types.Header{Number: big.NewInt(1)})
+	pool := New(chain)
+	defer pool.Close()
+
+	newBundles := make(chan NewBundleEvent, 1)
+	sub := pool.SubscribeNewBundles(newBundles)
+	defer sub.Unsubscribe()
+
+	var (
+		included = &Bundle{Txs: []*types.Transaction{newTx(0), newTx(1)}, BlockNumberMax: big.NewInt(3)}
+		partial  = &Bundle{Txs: []*types.Transaction{newTx(2), newTx(3)}, BlockNumberMax: big.NewInt(3)}
+		expiring = &Bundle{Txs: []*types.Transaction{newTx(4)}, BlockNumberMax: big.NewInt(2)}
+		future   = &Bundle{Txs: []*types.Transaction{newTx(5)}, BlockNumberMin: big.NewInt(3), BlockNumberMax: big.NewInt(3)}
+	)
+	for _, bundle := range []*Bundle{included, partial, expiring, future} {
+		require.NoError(pool.Add(bundle))
+		require.Equal(bundle, (<-newBundles).Bundle)
+	}
+	require.ErrorIs(pool.Add(included), txpool.ErrAlreadyKnown)
+	require.ErrorIs(pool.Add(&Bundle{Txs: []*types.Transaction{newTx(6)}, BlockNumberMax: big.NewInt(1)}), ErrBundleExpired)
+	require.ErrorIs(pool.Add(&Bundle{Txs: []*types.Transaction{newTx(6)}, BlockNumberMax: big.NewInt(2 + maxRangeBlocks)}), ErrRangeTooLong)
+
+	next := &types.Header{Number: big.NewInt(2)}
+	require.Equal([]*Bundle{included, partial, expiring}, pool.Pending(next))
+
+	block := newBlock(2, newTx(0), newTx(1), newTx(2))
+	pool.accept(block)
+
+	result, err := pool.Status(included.Hash())
+	require.NoError(err)
+	require.Equal(Result{Status: StatusIncluded, BlockHash: block.Hash(), BlockNumber: 2}, result)
+	result, err = pool.Status(partial.Hash())
+	require.NoError(err)
+	require.Equal(StatusFailed, result.Status)
+	result, err = pool.Status(expiring.Hash())
+	require.NoError(err)
+	require.Equal(StatusExpired, result.Status)
+	result, err = pool.Status(future.Hash())
+	require.NoError(err)
+	require.Equal(StatusPending, result.Status)
+
+	// Resolved bundles cannot be resubmitted.
+	require.ErrorIs(pool.Add(included), txpool.ErrAlreadyKnown)
+
+	_, err = pool.Status(common.Hash{1})
+	require.ErrorIs(err, ErrUnknownBundle)
+}
+
+func TestBundlePoolNonceUsed(t *testing.T) {
+	require := require.New(t)
+
+	chain := newTestChain(t, &types.Header{Number: big.NewInt(1)})
+	pool := New(chain)
+	defer pool.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	tx, err := types.SignTx(newTx(0), types.LatestSigner(chain.Config()), key)
+	require.NoError(err)
+	bundle := &Bundle{Txs: []*types.Transaction{tx}, BlockNumberMax: big.NewInt(3)}
+	require.NoError(pool.Add(bundle))
+
+	// The nonce of the transaction is used by another transaction in the
+	// accepted block, so the bundle can no longer be included.
+	chain.state.SetNonce(crypto.PubkeyToAddress(key.PublicKey), 1)
+	pool.accept(newBlock(2))
+
+	result, err := pool.Status(bundle.Hash())
+	require.NoError(err)
+	require.Equal(StatusFailed, result.Status)
+	require.ErrorIs(result.Err, errNonceUsed)
+	require.Empty(pool.Pending(&types.Header{Number: big.NewInt(3)}))
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+)
+
+// MaxConditionalCost is the maximum number of storage roots and storage slots
+// a single transaction conditional may reference.
+const MaxConditionalCost = 1000
+
+// KnownAccount is the expected storage of an account. Either the storage root
+// or a set of individual storage slots is checked, but never both.
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+// TransactionConditional is a set of preconditions that must hold in the block
+// a transaction is included in. A transaction carrying a conditional is never
+// included in a block that does not satisfy it.
+type TransactionConditional struct {
+	KnownAccounts  map[common.Address]KnownAccount
+	BlockNumberMin *big.Int
+	BlockNumberMax *big.Int
+	TimestampMin   *uint64
+	TimestampMax   *uint64
+}
+
+// Cost returns the number of storage roots and storage slots referenced by
+// the conditional.
+func (c *TransactionConditional) Cost() int {
+	cost := 0
+	for _, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			cost++
+		}
+		cost += len(account.StorageSlots)
+	}
+	return cost
+}
+
+// Validate performs sanity checks on the conditional that do not depend on
+// the chain.
+func (c *TransactionConditional) Validate() error {
+	if cost := c.Cost(); cost > MaxConditionalCost {
+		return fmt.Errorf("%w: cost %d, limit %d", ErrConditionalCost, cost, MaxConditionalCost)
+	}
+	for addr, account := range c.KnownAccounts {
+		if account.StorageRoot != nil && len(account.StorageSlots) > 0 {
+			return fmt.Errorf("%w: account %s specifies both a storage root and storage slots", ErrInvalidConditional, addr)
+		}
+	}
+	if c.BlockNumberMin != nil && c.BlockNumberMax != nil && c.BlockNumberMin.Cmp(c.BlockNumberMax) > 0 {
+		return fmt.Errorf("%w: block number min %d > max %d", ErrInvalidConditional, c.BlockNumberMin, c.BlockNumberMax)
+	}
+	if c.TimestampMin != nil && c.TimestampMax != nil && *c.TimestampMin > *c.TimestampMax {
+		return fmt.Errorf("%w: timestamp min %d > max %d", ErrInvalidConditional, *c.TimestampMin, *c.TimestampMax)
+	}
+	return nil
+}
+
+// CheckHeader checks the block number and timestamp bounds of the conditional
+// against the header of the block the transaction would be included in.
+func (c *TransactionConditional) CheckHeader(header *types.Header) error {
+	if c.BlockNumberMin != nil && header.Number.Cmp(c.BlockNumberMin) < 0 {
+		return fmt.Errorf("%w: block number %d below minimum %d", ErrConditionalFailed, header.Number, c.BlockNumberMin)
+	}
+	if c.BlockNumberMax != nil && header.Number.Cmp(c.BlockNumberMax) > 0 {
+		return fmt.Errorf("%w: block number %d above maximum %d", ErrConditionalFailed, header.Number, c.BlockNumberMax)
+	}
+	if c.TimestampMin != nil && header.Time < *c.TimestampMin {
+		return fmt.Errorf("%w: timestamp %d below minimum %d", ErrConditionalFailed, header.Time, *c.TimestampMin)
+	}
+	if c.TimestampMax != nil && header.Time > *c.TimestampMax {
+		return fmt.Errorf("%w: timestamp %d above maximum %d", ErrConditionalFailed, header.Time, *c.TimestampMax)
+	}
+	return nil
+}
+
+// CheckState checks the known accounts of the conditional against statedb.
+//
+// Storage roots are read from the state objects as they are, so callers
+// holding uncommitted storage changes must compute the intermediate root
+// beforehand.
+func (c *TransactionConditional) CheckState(statedb *state.StateDB) error {
+	for addr, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			if root := statedb.GetStorageRoot(addr); root != *account.StorageRoot {
+				return fmt.Errorf("%w: account %s storage root %s, expected %s", ErrConditionalFailed, addr, root, *account.StorageRoot)
+			}
+			continue
+		}
+		for slot, expected := range account.StorageSlots {
+			if value := statedb.GetState(addr, slot); value != expected {
+				return fmt.Errorf("%w: account %s slot %s is %s, expected %s", ErrConditionalFailed, addr, slot, value, expected)
+			}
+		}
+	}
+	return nil
+}
+
+// HasStorageRoots reports whether the conditional checks any storage root.
+func (c *TransactionConditional) HasStorageRoots() bool {
+	for _, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired reports whether the block number or timestamp bounds of the
+// conditional can no longer be met by any block built on top of head.
+func (c *TransactionConditional) Expired(head *types.Header) bool {
+	if c.BlockNumberMax != nil && c.BlockNumberMax.Cmp(head.Number) <= 0 {
+		return true
+	}
+	// Block timestamps never decrease, so the next block is at least as late
+	// as the head.
+	return c.TimestampMax != nil && *c.TimestampMax < head.Time
+}
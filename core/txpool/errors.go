@@ -71,4 +71,28 @@ var (
 	// input transaction of non-blob type when a blob transaction from this sender
 	// remains pending (and vice-versa).
 	ErrAlreadyReserved = errors.New("address already reserved")
+
+	// ErrInvalidSponsorship is returned if a transaction carries a fee sponsorship
+	// authorization that was not signed by its sponsor for this transaction.
+	ErrInvalidSponsorship = errors.New("invalid fee sponsorship")
+
+	// ErrAdmissionRejected is returned if a transaction is rejected by the
+	// admission policy of the pool.
+	ErrAdmissionRejected = errors.New("transaction rejected by admission policy")
+
+	// ErrInvalidConditional is returned if a transaction conditional is
+	// malformed, e.g. its minimum bounds exceed its maximum bounds.
+	ErrInvalidConditional = errors.New("invalid transaction conditional")
+
+	// ErrConditionalCost is returned if a transaction conditional references
+	// more storage than allowed.
+	ErrConditionalCost = errors.New("transaction conditional cost exceeds limit")
+
+	// ErrConditionalFailed is returned if a transaction conditional does not
+	// hold against the chain.
+	ErrConditionalFailed = errors.New("transaction conditional failed")
+
+	// ErrConditionalNotSupported is returned if a conditional transaction is
+	// submitted for a transaction type whose subpool does not track conditionals.
+	ErrConditionalNotSupported = errors.New("transaction conditionals not supported")
 )
@@ -28,6 +28,7 @@
 package legacypool
 
 import (
+	"bytes"
 	"errors"
 	"io"
 	"io/fs"
@@ -55,6 +56,7 @@ func (*devNull) Close() error                      { return nil }
 // journal is a rotating log of transactions with the aim of storing locally
 // created transactions to allow non-executed ones to survive node restarts.
 type journal struct {
+	name   string         // Kind of transactions journaled, for logging
 	path   string         // Filesystem path to store the transactions at
 	writer io.WriteCloser // Output stream to write new transactions into
 }
@@ -62,6 +64,16 @@ type journal struct {
 // newTxJournal creates a new transaction journal to
 func newTxJournal(path string) *journal {
 	return &journal{
+		name: "local",
+		path: path,
+	}
+}
+
+// newPoolJournal creates a new journal of all transactions in the pool, which is
+// only ever regenerated as a whole with dump.
+func newPoolJournal(path string) *journal {
+	return &journal{
+		name: "pool",
 		path: path,
 	}
 }
@@ -123,7 +135,7 @@ func (journal *journal) load(add func([]*types.Transaction) []error) error {
 			batch = batch[:0]
 		}
 	}
-	log.Info("Loaded local transaction journal", "transactions", total, "dropped", dropped)
+	log.Info("Loaded transaction journal", "journal", journal.name, "transactions", total, "dropped", dropped)
 
 	return failure
 }
@@ -180,7 +192,46 @@ func (journal *journal) rotate(all map[common.Address]types.Transactions) error
 	if len(all) == 0 {
 		logger = log.Debug
 	}
-	logger("Regenerated local transaction journal", "transactions", journaled, "accounts", len(all))
+	logger("Regenerated transaction journal", "journal", journal.name, "transactions", journaled, "accounts", len(all))
+
+	return nil
+}
+
+// dump regenerates the transaction journal with the transactions of each
+// account in all, in order. Once a transaction would grow the journal beyond
+// limit bytes, it and the remaining transactions of its account are left out,
+// since they could not be executed without it.
+func (journal *journal) dump(all []types.Transactions, limit uint64) error {
+	var (
+		buf     bytes.Buffer
+		size    uint64
+		dropped int
+	)
+	for _, txs := range all {
+		for i, tx := range txs {
+			enc, err := rlp.EncodeToBytes(tx)
+			if err != nil {
+				return err
+			}
+			if size+uint64(len(enc)) > limit {
+				dropped += len(txs) - i
+				break
+			}
+			buf.Write(enc)
+			size += uint64(len(enc))
+		}
+	}
+	if err := os.WriteFile(journal.path+".new", buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(journal.path+".new", journal.path); err != nil {
+		return err
+	}
+	logger := log.Info
+	if len(all) == 0 {
+		logger = log.Debug
+	}
+	logger("Regenerated transaction journal", "journal", journal.name, "bytes", size, "accounts", len(all), "dropped", dropped)
 
 	return nil
 }
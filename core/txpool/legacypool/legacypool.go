@@ -30,6 +30,7 @@ package legacypool
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"sort"
@@ -112,6 +113,14 @@ var (
 	underpricedTxMeter = metrics.GetOrRegisterMeter("txpool/underpriced", nil)
 	overflowedTxMeter  = metrics.GetOrRegisterMeter("txpool/overflowed", nil)
 
+	// conditionalEvictedMeter counts how many conditional transactions are dropped
+	// because their conditionals can no longer be met.
+	conditionalEvictedMeter = metrics.GetOrRegisterMeter("txpool/conditional/evicted", nil)
+
+	// admissionRejectedMeter counts how many transactions are rejected by the
+	// admission policy.
+	admissionRejectedMeter = metrics.GetOrRegisterMeter("txpool/admission/rejected", nil)
+
 	// throttleTxMeter counts how many transactions are rejected due to too-many-changes between
 	// txpool reorgs.
 	throttleTxMeter = metrics.GetOrRegisterMeter("txpool/throttle", nil)
@@ -145,7 +154,7 @@ type BlockChain interface {
 	StateAt(root common.Hash) (*state.StateDB, error)
 
 	SenderCacher() *core.TxSenderCacher
-	GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error)
+	GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error)
 }
 
 // Config are the configuration parameters of the transaction pool.
@@ -153,7 +162,10 @@ type Config struct {
 	Locals    []common.Address // Addresses that should be treated by default as local
 	NoLocals  bool             // Whether local transaction handling should be disabled
 	Journal   string           // Journal of local transactions to survive node restarts
-	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
+	Rejournal time.Duration    // Time interval to regenerate the transaction journals
+
+	PoolJournal      string // Journal of all pending and queued transactions to survive node restarts
+	PoolJournalLimit uint64 // Maximum size in bytes of the pool journal
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
@@ -164,6 +176,8 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+
+	LifecycleRetention time.Duration // Amount of time the lifecycle of transactions that left the pool is kept
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -173,6 +187,9 @@ var DefaultConfig = Config{
 	Journal:   "",
 	Rejournal: time.Hour,
 
+	PoolJournal:      "",
+	PoolJournalLimit: 32 * 1024 * 1024,
+
 	PriceLimit: 1,
 	PriceBump:  10,
 
@@ -182,6 +199,8 @@ var DefaultConfig = Config{
 	GlobalQueue:  1024,
 
 	Lifetime: 10 * time.Minute,
+
+	LifecycleRetention: time.Hour,
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -220,6 +239,10 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
 		conf.Lifetime = DefaultConfig.Lifetime
 	}
+	if conf.LifecycleRetention < 1 {
+		log.Warn("Sanitizing invalid txpool lifecycle retention", "provided", conf.LifecycleRetention, "updated", DefaultConfig.LifecycleRetention)
+		conf.LifecycleRetention = DefaultConfig.LifecycleRetention
+	}
 	return conf
 }
 
@@ -248,16 +271,20 @@ type LegacyPool struct {
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
 
-	locals  *accountSet // Set of local transaction to exempt from eviction rules
-	journal *journal    // Journal of local transaction to back up to disk
+	locals      *accountSet // Set of local transaction to exempt from eviction rules
+	journal     *journal    // Journal of local transaction to back up to disk
+	poolJournal *journal    // Journal of all pending and queued transactions to back up to disk
 
 	reserve txpool.AddressReserver       // Address reserver to ensure exclusivity across subpools
+	policy  txpool.AdmissionPolicy       // Operator rules for admitting transactions, nil admits all
 	pending map[common.Address]*list     // All currently processable transactions
 	queue   map[common.Address]*list     // Queued but non-processable transactions
 	beats   map[common.Address]time.Time // Last heartbeat from each known account
 	all     *lookup                      // All transactions to allow lookups
 	priced  *pricedList                  // All transactions sorted by price
 
+	lifecycles *lifecycles // Recorded lifecycle events of transactions
+
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
 	queueTxEventCh  chan *types.Transaction
@@ -289,6 +316,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		queue:               make(map[common.Address]*list),
 		beats:               make(map[common.Address]time.Time),
 		all:                 newLookup(),
+		lifecycles:          newLifecycles(config.LifecycleRetention),
 		reqResetCh:          make(chan *txpoolResetRequest),
 		reqPromoteCh:        make(chan *accountSet),
 		queueTxEventCh:      make(chan *types.Transaction),
@@ -307,6 +335,9 @@ func New(config Config, chain BlockChain) *LegacyPool {
 	if !config.NoLocals && config.Journal != "" {
 		pool.journal = newTxJournal(config.Journal)
 	}
+	if config.PoolJournal != "" {
+		pool.poolJournal = newPoolJournal(config.PoolJournal)
+	}
 	return pool
 }
 
@@ -353,13 +384,21 @@ func (pool *LegacyPool) Init(gasTip uint64, head *types.Header, reserve txpool.A
 
 	// If local transactions and journaling is enabled, load from disk
 	if pool.journal != nil {
-		if err := pool.journal.load(pool.addLocals); err != nil {
+		if err := pool.journal.load(pool.addJournaled(true)); err != nil {
 			log.Warn("Failed to load transaction journal", "err", err)
 		}
 		if err := pool.journal.rotate(pool.local()); err != nil {
 			log.Warn("Failed to rotate transaction journal", "err", err)
 		}
 	}
+	// If pool journaling is enabled, load the remaining transactions from disk.
+	// They are added as remote transactions, revalidated against the head state.
+	if pool.poolJournal != nil {
+		if err := pool.poolJournal.load(pool.addJournaled(false)); err != nil {
+			log.Warn("Failed to load pool transaction journal", "err", err)
+		}
+		pool.dumpPool()
+	}
 	pool.wg.Add(1)
 	go pool.loop()
 
@@ -420,12 +459,14 @@ func (pool *LegacyPool) loop() {
 					for _, tx := range list {
 						pool.removeTx(tx.Hash(), true, true)
 					}
+					pool.lifecycles.addAll(list, txpool.TxEventEvicted, reasonLifetime)
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
 			}
 			pool.mu.Unlock()
+			pool.lifecycles.sweep()
 
-		// Handle local transaction journal rotation
+		// Handle transaction journal rotation
 		case <-journal.C:
 			if pool.journal != nil {
 				pool.mu.Lock()
@@ -434,6 +475,9 @@ func (pool *LegacyPool) loop() {
 				}
 				pool.mu.Unlock()
 			}
+			if pool.poolJournal != nil {
+				pool.dumpPool()
+			}
 		}
 	}
 }
@@ -449,6 +493,9 @@ func (pool *LegacyPool) Close() error {
 	if pool.journal != nil {
 		pool.journal.close()
 	}
+	if pool.poolJournal != nil {
+		pool.dumpPool()
+	}
 	log.Info("Transaction pool stopped")
 	return nil
 }
@@ -493,6 +540,32 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 	log.Info("Legacy pool tip threshold updated", "tip", newTip)
 }
 
+// SetAdmissionPolicy replaces the admission policy of the pool, and removes the
+// transactions denied by it. The transactions already in the pool don't count
+// against the quotas of the policy.
+func (pool *LegacyPool) SetAdmissionPolicy(policy txpool.AdmissionPolicy) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.policy = policy
+	if policy == nil {
+		return
+	}
+	denied := make(map[common.Hash]error)
+	pool.all.Range(func(hash common.Hash, tx *types.Transaction, _ bool) bool {
+		from, _ := types.Sender(pool.signer, tx) // already validated during insertion
+		if err := policy.Check(tx, from); err != nil {
+			denied[hash] = err
+		}
+		return true
+	}, true, true)
+	for hash, err := range denied {
+		log.Trace("Evicting transaction denied by admission policy", "hash", hash, "err", err)
+		pool.removeTx(hash, true, true)
+		pool.lifecycles.add(hash, txpool.TxEventEvicted, fmt.Sprintf("%s: %v", reasonDenied, err))
+	}
+}
+
 func (pool *LegacyPool) SetMinFee(minFee *big.Int) {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
@@ -621,6 +694,8 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 					GasTipCap: uint256.MustFromBig(txs[i].GasTipCap()),
 					Gas:       txs[i].Gas(),
 					BlobGas:   txs[i].BlobGas(),
+
+					Conditional: pool.all.Conditional(txs[i].Hash()),
 				}
 			}
 			pending[addr] = lazies
@@ -631,12 +706,18 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 
 // IteratePending iterates over [pool.pending] until [f] returns false.
 // The caller must not modify [tx]. Returns false if iteration was interrupted.
+//
+// Transactions carrying a conditional are skipped, since they are served to
+// peers for gossip and other nodes would not enforce the conditional.
 func (pool *LegacyPool) IteratePending(f func(tx *types.Transaction) bool) bool {
 	pool.mu.RLock()
 	defer pool.mu.RUnlock()
 
 	for _, list := range pool.pending {
 		for _, tx := range list.txs.items {
+			if pool.all.Conditional(tx.Hash()) != nil {
+				continue
+			}
 			if !f(tx) {
 				return false
 			}
@@ -669,6 +750,49 @@ func (pool *LegacyPool) local() map[common.Address]types.Transactions {
 	return txs
 }
 
+// journaled retrieves the transactions to back up to the pool journal, grouped
+// by origin account and sorted by nonce. Pending accounts come before queued
+// ones, and accounts paying higher tips first come before others. Conditional
+// transactions are left out, since the journal does not retain conditionals,
+// and so are local transactions already kept by the local journal. The
+// transaction pool lock must be held.
+func (pool *LegacyPool) journaled() []types.Transactions {
+	var all []types.Transactions
+	for _, lists := range []map[common.Address]*list{pool.pending, pool.queue} {
+		accounts := make([]types.Transactions, 0, len(lists))
+		for addr, list := range lists {
+			if pool.journal != nil && pool.locals.contains(addr) {
+				continue
+			}
+			var txs types.Transactions
+			for _, tx := range list.Flatten() {
+				if pool.all.Conditional(tx.Hash()) == nil {
+					txs = append(txs, tx)
+				}
+			}
+			if len(txs) > 0 {
+				accounts = append(accounts, txs)
+			}
+		}
+		sort.Slice(accounts, func(i, j int) bool {
+			return accounts[i][0].GasTipCapCmp(accounts[j][0]) > 0
+		})
+		all = append(all, accounts...)
+	}
+	return all
+}
+
+// dumpPool regenerates the pool journal with the current contents of the pool.
+func (pool *LegacyPool) dumpPool() {
+	pool.mu.Lock()
+	all := pool.journaled()
+	pool.mu.Unlock()
+
+	if err := pool.poolJournal.dump(all, pool.config.PoolJournalLimit); err != nil {
+		log.Warn("Failed to regenerate pool tx journal", "err", err)
+	}
+}
+
 // validateTxBasics checks whether a transaction is valid according to the consensus
 // rules, but does not check state-dependent validation such as sufficient balance.
 // This check is meant as an early check which only needs to be performed once,
@@ -723,9 +847,7 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 		},
 		ExistingCost: func(addr common.Address, nonce uint64) *big.Int {
 			if list := pool.pending[addr]; list != nil {
-				if tx := list.txs.Get(nonce); tx != nil {
-					return tx.Cost()
-				}
+				return list.Cost(nonce)
 			}
 			return nil
 		},
@@ -736,6 +858,17 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 	return nil
 }
 
+// senderCost returns the amount the sender of tx pays for it in the current
+// state, which excludes its gas if paid by a sponsor.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) senderCost(tx *types.Transaction) *big.Int {
+	from, _ := types.Sender(pool.signer, tx) // already validated
+	head := pool.currentHead.Load()
+	rules := pool.chainconfig.Rules(head.Number, params.IsMergeTODO, head.Time)
+	return txpool.SenderCost(tx, from, rules, pool.currentState)
+}
+
 // add validates a transaction and inserts it into the non-executable queue for later
 // pending promotion and execution. If the transaction is a replacement for an already
 // pending or queued one, it overwrites the previous transaction if its price is higher.
@@ -743,7 +876,11 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 // If a newly added transaction is marked as local, its sending account will be
 // added to the allowlist, preventing any associated transaction from being dropped
 // out of the pool due to pricing constraints.
-func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, err error) {
+//
+// If admit is set, the transaction must also be admitted by the admission policy.
+// Otherwise, it is only checked against the rules of the policy denying it,
+// without counting against its quotas.
+func (pool *LegacyPool) add(tx *types.Transaction, local bool, admit bool) (replaced bool, err error) {
 	// If the transaction is already known, discard it
 	hash := tx.Hash()
 	if pool.all.Get(hash) != nil {
@@ -786,7 +923,18 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 			}
 		}()
 	}
+	// If the transaction doesn't meet the price bump of a transaction it would
+	// replace, discard it before making room for it
+	if list := pool.pending[from]; list != nil && list.Underpriced(tx, pool.config.PriceBump) {
+		pendingDiscardMeter.Mark(1)
+		return false, txpool.ErrReplaceUnderpriced
+	}
+	if list := pool.queue[from]; list != nil && list.Underpriced(tx, pool.config.PriceBump) {
+		queuedDiscardMeter.Mark(1)
+		return false, txpool.ErrReplaceUnderpriced
+	}
 	// If the transaction pool is full, discard underpriced transactions
+	var drop types.Transactions
 	if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.GlobalSlots+pool.config.GlobalQueue {
 		// If the new transaction is underpriced, don't accept it
 		if !isLocal && pool.priced.Underpriced(tx) {
@@ -807,7 +955,8 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		// New transaction is better than our worse ones, make room for it.
 		// If it's a local transaction, forcibly discard all available transactions.
 		// Otherwise if we can't make enough room for new one, abort the operation.
-		drop, success := pool.priced.Discard(pool.all.Slots()-int(pool.config.GlobalSlots+pool.config.GlobalQueue)+numSlots(tx), isLocal)
+		var success bool
+		drop, success = pool.priced.Discard(pool.all.Slots()-int(pool.config.GlobalSlots+pool.config.GlobalQueue)+numSlots(tx), isLocal)
 
 		// Special case, we still can't make the room for the new remote one.
 		if !isLocal && !success {
@@ -835,23 +984,40 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 				return false, txpool.ErrFutureReplacePending
 			}
 		}
+	}
+	// If the operator's admission policy rejects the transaction, discard it.
+	// This is checked last, as admitted transactions count against its limits.
+	if pool.policy != nil {
+		check := pool.policy.Check
+		if admit {
+			check = pool.policy.Admit
+		}
+		if err := check(tx, from); err != nil {
+			// Add all transactions back to the priced queue
+			for _, dropTx := range drop {
+				pool.priced.Put(dropTx, false)
+			}
+			log.Trace("Discarding transaction rejected by admission policy", "hash", hash, "err", err)
+			admissionRejectedMeter.Mark(1)
+			return false, fmt.Errorf("%w: %w", txpool.ErrAdmissionRejected, err)
+		}
+	}
+	// Kick out the underpriced remote transactions.
+	for _, tx := range drop {
+		log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
+		underpricedTxMeter.Mark(1)
 
-		// Kick out the underpriced remote transactions.
-		for _, tx := range drop {
-			log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
-			underpricedTxMeter.Mark(1)
-
-			sender, _ := types.Sender(pool.signer, tx)
-			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
+		sender, _ := types.Sender(pool.signer, tx)
+		dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
+		pool.lifecycles.add(tx.Hash(), txpool.TxEventEvicted, reasonUnderpriced)
 
-			pool.changesSinceReorg += dropped
-		}
+		pool.changesSinceReorg += dropped
 	}
 
 	// Try to replace an existing transaction in the pending pool
 	if list := pool.pending[from]; list != nil && list.Contains(tx.Nonce()) {
 		// Nonce already pending, check if required price bump is met
-		inserted, old := list.Add(tx, pool.config.PriceBump)
+		inserted, old := list.Add(tx, pool.senderCost(tx), pool.config.PriceBump)
 		if !inserted {
 			pendingDiscardMeter.Mark(1)
 			return false, txpool.ErrReplaceUnderpriced
@@ -861,11 +1027,13 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.lifecycles.replaced(old.Hash(), hash)
 		}
 		pool.all.Add(tx, isLocal)
 		pool.priced.Put(tx, isLocal)
 		pool.journalTx(from, tx)
 		pool.queueTxEvent(tx)
+		pool.lifecycles.add(hash, txpool.TxEventPending, "")
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
 		// Successful promotion, bump the heartbeat
@@ -887,6 +1055,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		localGauge.Inc(1)
 	}
 	pool.journalTx(from, tx)
+	pool.lifecycles.add(hash, txpool.TxEventQueued, "")
 
 	log.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replaced, nil
@@ -925,7 +1094,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 	if pool.queue[from] == nil {
 		pool.queue[from] = newList(false)
 	}
-	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump)
+	inserted, old := pool.queue[from].Add(tx, pool.senderCost(tx), pool.config.PriceBump)
 	if !inserted {
 		// An older transaction was better, discard this
 		queuedDiscardMeter.Mark(1)
@@ -936,6 +1105,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		queuedReplaceMeter.Mark(1)
+		pool.lifecycles.replaced(old.Hash(), hash)
 	} else {
 		// Nothing was replaced, bump the queued counter
 		queuedGauge.Inc(1)
@@ -979,12 +1149,13 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 	}
 	list := pool.pending[addr]
 
-	inserted, old := list.Add(tx, pool.config.PriceBump)
+	inserted, old := list.Add(tx, pool.senderCost(tx), pool.config.PriceBump)
 	if !inserted {
 		// An older transaction was better, discard this
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
+		pool.lifecycles.add(hash, txpool.TxEventEvicted, reasonOutbid)
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -992,12 +1163,14 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.lifecycles.replaced(old.Hash(), hash)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
 	}
 	// Set the potentially new pending nonce and notify any subsystems of the new tx
 	pool.pendingNonces.set(addr, tx.Nonce()+1)
+	pool.lifecycles.add(hash, txpool.TxEventPromoted, "")
 
 	// Successful promotion, bump the heartbeat
 	pool.beats[addr] = time.Now()
@@ -1050,6 +1223,21 @@ func (pool *LegacyPool) addRemoteSync(tx *types.Transaction) error {
 // If sync is set, the method will block until all internal maintenance related
 // to the add is finished. Only use this during tests for determinism!
 func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error {
+	return pool.addTxs(txs, local, sync, true)
+}
+
+// addJournaled is like Add, but waits for pool reorganization and doesn't count
+// the transactions against the quotas of the admission policy, which admitted
+// them before they were journaled.
+func (pool *LegacyPool) addJournaled(local bool) func([]*types.Transaction) []error {
+	return func(txs []*types.Transaction) []error {
+		return pool.addTxs(txs, local, true, false)
+	}
+}
+
+// addTxs is like Add, counting the transactions against the quotas of the
+// admission policy only if admit is set.
+func (pool *LegacyPool) addTxs(txs []*types.Transaction, local, sync, admit bool) []error {
 	// Do not treat as local if local transactions have been disabled
 	local = local && !pool.config.NoLocals
 
@@ -1072,6 +1260,7 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 			errs[i] = err
 			log.Trace("Discarding invalid transaction", "hash", tx.Hash(), "err", err)
 			invalidTxMeter.Mark(1)
+			pool.lifecycles.add(tx.Hash(), txpool.TxEventRejected, err.Error())
 			continue
 		}
 		// Accumulate all unknown transactions for deeper processing
@@ -1083,7 +1272,7 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 
 	// Process all the new transaction and merge any errors into the original slice
 	pool.mu.Lock()
-	newErrs, dirtyAddrs := pool.addTxsLocked(news, local)
+	newErrs, dirtyAddrs := pool.addTxsLocked(news, local, admit)
 	pool.mu.Unlock()
 
 	var nilSlot = 0
@@ -1102,14 +1291,78 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 	return errs
 }
 
-// addTxsLocked attempts to queue a batch of transactions if they are valid.
+// AddConditional enqueues a remote transaction into the pool along with the
+// conditional that must hold for it to be included in a block. The conditional
+// is checked against the current head before the transaction is accepted.
+//
+// Conditional transactions are never treated as local, since the journal does
+// not retain their conditionals.
+func (pool *LegacyPool) AddConditional(tx *types.Transaction, cond *txpool.TransactionConditional, sync bool) error {
+	if err := cond.Validate(); err != nil {
+		return err
+	}
+	if pool.all.Get(tx.Hash()) != nil {
+		knownTxMeter.Mark(1)
+		return txpool.ErrAlreadyKnown
+	}
+	if err := pool.validateTxBasics(tx, false); err != nil {
+		log.Trace("Discarding invalid transaction", "hash", tx.Hash(), "err", err)
+		invalidTxMeter.Mark(1)
+		pool.lifecycles.add(tx.Hash(), txpool.TxEventRejected, err.Error())
+		return err
+	}
+	pool.mu.Lock()
+	if err := pool.checkConditional(cond); err != nil {
+		pool.mu.Unlock()
+		return err
+	}
+	errs, dirtyAddrs := pool.addTxsLocked([]*types.Transaction{tx}, false, true)
+	if errs[0] == nil {
+		pool.all.SetConditional(tx.Hash(), cond)
+	}
+	pool.mu.Unlock()
+
+	done := pool.requestPromoteExecutables(dirtyAddrs)
+	if sync {
+		<-done
+	}
+	return errs[0]
+}
+
+// checkConditional checks whether cond can still be met on top of the current
+// head. The transaction pool lock must be held.
+func (pool *LegacyPool) checkConditional(cond *txpool.TransactionConditional) error {
+	if cond.Expired(pool.currentHead.Load()) {
+		return fmt.Errorf("%w: expired", txpool.ErrConditionalFailed)
+	}
+	return cond.CheckState(pool.currentState)
+}
+
+// evictConditionals drops all transactions whose conditionals can no longer be
+// met on top of the current head. The transaction pool lock must be held.
+func (pool *LegacyPool) evictConditionals() {
+	for hash, cond := range pool.all.Conditionals() {
+		if err := pool.checkConditional(cond); err != nil {
+			log.Trace("Evicting conditional transaction", "hash", hash, "err", err)
+			pool.removeTx(hash, true, true)
+			pool.lifecycles.add(hash, txpool.TxEventEvicted, fmt.Sprintf("%s: %v", reasonConditional, err))
+			conditionalEvictedMeter.Mark(1)
+		}
+	}
+}
+
+// addTxsLocked attempts to queue a batch of transactions if they are valid,
+// checking them against the admission policy if admit is set.
 // The transaction pool lock must be held.
-func (pool *LegacyPool) addTxsLocked(txs []*types.Transaction, local bool) ([]error, *accountSet) {
+func (pool *LegacyPool) addTxsLocked(txs []*types.Transaction, local bool, admit bool) ([]error, *accountSet) {
 	dirty := newAccountSet(pool.signer)
 	errs := make([]error, len(txs))
 	for i, tx := range txs {
-		replaced, err := pool.add(tx, local)
+		replaced, err := pool.add(tx, local, admit)
 		errs[i] = err
+		if err != nil && !errors.Is(err, txpool.ErrAlreadyKnown) {
+			pool.lifecycles.add(tx.Hash(), txpool.TxEventRejected, err.Error())
+		}
 		if err == nil && !replaced {
 			dirty.addTx(tx)
 		}
@@ -1138,6 +1391,47 @@ func (pool *LegacyPool) Status(hash common.Hash) txpool.TxStatus {
 	return txpool.TxStatusUnknown
 }
 
+// Report returns the status and recorded lifecycle of a transaction, along with
+// the reasons it is not yet executable or would not be included in a block
+// built now. It returns nil if the pool has no record of the transaction.
+func (pool *LegacyPool) Report(hash common.Hash) *txpool.TxReport {
+	report := &txpool.TxReport{
+		Status: pool.Status(hash),
+		Events: pool.lifecycles.events(hash),
+	}
+	tx := pool.get(hash)
+	if tx == nil {
+		if len(report.Events) == 0 {
+			return nil
+		}
+		return report
+	}
+	from, _ := types.Sender(pool.signer, tx) // already validated
+
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	if report.Status == txpool.TxStatusQueued {
+		if next := pool.pendingNonces.get(from); tx.Nonce() > next {
+			report.Reasons = append(report.Reasons, fmt.Sprintf("nonce gap: nonce %d, next executable nonce %d", tx.Nonce(), next))
+		}
+		if !pool.locals.contains(from) {
+			evictAt := pool.beats[from].Add(pool.config.Lifetime)
+			report.Reasons = append(report.Reasons, fmt.Sprintf("queued transactions of the sender are evicted at %s without activity", evictAt.UTC().Format(time.RFC3339)))
+		}
+	}
+	if minTip := pool.gasTip.Load().ToBig(); tx.GasTipCapIntCmp(minTip) < 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("underpriced: gas tip cap %s below the minimum tip %s", tx.GasTipCap(), minTip))
+	}
+	if baseFee := pool.priced.urgent.baseFee; baseFee != nil && tx.GasFeeCapIntCmp(baseFee) < 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("underpriced: gas fee cap %s below the estimated base fee %s", tx.GasFeeCap(), baseFee))
+	}
+	if balance := pool.currentState.GetBalance(from).ToBig(); balance.Cmp(tx.Cost()) < 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("insufficient funds: balance %s below cost %s", balance, tx.Cost()))
+	}
+	return report
+}
+
 // Get returns a transaction if it is contained in the pool and nil otherwise.
 func (pool *LegacyPool) Get(hash common.Hash) *types.Transaction {
 	tx := pool.get(hash)
@@ -1213,6 +1507,7 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 				// Internal shuffle shouldn't touch the lookup set.
 				pool.enqueueTx(tx.Hash(), tx, false, false)
 			}
+			pool.lifecycles.addAll(invalids, txpool.TxEventDemoted, reasonNonceGap)
 			// Update the account nonce if needed
 			pool.pendingNonces.setIfLower(addr, tx.Nonce())
 			// Reduce the pending counter
@@ -1376,6 +1671,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	// because of another transaction (e.g. higher gas price).
 	if reset != nil {
 		pool.demoteUnexecutables()
+		pool.evictConditionals()
 		if reset.newHead != nil {
 			if pool.chainconfig.IsLondon(reset.newHead.Number) {
 				if err := pool.updateBaseFeeAt(reset.newHead); err != nil {
@@ -1471,6 +1767,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 				}
 				for add.NumberU64() > rem.NumberU64() {
 					included = append(included, add.Transactions()...)
+					pool.lifecycles.included(add)
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
 						return
@@ -1483,6 +1780,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 						return
 					}
 					included = append(included, add.Transactions()...)
+					pool.lifecycles.included(add)
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
 						return
@@ -1497,6 +1795,11 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 				reinject = lost
 			}
 		}
+	} else if oldHead != nil && newHead != nil {
+		// The new head extends the old one, record the inclusion of its transactions
+		if block := pool.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64()); block != nil {
+			pool.lifecycles.included(block)
+		}
 	}
 	// Initialize the internal state to the current head
 	if newHead == nil {
@@ -1515,7 +1818,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	// so that we can correctly drop txs with < minBaseFee from tx pool.
 	chainConfig := params.GetExtra(pool.chainconfig)
 	if chainConfig.IsPrecompileEnabled(feemanager.ContractAddress, newHead.Time) {
-		feeConfig, _, err := pool.chain.GetFeeConfigAt(newHead)
+		feeConfig, _, err := pool.chain.GetFeeConfigAt(newHead, uint64(time.Now().Unix()))
 		if err != nil {
 			log.Error("Failed to get fee config state", "err", err, "root", newHead.Root)
 			return
@@ -1523,10 +1826,12 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 		pool.minimumFee = feeConfig.MinBaseFee
 	}
 
-	// Inject any transactions discarded due to reorgs
+	// Inject any transactions discarded due to reorgs. They were admitted when
+	// first added, so they don't count against the quotas of the admission
+	// policy again.
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	pool.chain.SenderCacher().Recover(pool.signer, reinject)
-	pool.addTxsLocked(reinject, false)
+	pool.addTxsLocked(reinject, false, false)
 }
 
 // promoteExecutables moves transactions that have become processable from the
@@ -1549,13 +1854,15 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 			hash := tx.Hash()
 			pool.all.Remove(hash)
 		}
+		pool.lifecycles.stale(forwards)
 		log.Trace("Removed old queued transactions", "count", len(forwards))
 		// Drop all transactions that are too costly (low balance or out of gas)
-		drops, _ := list.Filter(pool.currentState.GetBalance(addr), gasLimit)
+		drops, _ := list.Filter(pool.currentState.GetBalance(addr), gasLimit, pool.senderCost)
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
 		}
+		pool.lifecycles.addAll(drops, txpool.TxEventEvicted, reasonUnpayable)
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
 
@@ -1579,6 +1886,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 				pool.all.Remove(hash)
 				log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 			}
+			pool.lifecycles.addAll(caps, txpool.TxEventEvicted, reasonAccountQueue)
 			queuedRateLimitMeter.Mark(int64(len(caps)))
 		}
 		// Mark all the items dropped as removed
@@ -1647,6 +1955,7 @@ func (pool *LegacyPool) truncatePending() {
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
 						log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 					}
+					pool.lifecycles.addAll(caps, txpool.TxEventEvicted, reasonGlobalSlots)
 					pool.priced.Removed(len(caps))
 					pendingGauge.Dec(int64(len(caps)))
 					if pool.locals.contains(offenders[i]) {
@@ -1674,6 +1983,7 @@ func (pool *LegacyPool) truncatePending() {
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
 					log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 				}
+				pool.lifecycles.addAll(caps, txpool.TxEventEvicted, reasonGlobalSlots)
 				pool.priced.Removed(len(caps))
 				pendingGauge.Dec(int64(len(caps)))
 				if pool.locals.contains(addr) {
@@ -1716,6 +2026,7 @@ func (pool *LegacyPool) truncateQueue() {
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
 				pool.removeTx(tx.Hash(), true, true)
+				pool.lifecycles.add(tx.Hash(), txpool.TxEventEvicted, reasonGlobalQueue)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
@@ -1725,6 +2036,7 @@ func (pool *LegacyPool) truncateQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true, true)
+			pool.lifecycles.add(txs[i].Hash(), txpool.TxEventEvicted, reasonGlobalQueue)
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1751,13 +2063,15 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			pool.all.Remove(hash)
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
+		pool.lifecycles.stale(olds)
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
-		drops, invalids := list.Filter(pool.currentState.GetBalance(addr), gasLimit)
+		drops, invalids := list.Filter(pool.currentState.GetBalance(addr), gasLimit, pool.senderCost)
 		for _, tx := range drops {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 			pool.all.Remove(hash)
 		}
+		pool.lifecycles.addAll(drops, txpool.TxEventEvicted, reasonUnpayable)
 		pendingNofundsMeter.Mark(int64(len(drops)))
 
 		for _, tx := range invalids {
@@ -1767,6 +2081,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			// Internal shuffle shouldn't touch the lookup set.
 			pool.enqueueTx(hash, tx, false, false)
 		}
+		pool.lifecycles.addAll(invalids, txpool.TxEventDemoted, reasonUnpayable)
 		pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
 		if pool.locals.contains(addr) {
 			localGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
@@ -1781,6 +2096,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 				// Internal shuffle shouldn't touch the lookup set.
 				pool.enqueueTx(hash, tx, false, false)
 			}
+			pool.lifecycles.addAll(gapped, txpool.TxEventDemoted, reasonNonceGap)
 			pendingGauge.Dec(int64(len(gapped)))
 		}
 		// Delete the entire pending entry if it became empty.
@@ -1847,12 +2163,13 @@ func (pool *LegacyPool) updateBaseFee() {
 // assumes lock is already held
 // should only be called when the chain is in Subnet EVM.
 func (pool *LegacyPool) updateBaseFeeAt(head *types.Header) error {
-	feeConfig, _, err := pool.chain.GetFeeConfigAt(head)
+	now := time.Now()
+	feeConfig, _, err := pool.chain.GetFeeConfigAt(head, uint64(now.Unix()))
 	if err != nil {
 		return err
 	}
 	chainConfig := params.GetExtra(pool.chainconfig)
-	baseFeeEstimate, err := customheader.EstimateNextBaseFee(chainConfig, feeConfig, head, uint64(time.Now().UnixMilli()))
+	baseFeeEstimate, err := customheader.EstimateNextBaseFee(chainConfig, feeConfig, head, uint64(now.UnixMilli()))
 	if err != nil {
 		return err
 	}
@@ -1955,17 +2272,19 @@ func (as *accountSet) merge(other *accountSet) {
 // This lookup set combines the notion of "local transactions", which is useful
 // to build upper-level structure.
 type lookup struct {
-	slots   int
-	lock    sync.RWMutex
-	locals  map[common.Hash]*types.Transaction
-	remotes map[common.Hash]*types.Transaction
+	slots        int
+	lock         sync.RWMutex
+	locals       map[common.Hash]*types.Transaction
+	remotes      map[common.Hash]*types.Transaction
+	conditionals map[common.Hash]*txpool.TransactionConditional
 }
 
 // newLookup returns a new lookup structure.
 func newLookup() *lookup {
 	return &lookup{
-		locals:  make(map[common.Hash]*types.Transaction),
-		remotes: make(map[common.Hash]*types.Transaction),
+		locals:       make(map[common.Hash]*types.Transaction),
+		remotes:      make(map[common.Hash]*types.Transaction),
+		conditionals: make(map[common.Hash]*txpool.TransactionConditional),
 	}
 }
 
@@ -2084,6 +2403,38 @@ func (t *lookup) Remove(hash common.Hash) {
 
 	delete(t.locals, hash)
 	delete(t.remotes, hash)
+	delete(t.conditionals, hash)
+}
+
+// SetConditional attaches a conditional to a transaction in the lookup. The
+// conditional is dropped along with the transaction.
+func (t *lookup) SetConditional(hash common.Hash, cond *txpool.TransactionConditional) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.conditionals[hash] = cond
+}
+
+// Conditional returns the conditional attached to a transaction, or nil if
+// the transaction has none.
+func (t *lookup) Conditional(hash common.Hash) *txpool.TransactionConditional {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.conditionals[hash]
+}
+
+// Conditionals returns a copy of all conditionals in the lookup, keyed by
+// transaction hash.
+func (t *lookup) Conditionals() map[common.Hash]*txpool.TransactionConditional {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	conditionals := make(map[common.Hash]*txpool.TransactionConditional, len(t.conditionals))
+	for hash, cond := range t.conditionals {
+		conditionals[hash] = cond
+	}
+	return conditionals
 }
 
 // RemoteToLocals migrates the transactions belongs to the given locals to locals
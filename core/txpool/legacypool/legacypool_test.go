@@ -32,26 +32,38 @@ import (
 	crand "crypto/rand"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/rawdb"
 	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/crypto"
 	"github.com/ava-labs/libevm/event"
+	"github.com/ava-labs/libevm/rlp"
 	"github.com/ava-labs/libevm/trie"
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/extstate"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/txpool/admission"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feesponsor"
+	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/holiman/uint256"
 )
 
@@ -140,7 +152,7 @@ func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent)
 	return bc.chainHeadFeed.Subscribe(ch)
 }
 
-func (bc *testBlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
+func (bc *testBlockChain) GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error) {
 	return testFeeConfig, common.Big0, nil
 }
 
@@ -252,6 +264,21 @@ func validatePoolInternals(pool *LegacyPool) error {
 			return fmt.Errorf("pending nonce mismatch: have %v, want %v", nonce, last+1)
 		}
 	}
+	// Ensure the total cost of each list is the sum of the costs of its transactions
+	for _, lists := range []map[common.Address]*list{pool.pending, pool.queue} {
+		for addr, list := range lists {
+			if len(list.costs) != list.Len() {
+				return fmt.Errorf("cost count mismatch for %v: have %d, want %d", addr, len(list.costs), list.Len())
+			}
+			total := new(uint256.Int)
+			for _, cost := range list.costs {
+				total.Add(total, cost)
+			}
+			if !total.Eq(list.totalcost) {
+				return fmt.Errorf("total cost mismatch for %v: have %v, want %v", addr, list.totalcost, total)
+			}
+		}
+	}
 	return nil
 }
 
@@ -407,6 +434,441 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+func TestFeeSponsoredTransactions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sponsorKey, _ = crypto.GenerateKey()
+		otherKey, _   = crypto.GenerateKey()
+		senderKey, _  = crypto.GenerateKey()
+		sponsorAddr   = crypto.PubkeyToAddress(sponsorKey.PublicKey)
+		senderAddr    = crypto.PubkeyToAddress(senderKey.PublicKey)
+		config        = params.Copy(params.TestChainConfig)
+		statedb, _    = state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		feesponsor.ConfigKey: feesponsor.NewConfig(utils.NewUint64(0), nil, []common.Address{sponsorAddr}, nil),
+	}
+	statedb.SetBalance(sponsorAddr, new(uint256.Int).SetUint64(params.Ether))
+	feesponsor.SetFeeSponsorStatus(extstate.New(statedb), sponsorAddr, allowlist.EnabledRole)
+
+	blockchain := newTestBlockChain(&config, 10000000, statedb, new(event.Feed))
+	pool := New(testTxPoolConfig, blockchain)
+	if err := pool.Init(testTxPoolConfig.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver()); err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	sponsoredTx := func(nonce uint64, auth *feesponsor.Authorization, key *ecdsa.PrivateKey) *types.Transaction {
+		if err := auth.Sign(ids.Empty, key); err != nil {
+			t.Fatal(err)
+		}
+		tx, err := types.SignNewTx(senderKey, types.LatestSignerForChainID(config.ChainID), &types.AccessListTx{
+			ChainID:    config.ChainID,
+			Nonce:      nonce,
+			GasPrice:   big.NewInt(1),
+			Gas:        100000,
+			To:         &common.Address{},
+			AccessList: types.AccessList{{Address: feesponsor.ContractAddress, StorageKeys: auth.Predicate()}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	newAuth := func(sponsor common.Address, sender common.Address, nonce uint64) *feesponsor.Authorization {
+		return &feesponsor.Authorization{
+			Sponsor: sponsor,
+			Sender:  sender,
+			Nonce:   nonce,
+			Expiry:  math.MaxUint64,
+			MaxFee:  new(uint256.Int).SetUint64(params.Ether),
+		}
+	}
+
+	// The sender has no balance, but its transactions are paid by the sponsor.
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if err := pool.addRemoteSync(sponsoredTx(nonce, newAuth(sponsorAddr, senderAddr, nonce), sponsorKey)); err != nil {
+			t.Fatalf("failed to add sponsored transaction %d: %v", nonce, err)
+		}
+	}
+	if pending, _ := pool.Stats(); pending != 2 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 2)
+	}
+	// Resetting the pool keeps sponsored transactions the sender cannot pay for.
+	<-pool.requestReset(nil, nil)
+	if pending, _ := pool.Stats(); pending != 2 {
+		t.Fatalf("pending transactions mismatched after reset: have %d, want %d", pending, 2)
+	}
+
+	// Authorizations must be signed by the sponsor for the transaction.
+	if err, want := pool.addRemote(sponsoredTx(2, newAuth(sponsorAddr, senderAddr, 2), otherKey)), txpool.ErrInvalidSponsorship; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	if err, want := pool.addRemote(sponsoredTx(2, newAuth(sponsorAddr, senderAddr, 3), sponsorKey)), txpool.ErrInvalidSponsorship; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	// Addresses that are not enabled sponsors cannot pay for gas.
+	otherAddr := crypto.PubkeyToAddress(otherKey.PublicKey)
+	testAddBalance(pool, otherAddr, big.NewInt(params.Ether))
+	if err, want := pool.addRemote(sponsoredTx(2, newAuth(otherAddr, senderAddr, 2), otherKey)), core.ErrInsufficientFunds; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+
+	// Sponsored transactions are dropped on reset once their sponsor can no
+	// longer pay for them.
+	pool.mu.Lock()
+	pool.currentState.SetBalance(sponsorAddr, new(uint256.Int))
+	pool.mu.Unlock()
+	<-pool.requestReset(nil, nil)
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("transactions mismatched after sponsor ran out of funds: have %d pending and %d queued, want none", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+func TestConditionalTransactions(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupPool()
+	defer pool.Close()
+
+	var (
+		contract = common.Address{0x01}
+		slot     = common.Hash{0x02}
+		value    = common.Hash{0x03}
+		keys     = make([]*ecdsa.PrivateKey, 3)
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(params.Ether))
+	}
+	pool.mu.Lock()
+	pool.currentState.SetState(contract, slot, value)
+	pool.mu.Unlock()
+
+	knownSlot := func(value common.Hash) map[common.Address]txpool.KnownAccount {
+		return map[common.Address]txpool.KnownAccount{
+			contract: {StorageSlots: map[common.Hash]common.Hash{slot: value}},
+		}
+	}
+	tests := []struct {
+		name string
+		cond *txpool.TransactionConditional
+		want error
+	}{
+		{
+			name: "inverted bounds",
+			cond: &txpool.TransactionConditional{BlockNumberMin: big.NewInt(2), BlockNumberMax: big.NewInt(1)},
+			want: txpool.ErrInvalidConditional,
+		},
+		{
+			name: "root and slots",
+			cond: &txpool.TransactionConditional{KnownAccounts: map[common.Address]txpool.KnownAccount{
+				contract: {StorageRoot: &common.Hash{}, StorageSlots: map[common.Hash]common.Hash{slot: value}},
+			}},
+			want: txpool.ErrInvalidConditional,
+		},
+		{
+			name: "slot mismatch",
+			cond: &txpool.TransactionConditional{KnownAccounts: knownSlot(common.Hash{})},
+			want: txpool.ErrConditionalFailed,
+		},
+		{
+			name: "expired",
+			cond: &txpool.TransactionConditional{BlockNumberMax: big.NewInt(0)},
+			want: txpool.ErrConditionalFailed,
+		},
+	}
+	for _, test := range tests {
+		if err := pool.AddConditional(transaction(0, 100000, keys[0]), test.cond, true); !errors.Is(err, test.want) {
+			t.Errorf("%s: want %v have %v", test.name, test.want, err)
+		}
+	}
+	slots := make(map[common.Hash]common.Hash, txpool.MaxConditionalCost+1)
+	for i := 0; i <= txpool.MaxConditionalCost; i++ {
+		slots[common.BigToHash(big.NewInt(int64(i)))] = common.Hash{}
+	}
+	costly := &txpool.TransactionConditional{KnownAccounts: map[common.Address]txpool.KnownAccount{contract: {StorageSlots: slots}}}
+	if err, want := pool.AddConditional(transaction(0, 100000, keys[0]), costly, true), txpool.ErrConditionalCost; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+
+	// Add one transaction bound to the storage slot, one bound to a timestamp
+	// and a plain one.
+	var (
+		slotTx  = transaction(0, 100000, keys[0])
+		timeTx  = transaction(0, 100000, keys[1])
+		plainTx = transaction(0, 100000, keys[2])
+		maxTime = uint64(5)
+	)
+	if err := pool.AddConditional(slotTx, &txpool.TransactionConditional{KnownAccounts: knownSlot(value)}, true); err != nil {
+		t.Fatalf("failed to add slot conditional transaction: %v", err)
+	}
+	if err := pool.AddConditional(timeTx, &txpool.TransactionConditional{TimestampMax: &maxTime}, true); err != nil {
+		t.Fatalf("failed to add timestamp conditional transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(plainTx); err != nil {
+		t.Fatalf("failed to add plain transaction: %v", err)
+	}
+	for addr, txs := range pool.Pending(txpool.PendingFilter{}) {
+		want := addr != crypto.PubkeyToAddress(keys[2].PublicKey)
+		if have := txs[0].Conditional != nil; have != want {
+			t.Errorf("pending conditional of %s mismatch: have %v, want %v", addr, have, want)
+		}
+	}
+	// Conditional transactions are not served to peers.
+	var iterated []common.Hash
+	pool.IteratePending(func(tx *types.Transaction) bool {
+		iterated = append(iterated, tx.Hash())
+		return true
+	})
+	if len(iterated) != 1 || iterated[0] != plainTx.Hash() {
+		t.Errorf("iterated pending mismatch: have %v, want [%v]", iterated, plainTx.Hash())
+	}
+
+	// Advancing the head past the timestamp bound evicts the timestamp bound
+	// transaction.
+	head := &types.Header{Number: big.NewInt(1), Time: maxTime + 1, GasLimit: 10000000}
+	<-pool.requestReset(nil, head)
+	if pool.Has(timeTx.Hash()) {
+		t.Error("expired conditional transaction not evicted")
+	}
+	if !pool.Has(slotTx.Hash()) || !pool.Has(plainTx.Hash()) {
+		t.Error("valid transactions evicted")
+	}
+	// Modifying the storage slot evicts the slot bound transaction.
+	pool.mu.Lock()
+	pool.currentState.SetState(contract, slot, common.Hash{})
+	pool.mu.Unlock()
+	<-pool.requestReset(nil, head)
+	if pool.Has(slotTx.Hash()) {
+		t.Error("failed conditional transaction not evicted")
+	}
+	if !pool.Has(plainTx.Hash()) {
+		t.Error("plain transaction evicted")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// denySender is an admission policy rejecting the transactions of a sender.
+type denySender common.Address
+
+func (d denySender) Admit(tx *types.Transaction, from common.Address) error {
+	return d.Check(tx, from)
+}
+
+func (d denySender) Check(_ *types.Transaction, from common.Address) error {
+	if from == common.Address(d) {
+		return errors.New("sender denied")
+	}
+	return nil
+}
+
+func TestAdmissionPolicy(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	tx := transaction(0, 100000, key)
+	from, _ := deriveSender(tx)
+	testAddBalance(pool, from, big.NewInt(params.Ether))
+
+	pool.SetAdmissionPolicy(denySender(from))
+	err := pool.addRemoteSync(tx)
+	if !errors.Is(err, txpool.ErrAdmissionRejected) {
+		t.Fatalf("want %v have %v", txpool.ErrAdmissionRejected, err)
+	}
+	if want := "transaction rejected by admission policy: sender denied"; err.Error() != want {
+		t.Errorf("rejection reason mismatch: have %q, want %q", err, want)
+	}
+	// Invalid transactions are rejected before the policy is consulted.
+	if err, want := pool.addRemoteSync(transaction(0, 100, key)), core.ErrIntrinsicGas; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+
+	pool.SetAdmissionPolicy(nil)
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Fatalf("failed to add transaction without policy: %v", err)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the transactions denied by a new admission policy are removed from
+// the pool, and that journaled transactions are checked against it.
+func TestAdmissionPolicyDenied(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	deniedKey, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	denied := crypto.PubkeyToAddress(deniedKey.PublicKey)
+	testAddBalance(pool, from, big.NewInt(params.Ether))
+	testAddBalance(pool, denied, big.NewInt(params.Ether))
+
+	tx := transaction(0, 100000, key)
+	deniedTxs := []*types.Transaction{transaction(0, 100000, deniedKey), transaction(2, 100000, deniedKey)}
+	for _, tx := range append(deniedTxs, tx) {
+		if err := pool.addRemoteSync(tx); err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	pool.SetAdmissionPolicy(denySender(denied))
+	for _, deniedTx := range deniedTxs {
+		if pool.Has(deniedTx.Hash()) {
+			t.Errorf("denied transaction %x not removed", deniedTx.Hash())
+		}
+	}
+	if !pool.Has(tx.Hash()) {
+		t.Error("admitted transaction removed")
+	}
+	// Journaled transactions don't count against the quotas of the policy,
+	// but are still denied by it.
+	if errs := pool.addJournaled(false)(deniedTxs[:1]); !errors.Is(errs[0], txpool.ErrAdmissionRejected) {
+		t.Fatalf("want %v have %v", txpool.ErrAdmissionRejected, errs[0])
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that transactions rejected by the pool don't count against the limits
+// of the admission policy.
+func TestAdmissionPolicyRejectedNotCounted(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(params.Ether))
+
+	pool.SetAdmissionPolicy(admission.New(admission.Config{
+		SenderRateLimit: &admission.RateLimit{Transactions: 3, Window: time.Hour},
+	}))
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(2), key)); err != nil {
+		t.Fatalf("failed to add pending transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(2, 100000, big.NewInt(2), key)); err != nil {
+		t.Fatalf("failed to add queued transaction: %v", err)
+	}
+	// Underpriced replacements of the pending and queued transactions are
+	// rejected by the pool before the policy is consulted.
+	for _, nonce := range []uint64{0, 2} {
+		if err, want := pool.addRemoteSync(pricedTransaction(nonce, 100001, big.NewInt(2), key)), txpool.ErrReplaceUnderpriced; !errors.Is(err, want) {
+			t.Fatalf("nonce %d: want %v have %v", nonce, want, err)
+		}
+	}
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(2), key)); err != nil {
+		t.Fatalf("failed to add transaction within rate limit: %v", err)
+	}
+	if err, want := pool.addRemoteSync(pricedTransaction(3, 100000, big.NewInt(2), key)), admission.ErrRateLimited; !errors.Is(err, want) {
+		t.Fatalf("want %v have %v", want, err)
+	}
+	pending, queued := pool.Stats()
+	if pending != 3 || queued != 0 {
+		t.Fatalf("pool stats mismatch: have %d pending and %d queued, want 3 and 0", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// eventTypes returns the types of the recorded lifecycle of a transaction.
+func eventTypes(pool *LegacyPool, hash common.Hash) []txpool.TxEventType {
+	var have []txpool.TxEventType
+	for _, event := range pool.lifecycles.events(hash) {
+		have = append(have, event.Type)
+	}
+	return have
+}
+
+func TestTransactionLifecycle(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(params.Ether))
+
+	// A gapped transaction is queued, and explained by the gap.
+	gapped := transaction(1, 100000, key)
+	if err := pool.addRemoteSync(gapped); err != nil {
+		t.Fatalf("failed to add gapped transaction: %v", err)
+	}
+	report := pool.Report(gapped.Hash())
+	if report == nil || report.Status != txpool.TxStatusQueued {
+		t.Fatalf("gapped transaction report mismatch: have %+v", report)
+	}
+	if len(report.Reasons) == 0 || !strings.Contains(report.Reasons[0], "nonce gap") {
+		t.Errorf("gapped transaction reasons mismatch: have %v", report.Reasons)
+	}
+	// Filling the gap promotes both transactions.
+	first := transaction(0, 100000, key)
+	if err := pool.addRemoteSync(first); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if have, want := eventTypes(pool, gapped.Hash()), []txpool.TxEventType{txpool.TxEventQueued, txpool.TxEventPromoted}; !slices.Equal(have, want) {
+		t.Errorf("gapped transaction events mismatch: have %v, want %v", have, want)
+	}
+	report = pool.Report(gapped.Hash())
+	if report.Status != txpool.TxStatusPending {
+		t.Errorf("promoted transaction status mismatch: have %v", report.Status)
+	}
+	// The test transactions are priced below the base fee.
+	if len(report.Reasons) != 1 || !strings.Contains(report.Reasons[0], "below the estimated base fee") {
+		t.Errorf("promoted transaction reasons mismatch: have %v", report.Reasons)
+	}
+	// Replacing a pending transaction records the replacement.
+	replacement := pricedTransaction(0, 100000, big.NewInt(2), key)
+	if err := pool.addRemoteSync(replacement); err != nil {
+		t.Fatalf("failed to add replacement transaction: %v", err)
+	}
+	report = pool.Report(first.Hash())
+	if report == nil || report.Status != txpool.TxStatusUnknown {
+		t.Fatalf("replaced transaction report mismatch: have %+v", report)
+	}
+	if last := report.Events[len(report.Events)-1]; last.Type != txpool.TxEventReplaced || last.Replacement != replacement.Hash() {
+		t.Errorf("replaced transaction last event mismatch: have %+v", last)
+	}
+	// Rejected transactions are recorded with the reason.
+	rejected := transaction(2, 100, key)
+	if err := pool.addRemoteSync(rejected); !errors.Is(err, core.ErrIntrinsicGas) {
+		t.Fatalf("want %v have %v", core.ErrIntrinsicGas, err)
+	}
+	if events := pool.lifecycles.events(rejected.Hash()); len(events) != 1 || events[0].Type != txpool.TxEventRejected || !strings.Contains(events[0].Reason, core.ErrIntrinsicGas.Error()) {
+		t.Errorf("rejected transaction events mismatch: have %+v", events)
+	}
+	// Transactions whose nonce is used on chain leave the pool.
+	testSetNonce(pool, from, 2)
+	<-pool.requestReset(nil, nil)
+	if have, want := eventTypes(pool, gapped.Hash()), []txpool.TxEventType{txpool.TxEventQueued, txpool.TxEventPromoted, txpool.TxEventEvicted}; !slices.Equal(have, want) {
+		t.Errorf("stale transaction events mismatch: have %v, want %v", have, want)
+	}
+	// Lifecycles of transactions that left the pool are dropped after the
+	// retention window.
+	pool.lifecycles.now = func() time.Time { return time.Now().Add(pool.config.LifecycleRetention + time.Minute) }
+	pool.lifecycles.sweep()
+	for _, tx := range []*types.Transaction{gapped, first, replacement, rejected} {
+		if report := pool.Report(tx.Hash()); report != nil {
+			t.Errorf("lifecycle of %s not dropped: %+v", tx.Hash(), report)
+		}
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -528,14 +990,14 @@ func TestChainFork(t *testing.T) {
 	resetState()
 
 	tx := transaction(0, 100000, key)
-	if _, err := pool.add(tx, false); err != nil {
+	if _, err := pool.add(tx, false, true); err != nil {
 		t.Error("didn't expect error", err)
 	}
 	pool.removeTx(tx.Hash(), true, true)
 
 	// reset the pool's internal state
 	resetState()
-	if _, err := pool.add(tx, false); err != nil {
+	if _, err := pool.add(tx, false, true); err != nil {
 		t.Error("didn't expect error", err)
 	}
 }
@@ -562,10 +1024,10 @@ func TestDoubleNonce(t *testing.T) {
 	tx3, _ := types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(100), 1000000, big.NewInt(1), nil), signer, key)
 
 	// Add the first two transaction, ensure higher priced stays only
-	if replace, err := pool.add(tx1, false); err != nil || replace {
+	if replace, err := pool.add(tx1, false, true); err != nil || replace {
 		t.Errorf("first transaction insert failed (%v) or reported replacement (%v)", err, replace)
 	}
-	if replace, err := pool.add(tx2, false); err != nil || !replace {
+	if replace, err := pool.add(tx2, false, true); err != nil || !replace {
 		t.Errorf("second transaction insert failed (%v) or not reported replacement (%v)", err, replace)
 	}
 	<-pool.requestPromoteExecutables(newAccountSet(signer, addr))
@@ -577,7 +1039,7 @@ func TestDoubleNonce(t *testing.T) {
 	}
 
 	// Add the third transaction and ensure it's not saved (smaller price)
-	pool.add(tx3, false)
+	pool.add(tx3, false, true)
 	<-pool.requestPromoteExecutables(newAccountSet(signer, addr))
 	if pool.pending[addr].Len() != 1 {
 		t.Error("expected 1 pending transactions, got", pool.pending[addr].Len())
@@ -600,7 +1062,7 @@ func TestMissingNonce(t *testing.T) {
 	addr := crypto.PubkeyToAddress(key.PublicKey)
 	testAddBalance(pool, addr, big.NewInt(100000000000000))
 	tx := transaction(1, 100000, key)
-	if _, err := pool.add(tx, false); err != nil {
+	if _, err := pool.add(tx, false, true); err != nil {
 		t.Error("didn't expect error", err)
 	}
 	if len(pool.pending) != 0 {
@@ -2511,6 +2973,90 @@ func testJournaling(t *testing.T, nolocals bool) {
 	pool.Close()
 }
 
+// TestPoolJournaling tests that remote pending and queued transactions survive
+// restarts through the pool journal, revalidated against the head state and
+// bounded by the journal size limit.
+func TestPoolJournaling(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.PoolJournal = filepath.Join(t.TempDir(), "pool.rlp")
+	config.Rejournal = time.Second
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000))
+	}
+	// Add two pending transactions, a queued one and a conditional one.
+	var (
+		stale       = pricedTransaction(0, 100000, big.NewInt(2), keys[0])
+		pending     = pricedTransaction(1, 100000, big.NewInt(2), keys[0])
+		queued      = pricedTransaction(2, 100000, big.NewInt(1), keys[1])
+		conditional = pricedTransaction(0, 100000, big.NewInt(1), keys[2])
+	)
+	if errs := pool.addRemotesSync([]*types.Transaction{stale, pending, queued}); errors.Join(errs...) != nil {
+		t.Fatalf("failed to add remote transactions: %v", errs)
+	}
+	if err := pool.AddConditional(conditional, &txpool.TransactionConditional{BlockNumberMax: big.NewInt(10)}, true); err != nil {
+		t.Fatalf("failed to add conditional transaction: %v", err)
+	}
+	if pending, queued := pool.Stats(); pending != 3 || queued != 1 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want 3/1", pending, queued)
+	}
+	pool.Close()
+
+	// Use the nonce of the first transaction and restart the pool. Only the
+	// conditional and now stale transactions should be dropped.
+	statedb.SetNonce(crypto.PubkeyToAddress(keys[0].PublicKey), 1)
+	blockchain = newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	pool = New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+
+	for _, tx := range []*types.Transaction{stale, conditional} {
+		if pool.Has(tx.Hash()) {
+			t.Errorf("transaction %s restored", tx.Hash())
+		}
+	}
+	if status := pool.Status(pending.Hash()); status != txpool.TxStatusPending {
+		t.Errorf("pending transaction status mismatched: have %v, want %v", status, txpool.TxStatusPending)
+	}
+	if status := pool.Status(queued.Hash()); status != txpool.TxStatusQueued {
+		t.Errorf("queued transaction status mismatched: have %v, want %v", status, txpool.TxStatusQueued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	pool.Close()
+
+	// Restart the pool with room for a single transaction in the journal. Only
+	// the pending one should be kept.
+	enc, _ := rlp.EncodeToBytes(pending)
+	config.PoolJournalLimit = uint64(len(enc))
+
+	pool = New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+	pool.Close()
+
+	pool = New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	if pending, queued := pool.Stats(); pending != 1 || queued != 0 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want 1/0", pending, queued)
+	}
+	if !pool.Has(pending.Hash()) {
+		t.Errorf("pending transaction %s not restored", pending.Hash())
+	}
+}
+
 // TestStatusCheck tests that the pool can correctly retrieve the
 // pending status of individual transactions.
 func TestStatusCheck(t *testing.T) {
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package legacypool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/lru"
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/core/txpool"
+)
+
+const (
+	// maxLifecycles is the maximum number of transactions whose lifecycle is
+	// recorded. The least recently updated lifecycles are dropped beyond it.
+	maxLifecycles = 64 * 1024
+
+	// maxLifecycleEvents is the maximum number of events recorded for a single
+	// transaction. The oldest events are dropped beyond it.
+	maxLifecycleEvents = 32
+)
+
+// Reasons recorded for lifecycle events.
+const (
+	reasonLifetime     = "queued longer than the pool lifetime"
+	reasonUnderpriced  = "underpriced while the pool is full"
+	reasonUnpayable    = "balance or block gas limit too low"
+	reasonAccountQueue = "account queue limit exceeded"
+	reasonGlobalQueue  = "global queue limit exceeded"
+	reasonGlobalSlots  = "pending limit exceeded"
+	reasonStaleNonce   = "nonce used by another transaction"
+	reasonNonceGap     = "nonce gap after removal of an earlier transaction"
+	reasonConditional  = "conditional can no longer be met"
+	reasonOutbid       = "outbid by a transaction with the same nonce"
+	reasonDenied       = "denied by the admission policy"
+)
+
+// lifecycles records the lifecycle events of transactions. Lifecycles of
+// transactions that left the pool are kept for the retention window.
+type lifecycles struct {
+	retention time.Duration
+	now       func() time.Time
+
+	lock sync.Mutex
+	txs  lru.BasicLRU[common.Hash, []txpool.TxEvent]
+}
+
+func newLifecycles(retention time.Duration) *lifecycles {
+	return &lifecycles{
+		retention: retention,
+		now:       time.Now,
+		txs:       lru.NewBasicLRU[common.Hash, []txpool.TxEvent](maxLifecycles),
+	}
+}
+
+// record appends event to the lifecycle of the transaction with hash.
+func (l *lifecycles) record(hash common.Hash, event txpool.TxEvent) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	event.Time = l.now()
+	events, _ := l.txs.Get(hash)
+	if len(events) >= maxLifecycleEvents {
+		events = append(events[:0:0], events[len(events)-maxLifecycleEvents+1:]...)
+	}
+	l.txs.Add(hash, append(events, event))
+}
+
+// add records an event of type typ for the transaction with hash.
+func (l *lifecycles) add(hash common.Hash, typ txpool.TxEventType, reason string) {
+	l.record(hash, txpool.TxEvent{Type: typ, Reason: reason})
+}
+
+// addAll records an event of type typ for each of txs.
+func (l *lifecycles) addAll(txs types.Transactions, typ txpool.TxEventType, reason string) {
+	for _, tx := range txs {
+		l.add(tx.Hash(), typ, reason)
+	}
+}
+
+// replaced records the replacement of the transaction with hash.
+func (l *lifecycles) replaced(hash common.Hash, replacement common.Hash) {
+	l.record(hash, txpool.TxEvent{Type: txpool.TxEventReplaced, Replacement: replacement})
+}
+
+// included records the inclusion of the transactions of block seen by the
+// pool.
+func (l *lifecycles) included(block *types.Block) {
+	for _, tx := range block.Transactions() {
+		if l.has(tx.Hash()) {
+			l.record(tx.Hash(), txpool.TxEvent{Type: txpool.TxEventIncluded, BlockNumber: block.NumberU64()})
+		}
+	}
+}
+
+// stale records the removal of txs whose nonce was used on chain, unless their
+// inclusion was already recorded.
+func (l *lifecycles) stale(txs types.Transactions) {
+	for _, tx := range txs {
+		if events := l.events(tx.Hash()); len(events) == 0 || events[len(events)-1].Type != txpool.TxEventIncluded {
+			l.add(tx.Hash(), txpool.TxEventEvicted, reasonStaleNonce)
+		}
+	}
+}
+
+func (l *lifecycles) has(hash common.Hash) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.txs.Contains(hash)
+}
+
+// events returns a copy of the lifecycle of the transaction with hash.
+func (l *lifecycles) events(hash common.Hash) []txpool.TxEvent {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	events, _ := l.txs.Peek(hash)
+	return append([]txpool.TxEvent(nil), events...)
+}
+
+// sweep drops the lifecycles of transactions that left the pool before the
+// retention window.
+func (l *lifecycles) sweep() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	cutoff := l.now().Add(-l.retention)
+	for _, hash := range l.txs.Keys() {
+		events, _ := l.txs.Peek(hash)
+		if last := events[len(events)-1]; last.Type.Final() && last.Time.Before(cutoff) {
+			l.txs.Remove(hash)
+		}
+	}
+}
@@ -287,6 +287,9 @@ type list struct {
 	costcap   *uint256.Int // Price of the highest costing transaction (reset only if exceeds balance)
 	gascap    uint64       // Gas limit of the highest spending transaction (reset only if exceeds block limit)
 	totalcost *uint256.Int // Total cost of all transactions in the list
+
+	costs     map[uint64]*uint256.Int // Cost of each transaction to the sender, by nonce
+	sponsored int                     // Number of transactions whose gas is paid by a sponsor
 }
 
 // newList creates a new transaction list for maintaining nonce-indexable fast,
@@ -297,6 +300,7 @@ func newList(strict bool) *list {
 		txs:       newSortedMap(),
 		costcap:   new(uint256.Int),
 		totalcost: new(uint256.Int),
+		costs:     make(map[uint64]*uint256.Int),
 	}
 }
 
@@ -310,44 +314,29 @@ func (l *list) Contains(nonce uint64) bool {
 // transaction was accepted, and if yes, any previous transaction it replaced.
 //
 // If the new transaction is accepted into the list, the lists' cost and gas
-// thresholds are also potentially updated.
-func (l *list) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Transaction) {
+// thresholds are also potentially updated. The sender of the transaction pays
+// cost for it, which is less than its full cost if its gas is sponsored.
+func (l *list) Add(tx *types.Transaction, cost *big.Int, priceBump uint64) (bool, *types.Transaction) {
 	// If there's an older better transaction, abort
 	old := l.txs.Get(tx.Nonce())
 	if old != nil {
-		if old.GasFeeCapCmp(tx) >= 0 || old.GasTipCapCmp(tx) >= 0 {
-			return false, nil
-		}
-		// thresholdFeeCap = oldFC  * (100 + priceBump) / 100
-		a := big.NewInt(100 + int64(priceBump))
-		aFeeCap := new(big.Int).Mul(a, old.GasFeeCap())
-		aTip := a.Mul(a, old.GasTipCap())
-
-		// thresholdTip    = oldTip * (100 + priceBump) / 100
-		b := big.NewInt(100)
-		thresholdFeeCap := aFeeCap.Div(aFeeCap, b)
-		thresholdTip := aTip.Div(aTip, b)
-
-		// We have to ensure that both the new fee cap and tip are higher than the
-		// old ones as well as checking the percentage threshold to ensure that
-		// this is accurate for low (Wei-level) gas price replacements.
-		if tx.GasFeeCapIntCmp(thresholdFeeCap) < 0 || tx.GasTipCapIntCmp(thresholdTip) < 0 {
+		if !replaces(tx, old, priceBump) {
 			return false, nil
 		}
 		// Old is being replaced, subtract old cost
 		l.subTotalCost([]*types.Transaction{old})
 	}
 	// Add new tx cost to totalcost
-	cost, overflow := uint256.FromBig(tx.Cost())
+	senderCost, overflow := uint256.FromBig(cost)
 	if overflow {
 		return false, nil
 	}
-	l.totalcost.Add(l.totalcost, cost)
+	l.addTotalCost(tx, senderCost)
 
 	// Otherwise overwrite the old transaction with the current one
 	l.txs.Put(tx)
-	if l.costcap.Cmp(cost) < 0 {
-		l.costcap = cost
+	if l.costcap.Cmp(senderCost) < 0 {
+		l.costcap = senderCost
 	}
 	if gas := tx.Gas(); l.gascap < gas {
 		l.gascap = gas
@@ -355,6 +344,34 @@ func (l *list) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Transa
 	return true, old
 }
 
+// Underpriced checks whether tx is rejected by Add for not bumping the price of
+// the transaction with the same nonce in the list by priceBump percent.
+func (l *list) Underpriced(tx *types.Transaction, priceBump uint64) bool {
+	old := l.txs.Get(tx.Nonce())
+	return old != nil && !replaces(tx, old, priceBump)
+}
+
+// replaces checks whether tx bumps the price of old by priceBump percent.
+func replaces(tx, old *types.Transaction, priceBump uint64) bool {
+	if old.GasFeeCapCmp(tx) >= 0 || old.GasTipCapCmp(tx) >= 0 {
+		return false
+	}
+	// thresholdFeeCap = oldFC  * (100 + priceBump) / 100
+	a := big.NewInt(100 + int64(priceBump))
+	aFeeCap := new(big.Int).Mul(a, old.GasFeeCap())
+	aTip := a.Mul(a, old.GasTipCap())
+
+	// thresholdTip    = oldTip * (100 + priceBump) / 100
+	b := big.NewInt(100)
+	thresholdFeeCap := aFeeCap.Div(aFeeCap, b)
+	thresholdTip := aTip.Div(aTip, b)
+
+	// We have to ensure that both the new fee cap and tip are higher than the
+	// old ones as well as checking the percentage threshold to ensure that
+	// this is accurate for low (Wei-level) gas price replacements.
+	return tx.GasFeeCapIntCmp(thresholdFeeCap) >= 0 && tx.GasTipCapIntCmp(thresholdTip) >= 0
+}
+
 // Forward removes all transactions from the list with a nonce lower than the
 // provided threshold. Every removed transaction is returned for any post-removal
 // maintenance.
@@ -367,13 +384,17 @@ func (l *list) Forward(threshold uint64) types.Transactions {
 // Filter removes all transactions from the list with a cost or gas limit higher
 // than the provided thresholds. Every removed transaction is returned for any
 // post-removal maintenance. Strict-mode invalidated transactions are also
-// returned.
+// returned. The cost of sponsored transactions is first recomputed with
+// senderCost, as their sponsorship may no longer apply.
 //
 // This method uses the cached costcap and gascap to quickly decide if there's even
 // a point in calculating all the costs or if the balance covers all. If the threshold
 // is lower than the costgas cap, the caps will be reset to a new high after removing
 // the newly invalidated transactions.
-func (l *list) Filter(costLimit *uint256.Int, gasLimit uint64) (types.Transactions, types.Transactions) {
+func (l *list) Filter(costLimit *uint256.Int, gasLimit uint64, senderCost func(*types.Transaction) *big.Int) (types.Transactions, types.Transactions) {
+	if l.sponsored > 0 {
+		l.recost(senderCost)
+	}
 	// If all transactions are below the threshold, short circuit
 	if l.costcap.Cmp(costLimit) <= 0 && l.gascap <= gasLimit {
 		return nil, nil
@@ -383,7 +404,7 @@ func (l *list) Filter(costLimit *uint256.Int, gasLimit uint64) (types.Transactio
 
 	// Filter out all the transactions above the account's funds
 	removed := l.txs.Filter(func(tx *types.Transaction) bool {
-		return tx.Gas() > gasLimit || tx.Cost().Cmp(costLimit.ToBig()) > 0
+		return tx.Gas() > gasLimit || l.costs[tx.Nonce()].Cmp(costLimit) > 0
 	})
 
 	if len(removed) == 0 {
@@ -470,17 +491,61 @@ func (l *list) LastElement() *types.Transaction {
 	return l.txs.LastElement()
 }
 
+// recost recomputes the cost of the sponsored transactions with senderCost,
+// raising the cost cap if needed.
+func (l *list) recost(senderCost func(*types.Transaction) *big.Int) {
+	for nonce, cost := range l.costs {
+		tx := l.txs.Get(nonce)
+		if cost.CmpBig(tx.Cost()) >= 0 {
+			continue
+		}
+		newCost, overflow := uint256.FromBig(senderCost(tx))
+		if overflow || newCost.Eq(cost) {
+			continue
+		}
+		l.subTotalCost([]*types.Transaction{tx})
+		l.addTotalCost(tx, newCost)
+		if l.costcap.Cmp(newCost) < 0 {
+			l.costcap = newCost
+		}
+	}
+}
+
+// addTotalCost records the cost of the given transaction to the sender and
+// adds it to the total cost of all transactions.
+func (l *list) addTotalCost(tx *types.Transaction, cost *uint256.Int) {
+	l.costs[tx.Nonce()] = cost
+	if cost.CmpBig(tx.Cost()) < 0 {
+		l.sponsored++
+	}
+	l.totalcost.Add(l.totalcost, cost)
+}
+
 // subTotalCost subtracts the cost of the given transactions from the
 // total cost of all transactions.
 func (l *list) subTotalCost(txs []*types.Transaction) {
 	for _, tx := range txs {
-		_, underflow := l.totalcost.SubOverflow(l.totalcost, uint256.MustFromBig(tx.Cost()))
+		cost := l.costs[tx.Nonce()]
+		delete(l.costs, tx.Nonce())
+		if cost.CmpBig(tx.Cost()) < 0 {
+			l.sponsored--
+		}
+		_, underflow := l.totalcost.SubOverflow(l.totalcost, cost)
 		if underflow {
 			panic("totalcost underflow")
 		}
 	}
 }
 
+// Cost returns the cost to the sender of the transaction with the given nonce,
+// or nil if the list does not contain it.
+func (l *list) Cost(nonce uint64) *big.Int {
+	if cost, ok := l.costs[nonce]; ok {
+		return cost.ToBig()
+	}
+	return nil
+}
+
 // priceHeap is a heap.Interface implementation over transactions for retrieving
 // price-sorted transactions to discard when the pool fills up. If baseFee is set
 // then the heap is sorted based on the effective tip based on the given base fee.
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+)
+
+// TxEventType is the type of an event in the lifecycle of a transaction.
+type TxEventType string
+
+const (
+	TxEventRejected TxEventType = "rejected" // Not admitted to the pool
+	TxEventQueued   TxEventType = "queued"   // Admitted to the non-executable queue
+	TxEventPending  TxEventType = "pending"  // Admitted directly to the executable pending set
+	TxEventPromoted TxEventType = "promoted" // Moved from the queue to the pending set
+	TxEventDemoted  TxEventType = "demoted"  // Moved from the pending set back to the queue
+	TxEventReplaced TxEventType = "replaced" // Replaced by a transaction with the same nonce
+	TxEventEvicted  TxEventType = "evicted"  // Removed from the pool without being included
+	TxEventIncluded TxEventType = "included" // Included in a block
+)
+
+// Final reports whether the transaction is no longer in the pool after an event
+// of type t.
+func (t TxEventType) Final() bool {
+	switch t {
+	case TxEventRejected, TxEventReplaced, TxEventEvicted, TxEventIncluded:
+		return true
+	default:
+		return false
+	}
+}
+
+// TxEvent is an event in the lifecycle of a transaction.
+type TxEvent struct {
+	Type        TxEventType
+	Time        time.Time
+	Reason      string      // Why the event happened, if not implied by its type
+	Replacement common.Hash // Transaction replacing this one, for replacements
+	BlockNumber uint64      // Block including the transaction, for inclusions
+}
+
+// TxReport describes the current status of a transaction in a pool, the
+// reasons it is not yet executable or included, and its recorded lifecycle.
+type TxReport struct {
+	Status  TxStatus
+	Reasons []string
+	Events  []TxEvent
+}
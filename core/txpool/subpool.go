@@ -52,6 +52,8 @@ type LazyTransaction struct {
 
 	Gas     uint64 // Amount of gas required by the transaction
 	BlobGas uint64 // Amount of blob gas required by the transaction
+
+	Conditional *TransactionConditional // Preconditions for including the transaction, if any
 }
 
 // Resolve retrieves the full transaction belonging to a lazy handle if it is still
@@ -77,6 +79,21 @@ type LazyResolver interface {
 	Get(hash common.Hash) *types.Transaction
 }
 
+// AdmissionPolicy decides whether a transaction that is valid for a subpool may
+// be admitted into it, on top of the limits of the subpool itself.
+type AdmissionPolicy interface {
+	// Admit returns the reason tx sent by from is rejected, or nil if it is
+	// admitted. Admitted transactions count against the quotas of the policy,
+	// so subpools only call it once all of their own checks have passed.
+	Admit(tx *types.Transaction, from common.Address) error
+
+	// Check returns the reason tx sent by from is denied, ignoring the quotas
+	// of the policy. It is used for transactions admitted before, such as the
+	// ones loaded from the journal or reinjected after a reorg, or already in
+	// the pool when the policy is set.
+	Check(tx *types.Transaction, from common.Address) error
+}
+
 // AddressReserver is passed by the main transaction pool to subpools, so they
 // may request (and relinquish) exclusive access to certain addresses.
 type AddressReserver func(addr common.Address, reserve bool) error
@@ -177,3 +194,34 @@ type SubPool interface {
 	// identified by their hashes.
 	Status(hash common.Hash) TxStatus
 }
+
+// ConditionalSubPool is implemented by subpools that can track transactions
+// carrying inclusion preconditions.
+type ConditionalSubPool interface {
+	SubPool
+
+	// AddConditional enqueues a remote transaction along with the conditional
+	// that must hold for it to be included in a block.
+	AddConditional(tx *types.Transaction, cond *TransactionConditional, sync bool) error
+}
+
+// AdmissionPolicySubPool is implemented by subpools enforcing an admission
+// policy.
+type AdmissionPolicySubPool interface {
+	SubPool
+
+	// SetAdmissionPolicy replaces the admission policy of the subpool, and
+	// removes the transactions denied by it. A nil policy admits all valid
+	// transactions.
+	SetAdmissionPolicy(policy AdmissionPolicy)
+}
+
+// LifecycleSubPool is implemented by subpools recording the lifecycle of the
+// transactions they see.
+type LifecycleSubPool interface {
+	SubPool
+
+	// Report returns the status and recorded lifecycle of a transaction, or nil
+	// if the subpool has no record of it.
+	Report(hash common.Hash) *TxReport
+}
@@ -408,6 +408,33 @@ func (p *TxPool) AddRemotesSync(txs []*types.Transaction) []error {
 	return p.Add(txs, false, true)
 }
 
+// SetAdmissionPolicy replaces the admission policy of the subpools enforcing
+// one. Transactions already in the pool are removed if the policy denies them,
+// but don't count against its quotas.
+func (p *TxPool) SetAdmissionPolicy(policy AdmissionPolicy) {
+	for _, subpool := range p.subpools {
+		if policyPool, ok := subpool.(AdmissionPolicySubPool); ok {
+			policyPool.SetAdmissionPolicy(policy)
+		}
+	}
+}
+
+// AddConditional enqueues a remote transaction into the subpool accepting it,
+// along with the conditional that must hold for it to be included in a block.
+func (p *TxPool) AddConditional(tx *types.Transaction, cond *TransactionConditional, sync bool) error {
+	for _, subpool := range p.subpools {
+		if !subpool.Filter(tx) {
+			continue
+		}
+		conditionalPool, ok := subpool.(ConditionalSubPool)
+		if !ok {
+			return ErrConditionalNotSupported
+		}
+		return conditionalPool.AddConditional(tx, cond, sync)
+	}
+	return core.ErrTxTypeNotSupported
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -556,6 +583,19 @@ func (p *TxPool) Status(hash common.Hash) TxStatus {
 	return TxStatusUnknown
 }
 
+// Report returns the status and recorded lifecycle of a transaction from the
+// first subpool with a record of it, or nil if there is none.
+func (p *TxPool) Report(hash common.Hash) *TxReport {
+	for _, subpool := range p.subpools {
+		if lifecyclePool, ok := subpool.(LifecycleSubPool); ok {
+			if report := lifecyclePool.Report(hash); report != nil {
+				return report
+			}
+		}
+	}
+	return nil
+}
+
 // Sync is a helper method for unit tests or simulator runs where the chain events
 // are arriving in quick succession, without any time in between them to run the
 // internal background reset operations. This method will run an explicit reset
@@ -32,6 +32,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/types"
@@ -42,7 +43,9 @@ import (
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/vmerrors"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feesponsor"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/holiman/uint256"
 )
 
 var (
@@ -130,6 +133,21 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if txGas := tx.Gas(); txGas < intrGas {
 		return fmt.Errorf("%w: address %v tx gas (%v), minimum needed %v", core.ErrIntrinsicGas, from.Hex(), txGas, intrGas)
 	}
+	// Ensure a fee sponsorship authorization was signed by its sponsor for this
+	// transaction, as the pool relies on it to accept transactions whose sender
+	// cannot pay for gas.
+	if auth, ok := feesponsor.AuthorizationFromAccessList(tx.AccessList()); ok && params.GetExtra(opts.Config).IsPrecompileEnabled(feesponsor.ContractAddress, head.Time) {
+		if auth.Sender != from || auth.Nonce != tx.Nonce() {
+			return fmt.Errorf("%w: authorization for %s with nonce %d", ErrInvalidSponsorship, auth.Sender, auth.Nonce)
+		}
+		chainID := ids.Empty
+		if snowCtx := params.GetExtra(opts.Config).SnowCtx; snowCtx != nil {
+			chainID = snowCtx.ChainID
+		}
+		if err := auth.Verify(chainID); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSponsorship, err)
+		}
+	}
 	// Ensure the gasprice is high enough to cover the requirement of the calling pool
 	if tx.GasTipCapIntCmp(opts.MinTip) < 0 {
 		return fmt.Errorf("%w: gas tip cap %v, minimum needed %v", ErrUnderpriced, tx.GasTipCap(), opts.MinTip)
@@ -249,7 +267,7 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 	// Ensure the transactor has enough funds to cover the transaction costs
 	var (
 		balance = opts.State.GetBalance(from).ToBig()
-		cost    = tx.Cost()
+		cost    = SenderCost(tx, from, opts.Rules, opts.State)
 	)
 	if balance.Cmp(cost) < 0 {
 		return fmt.Errorf("%w: balance %v, tx cost %v, overshot %v", core.ErrInsufficientFunds, balance, cost, new(big.Int).Sub(cost, balance))
@@ -277,12 +295,52 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 	}
 
 	// If the tx allow list is enabled, return an error if the from address is not allow listed.
-	if params.GetRulesExtra(opts.Rules).IsPrecompileEnabled(txallowlist.ContractAddress) {
-		txAllowListRole := txallowlist.GetTxAllowListStatus(opts.State, from)
+	if rulesExtra := params.GetRulesExtra(opts.Rules); rulesExtra.IsPrecompileEnabled(txallowlist.ContractAddress) {
+		txAllowListRole := txallowlist.GetTxAllowListStatus(opts.State, from, rulesExtra.IsHeliconActivated(), rulesExtra.Timestamp)
 		if !txAllowListRole.IsEnabled() {
 			return fmt.Errorf("%w: %s", vmerrors.ErrSenderAddressNotAllowListed, from)
 		}
 	}
 
+	// If the call allow list is enabled, return an error if the from address may not call the destination contract,
+	// or create a contract.
+	if err := core.CheckCallAllowList(opts.Rules, opts.State, from, tx.To()); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// SenderCost returns the amount [from] pays for [tx] in [stateDB] under
+// [rules]. A sender only pays for the value of a transaction whose gas is paid
+// by the sponsor of its fee sponsorship authorization. Since the sponsorship
+// depends on the state, the cost of pooled transactions must be recomputed as
+// the state changes.
+func SenderCost(tx *types.Transaction, from common.Address, rules params.Rules, stateDB *state.StateDB) *big.Int {
+	if isSponsored(tx, from, rules, stateDB) {
+		return tx.Value()
+	}
+	return tx.Cost()
+}
+
+// isSponsored returns true if the gas of [tx] sent by [from] can be paid by
+// the sponsor of its fee sponsorship authorization. The signature of the
+// authorization is verified in [ValidateTransaction].
+func isSponsored(tx *types.Transaction, from common.Address, rules params.Rules, stateDB *state.StateDB) bool {
+	rulesExtra := params.GetRulesExtra(rules)
+	if !rulesExtra.IsPrecompileEnabled(feesponsor.ContractAddress) {
+		return false
+	}
+	auth, ok := feesponsor.AuthorizationFromAccessList(tx.AccessList())
+	if !ok {
+		return false
+	}
+	gasCost, overflow := uint256.FromBig(new(big.Int).Sub(tx.Cost(), tx.Value()))
+	if overflow || !auth.Covers(from, tx.Nonce(), gasCost, rulesExtra.Timestamp) {
+		return false
+	}
+	if !feesponsor.CanSponsor(stateDB, auth.Sponsor, gasCost, rulesExtra.IsHeliconActivated(), rulesExtra.Timestamp) {
+		return false
+	}
+	return stateDB.GetBalance(auth.Sponsor).Cmp(gasCost) >= 0
+}
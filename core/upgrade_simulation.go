@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+)
+
+// UpgradeSimulation is the result of applying candidate upgrades on top of the
+// state of a block with [SimulateUpgrades].
+type UpgradeSimulation struct {
+	// VerifyError is the error verifying the chain config with the candidate upgrades, if any.
+	VerifyError string `json:"verifyError,omitempty"`
+	// CompatibilityError is the error checking the candidate upgrades are
+	// compatible with the upgrades already activated, if any.
+	CompatibilityError string `json:"compatibilityError,omitempty"`
+	// ApplyError is the error applying the upgrades, if any.
+	ApplyError string `json:"applyError,omitempty"`
+
+	// Activations are the upgrades activated after the block, in the order they are applied.
+	Activations []UpgradeActivation `json:"activations"`
+	// PrecompileConfigs are the precompile configs active after the last
+	// activation, keyed by their config key.
+	PrecompileConfigs extras.Precompiles `json:"precompileConfigs"`
+}
+
+// UpgradeActivation describes the upgrades activated at a timestamp.
+type UpgradeActivation struct {
+	Timestamp uint64 `json:"timestamp"`
+	// Precompiles are the precompile configs enabling or disabling a precompile at the timestamp.
+	Precompiles []extras.PrecompileUpgrade `json:"precompiles,omitempty"`
+	// Accounts are the changes of the accounts modified by state upgrades at the timestamp.
+	Accounts map[common.Address]*AccountDiff `json:"accounts,omitempty"`
+}
+
+// AccountDiff is the change of an account modified by a state upgrade.
+type AccountDiff struct {
+	BalanceBefore  *hexutil.Big                 `json:"balanceBefore"`
+	BalanceAfter   *hexutil.Big                 `json:"balanceAfter"`
+	NonceBefore    hexutil.Uint64               `json:"nonceBefore"`
+	NonceAfter     hexutil.Uint64               `json:"nonceAfter"`
+	CodeHashBefore common.Hash                  `json:"codeHashBefore"`
+	CodeHashAfter  common.Hash                  `json:"codeHashAfter"`
+	Storage        map[common.Hash]*StorageDiff `json:"storage,omitempty"`
+}
+
+// StorageDiff is the change of a storage slot modified by a state upgrade.
+type StorageDiff struct {
+	Before common.Hash `json:"before"`
+	After  common.Hash `json:"after"`
+}
+
+// SimulateUpgrades applies the precompile and state upgrades of [upgradeConfig]
+// activating after [head] on top of [statedb], the state of [head], as if
+// [upgradeConfig] replaced the upgrades of [config]. Each activation timestamp
+// is applied as an empty block following the previous one.
+//
+// [statedb] is modified and must be discarded. [config] is not modified.
+func SimulateUpgrades(config *params.ChainConfig, upgradeConfig extras.UpgradeConfig, head *types.Header, statedb *state.StateDB) *UpgradeSimulation {
+	simConfig := params.Copy(config)
+	simExtra := params.GetExtra(&simConfig)
+	simExtra.UpgradeConfig = upgradeConfig
+	if overrides := upgradeConfig.NetworkUpgradeOverrides; overrides != nil {
+		simExtra.Override(overrides)
+	}
+
+	result := &UpgradeSimulation{
+		Activations:       []UpgradeActivation{},
+		PrecompileConfigs: make(extras.Precompiles),
+	}
+	if err := simExtra.Verify(); err != nil {
+		result.VerifyError = err.Error()
+		return result
+	}
+	if err := params.GetExtra(config).CheckConfigCompatible(&simConfig, head.Number, head.Time); err != nil {
+		result.CompatibilityError = err.Error()
+	}
+
+	var (
+		parentTimestamp = head.Time
+		number          = new(big.Int).Set(head.Number)
+	)
+	for _, timestamp := range upgradeTimestamps(simExtra, head.Time) {
+		number.Add(number, common.Big1)
+		activation := UpgradeActivation{Timestamp: timestamp}
+		for _, module := range modules.RegisteredModules() {
+			for _, activatingConfig := range simExtra.GetActivatingPrecompileConfigs(module.Address, &parentTimestamp, timestamp, simExtra.PrecompileUpgrades) {
+				activation.Precompiles = append(activation.Precompiles, extras.PrecompileUpgrade{Config: activatingConfig})
+			}
+		}
+		stateUpgrades := simExtra.GetActivatingStateUpgrades(&parentTimestamp, timestamp, simExtra.StateUpgrades)
+		diffs := accountDiffsBefore(stateUpgrades, statedb)
+
+		if err := ApplyUpgrades(&simConfig, &parentTimestamp, NewBlockContext(number, timestamp), statedb); err != nil {
+			result.ApplyError = fmt.Errorf("applying upgrades at timestamp %d: %w", timestamp, err).Error()
+			return result
+		}
+		statedb.Finalise(true)
+		accountDiffsAfter(diffs, statedb)
+		if len(diffs) > 0 {
+			activation.Accounts = diffs
+		}
+		result.Activations = append(result.Activations, activation)
+		parentTimestamp = timestamp
+	}
+
+	for _, module := range modules.RegisteredModules() {
+		if activeConfig := simExtra.GetActivePrecompileConfig(module.Address, parentTimestamp); activeConfig != nil && !activeConfig.IsDisabled() {
+			result.PrecompileConfigs[module.ConfigKey] = activeConfig
+		}
+	}
+	return result
+}
+
+// upgradeTimestamps returns the sorted distinct timestamps after [after] at
+// which the network, precompile and state upgrades of [config] activate.
+// Network upgrades are included since [ApplyUpgrades] also applies the
+// changes of their transitions, such as enabling the allow list indexes.
+func upgradeTimestamps(config *extras.ChainConfig, after uint64) []uint64 {
+	timestamps := make(map[uint64]struct{})
+	for _, ts := range config.NetworkUpgrades.Timestamps() {
+		if ts > after {
+			timestamps[ts] = struct{}{}
+		}
+	}
+	for _, upgrade := range config.PrecompileUpgrades {
+		if ts := upgrade.Timestamp(); ts != nil && *ts > after {
+			timestamps[*ts] = struct{}{}
+		}
+	}
+	for _, upgrade := range config.StateUpgrades {
+		if ts := upgrade.BlockTimestamp; ts != nil && *ts > after {
+			timestamps[*ts] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(timestamps))
+}
+
+// accountDiffsBefore returns the diffs of the accounts modified by
+// [stateUpgrades], populated with their current values in [statedb].
+func accountDiffsBefore(stateUpgrades []extras.StateUpgrade, statedb *state.StateDB) map[common.Address]*AccountDiff {
+	diffs := make(map[common.Address]*AccountDiff)
+	for _, upgrade := range stateUpgrades {
+		for account, accountUpgrade := range upgrade.StateUpgradeAccounts {
+			diff, ok := diffs[account]
+			if !ok {
+				diff = &AccountDiff{
+					BalanceBefore:  (*hexutil.Big)(statedb.GetBalance(account).ToBig()),
+					NonceBefore:    hexutil.Uint64(statedb.GetNonce(account)),
+					CodeHashBefore: statedb.GetCodeHash(account),
+				}
+				diffs[account] = diff
+			}
+			for key := range accountUpgrade.Storage {
+				if diff.Storage == nil {
+					diff.Storage = make(map[common.Hash]*StorageDiff)
+				}
+				if _, ok := diff.Storage[key]; !ok {
+					diff.Storage[key] = &StorageDiff{Before: statedb.GetState(account, key)}
+				}
+			}
+		}
+	}
+	return diffs
+}
+
+// accountDiffsAfter populates [diffs] with the values of the accounts in [statedb].
+func accountDiffsAfter(diffs map[common.Address]*AccountDiff, statedb *state.StateDB) {
+	for account, diff := range diffs {
+		diff.BalanceAfter = (*hexutil.Big)(statedb.GetBalance(account).ToBig())
+		diff.NonceAfter = hexutil.Uint64(statedb.GetNonce(account))
+		diff.CodeHashAfter = statedb.GetCodeHash(account)
+		for key, storageDiff := range diff.Storage {
+			storageDiff.After = statedb.GetState(account, key)
+		}
+	}
+}
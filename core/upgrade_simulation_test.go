@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/math"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ava-labs/subnet-evm/utils/utilstest"
+)
+
+func TestSimulateUpgrades(t *testing.T) {
+	var (
+		admin   = common.HexToAddress("0x0100000000000000000000000000000000000000")
+		account = common.HexToAddress("0x0200000000000000000000000000000000000000")
+		slot    = common.HexToHash("0x01")
+		value   = common.HexToHash("0x02")
+		head    = &types.Header{Number: big.NewInt(1), Time: 10}
+	)
+	newState := func(t *testing.T) *state.StateDB {
+		statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		require.NoError(t, err)
+		statedb.SetBalance(account, common.U2560)
+		return statedb
+	}
+	newConfig := func(t *testing.T, upgrades extras.UpgradeConfig) *params.ChainConfig {
+		config := params.Copy(params.TestChainConfig)
+		configExtra := params.GetExtra(&config)
+		configExtra.SnowCtx = utilstest.NewTestSnowContext(t)
+		configExtra.UpgradeConfig = upgrades
+		return &config
+	}
+
+	t.Run("applies precompile and state upgrades", func(t *testing.T) {
+		require := require.New(t)
+
+		config := newConfig(t, extras.UpgradeConfig{})
+		candidate := extras.UpgradeConfig{
+			PrecompileUpgrades: []extras.PrecompileUpgrade{
+				{Config: txallowlist.NewConfig(utils.NewUint64(20), []common.Address{admin}, nil, nil)},
+			},
+			StateUpgrades: []extras.StateUpgrade{
+				{
+					BlockTimestamp: utils.NewUint64(30),
+					StateUpgradeAccounts: map[common.Address]extras.StateUpgradeAccount{
+						account: {
+							Storage:       map[common.Hash]common.Hash{slot: value},
+							BalanceChange: (*math.HexOrDecimal256)(big.NewInt(100)),
+						},
+					},
+				},
+			},
+		}
+		statedb := newState(t)
+		result := SimulateUpgrades(config, candidate, head, statedb)
+		require.Empty(result.VerifyError)
+		require.Empty(result.CompatibilityError)
+		require.Empty(result.ApplyError)
+		require.Len(result.Activations, 2)
+
+		precompileActivation := result.Activations[0]
+		require.Equal(uint64(20), precompileActivation.Timestamp)
+		require.Len(precompileActivation.Precompiles, 1)
+		require.Equal(txallowlist.ConfigKey, precompileActivation.Precompiles[0].Key())
+		require.Empty(precompileActivation.Accounts)
+		require.Equal(allowlist.AdminRole, txallowlist.GetTxAllowListStatus(statedb, admin, false, 20))
+
+		stateActivation := result.Activations[1]
+		require.Equal(uint64(30), stateActivation.Timestamp)
+		require.Empty(stateActivation.Precompiles)
+		require.Len(stateActivation.Accounts, 1)
+		diff := stateActivation.Accounts[account]
+		require.Zero(diff.BalanceBefore.ToInt().Sign())
+		require.Equal(big.NewInt(100), diff.BalanceAfter.ToInt())
+		require.Equal(&StorageDiff{After: value}, diff.Storage[slot])
+
+		require.Len(result.PrecompileConfigs, 1)
+		require.Contains(result.PrecompileConfigs, txallowlist.ConfigKey)
+
+		// The simulated upgrades must not leak into the chain config.
+		require.Empty(params.GetExtra(config).PrecompileUpgrades)
+		require.Empty(params.GetExtra(config).StateUpgrades)
+	})
+
+	t.Run("skips activated upgrades", func(t *testing.T) {
+		require := require.New(t)
+
+		activated := extras.UpgradeConfig{
+			PrecompileUpgrades: []extras.PrecompileUpgrade{
+				{Config: txallowlist.NewConfig(utils.NewUint64(5), []common.Address{admin}, nil, nil)},
+			},
+		}
+		config := newConfig(t, activated)
+		candidate := extras.UpgradeConfig{
+			PrecompileUpgrades: []extras.PrecompileUpgrade{
+				activated.PrecompileUpgrades[0],
+				{Config: txallowlist.NewDisableConfig(utils.NewUint64(20))},
+			},
+		}
+		result := SimulateUpgrades(config, candidate, head, newState(t))
+		require.Empty(result.VerifyError)
+		require.Empty(result.CompatibilityError)
+		require.Empty(result.ApplyError)
+		require.Len(result.Activations, 1)
+		require.True(result.Activations[0].Precompiles[0].IsDisabled())
+		require.Empty(result.PrecompileConfigs)
+	})
+
+	t.Run("applies network upgrade overrides", func(t *testing.T) {
+		require := require.New(t)
+
+		config := newConfig(t, extras.UpgradeConfig{
+			PrecompileUpgrades: []extras.PrecompileUpgrade{
+				{Config: txallowlist.NewConfig(utils.NewUint64(5), []common.Address{admin}, nil, nil)},
+			},
+		})
+		candidate := params.GetExtra(config).UpgradeConfig
+		candidate.NetworkUpgradeOverrides = &extras.NetworkUpgrades{HeliconTimestamp: utils.NewUint64(20)}
+		statedb := newState(t)
+		// The precompile was configured at its activation.
+		statedb.SetNonce(txallowlist.ContractAddress, 1)
+		result := SimulateUpgrades(config, candidate, head, statedb)
+		require.Empty(result.VerifyError)
+		require.Empty(result.CompatibilityError)
+		require.Empty(result.ApplyError)
+		require.Len(result.Activations, 1)
+		require.Equal(uint64(20), result.Activations[0].Timestamp)
+		// The allow list index is enabled at the Helicon transition.
+		require.True(allowlist.IsIndexEnabled(statedb, txallowlist.ContractAddress))
+
+		// The simulated overrides must not leak into the chain config.
+		require.Nil(params.GetExtra(config).HeliconTimestamp)
+	})
+
+	t.Run("reports incompatible upgrades", func(t *testing.T) {
+		require := require.New(t)
+
+		config := newConfig(t, extras.UpgradeConfig{
+			PrecompileUpgrades: []extras.PrecompileUpgrade{
+				{Config: txallowlist.NewConfig(utils.NewUint64(5), []common.Address{admin}, nil, nil)},
+			},
+		})
+		// Removing an activated upgrade is incompatible with the chain.
+		result := SimulateUpgrades(config, extras.UpgradeConfig{}, head, newState(t))
+		require.Empty(result.VerifyError)
+		require.NotEmpty(result.CompatibilityError)
+		require.Empty(result.Activations)
+	})
+
+	t.Run("reports invalid upgrades", func(t *testing.T) {
+		require := require.New(t)
+
+		config := newConfig(t, extras.UpgradeConfig{})
+		candidate := extras.UpgradeConfig{
+			StateUpgrades: []extras.StateUpgrade{
+				{BlockTimestamp: utils.NewUint64(30)},
+				{BlockTimestamp: utils.NewUint64(20)},
+			},
+		}
+		result := SimulateUpgrades(config, candidate, head, newState(t))
+		require.Contains(result.VerifyError, "invalid state upgrades")
+		require.Empty(result.Activations)
+	})
+}
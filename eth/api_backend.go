@@ -122,8 +122,12 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 	return b.eth.blockchain.GetHeaderByNumber(uint64(number)), nil
 }
 
-func (b *EthAPIBackend) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
-	return b.eth.blockchain.GetFeeConfigAt(parent)
+func (b *EthAPIBackend) GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error) {
+	return b.eth.blockchain.GetFeeConfigAt(parent, timestamp)
+}
+
+func (b *EthAPIBackend) GenesisSupply() *big.Int {
+	return b.eth.blockchain.GenesisSupply()
 }
 
 func (b *EthAPIBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
@@ -367,6 +371,19 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return nil
 }
 
+func (b *EthAPIBackend) SendConditionalTx(ctx context.Context, signedTx *types.Transaction, cond *txpool.TransactionConditional) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := b.eth.txPool.AddConditional(signedTx, cond, false); err != nil {
+		return err
+	}
+
+	// Conditionals are not gossiped, so the transaction is kept to this node's
+	// mempool where its conditional is enforced during block building.
+	return nil
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.eth.txPool.Pending(txpool.PendingFilter{})
 	var txs types.Transactions
@@ -419,6 +436,10 @@ func (b *EthAPIBackend) TxPoolContent() (map[common.Address][]*types.Transaction
 	return b.eth.txPool.Content()
 }
 
+func (b *EthAPIBackend) TxPoolReport(hash common.Hash) *txpool.TxReport {
+	return b.eth.txPool.Report(hash)
+}
+
 func (b *EthAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	return b.eth.txPool.ContentFrom(addr)
 }
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/core/txpool/bundlepool"
+)
+
+// BundleAPI provides an API to submit transaction bundles to the miner.
+type BundleAPI struct {
+	e *Ethereum
+}
+
+// NewBundleAPI creates a new bundle API.
+func NewBundleAPI(e *Ethereum) *BundleAPI {
+	return &BundleAPI{e}
+}
+
+// SendBundleArgs are the arguments of eth_sendBundle.
+type SendBundleArgs struct {
+	Txs            []hexutil.Bytes `json:"txs"`
+	BlockNumberMin *hexutil.Big    `json:"blockNumberMin,omitempty"`
+	BlockNumberMax *hexutil.Big    `json:"blockNumberMax,omitempty"`
+	TimestampMin   *hexutil.Uint64 `json:"timestampMin,omitempty"`
+	TimestampMax   *hexutil.Uint64 `json:"timestampMax,omitempty"`
+	Signature      hexutil.Bytes   `json:"signature,omitempty"`
+}
+
+// toBundle decodes the signed transactions, range and signature of the bundle.
+func (args *SendBundleArgs) toBundle() (*bundlepool.Bundle, error) {
+	bundle := &bundlepool.Bundle{
+		Txs:            make([]*types.Transaction, len(args.Txs)),
+		BlockNumberMin: (*big.Int)(args.BlockNumberMin),
+		BlockNumberMax: (*big.Int)(args.BlockNumberMax),
+		TimestampMin:   (*uint64)(args.TimestampMin),
+		TimestampMax:   (*uint64)(args.TimestampMax),
+		Signature:      args.Signature,
+	}
+	for i, input := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		bundle.Txs[i] = tx
+	}
+	return bundle, nil
+}
+
+// SendBundle simulates the bundle on the pending state and, if all of its
+// transactions apply, adds it to the bundles included by the miner. It returns
+// the hash of the bundle.
+func (api *BundleAPI) SendBundle(ctx context.Context, args SendBundleArgs) (common.Hash, error) {
+	if err := ctx.Err(); err != nil {
+		return common.Hash{}, err
+	}
+	bundle, err := args.toBundle()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := bundle.Validate(); err != nil {
+		return common.Hash{}, err
+	}
+	for _, tx := range bundle.Txs {
+		if !api.e.APIBackend.UnprotectedAllowed(tx) && !tx.Protected() {
+			// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
+			return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+		}
+	}
+	if err := api.e.miner.SimulateBundle(ctx, bundle); err != nil {
+		return common.Hash{}, fmt.Errorf("bundle simulation failed: %w", err)
+	}
+	if err := api.e.bundlePool.Add(bundle); err != nil {
+		return common.Hash{}, err
+	}
+	return bundle.Hash(), nil
+}
+
+// BundleStatus is the status of a bundle returned by eth_getBundleStatus.
+type BundleStatus struct {
+	Status      bundlepool.Status `json:"status"`
+	BlockHash   *common.Hash      `json:"blockHash,omitempty"`
+	BlockNumber *hexutil.Uint64   `json:"blockNumber,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// GetBundleStatus returns the status of the bundle with hash.
+func (api *BundleAPI) GetBundleStatus(hash common.Hash) (*BundleStatus, error) {
+	result, err := api.e.bundlePool.Status(hash)
+	if err != nil {
+		return nil, err
+	}
+	status := &BundleStatus{Status: result.Status}
+	if result.Status == bundlepool.StatusIncluded {
+		status.BlockHash = &result.BlockHash
+		status.BlockNumber = (*hexutil.Uint64)(&result.BlockNumber)
+	}
+	if result.Err != nil {
+		status.Error = result.Err.Error()
+	}
+	return status, nil
+}
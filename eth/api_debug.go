@@ -336,10 +336,10 @@ func (api *DebugAPI) getModifiedAccounts(startBlock, endBlock *types.Block) ([]c
 // of the next block.
 // The (from, to) parameters are the sequence of blocks to search, which can go
 // either forwards or backwards
+//
+// In the path-based scheme only the state of the last accepted blocks kept in
+// memory by the trie database is accessible.
 func (api *DebugAPI) GetAccessibleState(from, to rpc.BlockNumber) (uint64, error) {
-	if api.eth.blockchain.TrieDB().Scheme() == rawdb.PathScheme {
-		return 0, errors.New("state history is not yet available in path-based scheme")
-	}
 	var resolveNum = func(num rpc.BlockNumber) (uint64, error) {
 		// We don't have state for pending (-2), so treat it as latest
 		if num.Int64() < 0 {
@@ -49,6 +49,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/state/pruner"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/txpool/bundlepool"
 	"github.com/ava-labs/subnet-evm/core/txpool/legacypool"
 	"github.com/ava-labs/subnet-evm/eth/ethconfig"
 	"github.com/ava-labs/subnet-evm/eth/filters"
@@ -61,6 +62,7 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
 	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
 )
 
 // Config contains the configuration options of the ETH protocol.
@@ -84,7 +86,8 @@ type Ethereum struct {
 	config *Config
 
 	// Handlers
-	txPool *txpool.TxPool
+	txPool     *txpool.TxPool
+	bundlePool *bundlepool.BundlePool
 
 	blockchain *core.BlockChain
 	gossiper   PushGossiper
@@ -268,6 +271,8 @@ func New(
 		return nil, err
 	}
 
+	eth.bundlePool = bundlepool.New(eth.blockchain)
+
 	eth.miner = miner.New(eth, &config.Miner, eth.blockchain.Config(), eth.EventMux(), eth.engine, clock)
 
 	allowUnprotectedTxHashes := make(map[common.Hash]struct{})
@@ -333,6 +338,10 @@ func (s *Ethereum) APIs() []rpc.API {
 			Namespace: "eth",
 			Service:   filters.NewFilterAPI(filterSystem),
 			Name:      "eth-filter",
+		}, {
+			Namespace: "eth",
+			Service:   NewBundleAPI(s),
+			Name:      "eth-bundle",
 		}, {
 			Namespace: "admin",
 			Service:   NewAdminAPI(s),
@@ -371,12 +380,13 @@ func (s *Ethereum) SetEtherbase(etherbase common.Address) {
 
 func (s *Ethereum) Miner() *miner.Miner { return s.miner }
 
-func (s *Ethereum) AccountManager() *accounts.Manager { return s.accountManager }
-func (s *Ethereum) BlockChain() *core.BlockChain      { return s.blockchain }
-func (s *Ethereum) TxPool() *txpool.TxPool            { return s.txPool }
-func (s *Ethereum) EventMux() *event.TypeMux          { return s.eventMux }
-func (s *Ethereum) Engine() consensus.Engine          { return s.engine }
-func (s *Ethereum) ChainDb() ethdb.Database           { return s.chainDb }
+func (s *Ethereum) AccountManager() *accounts.Manager  { return s.accountManager }
+func (s *Ethereum) BlockChain() *core.BlockChain       { return s.blockchain }
+func (s *Ethereum) TxPool() *txpool.TxPool             { return s.txPool }
+func (s *Ethereum) BundlePool() *bundlepool.BundlePool { return s.bundlePool }
+func (s *Ethereum) EventMux() *event.TypeMux           { return s.eventMux }
+func (s *Ethereum) Engine() consensus.Engine           { return s.engine }
+func (s *Ethereum) ChainDb() ethdb.Database            { return s.chainDb }
 
 func (s *Ethereum) NetVersion() uint64               { return s.networkID }
 func (s *Ethereum) ArchiveMode() bool                { return !s.config.Pruning }
@@ -399,6 +409,7 @@ func (s *Ethereum) Stop() error {
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
 	s.txPool.Close()
+	s.bundlePool.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
 
@@ -478,21 +489,33 @@ func (s *Ethereum) handleOfflinePruning(cacheConfig *core.CacheConfig, gspec *co
 	// Allow the blockchain to be garbage collected immediately, since we will shut down the chain after offline pruning completes.
 	s.blockchain.Stop()
 	s.blockchain = nil
-	log.Info("Starting offline pruning", "dataDir", s.config.OfflinePruningDataDirectory, "bloomFilterSize", s.config.OfflinePruningBloomFilterSize)
-	prunerConfig := pruner.Config{
-		BloomSize: s.config.OfflinePruningBloomFilterSize,
-		Datadir:   s.config.OfflinePruningDataDirectory,
-	}
+	if cacheConfig.StateScheme == customrawdb.FirewoodScheme {
+		// Firewood is pruned by rebuilding the database from the last accepted revision.
+		log.Info("Starting offline pruning of firewood database")
+		if err := customrawdb.WriteOfflinePruning(s.chainDb); err != nil {
+			return fmt.Errorf("failed to write offline pruning success marker: %w", err)
+		}
+		if _, err := firewood.Prune(cacheConfig.FirewoodConfig(), targetRoot); err != nil {
+			return fmt.Errorf("failed to prune firewood database with target root: %s due to: %w", targetRoot, err)
+		}
+	} else {
+		log.Info("Starting offline pruning", "dataDir", s.config.OfflinePruningDataDirectory, "bloomFilterSize", s.config.OfflinePruningBloomFilterSize)
+		prunerConfig := pruner.Config{
+			BloomSize: s.config.OfflinePruningBloomFilterSize,
+			Datadir:   s.config.OfflinePruningDataDirectory,
+		}
 
-	pruner, err := pruner.NewPruner(s.chainDb, prunerConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create new pruner with data directory: %s, size: %d, due to: %w", s.config.OfflinePruningDataDirectory, s.config.OfflinePruningBloomFilterSize, err)
-	}
-	if err := pruner.Prune(targetRoot); err != nil {
-		return fmt.Errorf("failed to prune blockchain with target root: %s due to: %w", targetRoot, err)
+		pruner, err := pruner.NewPruner(s.chainDb, prunerConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create new pruner with data directory: %s, size: %d, due to: %w", s.config.OfflinePruningDataDirectory, s.config.OfflinePruningBloomFilterSize, err)
+		}
+		if err := pruner.Prune(targetRoot); err != nil {
+			return fmt.Errorf("failed to prune blockchain with target root: %s due to: %w", targetRoot, err)
+		}
+		// Note: Time Marker is written inside of [Prune] before compaction begins
+		// (considered an optional optimization)
 	}
-	// Note: Time Marker is written inside of [Prune] before compaction begins
-	// (considered an optional optimization)
+	var err error
 	s.blockchain, err = core.NewBlockChain(s.chainDb, cacheConfig, gspec, s.engine, vmConfig, lastAcceptedHash, s.config.SkipUpgradeCheck)
 	if err != nil {
 		return fmt.Errorf("failed to re-initialize blockchain after offline pruning: %w", err)
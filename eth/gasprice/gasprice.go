@@ -101,7 +101,7 @@ type OracleBackend interface {
 	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
 	SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription
 	LastAcceptedBlock() *types.Block
-	GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error)
+	GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error)
 }
 
 // Oracle recommends gas prices based on the content of recent
@@ -226,7 +226,8 @@ func (oracle *Oracle) estimateNextBaseFee(ctx context.Context) (*big.Int, error)
 	if err != nil {
 		return nil, err
 	}
-	feeConfig, _, err := oracle.backend.GetFeeConfigAt(header)
+	now := oracle.clock.Time()
+	feeConfig, _, err := oracle.backend.GetFeeConfigAt(header, uint64(now.Unix()))
 	if err != nil {
 		return nil, err
 	}
@@ -239,7 +240,7 @@ func (oracle *Oracle) estimateNextBaseFee(ctx context.Context) (*big.Int, error)
 	// based on the current time and add it to the tip to estimate the
 	// total gas price estimate.
 	chainConfig := params.GetExtra(oracle.backend.ChainConfig())
-	return customheader.EstimateNextBaseFee(chainConfig, feeConfig, header, uint64(oracle.clock.Time().UnixMilli()))
+	return customheader.EstimateNextBaseFee(chainConfig, feeConfig, header, uint64(now.UnixMilli()))
 }
 
 // SuggestPrice returns an estimated price for legacy transactions.
@@ -99,8 +99,8 @@ func (b *testBackend) SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) eve
 	return nil
 }
 
-func (b *testBackend) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
-	return b.chain.GetFeeConfigAt(parent)
+func (b *testBackend) GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error) {
+	return b.chain.GetFeeConfigAt(parent, timestamp)
 }
 
 func (b *testBackend) teardown() {
@@ -30,6 +30,7 @@ package ethapi
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -52,6 +53,7 @@ import (
 	"github.com/ava-labs/libevm/trie"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/eth/gasestimator"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
@@ -212,13 +214,98 @@ func (s *TxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*RPCT
 	return content
 }
 
-// Status returns the number of pending and queued transaction in the pool.
-func (s *TxPoolAPI) Status() map[string]hexutil.Uint {
-	pending, queue := s.b.Stats()
-	return map[string]hexutil.Uint{
-		"pending": hexutil.Uint(pending),
-		"queued":  hexutil.Uint(queue),
+// Status returns the number of pending and queued transaction in the pool or,
+// given the hash of a transaction, its status and recorded lifecycle.
+func (s *TxPoolAPI) Status(hash *common.Hash) interface{} {
+	if hash == nil {
+		pending, queue := s.b.Stats()
+		return map[string]hexutil.Uint{
+			"pending": hexutil.Uint(pending),
+			"queued":  hexutil.Uint(queue),
+		}
+	}
+	return newRPCTxPoolStatus(*hash, s.b.TxPoolReport(*hash))
+}
+
+// Explain returns the status and recorded lifecycle of a transaction, along
+// with the reasons it is not yet executable or included, or it left the pool.
+func (s *TxPoolAPI) Explain(hash common.Hash) *RPCTxPoolExplanation {
+	report := s.b.TxPoolReport(hash)
+	explanation := &RPCTxPoolExplanation{
+		RPCTxPoolStatus: newRPCTxPoolStatus(hash, report),
+		Reasons:         []string{},
+	}
+	switch {
+	case report == nil:
+		explanation.Reasons = append(explanation.Reasons, "transaction not seen by the pool, or its lifecycle is no longer retained")
+	case report.Status != txpool.TxStatusUnknown:
+		explanation.Reasons = append(explanation.Reasons, report.Reasons...)
+	default:
+		// The transaction left the pool, explained by its last event.
+		last := report.Events[len(report.Events)-1]
+		switch {
+		case last.Reason != "":
+			explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("%s: %s", last.Type, last.Reason))
+		case last.Type == txpool.TxEventReplaced:
+			explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("replaced by %s", last.Replacement))
+		case last.Type == txpool.TxEventIncluded:
+			explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("included in block %d", last.BlockNumber))
+		}
+	}
+	return explanation
+}
+
+// RPCTxEvent is an event in the lifecycle of a transaction in the pool.
+type RPCTxEvent struct {
+	Type        txpool.TxEventType `json:"type"`
+	Time        time.Time          `json:"time"`
+	Reason      string             `json:"reason,omitempty"`
+	Replacement *common.Hash       `json:"replacement,omitempty"`
+	BlockNumber *hexutil.Uint64    `json:"blockNumber,omitempty"`
+}
+
+// RPCTxPoolStatus is the status of a transaction returned by txpool_status.
+// The status is pending or queued while the transaction is in the pool, the
+// type of its last event once it left the pool, or unknown.
+type RPCTxPoolStatus struct {
+	Hash   common.Hash  `json:"hash"`
+	Status string       `json:"status"`
+	Events []RPCTxEvent `json:"events"`
+}
+
+// RPCTxPoolExplanation is the explanation of a transaction status returned by
+// txpool_explain.
+type RPCTxPoolExplanation struct {
+	RPCTxPoolStatus
+	Reasons []string `json:"reasons"`
+}
+
+func newRPCTxPoolStatus(hash common.Hash, report *txpool.TxReport) RPCTxPoolStatus {
+	status := RPCTxPoolStatus{Hash: hash, Status: "unknown", Events: []RPCTxEvent{}}
+	if report == nil {
+		return status
 	}
+	for _, event := range report.Events {
+		rpcEvent := RPCTxEvent{Type: event.Type, Time: event.Time, Reason: event.Reason}
+		switch event.Type {
+		case txpool.TxEventReplaced:
+			rpcEvent.Replacement = &event.Replacement
+		case txpool.TxEventIncluded:
+			rpcEvent.BlockNumber = (*hexutil.Uint64)(&event.BlockNumber)
+		}
+		status.Events = append(status.Events, rpcEvent)
+	}
+	switch report.Status {
+	case txpool.TxStatusPending:
+		status.Status = "pending"
+	case txpool.TxStatusQueued:
+		status.Status = "queued"
+	default:
+		if n := len(report.Events); n > 0 && report.Events[n-1].Type.Final() {
+			status.Status = string(report.Events[n-1].Type)
+		}
+	}
+	return status
 }
 
 // Inspect retrieves the content of the transaction pool and flattens it into an
@@ -1104,7 +1191,11 @@ func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.S
 		return nil, err
 	}
 	evm := b.GetEVM(ctx, msg, state, header, &vm.Config{NoBaseFee: true}, &blockCtx)
+	gp := new(core.GasPool).AddGas(math.MaxUint64)
+	return applyMessageWithEVM(ctx, evm, msg, state, timeout, gp)
+}
 
+func applyMessageWithEVM(ctx context.Context, evm *vm.EVM, msg *core.Message, state *state.StateDB, timeout time.Duration, gp *core.GasPool) (*core.ExecutionResult, error) {
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
 	go func() {
@@ -1113,7 +1204,6 @@ func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.S
 	}()
 
 	// Execute the message.
-	gp := new(core.GasPool).AddGas(math.MaxUint64)
 	result, err := core.ApplyMessage(evm, msg, gp)
 	if err := state.Error(); err != nil {
 		return nil, err
@@ -1180,6 +1270,45 @@ func (s *BlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockNrO
 	return result.Return(), result.Err
 }
 
+// SimulateV1 executes series of transactions on top of a base state.
+// The transactions are packed into blocks. For each block, block header
+// fields can be overridden. The state can also be overridden prior to
+// execution of each block.
+//
+// Note, this function doesn't make any changes in the state/blockchain and is
+// useful to execute and retrieve values.
+func (s *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, &invalidParamsError{message: "empty input"}
+	} else if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, &clientLimitExceededError{message: "too many blocks"}
+	}
+	if blockNrOrHash == nil {
+		n := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &n
+	}
+	state, base, err := s.b.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	gasCap := s.b.RPCGasCap()
+	if gasCap == 0 {
+		gasCap = math.MaxUint64
+	}
+	sim := &simulator{
+		b:           s.b,
+		state:       state,
+		base:        base,
+		chainConfig: s.b.ChainConfig(),
+		// Each tx and all the series of txes shouldn't consume more gas than cap
+		gp:             new(core.GasPool).AddGas(gasCap),
+		traceTransfers: opts.TraceTransfers,
+		validate:       opts.Validation,
+		fullTx:         opts.ReturnFullTransactions,
+	}
+	return sim.execute(ctx, opts.BlockStateCalls)
+}
+
 // DoEstimateGas returns the lowest possible gas limit that allows the transaction to run
 // successfully at block `blockNrOrHash`. It returns error if the transaction would revert, or if
 // there are unexpected failures. The gas limit is capped by both `args.Gas` (if non-nil &
@@ -1773,6 +1902,12 @@ func (s *TransactionAPI) sign(addr common.Address, tx *types.Transaction) (*type
 
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
 func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+	return submitTransaction(ctx, b, tx, nil)
+}
+
+// submitTransaction submits tx to txPool along with cond, if it is not nil,
+// and logs a message.
+func submitTransaction(ctx context.Context, b Backend, tx *types.Transaction, cond *txpool.TransactionConditional) (common.Hash, error) {
 	// If the transaction fee cap is already specified, ensure the
 	// fee of the given transaction is _reasonable_.
 	if err := checkTxFee(tx.GasPrice(), tx.Gas(), b.RPCTxFeeCap()); err != nil {
@@ -1782,7 +1917,11 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
 		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
-	if err := b.SendTx(ctx, tx); err != nil {
+	if cond == nil {
+		if err := b.SendTx(ctx, tx); err != nil {
+			return common.Hash{}, err
+		}
+	} else if err := b.SendConditionalTx(ctx, tx, cond); err != nil {
 		return common.Hash{}, err
 	}
 	// Print a log with full tx details for manual investigations and interventions
@@ -1866,6 +2005,78 @@ func (s *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil.B
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
+// KnownAccount is the expected storage of an account in a transaction
+// conditional. It is encoded either as a storage root, or as an object mapping
+// storage slots to their expected values.
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+func (a KnownAccount) MarshalJSON() ([]byte, error) {
+	if a.StorageRoot != nil {
+		return json.Marshal(a.StorageRoot)
+	}
+	return json.Marshal(a.StorageSlots)
+}
+
+func (a *KnownAccount) UnmarshalJSON(input []byte) error {
+	var root common.Hash
+	if err := json.Unmarshal(input, &root); err == nil {
+		a.StorageRoot = &root
+		return nil
+	}
+	var slots map[common.Hash]common.Hash
+	if err := json.Unmarshal(input, &slots); err != nil {
+		return errors.New("known account must be a storage root or a map of storage slots")
+	}
+	a.StorageSlots = slots
+	return nil
+}
+
+// TransactionConditional is the set of preconditions accepted by
+// SendRawTransactionConditional.
+type TransactionConditional struct {
+	KnownAccounts  map[common.Address]KnownAccount `json:"knownAccounts"`
+	BlockNumberMin *hexutil.Big                    `json:"blockNumberMin"`
+	BlockNumberMax *hexutil.Big                    `json:"blockNumberMax"`
+	TimestampMin   *hexutil.Uint64                 `json:"timestampMin"`
+	TimestampMax   *hexutil.Uint64                 `json:"timestampMax"`
+}
+
+// toConditional converts the RPC representation into the one tracked by the
+// transaction pool.
+func (c *TransactionConditional) toConditional() *txpool.TransactionConditional {
+	cond := &txpool.TransactionConditional{
+		KnownAccounts:  make(map[common.Address]txpool.KnownAccount, len(c.KnownAccounts)),
+		BlockNumberMin: (*big.Int)(c.BlockNumberMin),
+		BlockNumberMax: (*big.Int)(c.BlockNumberMax),
+		TimestampMin:   (*uint64)(c.TimestampMin),
+		TimestampMax:   (*uint64)(c.TimestampMax),
+	}
+	for addr, account := range c.KnownAccounts {
+		cond.KnownAccounts[addr] = txpool.KnownAccount{
+			StorageRoot:  account.StorageRoot,
+			StorageSlots: account.StorageSlots,
+		}
+	}
+	return cond
+}
+
+// SendRawTransactionConditional will add the signed transaction to the transaction
+// pool, to be included only in a block satisfying the given conditional. The
+// transaction is evicted from the pool once the conditional can no longer hold.
+//
+// Conditional transactions are not gossiped, so they are only included in blocks
+// built by this node.
+func (s *TransactionAPI) SendRawTransactionConditional(ctx context.Context, input hexutil.Bytes, options TransactionConditional) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	return submitTransaction(ctx, s.b, tx, options.toConditional())
+}
+
 // Sign calculates an ECDSA signature for:
 // keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/ava-labs/libevm/common"
@@ -15,9 +16,13 @@ import (
 	"github.com/ava-labs/libevm/rlp"
 
 	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
 	"github.com/ava-labs/subnet-evm/rpc"
 )
 
@@ -99,8 +104,16 @@ func (s *BlockChainAPI) GetBadBlocks(context.Context) ([]*BadBlockArgs, error) {
 }
 
 type FeeConfigResult struct {
-	FeeConfig     commontype.FeeConfig `json:"feeConfig"`
-	LastChangedAt *big.Int             `json:"lastChangedAt,omitempty"`
+	FeeConfig          commontype.FeeConfig      `json:"feeConfig"`
+	LastChangedAt      *big.Int                  `json:"lastChangedAt,omitempty"`
+	ScheduledFeeConfig *ScheduledFeeConfigResult `json:"scheduledFeeConfig,omitempty"`
+}
+
+// ScheduledFeeConfigResult is a fee config scheduled in the fee manager
+// precompile that has not been applied yet.
+type ScheduledFeeConfigResult struct {
+	FeeConfig           commontype.FeeConfig `json:"feeConfig"`
+	ActivationTimestamp hexutil.Uint64       `json:"activationTimestamp"`
 }
 
 func (s *BlockChainAPI) FeeConfig(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash) (*FeeConfigResult, error) {
@@ -117,11 +130,189 @@ func (s *BlockChainAPI) FeeConfig(ctx context.Context, blockNrOrHash *rpc.BlockN
 		}
 	}
 
-	feeConfig, lastChangedAt, err := s.b.GetFeeConfigAt(header)
+	feeConfig, lastChangedAt, err := s.b.GetFeeConfigAt(header, header.Time)
+	if err != nil {
+		return nil, err
+	}
+	result := &FeeConfigResult{FeeConfig: feeConfig, LastChangedAt: lastChangedAt}
+	if !params.GetExtra(s.b.ChainConfig()).IsPrecompileEnabled(feemanager.ContractAddress, header.Time) {
+		return result, nil
+	}
+
+	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithHash(header.Hash(), false))
 	if err != nil {
 		return nil, err
 	}
-	return &FeeConfigResult{FeeConfig: feeConfig, LastChangedAt: lastChangedAt}, nil
+	if scheduled, activationTimestamp, ok := feemanager.GetScheduledFeeConfig(state); ok {
+		result.ScheduledFeeConfig = &ScheduledFeeConfigResult{
+			FeeConfig:           scheduled,
+			ActivationTimestamp: hexutil.Uint64(activationTimestamp),
+		}
+	}
+	return result, nil
+}
+
+// SupplyResult is the native coin supply at a block.
+type SupplyResult struct {
+	GenesisSupply     *hexutil.Big `json:"genesisSupply,omitempty"`
+	TotalMinted       *hexutil.Big `json:"totalMinted"`
+	TotalBurned       *hexutil.Big `json:"totalBurned"`
+	BurnedFees        *hexutil.Big `json:"burnedFees"`
+	CirculatingSupply *hexutil.Big `json:"circulatingSupply,omitempty"`
+}
+
+// GetSupply returns the native coin supply at the given block.
+// TotalMinted and TotalBurned are the supply counters of the native minter precompile,
+// which are only tracked after Helicon. BurnedFees is the balance of the blackhole address.
+// CirculatingSupply is the genesis supply plus the minted amount and the balance changes of
+// the state upgrades, minus the burned amount and the burned fees. It is omitted if the
+// genesis supply is unknown, or if the native minter was enabled before Helicon, since the
+// amounts minted before Helicon are not recorded.
+// Disabling the native minter wipes its supply counters, so the supply is not available
+// once the native minter has been disabled after Helicon.
+func (s *BlockChainAPI) GetSupply(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*SupplyResult, error) {
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	configExtra := params.GetExtra(s.b.ChainConfig())
+	if nativeMinterDisabledAfterHelicon(configExtra, header.Time) {
+		return nil, errSupplyCountersWiped
+	}
+	var (
+		totalMinted = nativeminter.GetTotalMinted(state)
+		totalBurned = nativeminter.GetTotalBurned(state)
+		burnedFees  = state.GetBalance(constants.BlackholeAddr).ToBig()
+		result      = &SupplyResult{
+			TotalMinted: (*hexutil.Big)(totalMinted),
+			TotalBurned: (*hexutil.Big)(totalBurned),
+			BurnedFees:  (*hexutil.Big)(burnedFees),
+		}
+	)
+	genesisSupply := s.b.GenesisSupply()
+	if genesisSupply == nil {
+		return result, nil
+	}
+	result.GenesisSupply = (*hexutil.Big)(genesisSupply)
+
+	if nativeMinterEnabledBeforeHelicon(configExtra, header.Time) {
+		return result, nil
+	}
+	circulatingSupply := new(big.Int).Add(genesisSupply, totalMinted)
+	circulatingSupply.Add(circulatingSupply, stateUpgradesBalanceChange(configExtra, header.Time))
+	circulatingSupply.Sub(circulatingSupply, totalBurned)
+	circulatingSupply.Sub(circulatingSupply, burnedFees)
+	result.CirculatingSupply = (*hexutil.Big)(circulatingSupply)
+	return result, nil
+}
+
+// nativeMinterEnabledBeforeHelicon returns whether the native minter precompile was enabled
+// before Helicon, at or before [timestamp].
+func nativeMinterEnabledBeforeHelicon(config *extras.ChainConfig, timestamp uint64) bool {
+	for _, cfg := range config.GetActivatingPrecompileConfigs(nativeminter.ContractAddress, nil, timestamp, config.PrecompileUpgrades) {
+		if !cfg.IsDisabled() && !config.IsHelicon(*cfg.Timestamp()) {
+			return true
+		}
+	}
+	return false
+}
+
+// nativeMinterDisabledAfterHelicon returns whether the native minter precompile was disabled
+// after Helicon, at or before [timestamp].
+func nativeMinterDisabledAfterHelicon(config *extras.ChainConfig, timestamp uint64) bool {
+	for _, cfg := range config.GetActivatingPrecompileConfigs(nativeminter.ContractAddress, nil, timestamp, config.PrecompileUpgrades) {
+		if cfg.IsDisabled() && config.IsHelicon(*cfg.Timestamp()) {
+			return true
+		}
+	}
+	return false
+}
+
+// stateUpgradesBalanceChange returns the sum of the balance changes of the state upgrades
+// applied at or before [timestamp].
+func stateUpgradesBalanceChange(config *extras.ChainConfig, timestamp uint64) *big.Int {
+	change := new(big.Int)
+	for _, upgrade := range config.GetActivatingStateUpgrades(nil, timestamp, config.StateUpgrades) {
+		for _, account := range upgrade.StateUpgradeAccounts {
+			if account.BalanceChange != nil {
+				change.Add(change, (*big.Int)(account.BalanceChange))
+			}
+		}
+	}
+	return change
+}
+
+// AllowListMember is an address holding a role in an allow list.
+type AllowListMember struct {
+	Address common.Address  `json:"address"`
+	Expiry  *hexutil.Uint64 `json:"expiry,omitempty"`
+}
+
+// AllowListResult is the role table of an allow list precompile. It is complete if the
+// precompile was enabled after Helicon, so that every role it holds has been indexed.
+type AllowListResult struct {
+	Admins   []AllowListMember `json:"admins"`
+	Managers []AllowListMember `json:"managers"`
+	Enabled  []AllowListMember `json:"enabled"`
+	Complete bool              `json:"complete"`
+}
+
+var errSupplyCountersWiped = errors.New("supply counters were wiped by disabling the native minter")
+
+// GetAllowList returns the addresses holding each role in the allow list of the precompile at
+// [precompileAddress] at the given block. Expired roles are omitted.
+// The allow list is indexed from Helicon. Storage keys are hashed in the state, so addresses given
+// a role by a transaction before Helicon are only included once their role has been set again, and
+// only the addresses of the precompile config are included before Helicon. The result is marked
+// incomplete unless the precompile was enabled after Helicon.
+func (s *BlockChainAPI) GetAllowList(ctx context.Context, precompileAddress common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*AllowListResult, error) {
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	configExtra := params.GetExtra(s.b.ChainConfig())
+	config, ok := configExtra.GetActivePrecompileConfig(precompileAddress, header.Time).(allowlist.ConfigWithAllowList)
+	if !ok || config.IsDisabled() {
+		return nil, fmt.Errorf("no allow list precompile enabled at %s", precompileAddress)
+	}
+
+	var addresses []common.Address
+	if allowlist.IsIndexEnabled(state, precompileAddress) {
+		for _, role := range []allowlist.Role{allowlist.AdminRole, allowlist.ManagerRole, allowlist.EnabledRole} {
+			addresses = append(addresses, allowlist.GetRoleMembers(state, precompileAddress, role, 0, math.MaxUint64)...)
+		}
+	} else {
+		addresses = config.AllowListAddresses()
+	}
+	var enabledAt uint64
+	if timestamp := config.Timestamp(); timestamp != nil {
+		enabledAt = *timestamp
+	}
+	var (
+		result    = &AllowListResult{Complete: configExtra.IsHelicon(enabledAt)}
+		isHelicon = configExtra.IsHelicon(header.Time)
+		seen      = make(map[common.Address]bool, len(addresses))
+	)
+	for _, address := range addresses {
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		status, expiry := allowlist.GetAllowListStatusWithExpiry(state, precompileAddress, address, isHelicon, header.Time)
+		member := AllowListMember{Address: address}
+		if expiry != 0 {
+			member.Expiry = (*hexutil.Uint64)(&expiry)
+		}
+		switch status {
+		case allowlist.AdminRole:
+			result.Admins = append(result.Admins, member)
+		case allowlist.ManagerRole:
+			result.Managers = append(result.Managers, member)
+		case allowlist.EnabledRole:
+			result.Enabled = append(result.Enabled, member)
+		}
+	}
+	return result, nil
 }
 
 // GetActivePrecompilesAt returns the active precompile configs at the given block timestamp.
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/common/math"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,8 +20,14 @@ import (
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ava-labs/subnet-evm/utils"
 
 	ethparams "github.com/ava-labs/libevm/params"
 )
@@ -211,3 +218,190 @@ func TestBlockChainAPI_stateQueryBlockNumberAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockchainAPI_GetSupply(t *testing.T) {
+	t.Parallel()
+	var (
+		accounts = newAccounts(2)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		genBlocks   = 10
+		signer      = types.HomesteadSigner{}
+		blockNumber = rpc.LatestBlockNumber
+	)
+	api := NewBlockChainAPI(newTestBackend(t, genBlocks, genesis, dummy.NewCoinbaseFaker(), func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &accounts[1].addr, Value: big.NewInt(1000), Gas: ethparams.TxGas, GasPrice: b.BaseFee(), Data: nil}), signer, accounts[0].key)
+		b.AddTx(tx)
+	}))
+
+	result, err := api.GetSupply(t.Context(), rpc.BlockNumberOrHash{BlockNumber: &blockNumber})
+	require.NoError(t, err)
+
+	// newTestBackend funds an additional account with 1 ether.
+	genesisSupply := big.NewInt(3 * params.Ether)
+	require.Equal(t, genesisSupply, result.GenesisSupply.ToInt())
+	require.Zero(t, result.TotalMinted.ToInt().Sign())
+	require.Zero(t, result.TotalBurned.ToInt().Sign())
+	wantCirculating := new(big.Int).Sub(genesisSupply, result.BurnedFees.ToInt())
+	require.Equal(t, wantCirculating, result.CirculatingSupply.ToInt())
+}
+
+func TestBlockchainAPI_GetSupplyUpgrades(t *testing.T) {
+	t.Parallel()
+	var (
+		accounts  = newAccounts(2)
+		recipient = common.Address{0xbb}
+		upgrades  = []extras.StateUpgrade{{
+			BlockTimestamp: utils.NewUint64(1),
+			StateUpgradeAccounts: map[common.Address]extras.StateUpgradeAccount{
+				recipient:        {BalanceChange: (*math.HexOrDecimal256)(big.NewInt(2 * params.Ether))},
+				accounts[1].addr: {BalanceChange: (*math.HexOrDecimal256)(big.NewInt(-params.Ether / 2))},
+			},
+		}}
+		minter = extras.Precompiles{
+			nativeminter.ConfigKey: nativeminter.NewConfig(utils.NewUint64(0), []common.Address{accounts[0].addr}, nil, nil, nil),
+		}
+		// newTestBackend funds an additional account with 1 ether.
+		genesisSupply = big.NewInt(3 * params.Ether)
+	)
+	tests := map[string]struct {
+		helicon           *uint64
+		precompiles       extras.Precompiles
+		upgrades          []extras.PrecompileUpgrade
+		stateUpgrades     []extras.StateUpgrade
+		circulatingSupply *big.Int
+		wantErr           error
+	}{
+		"state upgrade balance changes": {
+			stateUpgrades:     upgrades,
+			circulatingSupply: new(big.Int).Add(genesisSupply, big.NewInt(3*params.Ether/2)),
+		},
+		"native minter before Helicon": {
+			precompiles: minter,
+		},
+		"native minter after Helicon": {
+			helicon:           utils.NewUint64(0),
+			precompiles:       minter,
+			circulatingSupply: genesisSupply,
+		},
+		"native minter disabled after Helicon": {
+			helicon:     utils.NewUint64(0),
+			precompiles: minter,
+			upgrades: []extras.PrecompileUpgrade{{
+				Config: nativeminter.NewDisableConfig(utils.NewUint64(1)),
+			}},
+			wantErr: errSupplyCountersWiped,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			config := params.Copy(params.TestChainConfig)
+			configExtra := params.GetExtra(&config)
+			configExtra.HeliconTimestamp = test.helicon
+			configExtra.GenesisPrecompiles = test.precompiles
+			configExtra.PrecompileUpgrades = test.upgrades
+			configExtra.StateUpgrades = test.stateUpgrades
+			genesis := &core.Genesis{
+				Config: &config,
+				Alloc: types.GenesisAlloc{
+					accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+					accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+				},
+			}
+			api := NewBlockChainAPI(newTestBackend(t, 2, genesis, dummy.NewCoinbaseFaker(), func(int, *core.BlockGen) {}))
+
+			blockNumber := rpc.LatestBlockNumber
+			result, err := api.GetSupply(t.Context(), rpc.BlockNumberOrHash{BlockNumber: &blockNumber})
+			require.ErrorIs(t, err, test.wantErr)
+			if test.wantErr != nil {
+				return
+			}
+			require.Equal(t, genesisSupply, result.GenesisSupply.ToInt())
+			require.Equal(t, test.circulatingSupply, result.CirculatingSupply.ToInt())
+		})
+	}
+}
+
+func TestBlockchainAPI_GetAllowList(t *testing.T) {
+	t.Parallel()
+	var (
+		accounts = newAccounts(3)
+		config   = params.Copy(params.TestChainConfig)
+		signer   = types.LatestSigner(&config)
+	)
+	configExtra := params.GetExtra(&config)
+	configExtra.HeliconTimestamp = utils.NewUint64(0)
+	configExtra.GenesisPrecompiles = extras.Precompiles{
+		txallowlist.ConfigKey: txallowlist.NewConfig(utils.NewUint64(0), []common.Address{accounts[0].addr}, []common.Address{accounts[1].addr}, nil),
+	}
+	genesis := &core.Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	expiry := uint64(1 << 40)
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, dummy.NewCoinbaseFaker(), func(i int, b *core.BlockGen) {
+		data, err := allowlist.PackModifyAllowListWithExpiry(accounts[2].addr, allowlist.ManagerRole, expiry)
+		require.NoError(t, err)
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &txallowlist.ContractAddress, Gas: 500_000, GasPrice: b.BaseFee(), Data: data}), signer, accounts[0].key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	}))
+
+	blockNumber := rpc.LatestBlockNumber
+	result, err := api.GetAllowList(t.Context(), txallowlist.ContractAddress, rpc.BlockNumberOrHash{BlockNumber: &blockNumber})
+	require.NoError(t, err)
+	expiryHex := hexutil.Uint64(expiry)
+	require.Equal(t, &AllowListResult{
+		Admins:   []AllowListMember{{Address: accounts[0].addr}},
+		Managers: []AllowListMember{{Address: accounts[2].addr, Expiry: &expiryHex}},
+		Enabled:  []AllowListMember{{Address: accounts[1].addr}},
+		Complete: true,
+	}, result)
+
+	_, err = api.GetAllowList(t.Context(), deployerallowlist.ContractAddress, rpc.BlockNumberOrHash{BlockNumber: &blockNumber})
+	require.ErrorContains(t, err, "no allow list precompile enabled")
+}
+
+func TestBlockchainAPI_GetAllowListBeforeHelicon(t *testing.T) {
+	t.Parallel()
+	var (
+		accounts = newAccounts(3)
+		config   = params.Copy(params.TestChainConfig)
+		signer   = types.LatestSigner(&config)
+	)
+	configExtra := params.GetExtra(&config)
+	configExtra.GenesisPrecompiles = extras.Precompiles{
+		txallowlist.ConfigKey: txallowlist.NewConfig(utils.NewUint64(0), []common.Address{accounts[0].addr}, []common.Address{accounts[1].addr}, nil),
+	}
+	genesis := &core.Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, dummy.NewCoinbaseFaker(), func(i int, b *core.BlockGen) {
+		data, err := allowlist.PackModifyAllowList(accounts[2].addr, allowlist.EnabledRole)
+		require.NoError(t, err)
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &txallowlist.ContractAddress, Gas: 500_000, GasPrice: b.BaseFee(), Data: data}), signer, accounts[0].key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	}))
+
+	// The role given by the transaction cannot be enumerated before Helicon.
+	blockNumber := rpc.LatestBlockNumber
+	result, err := api.GetAllowList(t.Context(), txallowlist.ContractAddress, rpc.BlockNumberOrHash{BlockNumber: &blockNumber})
+	require.NoError(t, err)
+	require.Equal(t, &AllowListResult{
+		Admins:   []AllowListMember{{Address: accounts[0].addr}},
+		Enabled:  []AllowListMember{{Address: accounts[1].addr}},
+		Complete: false,
+	}, result)
+}
@@ -61,6 +61,7 @@ import (
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/internal/blocktest"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/upgrade/legacy"
@@ -68,6 +69,7 @@ import (
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 	"golang.org/x/exp/slices"
 )
 
@@ -582,12 +584,15 @@ func (b testBackend) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) even
 func (b testBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) event.Subscription {
 	panic("implement me")
 }
-func (b testBackend) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
+func (b testBackend) GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error) {
 	panic("implement me")
 }
 func (b testBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	panic("implement me")
 }
+func (b testBackend) SendConditionalTx(ctx context.Context, signedTx *types.Transaction, cond *txpool.TransactionConditional) error {
+	panic("implement me")
+}
 func (b testBackend) GetTransaction(ctx context.Context, txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64, error) {
 	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(b.db, txHash)
 	return true, tx, blockHash, blockNumber, index, nil
@@ -604,6 +609,7 @@ func (b testBackend) TxPoolContent() (map[common.Address][]*types.Transaction, m
 func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	panic("implement me")
 }
+func (b testBackend) TxPoolReport(hash common.Hash) *txpool.TxReport { panic("implement me") }
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
@@ -639,6 +645,7 @@ func (b testBackend) IsArchive() bool {
 func (b testBackend) HistoricalProofQueryWindow() (queryWindow uint64) {
 	panic("implement me")
 }
+func (b testBackend) GenesisSupply() *big.Int { return b.chain.GenesisSupply() }
 
 func TestEstimateGas(t *testing.T) {
 	t.Parallel()
@@ -1102,6 +1109,136 @@ func TestSendBlobTransaction(t *testing.T) {
 	}
 }
 
+func TestSendRawTransactionConditional(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _   = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		root     = common.Hash{0x01}
+		rootAddr = common.Address{0x02}
+		slotAddr = common.Address{0x03}
+		config   = params.TestChainConfig
+	)
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(config.ChainID), &types.DynamicFeeTx{
+		ChainID:   config.ChainID,
+		Gas:       ethparams.TxGas,
+		GasFeeCap: big.NewInt(ethparams.GWei),
+		To:        &rootAddr,
+	})
+	require.NoError(t, err)
+	input, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	var options TransactionConditional
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"knownAccounts": {
+			"0x0200000000000000000000000000000000000000": "0x0100000000000000000000000000000000000000000000000000000000000000",
+			"0x0300000000000000000000000000000000000000": {"0x0000000000000000000000000000000000000000000000000000000000000004": "0x0000000000000000000000000000000000000000000000000000000000000005"}
+		},
+		"blockNumberMin": "0x1",
+		"blockNumberMax": "0x10",
+		"timestampMax": "0x64"
+	}`), &options))
+
+	timestampMax := uint64(100)
+	wantCond := &txpool.TransactionConditional{
+		KnownAccounts: map[common.Address]txpool.KnownAccount{
+			rootAddr: {StorageRoot: &root},
+			slotAddr: {StorageSlots: map[common.Hash]common.Hash{common.BigToHash(big.NewInt(4)): common.BigToHash(big.NewInt(5))}},
+		},
+		BlockNumberMin: big.NewInt(1),
+		BlockNumberMax: big.NewInt(16),
+		TimestampMax:   &timestampMax,
+	}
+
+	ctrl := gomock.NewController(t)
+	backend := NewMockBackend(ctrl)
+	backend.EXPECT().RPCTxFeeCap().Return(float64(1))
+	backend.EXPECT().UnprotectedAllowed(gomock.Any()).Return(false)
+	backend.EXPECT().SendConditionalTx(gomock.Any(), gomock.Any(), wantCond).Return(nil)
+	backend.EXPECT().CurrentBlock().Return(&types.Header{Number: common.Big0})
+	backend.EXPECT().ChainConfig().Return(config).AnyTimes()
+
+	hash, err := NewTransactionAPI(backend, nil).SendRawTransactionConditional(t.Context(), input, options)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), hash)
+}
+
+func TestTxPoolStatusAndExplain(t *testing.T) {
+	t.Parallel()
+
+	var (
+		queued      = common.Hash{0x01}
+		replaced    = common.Hash{0x02}
+		replacement = common.Hash{0x03}
+		evicted     = common.Hash{0x04}
+		unknown     = common.Hash{0x05}
+		now         = time.Unix(100, 0)
+	)
+	ctrl := gomock.NewController(t)
+	backend := NewMockBackend(ctrl)
+	backend.EXPECT().Stats().Return(1, 2)
+	backend.EXPECT().TxPoolReport(queued).Return(&txpool.TxReport{
+		Status:  txpool.TxStatusQueued,
+		Reasons: []string{"nonce gap"},
+		Events:  []txpool.TxEvent{{Type: txpool.TxEventQueued, Time: now}},
+	}).Times(2)
+	backend.EXPECT().TxPoolReport(replaced).Return(&txpool.TxReport{
+		Events: []txpool.TxEvent{
+			{Type: txpool.TxEventPending, Time: now},
+			{Type: txpool.TxEventReplaced, Time: now, Replacement: replacement},
+		},
+	}).Times(2)
+	backend.EXPECT().TxPoolReport(evicted).Return(&txpool.TxReport{
+		Events: []txpool.TxEvent{{Type: txpool.TxEventEvicted, Time: now, Reason: "queued longer than the pool lifetime"}},
+	})
+	backend.EXPECT().TxPoolReport(unknown).Return(nil).Times(2)
+
+	api := NewTxPoolAPI(backend)
+	require.Equal(t, map[string]hexutil.Uint{"pending": 1, "queued": 2}, api.Status(nil))
+
+	tests := []struct {
+		hash        common.Hash
+		wantStatus  string
+		wantEvents  int
+		wantReasons []string
+	}{
+		{
+			hash:        queued,
+			wantStatus:  "queued",
+			wantEvents:  1,
+			wantReasons: []string{"nonce gap"},
+		},
+		{
+			hash:        replaced,
+			wantStatus:  "replaced",
+			wantEvents:  2,
+			wantReasons: []string{"replaced by " + replacement.Hex()},
+		},
+		{
+			hash:        evicted,
+			wantStatus:  "evicted",
+			wantEvents:  1,
+			wantReasons: []string{"evicted: queued longer than the pool lifetime"},
+		},
+		{
+			hash:        unknown,
+			wantStatus:  "unknown",
+			wantReasons: []string{"transaction not seen by the pool, or its lifecycle is no longer retained"},
+		},
+	}
+	for _, test := range tests {
+		if test.hash != evicted {
+			status := api.Status(&test.hash).(RPCTxPoolStatus)
+			require.Equal(t, test.wantStatus, status.Status)
+			require.Len(t, status.Events, test.wantEvents)
+		}
+		explanation := api.Explain(test.hash)
+		require.Equal(t, test.wantStatus, explanation.Status)
+		require.Equal(t, test.wantReasons, explanation.Reasons)
+	}
+}
+
 func TestFillBlobTransaction(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -0,0 +1,204 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// This file is a derived work, based on the go-ethereum library whose original
+// notices appear below.
+//
+// It is distributed under a license compatible with the licensing terms of the
+// original code from which it is derived.
+//
+// Much love to the original authors for their work.
+// **********
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+)
+
+var (
+	// keccak256("Transfer(address,address,uint256)")
+	transferTopic = common.HexToHash("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	// ERC-7528
+	transferAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+)
+
+// transferLog is a transfer recorded as a log, along with the number of logs
+// the transaction had emitted when the transfer happened.
+type transferLog struct {
+	index int
+	log   *types.Log
+}
+
+// tracer is a simple tracer that records all logs and
+// ether transfers. Transfers are recorded as if they
+// were logs. Transfer events include:
+// - tx value
+// - call value
+// - self destructs
+//
+// The log format for a transfer is:
+// - address: 0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE
+// - data: Value
+// - topics:
+//   - Transfer(address,address,uint256)
+//   - Sender address
+//   - Recipient address
+//
+// Logs emitted by contracts and stateful precompiles are read from the state
+// once the transaction completes, and the transfers are interleaved with them
+// in execution order.
+type tracer struct {
+	// frames holds the transfers of each call frame. The transfers of a
+	// reverted frame are dropped along with it.
+	frames         [][]transferLog
+	transfers      []transferLog
+	state          *state.StateDB
+	traceTransfers bool
+	count          int
+	blockNumber    uint64
+	blockHash      common.Hash
+	txHash         common.Hash
+	txIdx          uint
+}
+
+func newTracer(traceTransfers bool, state *state.StateDB, blockNumber uint64, blockHash, txHash common.Hash, txIndex uint) *tracer {
+	return &tracer{
+		traceTransfers: traceTransfers,
+		state:          state,
+		blockNumber:    blockNumber,
+		blockHash:      blockHash,
+		txHash:         txHash,
+		txIdx:          txIndex,
+	}
+}
+
+func (t *tracer) CaptureTxStart(gasLimit uint64) {}
+
+func (t *tracer) CaptureTxEnd(restGas uint64) {}
+
+func (t *tracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.enter(vm.CALL, from, to, value)
+}
+
+func (t *tracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.exit(err)
+}
+
+func (t *tracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.enter(typ, from, to, value)
+}
+
+func (t *tracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.exit(err)
+}
+
+func (t *tracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+
+func (t *tracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+func (t *tracer) enter(typ vm.OpCode, from common.Address, to common.Address, value *big.Int) {
+	t.frames = append(t.frames, nil)
+	if !t.traceTransfers {
+		return
+	}
+	if value != nil && value.Sign() > 0 && typ != vm.DELEGATECALL {
+		t.captureTransfer(from, to, value)
+	}
+}
+
+func (t *tracer) exit(err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+	size := len(t.frames)
+	frame := t.frames[size-1]
+	t.frames = t.frames[:size-1]
+	if err != nil {
+		return
+	}
+	if size == 1 {
+		t.transfers = append(t.transfers, frame...)
+		return
+	}
+	t.frames[size-2] = append(t.frames[size-2], frame...)
+}
+
+func (t *tracer) captureTransfer(from, to common.Address, value *big.Int) {
+	topics := []common.Hash{
+		transferTopic,
+		common.BytesToHash(from.Bytes()),
+		common.BytesToHash(to.Bytes()),
+	}
+	log := &types.Log{
+		Address:     transferAddress,
+		Topics:      topics,
+		Data:        common.BigToHash(value).Bytes(),
+		BlockNumber: t.blockNumber,
+		BlockHash:   t.blockHash,
+		TxHash:      t.txHash,
+		TxIndex:     t.txIdx,
+	}
+	size := len(t.frames)
+	t.frames[size-1] = append(t.frames[size-1], transferLog{
+		index: len(t.state.GetLogs(t.txHash, t.blockNumber, t.blockHash)),
+		log:   log,
+	})
+}
+
+// reset prepares the tracer for the next transaction.
+func (t *tracer) reset(txHash common.Hash, txIdx uint) {
+	t.frames = nil
+	t.transfers = nil
+	t.txHash = txHash
+	t.txIdx = txIdx
+}
+
+// Logs returns the logs of the current transaction, including the transfers
+// if they are traced, indexed from the logs of the previous transactions.
+func (t *tracer) Logs() []*types.Log {
+	var (
+		emitted   = t.state.GetLogs(t.txHash, t.blockNumber, t.blockHash)
+		logs      = make([]*types.Log, 0, len(emitted)+len(t.transfers))
+		transfers = t.transfers
+	)
+	add := func(log *types.Log) {
+		log.Index = uint(t.count)
+		t.count++
+		logs = append(logs, log)
+	}
+	for i, emittedLog := range emitted {
+		for len(transfers) > 0 && transfers[0].index <= i {
+			add(transfers[0].log)
+			transfers = transfers[1:]
+		}
+		log := *emittedLog
+		add(&log)
+	}
+	for _, transfer := range transfers {
+		add(transfer.log)
+	}
+	return logs
+}
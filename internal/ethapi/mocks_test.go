@@ -26,6 +26,7 @@ import (
 	commontype "github.com/ava-labs/subnet-evm/commontype"
 	consensus "github.com/ava-labs/subnet-evm/consensus"
 	core "github.com/ava-labs/subnet-evm/core"
+	txpool "github.com/ava-labs/subnet-evm/core/txpool"
 	params "github.com/ava-labs/subnet-evm/params"
 	rpc "github.com/ava-labs/subnet-evm/rpc"
 	gomock "go.uber.org/mock/gomock"
@@ -261,6 +262,20 @@ func (mr *MockBackendMockRecorder) FeeHistory(ctx, blockCount, lastBlock, reward
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockBackend)(nil).FeeHistory), ctx, blockCount, lastBlock, rewardPercentiles)
 }
 
+// GenesisSupply mocks base method.
+func (m *MockBackend) GenesisSupply() *big.Int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenesisSupply")
+	ret0, _ := ret[0].(*big.Int)
+	return ret0
+}
+
+// GenesisSupply indicates an expected call of GenesisSupply.
+func (mr *MockBackendMockRecorder) GenesisSupply() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenesisSupply", reflect.TypeOf((*MockBackend)(nil).GenesisSupply))
+}
+
 // GetBody mocks base method.
 func (m *MockBackend) GetBody(ctx context.Context, hash common.Hash, number rpc.BlockNumber) (*types.Body, error) {
 	m.ctrl.T.Helper()
@@ -291,9 +306,9 @@ func (mr *MockBackendMockRecorder) GetEVM(ctx, msg, state, header, vmConfig, blo
 }
 
 // GetFeeConfigAt mocks base method.
-func (m *MockBackend) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
+func (m *MockBackend) GetFeeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, *big.Int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFeeConfigAt", parent)
+	ret := m.ctrl.Call(m, "GetFeeConfigAt", parent, timestamp)
 	ret0, _ := ret[0].(commontype.FeeConfig)
 	ret1, _ := ret[1].(*big.Int)
 	ret2, _ := ret[2].(error)
@@ -301,9 +316,9 @@ func (m *MockBackend) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 }
 
 // GetFeeConfigAt indicates an expected call of GetFeeConfigAt.
-func (mr *MockBackendMockRecorder) GetFeeConfigAt(parent any) *gomock.Call {
+func (mr *MockBackendMockRecorder) GetFeeConfigAt(parent, timestamp any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeConfigAt", reflect.TypeOf((*MockBackend)(nil).GetFeeConfigAt), parent)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeConfigAt", reflect.TypeOf((*MockBackend)(nil).GetFeeConfigAt), parent, timestamp)
 }
 
 // GetLogs mocks base method.
@@ -528,6 +543,20 @@ func (mr *MockBackendMockRecorder) RPCTxFeeCap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCTxFeeCap", reflect.TypeOf((*MockBackend)(nil).RPCTxFeeCap))
 }
 
+// SendConditionalTx mocks base method.
+func (m *MockBackend) SendConditionalTx(ctx context.Context, signedTx *types.Transaction, cond *txpool.TransactionConditional) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendConditionalTx", ctx, signedTx, cond)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendConditionalTx indicates an expected call of SendConditionalTx.
+func (mr *MockBackendMockRecorder) SendConditionalTx(ctx, signedTx, cond any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendConditionalTx", reflect.TypeOf((*MockBackend)(nil).SendConditionalTx), ctx, signedTx, cond)
+}
+
 // SendTx mocks base method.
 func (m *MockBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	m.ctrl.T.Helper()
@@ -759,6 +788,20 @@ func (mr *MockBackendMockRecorder) TxPoolContentFrom(addr any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolContentFrom", reflect.TypeOf((*MockBackend)(nil).TxPoolContentFrom), addr)
 }
 
+// TxPoolReport mocks base method.
+func (m *MockBackend) TxPoolReport(hash common.Hash) *txpool.TxReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPoolReport", hash)
+	ret0, _ := ret[0].(*txpool.TxReport)
+	return ret0
+}
+
+// TxPoolReport indicates an expected call of TxPoolReport.
+func (mr *MockBackendMockRecorder) TxPoolReport(hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolReport", reflect.TypeOf((*MockBackend)(nil).TxPoolReport), hash)
+}
+
 // UnprotectedAllowed mocks base method.
 func (m *MockBackend) UnprotectedAllowed(tx *types.Transaction) bool {
 	m.ctrl.T.Helper()
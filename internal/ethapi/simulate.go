@@ -0,0 +1,587 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// This file is a derived work, based on the go-ethereum library whose original
+// notices appear below.
+//
+// It is distributed under a license compatible with the licensing terms of the
+// original code from which it is derived.
+//
+// Much love to the original authors for their work.
+// **********
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/consensus/misc/eip4844"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/ava-labs/libevm/trie"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks that can be simulated
+	// in a single request.
+	maxSimulateBlocks = 256
+
+	// timestampIncrement is the default increment between block timestamps.
+	timestampIncrement = 2
+)
+
+// simBlock is a batch of calls to be simulated sequentially.
+type simBlock struct {
+	BlockOverrides *BlockOverrides
+	StateOverrides *StateOverride
+	Calls          []TransactionArgs
+}
+
+// simCallResult is the result of a simulated call.
+type simCallResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	Logs        []*types.Log   `json:"logs"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Status      hexutil.Uint64 `json:"status"`
+	Error       *callError     `json:"error,omitempty"`
+}
+
+func (r *simCallResult) MarshalJSON() ([]byte, error) {
+	type callResultAlias simCallResult
+	// Marshal logs to be an empty array instead of nil when empty
+	if r.Logs == nil {
+		r.Logs = []*types.Log{}
+	}
+	return json.Marshal((*callResultAlias)(r))
+}
+
+// simOpts are the inputs to eth_simulateV1.
+type simOpts struct {
+	BlockStateCalls        []simBlock
+	TraceTransfers         bool
+	Validation             bool
+	ReturnFullTransactions bool
+}
+
+// simulator is a stateful object that simulates a series of blocks.
+// It is not safe for concurrent use.
+type simulator struct {
+	b              Backend
+	state          *state.StateDB
+	base           *types.Header
+	chainConfig    *params.ChainConfig
+	gp             *core.GasPool
+	traceTransfers bool
+	validate       bool
+	fullTx         bool
+}
+
+// execute runs the simulation of a series of blocks.
+func (sim *simulator) execute(ctx context.Context, blocks []simBlock) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var (
+		cancel  context.CancelFunc
+		timeout = sim.b.RPCEVMTimeout()
+	)
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	// Make sure the context is cancelled when the call has completed
+	// this makes sure resources are cleaned up.
+	defer cancel()
+
+	blocks, err := sim.sanitizeChain(blocks)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		results = make([]map[string]interface{}, len(blocks))
+		headers = make([]*types.Header, 0, len(blocks))
+		parent  = sim.base
+	)
+	for bi, block := range blocks {
+		result, callResults, err := sim.processBlock(ctx, &block, parent, headers, timeout)
+		if err != nil {
+			return nil, err
+		}
+		enc := RPCMarshalBlock(result, true, sim.fullTx, sim.chainConfig)
+		// Note: Subnet-EVM enforces that the difficulty of a block is always 1, such that the total difficulty of a block
+		// will be equivalent to its height.
+		enc["totalDifficulty"] = (*hexutil.Big)(result.Number())
+		enc["calls"] = callResults
+		results[bi] = enc
+
+		parent = result.Header()
+		headers = append(headers, parent)
+	}
+	return results, nil
+}
+
+// processBlock builds the header of [block] on top of [parent] as the block
+// builder would, then applies the calls of [block] to the simulation state.
+// [headers] are the previously simulated blocks.
+func (sim *simulator) processBlock(ctx context.Context, block *simBlock, parent *types.Header, headers []*types.Header, timeout time.Duration) (*types.Block, []simCallResult, error) {
+	header, feeConfig, err := sim.makeHeader(block.BlockOverrides, parent)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Configure any upgrades that go into effect during this block, as done
+	// when processing a block.
+	if err := core.ApplyUpgrades(sim.chainConfig, &parent.Time, core.NewBlockContext(header.Number, header.Time), sim.state); err != nil {
+		return nil, nil, err
+	}
+	// State overrides are applied prior to execution of a block.
+	if err := sim.checkStateOverrides(block.StateOverrides, header.Time); err != nil {
+		return nil, nil, err
+	}
+	if err := block.StateOverrides.Apply(sim.state); err != nil {
+		return nil, nil, err
+	}
+	var (
+		gasUsed      uint64
+		txes         = make([]*types.Transaction, len(block.Calls))
+		callResults  = make([]simCallResult, len(block.Calls))
+		receipts     = make([]*types.Receipt, len(block.Calls))
+		blockContext = core.NewEVMBlockContext(header, sim.newSimulatedChainContext(ctx, headers), nil)
+		// Block hash will be repaired after execution.
+		tracer   = newTracer(sim.traceTransfers, sim.state, header.Number.Uint64(), common.Hash{}, common.Hash{}, 0)
+		vmConfig = vm.Config{
+			NoBaseFee: !sim.validate,
+			Tracer:    tracer,
+		}
+		evm = vm.NewEVM(blockContext, vm.TxContext{GasPrice: new(big.Int)}, sim.state, sim.chainConfig, vmConfig)
+	)
+	if block.BlockOverrides.BlobBaseFee != nil {
+		evm.Context.BlobBaseFee = block.BlockOverrides.BlobBaseFee.ToInt()
+	}
+	if header.ParentBeaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, evm, sim.state)
+	}
+	for i, call := range block.Calls {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		if err := sim.sanitizeCall(&call, header, gasUsed); err != nil {
+			return nil, nil, err
+		}
+		tx := call.toTransaction()
+		txes[i] = tx
+		tracer.reset(tx.Hash(), uint(i))
+		sim.state.SetTxContext(tx.Hash(), i)
+		msg, err := call.ToMessage(0, header.BaseFee)
+		if err != nil {
+			return nil, nil, err
+		}
+		msg.Nonce = uint64(*call.Nonce)
+		// Account checks are only performed in validation mode.
+		msg.SkipAccountChecks = !sim.validate
+		evm.Reset(core.NewEVMTxContext(msg), sim.state)
+		result, err := applyMessageWithEVM(ctx, evm, msg, sim.state, timeout, sim.gp)
+		if err != nil {
+			return nil, nil, txValidationError(err)
+		}
+		// Update the state with pending changes.
+		var root []byte
+		if sim.chainConfig.IsByzantium(header.Number) {
+			sim.state.Finalise(true)
+		} else {
+			root = sim.state.IntermediateRoot(sim.chainConfig.IsEIP158(header.Number)).Bytes()
+		}
+		gasUsed += result.UsedGas
+		receipts[i] = sim.makeReceipt(evm, msg, result, tx, header, gasUsed, root)
+		callRes := simCallResult{ReturnValue: result.Return(), Logs: tracer.Logs(), GasUsed: hexutil.Uint64(result.UsedGas)}
+		if result.Failed() {
+			callRes.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+			if errors.Is(result.Err, vm.ErrExecutionReverted) {
+				// If the result contains a revert reason, try to unpack it.
+				revertErr := newRevertError(result.Revert())
+				callRes.Error = &callError{Message: revertErr.Error(), Code: errCodeReverted, Data: revertErr.reason}
+			} else {
+				callRes.Error = &callError{Message: result.Err.Error(), Code: errCodeVMError}
+			}
+		} else {
+			callRes.Status = hexutil.Uint64(types.ReceiptStatusSuccessful)
+		}
+		callResults[i] = callRes
+	}
+	header.GasUsed = gasUsed
+	if err := sim.finalize(header, parent, feeConfig, txes, receipts); err != nil {
+		return nil, nil, err
+	}
+	b := types.NewBlock(header, txes, nil, receipts, trie.NewStackTrie(nil))
+	repairLogs(callResults, b.Hash())
+	return b, callResults, nil
+}
+
+// makeHeader builds the header of a block on top of [parent] with the
+// timestamp, gas limit, base fee and coinbase the block builder would use,
+// unless they are overridden. It returns the fee config used by the block,
+// which is read from the state of [parent].
+func (sim *simulator) makeHeader(overrides *BlockOverrides, parent *types.Header) (*types.Header, commontype.FeeConfig, error) {
+	var (
+		configExtra = params.GetExtra(sim.chainConfig)
+		number      = overrides.Number.ToInt()
+		timestamp   = uint64(*overrides.Time)
+		timestampMS = timestamp * 1000
+	)
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Set(number),
+		Time:       timestamp,
+		Difficulty: big.NewInt(1),
+	}
+	if overrides.Difficulty != nil {
+		header.Difficulty = overrides.Difficulty.ToInt()
+	}
+	if configExtra.IsGranite(timestamp) {
+		customtypes.GetHeaderExtra(header).TimeMilliseconds = &timestampMS
+	}
+
+	// The fee config is read from the state of the parent block because the
+	// fee config may be changed by the current block.
+	feeConfig, err := sim.feeConfigAt(parent, timestamp)
+	if err != nil {
+		return nil, commontype.FeeConfig{}, err
+	}
+	if overrides.GasLimit != nil {
+		header.GasLimit = uint64(*overrides.GasLimit)
+	} else {
+		header.GasLimit, err = customheader.GasLimit(configExtra, feeConfig, parent, timestampMS)
+		if err != nil {
+			return nil, commontype.FeeConfig{}, fmt.Errorf("calculating new gas limit: %w", err)
+		}
+	}
+	if overrides.BaseFee != nil {
+		header.BaseFee = overrides.BaseFee.ToInt()
+	} else {
+		header.BaseFee, err = customheader.BaseFee(configExtra, feeConfig, parent, timestampMS)
+		if err != nil {
+			return nil, commontype.FeeConfig{}, fmt.Errorf("calculating new base fee: %w", err)
+		}
+	}
+
+	coinbase, allowFeeRecipients := sim.coinbaseAt(parent)
+	header.Coinbase = coinbase
+	if allowFeeRecipients {
+		header.Coinbase = constants.BlackholeAddr
+	}
+	if overrides.Coinbase != nil {
+		if sim.validate && !allowFeeRecipients && *overrides.Coinbase != coinbase {
+			return nil, commontype.FeeConfig{}, &invalidParamsError{fmt.Sprintf("fee recipients are not allowed, coinbase must be %s", coinbase)}
+		}
+		header.Coinbase = *overrides.Coinbase
+	}
+
+	if sim.chainConfig.IsCancun(header.Number, header.Time) {
+		var excessBlobGas uint64
+		if sim.chainConfig.IsCancun(parent.Number, parent.Time) {
+			excessBlobGas = eip4844.CalcExcessBlobGas(*parent.ExcessBlobGas, *parent.BlobGasUsed)
+		} else {
+			// For the first post-fork block, both parent.data_gas_used and parent.excess_data_gas are evaluated as 0
+			excessBlobGas = eip4844.CalcExcessBlobGas(0, 0)
+		}
+		header.BlobGasUsed = new(uint64)
+		header.ExcessBlobGas = &excessBlobGas
+		header.ParentBeaconRoot = &common.Hash{}
+	}
+	return header, feeConfig, nil
+}
+
+// finalize completes [header] as the consensus engine does once the calls of
+// the block are applied. In validation mode, the block fee must be covered by
+// the calls.
+func (sim *simulator) finalize(header *types.Header, parent *types.Header, feeConfig commontype.FeeConfig, txs []*types.Transaction, receipts []*types.Receipt) error {
+	configExtra := params.GetExtra(sim.chainConfig)
+	headerExtra := customtypes.GetHeaderExtra(header)
+	headerExtra.BlockGasCost = customheader.BlockGasCost(
+		configExtra,
+		feeConfig,
+		parent,
+		header.Time,
+	)
+	if sim.validate && configExtra.IsSubnetEVM(header.Time) {
+		if err := customheader.VerifyBlockFee(
+			header.BaseFee,
+			headerExtra.BlockGasCost,
+			txs,
+			receipts,
+			nil,
+		); err != nil {
+			return fmt.Errorf("block %d: %w", header.Number, err)
+		}
+	}
+	if configExtra.IsHelicon(header.Time) && header.Coinbase == rewardmanager.ContractAddress {
+		rewardmanager.DistributeRewardSplit(extstate.New(sim.state))
+	}
+
+	extraPrefix, err := customheader.ExtraPrefix(configExtra, parent, header)
+	if err != nil {
+		return fmt.Errorf("failed to calculate new header.Extra: %w", err)
+	}
+	header.Extra = extraPrefix
+	headerExtra.MinDelayExcess, err = customheader.MinDelayExcess(configExtra, parent, header.Time, nil)
+	if err != nil {
+		return fmt.Errorf("failed to calculate min delay excess: %w", err)
+	}
+	header.Root = sim.state.IntermediateRoot(sim.chainConfig.IsEIP158(header.Number))
+	return nil
+}
+
+// feeConfigAt returns the fee config of a block with [timestamp] built on top of
+// [parent], reading the fee manager precompile from the simulation state, in the
+// same way as [core.BlockChain.GetFeeConfigAt].
+func (sim *simulator) feeConfigAt(parent *types.Header, timestamp uint64) (commontype.FeeConfig, error) {
+	configExtra := params.GetExtra(sim.chainConfig)
+	if !configExtra.IsSubnetEVM(parent.Time) {
+		return params.DefaultFeeConfig, nil
+	}
+	if !configExtra.IsPrecompileEnabled(feemanager.ContractAddress, parent.Time) {
+		return configExtra.FeeConfig, nil
+	}
+	feeConfig := feemanager.GetStoredFeeConfig(sim.state)
+	if scheduledFeeConfig, activation, ok := feemanager.GetScheduledFeeConfig(sim.state); ok && activation <= timestamp && configExtra.IsPrecompileEnabled(feemanager.ContractAddress, timestamp) {
+		feeConfig = scheduledFeeConfig
+	}
+	if err := feeConfig.Verify(); err != nil {
+		return commontype.EmptyFeeConfig, err
+	}
+	return feeConfig, nil
+}
+
+// coinbaseAt returns the coinbase required of a block built on top of
+// [parent], reading the reward manager precompile from the simulation state.
+// If fee recipients are allowed, returns true in the second return value.
+func (sim *simulator) coinbaseAt(parent *types.Header) (common.Address, bool) {
+	configExtra := params.GetExtra(sim.chainConfig)
+	if !configExtra.IsSubnetEVM(parent.Time) {
+		return constants.BlackholeAddr, false
+	}
+	if !configExtra.IsPrecompileEnabled(rewardmanager.ContractAddress, parent.Time) {
+		if configExtra.AllowFeeRecipients {
+			return common.Address{}, true
+		}
+		return constants.BlackholeAddr, false
+	}
+	return rewardmanager.GetStoredRewardAddress(sim.state)
+}
+
+// checkStateOverrides rejects overriding the code of precompiles active at
+// [timestamp], as the precompile would still be called instead of the code.
+func (sim *simulator) checkStateOverrides(overrides *StateOverride, timestamp uint64) error {
+	if overrides == nil {
+		return nil
+	}
+	rules := sim.chainConfig.Rules(common.Big0, params.IsMergeTODO, timestamp)
+	precompiles := make(map[common.Address]struct{})
+	for _, addr := range vm.ActivePrecompiles(rules) {
+		precompiles[addr] = struct{}{}
+	}
+	for key := range params.GetExtra(sim.chainConfig).EnabledStatefulPrecompiles(timestamp) {
+		if module, ok := modules.GetPrecompileModule(key); ok {
+			precompiles[module.Address] = struct{}{}
+		}
+	}
+	for addr, account := range *overrides {
+		if _, ok := precompiles[addr]; ok && account.Code != nil {
+			return &invalidParamsError{fmt.Sprintf("cannot override the code of precompile %s", addr)}
+		}
+	}
+	return nil
+}
+
+// makeReceipt creates the receipt of a simulated call.
+func (sim *simulator) makeReceipt(evm *vm.EVM, msg *core.Message, result *core.ExecutionResult, tx *types.Transaction, header *types.Header, cumulativeGasUsed uint64, root []byte) *types.Receipt {
+	receipt := &types.Receipt{Type: tx.Type(), PostState: root, CumulativeGasUsed: cumulativeGasUsed}
+	if result.Failed() {
+		receipt.Status = types.ReceiptStatusFailed
+	} else {
+		receipt.Status = types.ReceiptStatusSuccessful
+	}
+	receipt.TxHash = tx.Hash()
+	receipt.GasUsed = result.UsedGas
+	if msg.To == nil {
+		receipt.ContractAddress = crypto.CreateAddress(evm.TxContext.Origin, tx.Nonce())
+	}
+	receipt.Logs = sim.state.GetLogs(tx.Hash(), header.Number.Uint64(), common.Hash{})
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	receipt.BlockNumber = header.Number
+	receipt.TransactionIndex = uint(sim.state.TxIndex())
+	return receipt
+}
+
+// repairLogs updates the block hash in the logs present in the result of
+// a simulated block. This is needed as during execution when logs are collected
+// the block hash is not known.
+func repairLogs(calls []simCallResult, hash common.Hash) {
+	for i := range calls {
+		for j := range calls[i].Logs {
+			calls[i].Logs[j].BlockHash = hash
+		}
+	}
+}
+
+// sanitizeCall fills in the defaults of [call] for a block with [gasUsed] gas
+// already used, and checks that the call fits in the block.
+func (sim *simulator) sanitizeCall(call *TransactionArgs, header *types.Header, gasUsed uint64) error {
+	if call.BlobHashes != nil || call.Blobs != nil {
+		return &invalidParamsError{"blob transactions cannot be simulated"}
+	}
+	if call.GasPrice != nil && (call.MaxFeePerGas != nil || call.MaxPriorityFeePerGas != nil) {
+		return &invalidParamsError{"both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified"}
+	}
+	if call.Nonce == nil {
+		nonce := sim.state.GetNonce(call.from())
+		call.Nonce = (*hexutil.Uint64)(&nonce)
+	}
+	// Let the call run wild unless explicitly specified.
+	if call.Gas == nil {
+		remaining := header.GasLimit - gasUsed
+		call.Gas = (*hexutil.Uint64)(&remaining)
+	}
+	if gasUsed+uint64(*call.Gas) > header.GasLimit {
+		return &blockGasLimitReachedError{fmt.Sprintf("block gas limit reached: %d >= %d", gasUsed, header.GasLimit)}
+	}
+	if available := sim.gp.Gas(); uint64(*call.Gas) > available {
+		call.Gas = (*hexutil.Uint64)(&available)
+	}
+	chainID := sim.chainConfig.ChainID
+	if call.ChainID != nil && call.ChainID.ToInt().Cmp(chainID) != 0 {
+		return &invalidParamsError{fmt.Sprintf("chainId does not match node's (have=%v, want=%v)", call.ChainID, chainID)}
+	}
+	call.ChainID = (*hexutil.Big)(chainID)
+	if call.Value == nil {
+		call.Value = new(hexutil.Big)
+	}
+	if call.GasPrice == nil {
+		if call.MaxFeePerGas == nil {
+			call.MaxFeePerGas = new(hexutil.Big)
+		}
+		if call.MaxPriorityFeePerGas == nil {
+			call.MaxPriorityFeePerGas = new(hexutil.Big)
+		}
+	}
+	return nil
+}
+
+// simChainContext is a [core.ChainContext] that serves the headers of the
+// simulated blocks before falling back to the chain.
+type simChainContext struct {
+	*ChainContext
+	headers map[uint64]*types.Header
+}
+
+func (sim *simulator) newSimulatedChainContext(ctx context.Context, headers []*types.Header) *simChainContext {
+	chain := &simChainContext{
+		ChainContext: NewChainContext(ctx, sim.b),
+		headers:      make(map[uint64]*types.Header, len(headers)),
+	}
+	for _, header := range headers {
+		chain.headers[header.Number.Uint64()] = header
+	}
+	return chain
+}
+
+func (c *simChainContext) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header, ok := c.headers[number]; ok {
+		if header.Hash() != hash {
+			return nil
+		}
+		return header
+	}
+	return c.ChainContext.GetHeader(hash, number)
+}
+
+// sanitizeChain fills in the number and timestamp of each block and inserts
+// empty blocks to fill the gaps between block numbers.
+func (sim *simulator) sanitizeChain(blocks []simBlock) ([]simBlock, error) {
+	var (
+		res           = make([]simBlock, 0, len(blocks))
+		base          = sim.base
+		prevNumber    = base.Number
+		prevTimestamp = base.Time
+	)
+	for _, block := range blocks {
+		if block.BlockOverrides == nil {
+			block.BlockOverrides = new(BlockOverrides)
+		}
+		if block.BlockOverrides.Number == nil {
+			n := new(big.Int).Add(prevNumber, big.NewInt(1))
+			block.BlockOverrides.Number = (*hexutil.Big)(n)
+		}
+		diff := new(big.Int).Sub(block.BlockOverrides.Number.ToInt(), prevNumber)
+		if diff.Sign() <= 0 {
+			return nil, &invalidBlockNumberError{fmt.Sprintf("block numbers must be in order: %d <= %d", block.BlockOverrides.Number.ToInt(), prevNumber)}
+		}
+		if total := new(big.Int).Sub(block.BlockOverrides.Number.ToInt(), base.Number); total.Cmp(big.NewInt(maxSimulateBlocks)) > 0 {
+			return nil, &clientLimitExceededError{message: "too many blocks"}
+		}
+		if diff.Cmp(big.NewInt(1)) > 0 {
+			// Fill the gap with empty blocks.
+			gap := new(big.Int).Sub(diff, big.NewInt(1))
+			// Assign block number to the empty blocks.
+			for i := uint64(0); i < gap.Uint64(); i++ {
+				n := new(big.Int).Add(prevNumber, new(big.Int).SetUint64(i+1))
+				t := prevTimestamp + timestampIncrement
+				b := simBlock{BlockOverrides: &BlockOverrides{Number: (*hexutil.Big)(n), Time: (*hexutil.Uint64)(&t)}}
+				prevTimestamp = t
+				res = append(res, b)
+			}
+		}
+		// Only append block after filling a potential gap.
+		prevNumber = block.BlockOverrides.Number.ToInt()
+		var t uint64
+		if block.BlockOverrides.Time == nil {
+			t = prevTimestamp + timestampIncrement
+			block.BlockOverrides.Time = (*hexutil.Uint64)(&t)
+		} else {
+			// Blocks may share the timestamp of their parent, but the
+			// timestamp may not decrease.
+			t = uint64(*block.BlockOverrides.Time)
+			if t < prevTimestamp {
+				return nil, &invalidBlockTimestampError{fmt.Sprintf("block timestamps must be in order: %d < %d", t, prevTimestamp)}
+			}
+		}
+		prevTimestamp = t
+		res = append(res, block)
+	}
+	return res, nil
+}
@@ -0,0 +1,360 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/rpc"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+// newSimulateBackend returns a backend with [genBlocks] blocks of transfers
+// from accounts[0] to accounts[1].
+func newSimulateBackend(t *testing.T, config *params.ChainConfig, accounts []account, genBlocks int) *testBackend {
+	genesis := &core.Genesis{
+		Config: config,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	signer := types.LatestSigner(config)
+	return newTestBackend(t, genBlocks, genesis, dummy.NewCoinbaseFaker(), func(i int, b *core.BlockGen) {
+		b.SetCoinbase(constants.BlackholeAddr)
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &accounts[1].addr, Value: big.NewInt(1000), Gas: ethparams.TxGas, GasPrice: b.BaseFee()}), signer, accounts[0].key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+}
+
+func TestSimulateV1(t *testing.T) {
+	var (
+		accounts  = newAccounts(3)
+		genBlocks = 4
+		backend   = newSimulateBackend(t, params.TestChainConfig, accounts, genBlocks)
+		api       = NewBlockChainAPI(backend)
+		latest    = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		base      = backend.CurrentHeader()
+		// Reverts with the reason "boom" when called.
+		revertCode = hex2Bytes("7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452600460245263626f6f6d60e01b60445260646000fd")
+		// Emits a LOG0 with no data, then sends the value of the call to accounts[2].
+		forwardCode = hex2Bytes("60006000600060003460006000a073" + accounts[2].addr.Hex()[2:] + "5af1")
+		forwarder   = common.Address{0xf0}
+		reverter    = common.Address{0xee}
+	)
+
+	results, err := api.SimulateV1(context.Background(), simOpts{
+		BlockStateCalls: []simBlock{
+			{
+				StateOverrides: &StateOverride{
+					forwarder: {Code: forwardCode},
+					reverter:  {Code: revertCode},
+				},
+				Calls: []TransactionArgs{
+					{
+						From:  &accounts[1].addr,
+						To:    &accounts[2].addr,
+						Value: (*hexutil.Big)(big.NewInt(1000)),
+					},
+					{
+						From:  &accounts[2].addr,
+						To:    &forwarder,
+						Value: (*hexutil.Big)(big.NewInt(400)),
+					},
+					{
+						From: &accounts[1].addr,
+						To:   &reverter,
+					},
+				},
+			},
+			{
+				BlockOverrides: &BlockOverrides{Number: (*hexutil.Big)(big.NewInt(int64(genBlocks + 3)))},
+				Calls: []TransactionArgs{
+					{
+						From:  &accounts[2].addr,
+						To:    &accounts[1].addr,
+						Value: (*hexutil.Big)(big.NewInt(1000)),
+					},
+				},
+			},
+		},
+		TraceTransfers: true,
+	}, &latest)
+	require.NoError(t, err)
+
+	// The gap between the two blocks is filled with an empty block.
+	require.Len(t, results, 3)
+	for i, result := range results {
+		require.Equal(t, (*hexutil.Big)(big.NewInt(int64(genBlocks+i+1))), result["number"])
+		require.Equal(t, hexutil.Uint64(base.Time+uint64(i+1)*timestampIncrement), result["timestamp"])
+		require.NotNil(t, result["baseFeePerGas"])
+	}
+	require.Equal(t, results[0]["hash"], results[1]["parentHash"])
+	require.Equal(t, results[1]["hash"], results[2]["parentHash"])
+	require.Empty(t, results[1]["calls"])
+
+	calls := results[0]["calls"].([]simCallResult)
+	require.Len(t, calls, 3)
+	blockHash := results[0]["hash"].(common.Hash)
+
+	// The transfer is traced as a log.
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), calls[0].Status)
+	require.Len(t, calls[0].Logs, 1)
+	requireTransferLog(t, calls[0].Logs[0], accounts[1].addr, accounts[2].addr, 1000)
+	require.Equal(t, blockHash, calls[0].Logs[0].BlockHash)
+	require.Zero(t, calls[0].Logs[0].Index)
+
+	// The transfers are interleaved with the logs emitted by the contract in
+	// execution order, and indexed from the logs of the previous calls.
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), calls[1].Status)
+	require.Len(t, calls[1].Logs, 3)
+	requireTransferLog(t, calls[1].Logs[0], accounts[2].addr, forwarder, 400)
+	require.Equal(t, forwarder, calls[1].Logs[1].Address)
+	requireTransferLog(t, calls[1].Logs[2], forwarder, accounts[2].addr, 400)
+	for i, log := range calls[1].Logs {
+		require.Equal(t, uint(i+1), log.Index)
+		require.Equal(t, uint(1), log.TxIndex)
+	}
+
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusFailed), calls[2].Status)
+	require.Empty(t, calls[2].Logs)
+	require.NotNil(t, calls[2].Error)
+	require.Equal(t, errCodeReverted, calls[2].Error.Code)
+	require.Equal(t, "execution reverted: boom", calls[2].Error.Message)
+
+	// The state is carried over to the following blocks.
+	calls = results[2]["calls"].([]simCallResult)
+	require.Len(t, calls, 1)
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), calls[0].Status)
+}
+
+func requireTransferLog(t *testing.T, log *types.Log, from, to common.Address, value int64) {
+	t.Helper()
+	require.Equal(t, transferAddress, log.Address)
+	require.Equal(t, []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())}, log.Topics)
+	require.Equal(t, common.BigToHash(big.NewInt(value)).Bytes(), log.Data)
+}
+
+// TestSimulateV1MatchesChain checks that a simulated block in validation mode
+// has the same header fields and state root as the block the chain would
+// build with the same transactions.
+func TestSimulateV1MatchesChain(t *testing.T) {
+	var (
+		accounts  = newAccounts(2)
+		genBlocks = 4
+		backend   = newSimulateBackend(t, params.TestChainConfig, accounts, genBlocks)
+		api       = NewBlockChainAPI(backend)
+		latest    = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		signer    = types.LatestSigner(params.TestChainConfig)
+		parent    = backend.chain.CurrentBlock()
+	)
+	blocks, _, err := core.GenerateChain(params.TestChainConfig, backend.chain.GetBlockByHash(parent.Hash()), dummy.NewCoinbaseFaker(), backend.db, 2, 10, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(constants.BlackholeAddr)
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(genBlocks + i), To: &accounts[1].addr, Value: big.NewInt(1000), Gas: ethparams.TxGas, GasPrice: b.BaseFee()}), signer, accounts[0].key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+	require.NoError(t, err)
+
+	simBlocks := make([]simBlock, len(blocks))
+	for i, block := range blocks {
+		tx := block.Transactions()[0]
+		timestamp := hexutil.Uint64(block.Time())
+		simBlocks[i] = simBlock{
+			BlockOverrides: &BlockOverrides{Time: &timestamp},
+			Calls:          []TransactionArgs{argsFromTransaction(tx, accounts[0].addr)},
+		}
+	}
+	results, err := api.SimulateV1(context.Background(), simOpts{
+		BlockStateCalls: simBlocks,
+		Validation:      true,
+	}, &latest)
+	require.NoError(t, err)
+	require.Len(t, results, len(blocks))
+	for i, block := range blocks {
+		result := results[i]
+		require.Equal(t, block.Root(), result["stateRoot"], "block %d", i)
+		require.Equal(t, (*hexutil.Big)(block.BaseFee()), result["baseFeePerGas"], "block %d", i)
+		require.Equal(t, (*hexutil.Big)(customtypes.BlockGasCost(block)), result["blockGasCost"], "block %d", i)
+		require.Equal(t, hexutil.Uint64(block.GasLimit()), result["gasLimit"], "block %d", i)
+		require.Equal(t, hexutil.Uint64(block.GasUsed()), result["gasUsed"], "block %d", i)
+		require.Equal(t, hexutil.Bytes(block.Extra()), result["extraData"], "block %d", i)
+		require.Equal(t, block.Coinbase(), result["miner"], "block %d", i)
+	}
+}
+
+func TestSimulateV1Validation(t *testing.T) {
+	var (
+		accounts = newAccounts(2)
+		backend  = newSimulateBackend(t, params.TestChainConfig, accounts, 1)
+		api      = NewBlockChainAPI(backend)
+		latest   = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		base     = backend.CurrentHeader()
+		nonce    = hexutil.Uint64(5)
+		earlier  = hexutil.Uint64(base.Time - 1)
+		gasLimit = hexutil.Uint64(ethparams.TxGas)
+		gas      = hexutil.Uint64(ethparams.TxGas + 1)
+	)
+	transfer := TransactionArgs{
+		From:  &accounts[1].addr,
+		To:    &accounts[0].addr,
+		Value: (*hexutil.Big)(big.NewInt(1)),
+	}
+	tests := map[string]struct {
+		opts     simOpts
+		wantCode int
+	}{
+		"empty input": {
+			opts:     simOpts{},
+			wantCode: errCodeInvalidParams,
+		},
+		"fee cap below base fee": {
+			opts: simOpts{
+				BlockStateCalls: []simBlock{{Calls: []TransactionArgs{transfer}}},
+				Validation:      true,
+			},
+			wantCode: errCodeInvalidParams,
+		},
+		"nonce too high": {
+			opts: simOpts{
+				BlockStateCalls: []simBlock{{Calls: []TransactionArgs{{
+					From:     &accounts[1].addr,
+					To:       &accounts[0].addr,
+					Nonce:    &nonce,
+					GasPrice: (*hexutil.Big)(big.NewInt(params.GWei)),
+				}}}},
+				Validation: true,
+			},
+			wantCode: errCodeNonceTooHigh,
+		},
+		"block numbers out of order": {
+			opts: simOpts{
+				BlockStateCalls: []simBlock{{BlockOverrides: &BlockOverrides{Number: (*hexutil.Big)(base.Number)}}},
+			},
+			wantCode: errCodeBlockNumberInvalid,
+		},
+		"block timestamps out of order": {
+			opts: simOpts{
+				BlockStateCalls: []simBlock{{BlockOverrides: &BlockOverrides{Time: &earlier}}},
+			},
+			wantCode: errCodeBlockTimestampInvalid,
+		},
+		"too many blocks": {
+			opts: simOpts{
+				BlockStateCalls: []simBlock{{BlockOverrides: &BlockOverrides{Number: (*hexutil.Big)(new(big.Int).Add(base.Number, big.NewInt(maxSimulateBlocks+1)))}}},
+			},
+			wantCode: errCodeClientLimitExceeded,
+		},
+		"precompile code override": {
+			opts: simOpts{
+				BlockStateCalls: []simBlock{{StateOverrides: &StateOverride{
+					common.BytesToAddress([]byte{0x1}): {Code: hex2Bytes("00")},
+				}}},
+			},
+			wantCode: errCodeInvalidParams,
+		},
+		"block gas limit reached": {
+			opts: simOpts{
+				BlockStateCalls: []simBlock{{
+					BlockOverrides: &BlockOverrides{GasLimit: &gasLimit},
+					Calls: []TransactionArgs{{
+						From: &accounts[1].addr,
+						To:   &accounts[0].addr,
+						Gas:  &gas,
+					}},
+				}},
+			},
+			wantCode: errCodeBlockGasLimitReached,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := api.SimulateV1(context.Background(), test.opts, &latest)
+			var rpcErr rpc.Error
+			require.True(t, errors.As(err, &rpcErr), "unexpected error %v", err)
+			require.Equal(t, test.wantCode, rpcErr.ErrorCode())
+		})
+	}
+
+	// Without validation, calls are not required to pay the base fee.
+	results, err := api.SimulateV1(context.Background(), simOpts{
+		BlockStateCalls: []simBlock{{Calls: []TransactionArgs{transfer}}},
+	}, &latest)
+	require.NoError(t, err)
+	calls := results[0]["calls"].([]simCallResult)
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), calls[0].Status)
+}
+
+func TestSimulateV1PrecompileActivation(t *testing.T) {
+	var (
+		accounts   = newAccounts(2)
+		config     = params.Copy(params.TestChainConfig)
+		recipient  = common.Address{0xaa}
+		activation = uint64(30)
+		amount     = new(big.Int).Mul(big.NewInt(5), big.NewInt(params.Ether))
+	)
+	// The native minter is activated after the last accepted block.
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		nativeminter.ConfigKey: nativeminter.NewConfig(&activation, []common.Address{accounts[0].addr}, nil, nil, nil),
+	}
+	var (
+		backend = newSimulateBackend(t, &config, accounts, 2)
+		api     = NewBlockChainAPI(backend)
+		latest  = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	require.Less(t, backend.CurrentHeader().Time, activation)
+
+	mint, err := nativeminter.PackMintNativeCoin(recipient, amount)
+	require.NoError(t, err)
+	simulate := func(timestamp uint64) ([]map[string]interface{}, error) {
+		return api.SimulateV1(context.Background(), simOpts{
+			BlockStateCalls: []simBlock{{
+				BlockOverrides: &BlockOverrides{Time: (*hexutil.Uint64)(&timestamp)},
+				Calls: []TransactionArgs{
+					{
+						From:  &accounts[0].addr,
+						To:    &nativeminter.ContractAddress,
+						Input: (*hexutil.Bytes)(&mint),
+					},
+					{
+						From:  &recipient,
+						To:    &accounts[1].addr,
+						Value: (*hexutil.Big)(amount),
+					},
+				},
+			}},
+		}, &latest)
+	}
+
+	// Before the activation, nothing is minted.
+	_, err = simulate(activation - 1)
+	var rpcErr rpc.Error
+	require.True(t, errors.As(err, &rpcErr), "unexpected error %v", err)
+	require.Equal(t, errCodeInsufficientFunds, rpcErr.ErrorCode())
+
+	// The precompile is configured by the first simulated block at or after
+	// the activation.
+	results, err := simulate(activation)
+	require.NoError(t, err)
+	calls := results[0]["calls"].([]simCallResult)
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), calls[0].Status)
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), calls[1].Status)
+}
@@ -29,6 +29,8 @@
 package miner
 
 import (
+	"context"
+
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/types"
@@ -36,6 +38,7 @@ import (
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/txpool/bundlepool"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 )
@@ -44,12 +47,20 @@ import (
 type Backend interface {
 	BlockChain() *core.BlockChain
 	TxPool() *txpool.TxPool
+	BundlePool() *bundlepool.BundlePool
 }
 
 // Config is the configuration parameters of mining.
 type Config struct {
 	Etherbase                    common.Address `toml:",omitempty"` // Public address for block mining rewards
 	TestOnlyAllowDuplicateBlocks bool           // Allow mining of duplicate blocks (used in tests only)
+
+	// PriorityOperators are the operators whose signed bundles, followed by
+	// the transactions of PriorityAddresses, are included ahead of all others,
+	// using at most PriorityGasShare of the block gas.
+	PriorityOperators []common.Address `toml:",omitempty"`
+	PriorityAddresses []common.Address `toml:",omitempty"`
+	PriorityGasShare  float64          `toml:",omitempty"`
 }
 
 type Miner struct {
@@ -70,6 +81,18 @@ func (miner *Miner) GenerateBlock(predicateContext *precompileconfig.PredicateCo
 	return miner.worker.commitNewWork(predicateContext)
 }
 
+// SimulateBundle applies bundle to the state of the next block, returning the
+// error of the first transaction failing to apply.
+func (miner *Miner) SimulateBundle(ctx context.Context, bundle *bundlepool.Bundle) error {
+	return miner.worker.simulateBundle(ctx, bundle)
+}
+
+// SetPredicateContextFn sets the function returning the predicate context the
+// next block would be built with, used to simulate bundles.
+func (miner *Miner) SetPredicateContextFn(fn func(context.Context) (*precompileconfig.PredicateContext, error)) {
+	miner.worker.setPredicateContextFn(fn)
+}
+
 // SubscribePendingLogs starts delivering logs from pending transactions
 // to the given channel.
 func (miner *Miner) SubscribePendingLogs(ch chan<- []*types.Log) event.Subscription {
@@ -31,9 +31,12 @@
 package miner
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
+	"slices"
 	"sync"
 	"time"
 
@@ -53,6 +56,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/extstate"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/txpool/bundlepool"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
@@ -109,6 +113,10 @@ type worker struct {
 	coinbase   common.Address
 	clock      *mockable.Clock // Allows us mock the clock for testing
 	beaconRoot *common.Hash    // TODO: set to empty hash, retained for upstream compatibility and future use
+
+	// predicateContextFn returns the predicate context bundles are simulated
+	// with, if set.
+	predicateContextFn func(context.Context) (*precompileconfig.PredicateContext, error)
 }
 
 func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus.Engine, eth Backend, mux *event.TypeMux, clock *mockable.Clock) *worker {
@@ -127,6 +135,14 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 	return worker
 }
 
+// setPredicateContextFn sets the function returning the predicate context
+// bundles are simulated with.
+func (w *worker) setPredicateContextFn(fn func(context.Context) (*precompileconfig.PredicateContext, error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.predicateContextFn = fn
+}
+
 // setEtherbase sets the etherbase used to initialize the block coinbase field.
 func (w *worker) setEtherbase(addr common.Address) {
 	w.mu.Lock()
@@ -138,6 +154,74 @@ func (w *worker) setEtherbase(addr common.Address) {
 func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateContext) (*types.Block, error) {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
+
+	env, err := w.prepareWork(predicateContext)
+	if err != nil {
+		return nil, err
+	}
+	// Ensure we always stop prefetcher after block building is complete.
+	defer func() {
+		if env.state == nil {
+			return
+		}
+		env.state.StopPrefetcher()
+	}()
+
+	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
+	filter := txpool.PendingFilter{
+		MinTip: uint256.MustFromBig(w.eth.TxPool().GasTip()),
+	}
+	if env.header.BaseFee != nil {
+		filter.BaseFee = uint256.MustFromBig(env.header.BaseFee)
+	}
+	if env.header.ExcessBlobGas != nil {
+		filter.BlobFee = uint256.MustFromBig(eip4844.CalcBlobFee(*env.header.ExcessBlobGas))
+	}
+	filter.OnlyPlainTxs, filter.OnlyBlobTxs = true, false
+	pendingPlainTxs := w.eth.TxPool().Pending(filter)
+
+	filter.OnlyPlainTxs, filter.OnlyBlobTxs = false, true
+	pendingBlobTxs := w.eth.TxPool().Pending(filter)
+
+	// Fill the priority lane and the bundles ahead of all other transactions.
+	bundles := w.eth.BundlePool().Pending(env.header)
+	bundles = w.commitPriorityLane(env, bundles, pendingPlainTxs)
+	w.commitBundles(env, bundles)
+
+	// Split the pending transactions into locals and remotes.
+	localPlainTxs, remotePlainTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingPlainTxs
+	localBlobTxs, remoteBlobTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingBlobTxs
+	for _, account := range w.eth.TxPool().Locals() {
+		if txs := remotePlainTxs[account]; len(txs) > 0 {
+			delete(remotePlainTxs, account)
+			localPlainTxs[account] = txs
+		}
+		if txs := remoteBlobTxs[account]; len(txs) > 0 {
+			delete(remoteBlobTxs, account)
+			localBlobTxs[account] = txs
+		}
+	}
+	// Fill the block with all available pending transactions.
+	if len(localPlainTxs) > 0 || len(localBlobTxs) > 0 {
+		plainTxs := newTransactionsByPriceAndNonce(env.signer, localPlainTxs, env.header.BaseFee)
+		blobTxs := newTransactionsByPriceAndNonce(env.signer, localBlobTxs, env.header.BaseFee)
+
+		w.commitTransactions(env, plainTxs, blobTxs, env.header.Coinbase)
+	}
+	if len(remotePlainTxs) > 0 || len(remoteBlobTxs) > 0 {
+		plainTxs := newTransactionsByPriceAndNonce(env.signer, remotePlainTxs, env.header.BaseFee)
+		blobTxs := newTransactionsByPriceAndNonce(env.signer, remoteBlobTxs, env.header.BaseFee)
+
+		w.commitTransactions(env, plainTxs, blobTxs, env.header.Coinbase)
+	}
+
+	return w.commit(env)
+}
+
+// prepareWork creates the environment of a new block on top of the current
+// head. The caller must hold the worker lock, and stop the prefetcher of the
+// returned state once done.
+func (w *worker) prepareWork(predicateContext *precompileconfig.PredicateContext) (*environment, error) {
 	var (
 		parent      = w.chain.CurrentBlock()
 		chainExtra  = params.GetExtra(w.chainConfig)
@@ -159,7 +243,7 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 
 	// The fee manager relies on the state of the parent block to set the fee config
 	// because the fee config may be changed by the current block.
-	feeConfig, _, err := w.chain.GetFeeConfigAt(parent)
+	feeConfig, _, err := w.chain.GetFeeConfigAt(parent, timestamp)
 	if err != nil {
 		return nil, err
 	}
@@ -220,65 +304,200 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		vmenv := vm.NewEVM(context, vm.TxContext{}, env.state, w.chainConfig, vm.Config{})
 		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, vmenv, env.state)
 	}
-	// Ensure we always stop prefetcher after block building is complete.
-	defer func() {
-		if env.state == nil {
-			return
-		}
-		env.state.StopPrefetcher()
-	}()
 	// Configure any upgrades that should go into effect during this block.
 	blockContext := core.NewBlockContext(header.Number, header.Time)
 	err = core.ApplyUpgrades(w.chainConfig, &parent.Time, blockContext, env.state)
 	if err != nil {
 		log.Error("failed to configure precompiles mining new block", "parent", parent.Hash(), "number", header.Number, "timestamp", header.Time, "err", err)
+		env.state.StopPrefetcher()
 		return nil, err
 	}
+	return env, nil
+}
 
-	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
-	filter := txpool.PendingFilter{
-		MinTip: uint256.MustFromBig(w.eth.TxPool().GasTip()),
+// commitBundles commits the pending bundles that may be included in the block,
+// in the order they were submitted. A bundle failing to apply is skipped.
+func (w *worker) commitBundles(env *environment, bundles []*bundlepool.Bundle) {
+	for _, bundle := range bundles {
+		if err := w.commitBundle(env, bundle); err != nil {
+			log.Debug("Skipping bundle", "hash", bundle.Hash(), "err", err)
+		}
 	}
-	if env.header.BaseFee != nil {
-		filter.BaseFee = uint256.MustFromBig(env.header.BaseFee)
+}
+
+// commitBundle commits the transactions of bundle contiguously. The bundle is
+// applied to a copy of the environment, which replaces env only if all of its
+// transactions apply.
+func (w *worker) commitBundle(env *environment, bundle *bundlepool.Bundle) error {
+	// Reverting to a snapshot taken before the bundle is not possible, since
+	// applying each transaction finalises the state and drops its snapshots.
+	work := env.copy()
+	if err := w.applyBundle(work, bundle); err != nil {
+		work.state.StopPrefetcher()
+		return err
+	}
+	// The copied state only holds an inactive copy of the prefetcher, so it is
+	// restarted to prefetch for the rest of the block.
+	env.state.StopPrefetcher()
+	work.state.StartPrefetcher("miner", extstate.WithConcurrentWorkers(w.chain.CacheConfig().TriePrefetcherParallelism))
+	*env = *work
+	return nil
+}
+
+// applyBundle commits the transactions of bundle in order, stopping at the
+// first failure.
+func (w *worker) applyBundle(env *environment, bundle *bundlepool.Bundle) error {
+	for _, tx := range bundle.Txs {
+		if env.gasPool.Gas() < tx.Gas() {
+			return fmt.Errorf("%w: transaction %s needs %d gas, %d left", core.ErrGasLimitReached, tx.Hash(), tx.Gas(), env.gasPool.Gas())
+		}
+		if totalTxsSize := env.size + tx.Size(); totalTxsSize > targetTxsSize {
+			return fmt.Errorf("transaction %s exceeds target block size", tx.Hash())
+		}
+		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
+			return fmt.Errorf("replay protected transaction %s before EIP155", tx.Hash())
+		}
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		if _, err := w.commitTransaction(env, tx, env.header.Coinbase); err != nil {
+			return fmt.Errorf("transaction %s: %w", tx.Hash(), err)
+		}
+		env.tcount++
 	}
-	if env.header.ExcessBlobGas != nil {
-		filter.BlobFee = uint256.MustFromBig(eip4844.CalcBlobFee(*env.header.ExcessBlobGas))
+	return nil
+}
+
+// simulateBundle applies bundle to the state of a new block on top of the
+// current head, as it would be included if the block was built now.
+func (w *worker) simulateBundle(ctx context.Context, bundle *bundlepool.Bundle) error {
+	// Predicates are verified against the context the block would be built
+	// with, if known. Otherwise, transactions with predicates fail.
+	w.mu.RLock()
+	predicateContextFn := w.predicateContextFn
+	w.mu.RUnlock()
+
+	var predicateContext *precompileconfig.PredicateContext
+	if predicateContextFn != nil {
+		var err error
+		predicateContext, err = predicateContextFn(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get predicate context: %w", err)
+		}
 	}
-	filter.OnlyPlainTxs, filter.OnlyBlobTxs = true, false
-	pendingPlainTxs := w.eth.TxPool().Pending(filter)
 
-	filter.OnlyPlainTxs, filter.OnlyBlobTxs = false, true
-	pendingBlobTxs := w.eth.TxPool().Pending(filter)
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-	// Split the pending transactions into locals and remotes.
-	localPlainTxs, remotePlainTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingPlainTxs
-	localBlobTxs, remoteBlobTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingBlobTxs
-	for _, account := range w.eth.TxPool().Locals() {
-		if txs := remotePlainTxs[account]; len(txs) > 0 {
-			delete(remotePlainTxs, account)
-			localPlainTxs[account] = txs
+	env, err := w.prepareWork(predicateContext)
+	if err != nil {
+		return err
+	}
+	defer env.state.StopPrefetcher()
+	return w.applyBundle(env, bundle)
+}
+
+// commitPriorityLane commits the bundles signed by the configured priority
+// operators, in the order they were submitted, followed by the pending
+// transactions of the configured priority addresses, using at most the
+// configured share of the block gas. It returns the bundles not committed in
+// the lane. The transactions of the priority addresses are removed from
+// pending, and any not yet included are returned to it to compete with all
+// other transactions.
+func (w *worker) commitPriorityLane(env *environment, bundles []*bundlepool.Bundle, pending map[common.Address][]*txpool.LazyTransaction) []*bundlepool.Bundle {
+	if w.config.PriorityGasShare <= 0 {
+		return bundles
+	}
+	var laneBundles []*bundlepool.Bundle
+	if len(w.config.PriorityOperators) > 0 {
+		for _, bundle := range bundles {
+			operator, err := bundle.Operator(w.chainConfig.ChainID)
+			if err == nil && operator != (common.Address{}) && slices.Contains(w.config.PriorityOperators, operator) {
+				laneBundles = append(laneBundles, bundle)
+			}
 		}
-		if txs := remoteBlobTxs[account]; len(txs) > 0 {
-			delete(remoteBlobTxs, account)
-			localBlobTxs[account] = txs
+	}
+	laneTxs := make(map[common.Address][]*txpool.LazyTransaction)
+	for _, account := range w.config.PriorityAddresses {
+		if txs := pending[account]; len(txs) > 0 {
+			delete(pending, account)
+			laneTxs[account] = txs
 		}
 	}
-	// Fill the block with all available pending transactions.
-	if len(localPlainTxs) > 0 || len(localBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, localPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, localBlobTxs, env.header.BaseFee)
-
-		w.commitTransactions(env, plainTxs, blobTxs, env.header.Coinbase)
+	if len(laneBundles) == 0 && len(laneTxs) == 0 {
+		return bundles
 	}
-	if len(remotePlainTxs) > 0 || len(remoteBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, remotePlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, remoteBlobTxs, env.header.BaseFee)
+	// Commit the lane against a gas pool limited to its share of the block.
+	var (
+		blockPool = env.gasPool
+		budget    = uint64(float64(blockPool.Gas()) * min(w.config.PriorityGasShare, 1))
+	)
+	env.gasPool = new(core.GasPool).AddGas(budget)
 
-		w.commitTransactions(env, plainTxs, blobTxs, env.header.Coinbase)
+	committed := make(map[*bundlepool.Bundle]struct{}, len(laneBundles))
+	for _, bundle := range laneBundles {
+		if err := w.commitBundle(env, bundle); err != nil {
+			log.Debug("Deferring priority bundle", "hash", bundle.Hash(), "err", err)
+			continue
+		}
+		committed[bundle] = struct{}{}
+	}
+	if len(laneTxs) > 0 {
+		// The transactions are copied since newTransactionsByPriceAndNonce
+		// takes ownership of the map.
+		txs := make(map[common.Address][]*txpool.LazyTransaction, len(laneTxs))
+		for account, accountTxs := range laneTxs {
+			txs[account] = accountTxs
+		}
+		w.commitTransactions(
+			env,
+			newTransactionsByPriceAndNonce(env.signer, txs, env.header.BaseFee),
+			newTransactionsByPriceAndNonce(env.signer, nil, env.header.BaseFee),
+			env.header.Coinbase,
+		)
+	}
+	// Committing a bundle replaces the gas pool of env, so the gas used by the
+	// lane is computed from the gas pool left in env.
+	used := budget - env.gasPool.Gas()
+	env.gasPool = blockPool
+	if err := blockPool.SubGas(used); err != nil {
+		// The lane budget never exceeds the gas left in the block.
+		log.Error("Failed to charge priority lane gas", "err", err)
+	}
+
+	// Return the remaining transactions to the regular ordering.
+	for account, accountTxs := range laneTxs {
+		nonce := env.state.GetNonce(account)
+		for len(accountTxs) > 0 {
+			if tx := accountTxs[0].Resolve(); tx != nil && tx.Nonce() >= nonce {
+				break
+			}
+			accountTxs = accountTxs[1:]
+		}
+		if len(accountTxs) > 0 {
+			pending[account] = accountTxs
+		}
+	}
+	// Return the remaining bundles, in the order they were submitted.
+	remaining := make([]*bundlepool.Bundle, 0, len(bundles)-len(committed))
+	for _, bundle := range bundles {
+		if _, ok := committed[bundle]; !ok {
+			remaining = append(remaining, bundle)
+		}
 	}
+	return remaining
+}
 
-	return w.commit(env)
+// copy returns a deep copy of env, whose state can be modified without
+// affecting env.
+func (env *environment) copy() *environment {
+	cpy := *env
+	cpy.state = env.state.Copy()
+	cpy.gasPool = new(core.GasPool).AddGas(env.gasPool.Gas())
+	cpy.header = types.CopyHeader(env.header)
+	cpy.txs = slices.Clone(env.txs)
+	cpy.receipts = slices.Clone(env.receipts)
+	cpy.sidecars = slices.Clone(env.sidecars)
+	cpy.predicateResults = maps.Clone(env.predicateResults)
+	return &cpy
 }
 
 func (w *worker) createCurrentEnvironment(predicateContext *precompileconfig.PredicateContext, parent *types.Header, header *types.Header, feeConfig commontype.FeeConfig, tstart time.Time) (*environment, error) {
@@ -451,6 +670,15 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 			continue
 		}
 
+		// Skip the account if the transaction's conditional does not hold in this
+		// block. The pool evicts the transaction once it can no longer be met.
+		if ltx.Conditional != nil {
+			if err := w.checkConditional(env, ltx.Conditional); err != nil {
+				log.Trace("Skipping transaction with failed conditional", "hash", ltx.Hash, "err", err)
+				txs.Pop()
+				continue
+			}
+		}
 		// Error may be ignored here. The error has already been checked
 		// during transaction acceptance is the transaction pool.
 		from, _ := types.Sender(env.signer, tx)
@@ -486,6 +714,20 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 	}
 }
 
+// checkConditional checks cond against the block being built, including the
+// state changes of the transactions already committed to it.
+func (w *worker) checkConditional(env *environment, cond *txpool.TransactionConditional) error {
+	if err := cond.CheckHeader(env.header); err != nil {
+		return err
+	}
+	if cond.HasStorageRoots() {
+		// Storage roots of accounts modified earlier in the block are only
+		// updated once the intermediate root is computed.
+		env.state.IntermediateRoot(w.chainConfig.IsEIP158(env.header.Number))
+	}
+	return cond.CheckState(env.state)
+}
+
 // commit runs any post-transaction state modifications, assembles the final block
 // and commits new work if consensus engine is running.
 func (w *worker) commit(env *environment) (*types.Block, error) {
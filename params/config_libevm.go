@@ -66,6 +66,7 @@ func constructRulesExtra(c *ethparams.ChainConfig, r *ethparams.Rules, cEx *extr
 		return rules
 	}
 	rules.AvalancheRules = cEx.GetAvalancheRules(timestamp)
+	rules.Timestamp = timestamp
 
 	// Initialize the stateful precompiles that should be enabled at [blockTimestamp].
 	rules.Precompiles = make(map[common.Address]precompileconfig.Config)
@@ -39,6 +39,10 @@ type NetworkUpgrades struct {
 	FortunaTimestamp *uint64 `json:"fortunaTimestamp,omitempty"`
 	// Granite is a placeholder for the next upgrade.
 	GraniteTimestamp *uint64 `json:"graniteTimestamp,omitempty"`
+	// Helicon activates Subnet-EVM specific precompile extensions. It is not
+	// scheduled by AvalancheGo and is optional, so chains opt in by setting it
+	// in the genesis or through the upgrade network overrides.
+	HeliconTimestamp *uint64 `json:"heliconTimestamp,omitempty"`
 }
 
 func (n *NetworkUpgrades) Equal(other *NetworkUpgrades) bool {
@@ -61,6 +65,9 @@ func (n *NetworkUpgrades) checkNetworkUpgradesCompatible(newcfg *NetworkUpgrades
 	if isForkTimestampIncompatible(n.GraniteTimestamp, newcfg.GraniteTimestamp, time) {
 		return ethparams.NewTimestampCompatError("Granite fork block timestamp", n.GraniteTimestamp, newcfg.GraniteTimestamp)
 	}
+	if isForkTimestampIncompatible(n.HeliconTimestamp, newcfg.HeliconTimestamp, time) {
+		return ethparams.NewTimestampCompatError("Helicon fork block timestamp", n.HeliconTimestamp, newcfg.HeliconTimestamp)
+	}
 
 	return nil
 }
@@ -72,6 +79,7 @@ func (n *NetworkUpgrades) forkOrder() []fork {
 		{name: "etnaTimestamp", timestamp: n.EtnaTimestamp},
 		{name: "fortunaTimestamp", timestamp: n.FortunaTimestamp, optional: true},
 		{name: "graniteTimestamp", timestamp: n.GraniteTimestamp},
+		{name: "heliconTimestamp", timestamp: n.HeliconTimestamp, optional: true},
 	}
 }
 
@@ -99,6 +107,8 @@ func (n *NetworkUpgrades) SetDefaults(agoUpgrades upgrade.Config) {
 	if n.GraniteTimestamp == nil || *n.GraniteTimestamp == 0 {
 		n.GraniteTimestamp = defaults.GraniteTimestamp
 	}
+	// Helicon has no AvalancheGo default, so the configured value (including 0)
+	// is kept as is.
 }
 
 // verifyNetworkUpgrades checks that the network upgrades are well formed.
@@ -122,6 +132,17 @@ func (n *NetworkUpgrades) verifyNetworkUpgrades(agoUpgrades upgrade.Config) erro
 	return nil
 }
 
+// Timestamps returns the activation timestamps of the scheduled network upgrades.
+func (n *NetworkUpgrades) Timestamps() []uint64 {
+	var timestamps []uint64
+	for _, fork := range n.forkOrder() {
+		if fork.timestamp != nil {
+			timestamps = append(timestamps, *fork.timestamp)
+		}
+	}
+	return timestamps
+}
+
 func (n *NetworkUpgrades) Override(o *NetworkUpgrades) {
 	if o.SubnetEVMTimestamp != nil {
 		n.SubnetEVMTimestamp = o.SubnetEVMTimestamp
@@ -138,6 +159,9 @@ func (n *NetworkUpgrades) Override(o *NetworkUpgrades) {
 	if o.GraniteTimestamp != nil {
 		n.GraniteTimestamp = o.GraniteTimestamp
 	}
+	if o.HeliconTimestamp != nil {
+		n.HeliconTimestamp = o.HeliconTimestamp
+	}
 }
 
 // IsSubnetEVM returns whether [time] represents a block
@@ -170,6 +194,12 @@ func (n *NetworkUpgrades) IsGranite(time uint64) bool {
 	return isTimestampForked(n.GraniteTimestamp, time)
 }
 
+// IsHelicon returns whether [time] represents a block
+// with a timestamp after the Helicon upgrade time.
+func (n *NetworkUpgrades) IsHelicon(time uint64) bool {
+	return isTimestampForked(n.HeliconTimestamp, time)
+}
+
 func (n *NetworkUpgrades) Description() string {
 	var banner string
 	banner += fmt.Sprintf(" - SubnetEVM Timestamp:          @%-10v (https://github.com/ava-labs/avalanchego/releases/tag/v1.10.0)\n", ptrToString(n.SubnetEVMTimestamp))
@@ -177,6 +207,7 @@ func (n *NetworkUpgrades) Description() string {
 	banner += fmt.Sprintf(" - Etna Timestamp:               @%-10v (https://github.com/ava-labs/avalanchego/releases/tag/v1.12.0)\n", ptrToString(n.EtnaTimestamp))
 	banner += fmt.Sprintf(" - Fortuna Timestamp:            @%-10v (https://github.com/ava-labs/avalanchego/releases/tag/v1.13.0)\n", ptrToString(n.FortunaTimestamp))
 	banner += fmt.Sprintf(" - Granite Timestamp:            @%-10v (https://github.com/ava-labs/avalanchego/releases/tag/v1.14.0)\n", ptrToString(n.GraniteTimestamp))
+	banner += fmt.Sprintf(" - Helicon Timestamp:            @%-10v (optional, not scheduled by AvalancheGo)\n", ptrToString(n.HeliconTimestamp))
 	return banner
 }
 
@@ -186,6 +217,7 @@ type AvalancheRules struct {
 	IsEtna      bool
 	IsFortuna   bool
 	IsGranite   bool
+	IsHelicon   bool
 }
 
 // IsGraniteActivated is used by the warp precompile to determine which gas costs to use.
@@ -193,6 +225,12 @@ func (a AvalancheRules) IsGraniteActivated() bool {
 	return a.IsGranite
 }
 
+// IsHeliconActivated is used by precompiles to determine whether the
+// functions added in Helicon are available.
+func (a AvalancheRules) IsHeliconActivated() bool {
+	return a.IsHelicon
+}
+
 // IsDurangoActivated is used by the warp precompile to determine which gas costs to use.
 func (a AvalancheRules) IsDurangoActivated() bool {
 	return a.IsDurango
@@ -205,6 +243,7 @@ func (n *NetworkUpgrades) GetAvalancheRules(time uint64) AvalancheRules {
 		IsEtna:      n.IsEtna(time),
 		IsFortuna:   n.IsFortuna(time),
 		IsGranite:   n.IsGranite(time),
+		IsHelicon:   n.IsHelicon(time),
 	}
 }
 
@@ -217,6 +256,7 @@ func GetNetworkUpgrades(agoUpgrade upgrade.Config) NetworkUpgrades {
 		EtnaTimestamp:      utils.TimeToNewUint64(agoUpgrade.EtnaTime),
 		FortunaTimestamp:   nil, // Fortuna is optional and has no effect on Subnet-EVM
 		GraniteTimestamp:   utils.TimeToNewUint64(agoUpgrade.GraniteTime),
+		HeliconTimestamp:   nil, // Helicon is optional and must be opted into by each chain
 	}
 }
 
@@ -188,6 +188,20 @@ func TestCheckNetworkUpgradesCompatible(t *testing.T) {
 			time:  uint64(upgrade.Fuji.FortunaTime.Unix()),
 			valid: true,
 		},
+		{
+			name: "Incompatible_Helicon_fastforward_nil_NetworkUpgrades",
+			upgrades1: func() *NetworkUpgrades {
+				upgrades := GetNetworkUpgrades(upgrade.Fuji)
+				upgrades.HeliconTimestamp = utils.NewUint64(10)
+				return &upgrades
+			}(),
+			upgrades2: func() *NetworkUpgrades {
+				upgrades := GetNetworkUpgrades(upgrade.Fuji)
+				return &upgrades
+			}(),
+			time:  10,
+			valid: false,
+		},
 	}
 	for _, test := range testcases {
 		t.Run(test.name, func(t *testing.T) {
@@ -320,6 +334,17 @@ func TestForkOrder(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "Invalid Helicon before Granite",
+			upgrades: &NetworkUpgrades{
+				SubnetEVMTimestamp: utils.NewUint64(0),
+				DurangoTimestamp:   utils.NewUint64(0),
+				EtnaTimestamp:      utils.NewUint64(0),
+				GraniteTimestamp:   utils.NewUint64(2),
+				HeliconTimestamp:   utils.NewUint64(1),
+			},
+			expectedErr: true,
+		},
 	}
 	for _, test := range testcases {
 		t.Run(test.name, func(t *testing.T) {
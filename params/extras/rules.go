@@ -13,6 +13,9 @@ type Rules struct {
 	// Rules for Avalanche releases
 	AvalancheRules
 
+	// Timestamp is the block timestamp the rules were constructed for.
+	Timestamp uint64
+
 	// Precompiles maps addresses to stateful precompiled contracts that are enabled
 	// for this rule set.
 	// Note: none of these addresses should conflict with the address space used by
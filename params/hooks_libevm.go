@@ -45,7 +45,7 @@ func (r RulesExtra) CanCreateContract(ac *libevm.AddressContext, gas uint64, sta
 	// If the allow list is enabled, check that [ac.Origin] has permission to deploy a contract.
 	rules := (extras.Rules)(r)
 	if rules.IsPrecompileEnabled(deployerallowlist.ContractAddress) {
-		allowListRole := deployerallowlist.GetContractDeployerAllowListStatus(state, ac.Origin)
+		allowListRole := deployerallowlist.GetContractDeployerAllowListStatus(state, ac.Origin, rules.IsHeliconActivated(), rules.Timestamp)
 		if !allowListRole.IsEnabled() {
 			gas = 0
 			return gas, fmt.Errorf("tx.origin %s is not authorized to deploy a contract", ac.Origin)
@@ -4,6 +4,8 @@
 package evm
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -11,7 +13,10 @@ import (
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/libevm/log"
 
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/plugin/evm/client"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
 )
 
 // Admin is the API service for admin API calls
@@ -83,3 +88,85 @@ func (p *Admin) GetVMConfig(_ *http.Request, _ *struct{}, reply *client.ConfigRe
 	reply.Config = &p.vm.config
 	return nil
 }
+
+// SimulateUpgradeReply is the reply of [Admin.SimulateUpgrade]. It is encoded
+// as [client.SimulateUpgradeReply].
+type SimulateUpgradeReply struct {
+	Simulation *core.UpgradeSimulation `json:"simulation"`
+}
+
+// SimulateUpgrade applies the candidate upgrade bytes on top of the state of the
+// last accepted block and reports the resulting precompile configs, the
+// accounts modified by state upgrades and any verification error.
+// Nothing is committed.
+func (p *Admin) SimulateUpgrade(_ *http.Request, args *client.SimulateUpgradeArgs, reply *SimulateUpgradeReply) error {
+	log.Info("Admin: SimulateUpgrade called")
+
+	var upgradeConfig extras.UpgradeConfig
+	if err := json.Unmarshal(args.Upgrade, &upgradeConfig); err != nil {
+		return fmt.Errorf("failed to parse upgrade bytes: %w", err)
+	}
+
+	p.vm.vmLock.Lock()
+	defer p.vm.vmLock.Unlock()
+
+	lastAccepted := p.vm.blockChain.LastAcceptedBlock()
+	statedb, err := p.vm.blockChain.StateAt(lastAccepted.Root())
+	if err != nil {
+		return fmt.Errorf("failed to get state of last accepted block %s: %w", lastAccepted.Hash(), err)
+	}
+	reply.Simulation = core.SimulateUpgrades(p.vm.chainConfig, upgradeConfig, lastAccepted.Header(), statedb)
+	return nil
+}
+
+// SetTxPoolAdmissionPolicy replaces the admission policy of the tx pool, or
+// removes it if the policy is null. Transactions already in the pool are removed
+// if the new policy denies them, and the rate limits and gas quotas of the new
+// policy start empty.
+func (p *Admin) SetTxPoolAdmissionPolicy(_ *http.Request, args *client.SetTxPoolAdmissionPolicyArgs, _ *api.EmptyReply) error {
+	log.Info("Admin: SetTxPoolAdmissionPolicy called")
+
+	if args.Policy != nil {
+		if err := args.Policy.Validate(); err != nil {
+			return fmt.Errorf("invalid admission policy: %w", err)
+		}
+	}
+
+	p.vm.vmLock.Lock()
+	defer p.vm.vmLock.Unlock()
+
+	p.vm.txPool.SetAdmissionPolicy(newAdmissionPolicy(args.Policy))
+	p.vm.config.TxPoolAdmissionPolicy = args.Policy
+	return nil
+}
+
+var errFirewoodNotEnabled = errors.New("firewood state scheme is not enabled")
+
+// PruneFirewood rebuilds the Firewood database from its last committed
+// revisions while blocks are processed, reclaiming the space of older
+// revisions. It returns once the rebuilt database replaces the current one.
+func (p *Admin) PruneFirewood(_ *http.Request, args *client.PruneFirewoodArgs, reply *client.PruneFirewoodReply) error {
+	log.Info("Admin: PruneFirewood called", "revisions", args.Revisions)
+
+	fw, ok := p.vm.blockChain.TrieDB().Backend().(*firewood.Database)
+	if !ok {
+		return errFirewoodNotEnabled
+	}
+	revisions := args.Revisions
+	if revisions == 0 {
+		revisions = p.vm.config.FirewoodPruneRevisions
+	}
+	if revisions == 0 {
+		revisions = p.vm.config.StateHistory
+	}
+	result, err := fw.Prune(int(revisions))
+	if err != nil {
+		return err
+	}
+	reply.Root = result.Root
+	reply.Revisions = result.Revisions
+	reply.SizeBefore = result.SizeBefore
+	reply.SizeAfter = result.SizeAfter
+	reply.Reclaimed = result.Reclaimed()
+	return nil
+}
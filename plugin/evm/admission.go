@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"github.com/ava-labs/libevm/common"
+
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/txpool/admission"
+	"github.com/ava-labs/subnet-evm/plugin/evm/config"
+)
+
+// newAdmissionPolicy returns the tx pool admission policy enforcing c, or nil
+// to admit all valid transactions if c is nil. c must be valid.
+func newAdmissionPolicy(c *config.TxPoolAdmissionPolicy) txpool.AdmissionPolicy {
+	if c == nil {
+		return nil
+	}
+	policy := admission.Config{
+		DeniedAddresses:   c.DeniedAddresses,
+		DeniedSelectors:   make([][admission.SelectorLength]byte, len(c.DeniedSelectors)),
+		SenderRateLimits:  make(map[common.Address]admission.RateLimit, len(c.SenderRateLimits)),
+		ContractGasQuotas: make(map[common.Address]admission.GasQuota, len(c.ContractGasQuotas)),
+	}
+	for i, selector := range c.DeniedSelectors {
+		policy.DeniedSelectors[i] = [admission.SelectorLength]byte(selector)
+	}
+	if c.SenderRateLimit != nil {
+		policy.SenderRateLimit = &admission.RateLimit{
+			Transactions: c.SenderRateLimit.Transactions,
+			Window:       c.SenderRateLimit.Window.Duration,
+		}
+	}
+	for addr, limit := range c.SenderRateLimits {
+		policy.SenderRateLimits[addr] = admission.RateLimit{
+			Transactions: limit.Transactions,
+			Window:       limit.Window.Duration,
+		}
+	}
+	for addr, quota := range c.ContractGasQuotas {
+		policy.ContractGasQuotas[addr] = admission.GasQuota{
+			Gas:    quota.Gas,
+			Window: quota.Window.Duration,
+		}
+	}
+	return admission.New(policy)
+}
@@ -5,6 +5,7 @@ package evm
 
 import (
 	"context"
+	"math/big"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/txpool/bundlepool"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
 
 	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
@@ -34,7 +36,9 @@ type blockBuilder struct {
 	clock *mockable.Clock
 	ctx   *snow.Context
 
-	txPool *txpool.TxPool
+	chain      *core.BlockChain
+	txPool     *txpool.TxPool
+	bundlePool *bundlepool.BundlePool
 
 	shutdownChan <-chan struct{}
 	shutdownWg   *sync.WaitGroup
@@ -55,7 +59,9 @@ type blockBuilder struct {
 func (vm *VM) NewBlockBuilder() *blockBuilder {
 	b := &blockBuilder{
 		ctx:          vm.ctx,
+		chain:        vm.blockChain,
 		txPool:       vm.txPool,
+		bundlePool:   vm.eth.BundlePool(),
 		shutdownChan: vm.shutdownChan,
 		shutdownWg:   &vm.shutdownWg,
 		clock:        vm.clock,
@@ -72,13 +78,24 @@ func (b *blockBuilder) handleGenerateBlock(currentParentHash common.Hash) {
 	b.lastBuildParentHash = currentParentHash
 }
 
-// needToBuild returns true if there are outstanding transactions to be issued
-// into a block.
+// needToBuild returns true if there are outstanding transactions or bundles to
+// be issued into a block.
 func (b *blockBuilder) needToBuild() bool {
 	size := b.txPool.PendingSize(txpool.PendingFilter{
 		MinTip: uint256.MustFromBig(b.txPool.GasTip()),
 	})
-	return size > 0
+	return size > 0 || b.hasPendingBundles()
+}
+
+// hasPendingBundles returns true if there are bundles that may be included in
+// a block built on top of the current head now.
+func (b *blockBuilder) hasPendingBundles() bool {
+	head := b.chain.CurrentBlock()
+	next := &types.Header{
+		Number: new(big.Int).Add(head.Number, common.Big1),
+		Time:   uint64(b.clock.Time().Unix()),
+	}
+	return len(b.bundlePool.Pending(next)) > 0
 }
 
 // signalCanBuild signals that a new block can be built.
@@ -100,16 +117,23 @@ func (b *blockBuilder) awaitSubmittedTxs() {
 	events := make(chan core.NewTxPoolReorgEvent)
 	sub := b.txPool.SubscribeNewReorgEvent(events)
 
+	bundleChan := make(chan bundlepool.NewBundleEvent)
+	bundleSub := b.bundlePool.SubscribeNewBundles(bundleChan)
+
 	b.shutdownWg.Add(1)
 	go b.ctx.Log.RecoverAndPanic(func() {
 		defer b.shutdownWg.Done()
 		defer sub.Unsubscribe()
+		defer bundleSub.Unsubscribe()
 
 		for {
 			select {
 			case <-txSubmitChan:
 				log.Trace("New tx detected, trying to generate a block")
 				b.signalCanBuild()
+			case <-bundleChan:
+				log.Trace("New bundle detected, trying to generate a block")
+				b.signalCanBuild()
 			case <-b.shutdownChan:
 				return
 			case event := <-events:
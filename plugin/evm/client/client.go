@@ -5,11 +5,13 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/libevm/common"
 	"golang.org/x/exp/slog"
 
 	"github.com/ava-labs/subnet-evm/plugin/evm/config"
@@ -38,6 +40,9 @@ type Client interface {
 	LockProfile(ctx context.Context, options ...rpc.Option) error
 	SetLogLevel(ctx context.Context, level slog.Level, options ...rpc.Option) error
 	GetVMConfig(ctx context.Context, options ...rpc.Option) (*config.Config, error)
+	SimulateUpgrade(ctx context.Context, upgradeBytes []byte, options ...rpc.Option) (json.RawMessage, error)
+	PruneFirewood(ctx context.Context, revisions uint64, options ...rpc.Option) (*PruneFirewoodReply, error)
+	SetTxPoolAdmissionPolicy(ctx context.Context, policy *config.TxPoolAdmissionPolicy, options ...rpc.Option) error
 	GetCurrentValidators(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]CurrentValidator, error)
 }
 
@@ -103,6 +108,64 @@ func (c *client) GetVMConfig(ctx context.Context, options ...rpc.Option) (*confi
 	return res.Config, err
 }
 
+type SimulateUpgradeArgs struct {
+	Upgrade json.RawMessage `json:"upgrade"`
+}
+
+// SimulateUpgradeReply holds the JSON encoded simulation so this package does
+// not import core. It decodes into a core.UpgradeSimulation.
+type SimulateUpgradeReply struct {
+	Simulation json.RawMessage `json:"simulation"`
+}
+
+// SimulateUpgrade applies [upgradeBytes], the contents of a candidate upgrade.json,
+// on top of the last accepted state without committing it, and returns the
+// JSON encoded core.UpgradeSimulation.
+func (c *client) SimulateUpgrade(ctx context.Context, upgradeBytes []byte, options ...rpc.Option) (json.RawMessage, error) {
+	res := &SimulateUpgradeReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.simulateUpgrade", &SimulateUpgradeArgs{
+		Upgrade: upgradeBytes,
+	}, res, options...)
+	return res.Simulation, err
+}
+
+type PruneFirewoodArgs struct {
+	// Revisions is the number of the last committed revisions to keep, or 0
+	// to use the firewood-prune-revisions config.
+	Revisions uint64 `json:"revisions"`
+}
+
+type PruneFirewoodReply struct {
+	Root       common.Hash `json:"root"`
+	Revisions  int         `json:"revisions"`
+	SizeBefore uint64      `json:"sizeBefore"`
+	SizeAfter  uint64      `json:"sizeAfter"`
+	Reclaimed  uint64      `json:"reclaimed"`
+}
+
+// PruneFirewood rebuilds the Firewood database from its last [revisions]
+// committed revisions, or from the configured number of revisions if 0, and
+// returns the disk space reclaimed.
+func (c *client) PruneFirewood(ctx context.Context, revisions uint64, options ...rpc.Option) (*PruneFirewoodReply, error) {
+	res := &PruneFirewoodReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.pruneFirewood", &PruneFirewoodArgs{
+		Revisions: revisions,
+	}, res, options...)
+	return res, err
+}
+
+type SetTxPoolAdmissionPolicyArgs struct {
+	Policy *config.TxPoolAdmissionPolicy `json:"policy"`
+}
+
+// SetTxPoolAdmissionPolicy replaces the admission policy of the tx pool, or
+// removes it if [policy] is nil.
+func (c *client) SetTxPoolAdmissionPolicy(ctx context.Context, policy *config.TxPoolAdmissionPolicy, options ...rpc.Option) error {
+	return c.adminRequester.SendRequest(ctx, "admin.setTxPoolAdmissionPolicy", &SetTxPoolAdmissionPolicyArgs{
+		Policy: policy,
+	}, &api.EmptyReply{}, options...)
+}
+
 type GetCurrentValidatorsRequest struct {
 	NodeIDs []ids.NodeID `json:"nodeIDs"`
 }
@@ -78,6 +78,11 @@ type Config struct {
 	// Metric Settings
 	MetricsExpensiveEnabled bool `json:"metrics-expensive-enabled"` // Debug-level metrics that might impact runtime performance
 
+	// Health Check Settings
+	HealthMaxLastAcceptedBlockAge Duration `json:"health-max-last-accepted-block-age"` // Maximum age of the last accepted block before the chain is unhealthy (0 = disabled)
+	HealthMaxTxPoolUtilization    float64  `json:"health-max-tx-pool-utilization"`     // Fraction of the tx pool capacity at which the chain is unhealthy (0 = disabled)
+	HealthMinConnectedStake       float64  `json:"health-min-connected-stake"`         // Minimum fraction of validator stake that must be connected for the chain to be healthy
+
 	// API Settings
 	LocalTxsEnabled bool `json:"local-txs-enabled"`
 
@@ -89,6 +94,14 @@ type Config struct {
 	TxPoolGlobalQueue  uint64   `json:"tx-pool-global-queue"`
 	TxPoolLifetime     Duration `json:"tx-pool-lifetime"`
 
+	TxPoolLifecycleRetention Duration `json:"tx-pool-lifecycle-retention"`
+
+	TxPoolJournalEnabled  bool     `json:"tx-pool-journal-enabled"`
+	TxPoolJournalMaxSize  uint64   `json:"tx-pool-journal-max-size"` // Maximum size of the tx pool journal (MB)
+	TxPoolJournalInterval Duration `json:"tx-pool-journal-interval"`
+
+	TxPoolAdmissionPolicy *TxPoolAdmissionPolicy `json:"tx-pool-admission-policy,omitempty"`
+
 	APIMaxDuration           Duration      `json:"api-max-duration"`
 	WSCPURefillRate          Duration      `json:"ws-cpu-refill-rate"`
 	WSCPUMaxStored           Duration      `json:"ws-cpu-max-stored"`
@@ -120,6 +133,11 @@ type Config struct {
 	// Address for Tx Fees (must be empty if not supported by blockchain)
 	FeeRecipient string `json:"feeRecipient"`
 
+	// Block Building Priority Lane
+	PriorityLaneOperators []common.Address `json:"priority-lane-operators"`
+	PriorityLaneAddresses []common.Address `json:"priority-lane-addresses"`
+	PriorityLaneGasShare  float64          `json:"priority-lane-gas-share"`
+
 	// Offline Pruning Settings
 	OfflinePruning                bool   `json:"offline-pruning-enabled"`
 	OfflinePruningBloomFilterSize uint64 `json:"offline-pruning-bloom-filter-size"`
@@ -160,6 +178,10 @@ type Config struct {
 	TransactionHistory uint64 `json:"transaction-history"`
 	// The maximum number of blocks from head whose state histories are reserved for pruning blockchains.
 	StateHistory uint64 `json:"state-history"`
+	// FirewoodPruneRevisions is the number of the last committed revisions kept
+	// when a Firewood database is pruned through the admin API. If 0, the
+	// revisions of the last [StateHistory] blocks are kept.
+	FirewoodPruneRevisions uint64 `json:"firewood-prune-revisions"`
 
 	// SkipTxIndexing skips indexing transactions.
 	// This is useful for validators that don't need to index transactions.
@@ -172,6 +194,17 @@ type Config struct {
 	// https://github.com/ava-labs/avalanchego/tree/7623ffd4be915a5185c9ed5e11fa9be15a6e1f00/vms/platformvm/warp/payload#addressedcall
 	WarpOffChainMessages []hexutil.Bytes `json:"warp-off-chain-messages"`
 
+	// WarpStateAttestationsEnabled signs off-chain AddressedCall messages attesting the balance of an
+	// account or the value of a storage slot after an accepted block, once checked against that state.
+	WarpStateAttestationsEnabled bool `json:"warp-state-attestations-enabled"`
+
+	// WarpRetentionBlocks and WarpRetentionPeriod prune, every WarpPruneInterval, the warp messages sent
+	// in accepted blocks more than WarpRetentionBlocks blocks behind the last accepted block or older
+	// than WarpRetentionPeriod. Zero values disable the corresponding limit.
+	WarpRetentionBlocks uint64   `json:"warp-retention-blocks"`
+	WarpRetentionPeriod Duration `json:"warp-retention-period"`
+	WarpPruneInterval   Duration `json:"warp-prune-interval"`
+
 	// RPC settings
 	HTTPBodyLimit        uint64 `json:"http-body-limit"`
 	BatchRequestLimit    uint64 `json:"batch-request-limit"`
@@ -254,10 +287,78 @@ func (c *Config) validate(_ uint32) error {
 	if c.Pruning && c.StateHistory == 0 {
 		return errors.New("cannot use state history of 0 with pruning enabled")
 	}
+	if c.FirewoodPruneRevisions > c.StateHistory {
+		return fmt.Errorf("firewood-prune-revisions (%d) cannot exceed state-history (%d)", c.FirewoodPruneRevisions, c.StateHistory)
+	}
 
 	if c.PushGossipPercentStake < 0 || c.PushGossipPercentStake > 1 {
 		return fmt.Errorf("push-gossip-percent-stake is %f but must be in the range [0, 1]", c.PushGossipPercentStake)
 	}
+	if c.PriorityLaneGasShare < 0 || c.PriorityLaneGasShare > 1 {
+		return fmt.Errorf("priority-lane-gas-share is %f but must be in the range [0, 1]", c.PriorityLaneGasShare)
+	}
+	if c.HealthMaxTxPoolUtilization < 0 || c.HealthMaxTxPoolUtilization > 1 {
+		return fmt.Errorf("health-max-tx-pool-utilization is %f but must be in the range [0, 1]", c.HealthMaxTxPoolUtilization)
+	}
+	if c.HealthMinConnectedStake < 0 || c.HealthMinConnectedStake > 1 {
+		return fmt.Errorf("health-min-connected-stake is %f but must be in the range [0, 1]", c.HealthMinConnectedStake)
+	}
+	if c.TxPoolJournalEnabled && c.TxPoolJournalMaxSize == 0 {
+		return errors.New("cannot use tx-pool-journal-max-size of 0 with the tx pool journal enabled")
+	}
+	if (c.WarpRetentionBlocks > 0 || c.WarpRetentionPeriod.Duration > 0) && c.WarpPruneInterval.Duration <= 0 {
+		return fmt.Errorf("warp-prune-interval is %s but must be positive with a warp retention limit", c.WarpPruneInterval)
+	}
+	if c.TxPoolAdmissionPolicy != nil {
+		if err := c.TxPoolAdmissionPolicy.Validate(); err != nil {
+			return fmt.Errorf("invalid tx-pool-admission-policy: %w", err)
+		}
+	}
+	return nil
+}
+
+// TxPoolAdmissionPolicy configures the rules restricting which transactions
+// the tx pool admits, on top of its own limits.
+type TxPoolAdmissionPolicy struct {
+	DeniedAddresses   []common.Address                   `json:"denied-addresses,omitempty"`
+	DeniedSelectors   []hexutil.Bytes                    `json:"denied-selectors,omitempty"`
+	SenderRateLimit   *TxPoolRateLimit                   `json:"sender-rate-limit,omitempty"`
+	SenderRateLimits  map[common.Address]TxPoolRateLimit `json:"sender-rate-limits,omitempty"`
+	ContractGasQuotas map[common.Address]TxPoolGasQuota  `json:"contract-gas-quotas,omitempty"`
+}
+
+// TxPoolRateLimit is a maximum number of transactions admitted per window.
+type TxPoolRateLimit struct {
+	Transactions uint64   `json:"transactions"`
+	Window       Duration `json:"window"`
+}
+
+// TxPoolGasQuota is a maximum amount of gas admitted per window.
+type TxPoolGasQuota struct {
+	Gas    uint64   `json:"gas"`
+	Window Duration `json:"window"`
+}
+
+// Validate returns an error if the policy is invalid.
+func (p *TxPoolAdmissionPolicy) Validate() error {
+	for _, selector := range p.DeniedSelectors {
+		if len(selector) != 4 {
+			return fmt.Errorf("denied selector %s must be 4 bytes", selector)
+		}
+	}
+	if p.SenderRateLimit != nil && p.SenderRateLimit.Window.Duration <= 0 {
+		return errors.New("sender rate limit window must be positive")
+	}
+	for addr, limit := range p.SenderRateLimits {
+		if limit.Window.Duration <= 0 {
+			return fmt.Errorf("rate limit window of sender %s must be positive", addr)
+		}
+	}
+	for addr, quota := range p.ContractGasQuotas {
+		if quota.Window.Duration <= 0 {
+			return fmt.Errorf("gas quota window of contract %s must be positive", addr)
+		}
+	}
 	return nil
 }
 
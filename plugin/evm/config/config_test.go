@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -60,6 +61,22 @@ func TestUnmarshalConfig(t *testing.T) {
 			false,
 		},
 
+		{
+			"tx pool admission policy",
+			[]byte(`{"tx-pool-admission-policy": {"denied-addresses": ["0x0100000000000000000000000000000000000000"], "denied-selectors": ["0x095ea7b3"], "sender-rate-limit": {"transactions": 10, "window": "1m"}, "contract-gas-quotas": {"0x0200000000000000000000000000000000000000": {"gas": 1000000, "window": "1h"}}}}`),
+			Config{
+				TxPoolAdmissionPolicy: &TxPoolAdmissionPolicy{
+					DeniedAddresses: []common.Address{{0x01}},
+					DeniedSelectors: []hexutil.Bytes{{0x09, 0x5e, 0xa7, 0xb3}},
+					SenderRateLimit: &TxPoolRateLimit{Transactions: 10, Window: Duration{time.Minute}},
+					ContractGasQuotas: map[common.Address]TxPoolGasQuota{
+						{0x02}: {Gas: 1_000_000, Window: Duration{time.Hour}},
+					},
+				},
+			},
+			false,
+		},
+
 		{
 			"state sync enabled",
 			[]byte(`{"state-sync-enabled":true}`),
@@ -150,6 +167,18 @@ func TestGetConfig(t *testing.T) {
 				require.Equal(t, uint64(100), config.TxPoolPriceLimit)
 			},
 		},
+		{
+			name:        "invalid admission policy selector",
+			configJSON:  []byte(`{"tx-pool-admission-policy": {"denied-selectors": ["0x095ea7"]}}`),
+			networkID:   constants.TestnetID,
+			expectError: true,
+		},
+		{
+			name:        "invalid admission policy window",
+			configJSON:  []byte(`{"tx-pool-admission-policy": {"sender-rate-limit": {"transactions": 10}}}`),
+			networkID:   constants.TestnetID,
+			expectError: true,
+		},
 		{
 			name:       "nil config uses defaults",
 			configJSON: nil,
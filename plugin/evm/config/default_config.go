@@ -40,6 +40,10 @@ func NewDefaultConfig() Config {
 		RPCGasCap:                 50_000_000, // 50M Gas Limit
 		RPCTxFeeCap:               100,        // 100 AVAX
 		MetricsExpensiveEnabled:   true,
+		// Health check settings
+		HealthMaxLastAcceptedBlockAge: timeToDuration(0),
+		HealthMaxTxPoolUtilization:    .95,
+		HealthMinConnectedStake:       .8,
 		// Default to no maximum API call duration
 		APIMaxDuration: timeToDuration(0),
 		// Default to no maximum WS CPU usage
@@ -87,6 +91,13 @@ func NewDefaultConfig() Config {
 		TxPoolAccountQueue: 64,
 		TxPoolGlobalQueue:  1024,
 		TxPoolLifetime:     timeToDuration(10 * time.Minute),
+
+		TxPoolLifecycleRetention: timeToDuration(time.Hour),
+
+		TxPoolJournalMaxSize:  32,
+		TxPoolJournalInterval: timeToDuration(time.Minute),
+
+		WarpPruneInterval: timeToDuration(10 * time.Minute),
 		// RPC settings
 		BatchRequestLimit:    1000,
 		BatchResponseMaxSize: 25 * 1000 * 1000, // 25MB
@@ -5,6 +5,7 @@ package customrawdb
 
 import (
 	"encoding/binary"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/libevm/common"
@@ -33,6 +34,35 @@ func WriteSyncRoot(db ethdb.KeyValueWriter, root common.Hash) error {
 	return db.Put(syncRootKey, root[:])
 }
 
+// ReadFirewoodSyncProgress reads the root and the key the next range proof
+// starts at of an in-progress Firewood sync. It returns common.Hash{} if no
+// in-progress sync was found.
+func ReadFirewoodSyncProgress(db ethdb.KeyValueReader) (common.Hash, []byte, error) {
+	has, err := db.Has(syncFirewoodProgressKey)
+	if err != nil || !has {
+		return common.Hash{}, nil, err
+	}
+	progress, err := db.Get(syncFirewoodProgressKey)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	if len(progress) < common.HashLength {
+		return common.Hash{}, nil, fmt.Errorf("invalid firewood sync progress length %d", len(progress))
+	}
+	return common.BytesToHash(progress[:common.HashLength]), progress[common.HashLength:], nil
+}
+
+// WriteFirewoodSyncProgress writes root and the key the next range proof starts
+// at as the progress of the in-progress Firewood sync.
+func WriteFirewoodSyncProgress(db ethdb.KeyValueWriter, root common.Hash, next []byte) error {
+	return db.Put(syncFirewoodProgressKey, append(root.Bytes(), next...))
+}
+
+// DeleteFirewoodSyncProgress removes the progress of the Firewood sync.
+func DeleteFirewoodSyncProgress(db ethdb.KeyValueWriter) error {
+	return db.Delete(syncFirewoodProgressKey)
+}
+
 // AddCodeToFetch adds a marker that we need to fetch the code for `hash`.
 func AddCodeToFetch(db ethdb.KeyValueWriter, hash common.Hash) {
 	if err := db.Put(codeToFetchKey(hash), nil); err != nil {
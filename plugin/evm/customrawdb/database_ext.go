@@ -32,6 +32,7 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		rawdb.WithDatabaseMetadataKeys(func(key []byte) bool {
 			return bytes.Equal(key, snapshotBlockHashKey) ||
 				bytes.Equal(key, syncRootKey) ||
+				bytes.Equal(key, syncFirewoodProgressKey) ||
 				(bytes.HasPrefix(key, upgradeConfigPrefix) && len(key) == len(upgradeConfigPrefix)+common.HashLength)
 		}),
 		rawdb.WithDatabaseStatRecorder(func(key []byte, size common.StorageSize) bool {
@@ -28,6 +28,8 @@ var (
 var (
 	// syncRootKey indicates the root of the main account trie currently being synced
 	syncRootKey = []byte("sync_root")
+	// syncFirewoodProgressKey tracks the root and next start key of an in-progress Firewood state sync.
+	syncFirewoodProgressKey = []byte("sync_firewood_progress")
 	// syncStorageTriesPrefix is the prefix for storage tries that need to be fetched.
 	// syncStorageTriesPrefix + trie root + account hash: indicates a storage trie must be fetched for the account
 	syncStorageTriesPrefix = []byte("sync_storage")
@@ -3,12 +3,287 @@
 
 package evm
 
-import "context"
-
-// Health returns nil if this chain is healthy.
-// Also returns details, which should be one of:
-// string, []byte, map[string]string
-func (*VM) HealthCheck(context.Context) (interface{}, error) {
-	// TODO perform actual health check
-	return nil, nil
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+// uptimeSyncMaxStaleness is the maximum amount of time since the last
+// successful uptime tracker sync before the chain is reported as unhealthy.
+const uptimeSyncMaxStaleness = 3 * syncFrequency
+
+// Names of the individual checks reported by [VM.HealthCheck].
+const (
+	acceptorQueueHealthCheck = "acceptorQueue"
+	lastAcceptedHealthCheck  = "lastAcceptedBlock"
+	syncHealthCheck          = "sync"
+	txPoolHealthCheck        = "txPool"
+	networkHealthCheck       = "network"
+	uptimeHealthCheck        = "uptimeTracker"
+)
+
+var (
+	errUnhealthy           = errors.New("chain is unhealthy")
+	errAcceptorQueueFull   = errors.New("acceptor queue is full")
+	errLastAcceptedTooOld  = errors.New("last accepted block is too old")
+	errNotBootstrapped     = errors.New("chain is not bootstrapped")
+	errStateSyncInProgress = errors.New("state sync is in progress")
+	errTxPoolSaturated     = errors.New("tx pool is saturated")
+	errInsufficientStake   = errors.New("insufficient connected stake")
+	errUptimeSyncStale     = errors.New("uptime tracker has not synced recently")
+	errUptimeNeverSynced   = errors.New("uptime tracker has never synced")
+)
+
+// healthCheckResult is the structured outcome of a single health check.
+type healthCheckResult struct {
+	Healthy bool        `json:"healthy"`
+	Details interface{} `json:"details"`
+	Error   string      `json:"error,omitempty"`
+}
+
+func newHealthCheckResult(details interface{}, err error) healthCheckResult {
+	result := healthCheckResult{
+		Healthy: err == nil,
+		Details: details,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// uptimeSyncStatus records the outcome of the most recent uptime tracker sync.
+type uptimeSyncStatus struct {
+	lastAttempt time.Time
+	lastSuccess time.Time
+	err         error
+}
+
+// HealthCheck returns nil if this chain is healthy.
+// Also returns details, which are the results of every individual check keyed
+// by the check name. If any check fails, an error naming the failing checks is
+// returned.
+func (vm *VM) HealthCheck(ctx context.Context) (interface{}, error) {
+	checks := map[string]func(context.Context) healthCheckResult{
+		acceptorQueueHealthCheck: vm.acceptorQueueHealth,
+		lastAcceptedHealthCheck:  vm.lastAcceptedHealth,
+		syncHealthCheck:          vm.syncHealth,
+		txPoolHealthCheck:        vm.txPoolHealth,
+		networkHealthCheck:       vm.networkHealth,
+		uptimeHealthCheck:        vm.uptimeHealth,
+	}
+
+	var (
+		results = make(map[string]healthCheckResult, len(checks))
+		failing []string
+	)
+	for name, check := range checks {
+		result := check(ctx)
+		results[name] = result
+		if !result.Healthy {
+			failing = append(failing, name)
+		}
+	}
+	if len(failing) > 0 {
+		slices.Sort(failing)
+		return results, fmt.Errorf("%w: failing checks: %s", errUnhealthy, strings.Join(failing, ", "))
+	}
+	return results, nil
+}
+
+// acceptorQueueHealth reports the number of accepted blocks waiting to be
+// processed. The chain is unhealthy if the queue is full, since any further
+// acceptance will block until the acceptor catches up.
+func (vm *VM) acceptorQueueHealth(context.Context) healthCheckResult {
+	queueLen, queueLimit := vm.blockChain.AcceptorQueueLen()
+	details := map[string]int{
+		"queueLength": queueLen,
+		"queueLimit":  queueLimit,
+	}
+	var err error
+	if queueLimit > 0 && queueLen >= queueLimit {
+		err = errAcceptorQueueFull
+	}
+	return newHealthCheckResult(details, err)
+}
+
+// lastAcceptedHealth reports the age of the last accepted block. The chain is
+// unhealthy if the age exceeds the configured maximum (if any).
+func (vm *VM) lastAcceptedHealth(context.Context) healthCheckResult {
+	lastAccepted := vm.blockChain.LastAcceptedBlock()
+	blockTime := time.Unix(int64(lastAccepted.Time()), 0)
+	age := vm.clock.Time().Sub(blockTime)
+	maxAge := vm.config.HealthMaxLastAcceptedBlockAge.Duration
+	details := map[string]interface{}{
+		"height":    lastAccepted.NumberU64(),
+		"hash":      lastAccepted.Hash(),
+		"timestamp": blockTime.UTC(),
+		"age":       age.String(),
+		"maxAge":    maxAge.String(),
+	}
+	var err error
+	if maxAge > 0 && age > maxAge {
+		err = fmt.Errorf("%w: %s > %s", errLastAcceptedTooOld, age, maxAge)
+	}
+	return newHealthCheckResult(details, err)
+}
+
+// syncHealth reports whether the chain is bootstrapped and whether state sync
+// or snapshot generation is still running. The chain is unhealthy until it has
+// finished bootstrapping and state sync. Snapshot generation is reported but
+// does not mark the chain as unhealthy, since blocks are still processed while
+// the snapshot is being generated.
+func (vm *VM) syncHealth(context.Context) healthCheckResult {
+	bootstrapped := vm.bootstrapped.Get()
+	stateSyncInProgress := vm.Client.StateSyncInProgress()
+	details := map[string]interface{}{
+		"bootstrapped":        bootstrapped,
+		"stateSyncInProgress": stateSyncInProgress,
+	}
+	if snaps := vm.blockChain.Snapshots(); snaps != nil {
+		generating, err := snaps.Generating()
+		if err != nil {
+			details["snapshotError"] = err.Error()
+		} else {
+			details["snapshotGenerating"] = generating
+		}
+	}
+
+	var err error
+	switch {
+	case stateSyncInProgress:
+		err = errStateSyncInProgress
+	case !bootstrapped:
+		err = errNotBootstrapped
+	}
+	return newHealthCheckResult(details, err)
+}
+
+// txPoolHealth reports how full the transaction pool is relative to its global
+// pending and queued limits. The chain is unhealthy if the utilization reaches
+// the configured maximum (if any).
+func (vm *VM) txPoolHealth(context.Context) healthCheckResult {
+	pending, queued := vm.txPool.Stats()
+	capacity := vm.ethConfig.TxPool.GlobalSlots + vm.ethConfig.TxPool.GlobalQueue
+	var utilization float64
+	if capacity > 0 {
+		utilization = float64(pending+queued) / float64(capacity)
+	}
+	maxUtilization := vm.config.HealthMaxTxPoolUtilization
+	details := map[string]interface{}{
+		"pending":        pending,
+		"queued":         queued,
+		"capacity":       capacity,
+		"utilization":    utilization,
+		"maxUtilization": maxUtilization,
+	}
+	var err error
+	if maxUtilization > 0 && utilization >= maxUtilization {
+		err = fmt.Errorf("%w: utilization %.2f >= %.2f", errTxPoolSaturated, utilization, maxUtilization)
+	}
+	return newHealthCheckResult(details, err)
+}
+
+// networkHealth reports the number of connected peers and the percentage of
+// the current validator stake this node is connected to. This node's own
+// stake counts as connected. The chain is unhealthy if the connected stake
+// percentage is below the configured minimum.
+func (vm *VM) networkHealth(ctx context.Context) healthCheckResult {
+	details := map[string]interface{}{
+		"connectedPeers": vm.Network.Size(),
+	}
+
+	height, err := vm.ctx.ValidatorState.GetCurrentHeight(ctx)
+	if err != nil {
+		return newHealthCheckResult(details, fmt.Errorf("failed to get current P-chain height: %w", err))
+	}
+	validatorSet, err := vm.ctx.ValidatorState.GetValidatorSet(ctx, height, vm.ctx.SubnetID)
+	if err != nil {
+		return newHealthCheckResult(details, fmt.Errorf("failed to get validator set: %w", err))
+	}
+
+	var (
+		p2pValidators       = vm.P2PValidators()
+		totalWeight         uint64
+		connectedWeight     uint64
+		connectedValidators int
+	)
+	for nodeID, vdr := range validatorSet {
+		totalWeight, err = math.Add(totalWeight, vdr.Weight)
+		if err != nil {
+			return newHealthCheckResult(details, fmt.Errorf("failed to sum validator weights: %w", err))
+		}
+		if nodeID != vm.ctx.NodeID && !p2pValidators.Has(ctx, nodeID) {
+			continue
+		}
+		connectedWeight += vdr.Weight
+		connectedValidators++
+	}
+
+	// An empty validator set is trivially fully connected.
+	connectedStake := 1.0
+	if totalWeight > 0 {
+		connectedStake = float64(connectedWeight) / float64(totalWeight)
+	}
+	minConnectedStake := vm.config.HealthMinConnectedStake
+	details["validators"] = len(validatorSet)
+	details["connectedValidators"] = connectedValidators
+	details["connectedStake"] = connectedStake
+	details["minConnectedStake"] = minConnectedStake
+
+	if connectedStake < minConnectedStake {
+		err = fmt.Errorf("%w: %.2f < %.2f", errInsufficientStake, connectedStake, minConnectedStake)
+	}
+	return newHealthCheckResult(details, err)
+}
+
+// uptimeHealth reports the status of the uptime tracker. The uptime tracker is
+// only synced once the chain is bootstrapped, after which the chain is
+// unhealthy if the last sync failed or if it has not synced recently.
+func (vm *VM) uptimeHealth(context.Context) healthCheckResult {
+	status := vm.uptimeSyncStatus.Get()
+	bootstrapped := vm.bootstrapped.Get()
+	details := map[string]interface{}{
+		"synced": !status.lastSuccess.IsZero(),
+	}
+	if !status.lastAttempt.IsZero() {
+		details["lastAttempt"] = status.lastAttempt.UTC()
+	}
+	if !status.lastSuccess.IsZero() {
+		details["lastSuccess"] = status.lastSuccess.UTC()
+	}
+	if !bootstrapped {
+		return newHealthCheckResult(details, nil)
+	}
+
+	var err error
+	switch {
+	case status.err != nil:
+		err = fmt.Errorf("uptime tracker sync failed: %w", status.err)
+	case status.lastSuccess.IsZero():
+		err = errUptimeNeverSynced
+	case vm.clock.Time().Sub(status.lastSuccess) > uptimeSyncMaxStaleness:
+		err = fmt.Errorf("%w: last synced at %s", errUptimeSyncStale, status.lastSuccess.UTC())
+	}
+	return newHealthCheckResult(details, err)
+}
+
+// syncUptimeTracker syncs the uptime tracker and records the outcome so it can
+// be reported by [VM.HealthCheck].
+func (vm *VM) syncUptimeTracker(ctx context.Context) error {
+	err := vm.uptimeTracker.Sync(ctx)
+	status := vm.uptimeSyncStatus.Get()
+	status.lastAttempt = vm.clock.Time()
+	status.err = err
+	if err == nil {
+		status.lastSuccess = status.lastAttempt
+	}
+	vm.uptimeSyncStatus.Set(status)
+	return err
 }
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        testVMConfig
+		setup         func(*VM)
+		expectFailing []string
+	}{
+		{
+			name: "healthy",
+		},
+		{
+			name:          "not bootstrapped",
+			config:        testVMConfig{isSyncing: true},
+			expectFailing: []string{syncHealthCheck},
+		},
+		{
+			name:          "last accepted block too old",
+			config:        testVMConfig{configJSON: `{"health-max-last-accepted-block-age": "1s"}`},
+			expectFailing: []string{lastAcceptedHealthCheck},
+		},
+		{
+			name: "insufficient connected stake",
+			setup: func(vm *VM) {
+				disconnected := ids.GenerateTestNodeID()
+				vm.ctx.ValidatorState = &validatorSetOverride{
+					State: vm.ctx.ValidatorState,
+					validators: map[ids.NodeID]*validators.GetValidatorOutput{
+						vm.ctx.NodeID: {NodeID: vm.ctx.NodeID, Weight: 1},
+						disconnected:  {NodeID: disconnected, Weight: 9},
+					},
+				}
+			},
+			expectFailing: []string{networkHealthCheck},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			vm := newVM(t, test.config).vm
+			defer func() {
+				require.NoError(vm.Shutdown(t.Context()))
+			}()
+			if test.setup != nil {
+				test.setup(vm)
+			}
+
+			details, err := vm.HealthCheck(t.Context())
+			results, ok := details.(map[string]healthCheckResult)
+			require.True(ok)
+
+			var failing []string
+			for name, result := range results {
+				if !result.Healthy {
+					require.NotEmpty(result.Error)
+					failing = append(failing, name)
+				}
+			}
+			require.ElementsMatch(test.expectFailing, failing)
+			if len(test.expectFailing) == 0 {
+				require.NoError(err)
+			} else {
+				require.ErrorIs(err, errUnhealthy)
+			}
+		})
+	}
+}
+
+// validatorSetOverride returns a fixed validator set for the current height.
+type validatorSetOverride struct {
+	validators.State
+	validators map[ids.NodeID]*validators.GetValidatorOutput
+}
+
+func (v *validatorSetOverride) GetValidatorSet(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return v.validators, nil
+}
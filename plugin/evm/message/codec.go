@@ -42,6 +42,12 @@ func init() {
 	// See https://github.com/ava-labs/coreth/pull/999
 	c.SkipRegistrations(3)
 
+	errs.Add(
+		// Firewood state sync types
+		c.RegisterType(FirewoodLeafsRequest{}),
+		c.RegisterType(FirewoodLeafsResponse{}),
+	)
+
 	Codec.RegisterCodec(Version, c)
 
 	if errs.Errored() {
@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/libevm/common"
+)
+
+var _ Request = FirewoodLeafsRequest{}
+
+// FirewoodLeafsRequest is a request to receive a range proof of the leaves of
+// the Firewood revision at Root, starting at Start (inclusive).
+// Limit outlines the maximum number of leaves the proof may include.
+//
+// Firewood stores accounts and storage slots in a single flat key space, so
+// unlike [LeafsRequest] there is no Account or NodeType, and the proof covers
+// both accounts and storage.
+type FirewoodLeafsRequest struct {
+	Root  common.Hash `serialize:"true"`
+	Start []byte      `serialize:"true"`
+	Limit uint16      `serialize:"true"`
+}
+
+func (f FirewoodLeafsRequest) String() string {
+	return fmt.Sprintf(
+		"FirewoodLeafsRequest(Root=%s, Start=%s, Limit=%d)",
+		f.Root, common.Bytes2Hex(f.Start), f.Limit,
+	)
+}
+
+func (f FirewoodLeafsRequest) Handle(ctx context.Context, nodeID ids.NodeID, requestID uint32, handler RequestHandler) ([]byte, error) {
+	return handler.HandleFirewoodLeafsRequest(ctx, nodeID, requestID, f)
+}
+
+// FirewoodLeafsResponse is a response to a FirewoodLeafsRequest
+// Proof is a serialized Firewood range proof of the leaves of the requested
+// revision from the requested start key. It may be truncated to the requested
+// limit, in which case the next request starts at its last key.
+// handler: handlers.FirewoodLeafsRequestHandler
+type FirewoodLeafsResponse struct {
+	Proof []byte `serialize:"true"`
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMarshalFirewoodLeafsRequest requires that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalFirewoodLeafsRequest(t *testing.T) {
+	leafsRequest := FirewoodLeafsRequest{
+		Root:  common.BytesToHash([]byte("im ROOTing for ya")),
+		Start: common.FromHex("0x0102"),
+		Limit: 1024,
+	}
+
+	// The request is encoded with its type ID, which must not change.
+	base64LeafsRequest := "AAAAAAALAAAAAAAAAAAAAAAAAAAAaW0gUk9PVGluZyBmb3IgeWEAAAACAQIEAA=="
+
+	leafsRequestBytes, err := RequestToBytes(Codec, leafsRequest)
+	require.NoError(t, err)
+	require.Equal(t, base64LeafsRequest, base64.StdEncoding.EncodeToString(leafsRequestBytes))
+
+	var r Request
+	_, err = Codec.Unmarshal(leafsRequestBytes, &r)
+	require.NoError(t, err)
+	require.Equal(t, leafsRequest, r)
+}
+
+// TestMarshalFirewoodLeafsResponse requires that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalFirewoodLeafsResponse(t *testing.T) {
+	leafsResponse := FirewoodLeafsResponse{
+		Proof: []byte("some proof"),
+	}
+
+	base64LeafsResponse := "AAAAAAAKc29tZSBwcm9vZg=="
+
+	leafsResponseBytes, err := Codec.Marshal(Version, leafsResponse)
+	require.NoError(t, err)
+	require.Equal(t, base64LeafsResponse, base64.StdEncoding.EncodeToString(leafsResponseBytes))
+
+	var l FirewoodLeafsResponse
+	_, err = Codec.Unmarshal(leafsResponseBytes, &l)
+	require.NoError(t, err)
+	require.Equal(t, leafsResponse, l)
+}
@@ -19,6 +19,7 @@ type RequestHandler interface {
 	HandleLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest LeafsRequest) ([]byte, error)
 	HandleBlockRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, request BlockRequest) ([]byte, error)
 	HandleCodeRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, codeRequest CodeRequest) ([]byte, error)
+	HandleFirewoodLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest FirewoodLeafsRequest) ([]byte, error)
 }
 
 // ResponseHandler handles response for a sent request
@@ -43,3 +44,7 @@ func (NoopRequestHandler) HandleBlockRequest(context.Context, ids.NodeID, uint32
 func (NoopRequestHandler) HandleCodeRequest(context.Context, ids.NodeID, uint32, CodeRequest) ([]byte, error) {
 	return nil, nil
 }
+
+func (NoopRequestHandler) HandleFirewoodLeafsRequest(context.Context, ids.NodeID, uint32, FirewoodLeafsRequest) ([]byte, error) {
+	return nil, nil
+}
@@ -26,6 +26,8 @@ type networkHandler struct {
 	leafRequestHandlers LeafHandlers
 	blockRequestHandler *syncHandlers.BlockRequestHandler
 	codeRequestHandler  *syncHandlers.CodeRequestHandler
+	// firewoodLeafsRequestHandler is only set on nodes using the Firewood state scheme.
+	firewoodLeafsRequestHandler *syncHandlers.FirewoodLeafsRequestHandler
 }
 
 type LeafRequestTypeConfig struct {
@@ -74,3 +76,11 @@ func (n networkHandler) HandleBlockRequest(ctx context.Context, nodeID ids.NodeI
 func (n networkHandler) HandleCodeRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, codeRequest message.CodeRequest) ([]byte, error) {
 	return n.codeRequestHandler.OnCodeRequest(ctx, nodeID, requestID, codeRequest)
 }
+
+func (n networkHandler) HandleFirewoodLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest message.FirewoodLeafsRequest) ([]byte, error) {
+	if n.firewoodLeafsRequestHandler == nil {
+		log.Debug("firewood state is not served by this node, dropping request", "nodeID", nodeID, "requestID", requestID)
+		return nil, nil
+	}
+	return n.firewoodLeafsRequestHandler.OnFirewoodLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/vms/components/chain"
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/rawdb"
@@ -24,6 +25,7 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	"github.com/ava-labs/subnet-evm/sync/statesync"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
 
 	syncclient "github.com/ava-labs/subnet-evm/sync/client"
 )
@@ -91,6 +93,8 @@ type client struct {
 
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
+	// syncing is true while the state sync goroutine is running.
+	syncing utils.Atomic[bool]
 
 	// State Sync results
 	summary message.Syncable
@@ -113,6 +117,7 @@ type Client interface {
 	ClearOngoingSummary() error
 	Shutdown() error
 	Error() error
+	StateSyncInProgress() bool
 }
 
 // Syncer represents a step in state sync,
@@ -231,9 +236,11 @@ func (client *client) acceptSyncSummary(proposedSummary message.Syncable) (block
 	ctx, cancel := context.WithCancel(context.Background())
 	client.cancel = cancel
 	client.wg.Add(1) // track the state sync goroutine so we can wait for it on shutdown
+	client.syncing.Set(true)
 	go func() {
 		defer client.wg.Done()
 		defer cancel()
+		defer client.syncing.Set(false)
 
 		if err := client.stateSync(ctx); err != nil {
 			client.err = err
@@ -300,15 +307,7 @@ func (client *client) syncBlocks(ctx context.Context, fromHash common.Hash, from
 
 func (client *client) syncStateTrie(ctx context.Context) error {
 	log.Info("state sync: sync starting", "root", client.summary.GetBlockRoot())
-	evmSyncer, err := statesync.NewStateSyncer(&statesync.StateSyncerConfig{
-		Client:                   client.Client,
-		Root:                     client.summary.GetBlockRoot(),
-		BatchSize:                ethdb.IdealBatchSize,
-		DB:                       client.ChaindDB,
-		MaxOutstandingCodeHashes: statesync.DefaultMaxOutstandingCodeHashes,
-		NumCodeFetchingWorkers:   statesync.DefaultNumCodeFetchingWorkers,
-		RequestSize:              client.RequestSize,
-	})
+	evmSyncer, err := client.newStateSyncer()
 	if err != nil {
 		return err
 	}
@@ -320,6 +319,39 @@ func (client *client) syncStateTrie(ctx context.Context) error {
 	return err
 }
 
+// newStateSyncer returns the syncer for the state scheme of the chain.
+// Firewood state is synced as range proofs committed directly to the Firewood
+// database, rather than as trie nodes written to [client.ChaindDB].
+func (client *client) newStateSyncer() (Syncer, error) {
+	if fw, ok := client.Chain.BlockChain().TrieDB().Backend().(*firewood.Database); ok {
+		return statesync.NewFirewoodSyncer(&statesync.FirewoodSyncerConfig{
+			Client:                   client.Client,
+			Root:                     client.summary.GetBlockRoot(),
+			DB:                       client.ChaindDB,
+			Firewood:                 fw,
+			MaxOutstandingCodeHashes: statesync.DefaultMaxOutstandingCodeHashes,
+			NumCodeFetchingWorkers:   statesync.DefaultNumCodeFetchingWorkers,
+			RequestSize:              client.RequestSize,
+		})
+	}
+	return statesync.NewStateSyncer(&statesync.StateSyncerConfig{
+		Client:                   client.Client,
+		Root:                     client.summary.GetBlockRoot(),
+		BatchSize:                ethdb.IdealBatchSize,
+		DB:                       client.ChaindDB,
+		MaxOutstandingCodeHashes: statesync.DefaultMaxOutstandingCodeHashes,
+		NumCodeFetchingWorkers:   statesync.DefaultNumCodeFetchingWorkers,
+		RequestSize:              client.RequestSize,
+		Scheme:                   client.Chain.BlockChain().TrieDB().Scheme(),
+	})
+}
+
+// StateSyncInProgress returns true if the state sync goroutine has been
+// started and has not yet finished.
+func (client *client) StateSyncInProgress() bool {
+	return client.syncing.Get()
+}
+
 func (client *client) Shutdown() error {
 	if client.cancel != nil {
 		client.cancel()
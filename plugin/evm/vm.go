@@ -76,8 +76,10 @@ import (
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ava-labs/subnet-evm/sync/client/stats"
 	"github.com/ava-labs/subnet-evm/sync/handlers"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
 	"github.com/ava-labs/subnet-evm/triedb/hashdb"
 	"github.com/ava-labs/subnet-evm/warp"
+	"github.com/ava-labs/subnet-evm/warp/messages"
 
 	avalanchegossip "github.com/ava-labs/avalanchego/network/p2p/gossip"
 	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
@@ -111,6 +113,9 @@ const (
 
 	syncFrequency = 1 * time.Minute
 
+	// File in the chain data directory journaling the tx pool
+	txPoolJournalFile = "txpool.rlp"
+
 	// Prefixes for metrics gatherers
 	ethMetricsPrefix        = "eth"
 	sdkMetricsPrefix        = "sdk"
@@ -147,11 +152,11 @@ var (
 	errInvalidHeaderPredicateResults              = errors.New("invalid header predicate results")
 	errInitializingLogger                         = errors.New("failed to initialize logger")
 	errShuttingDownVM                             = errors.New("shutting down VM")
-	errPathStateUnsupported                       = errors.New("path state scheme is not supported")
+	errPathStatePruningDisabled                   = errors.New("pruning must be enabled for the path state scheme")
+	errPathStateOfflinePruningUnsupported         = errors.New("offline pruning is not supported for the path state scheme")
 	errFirewoodSnapshotCacheDisabled              = errors.New("snapshot cache must be disabled for Firewood")
-	errFirewoodOfflinePruningUnsupported          = errors.New("offline pruning is not supported for Firewood")
-	errFirewoodStateSyncUnsupported               = errors.New("state sync is not yet supported for Firewood")
 	errFirewoodMissingTrieRepopulationUnsupported = errors.New("missing trie repopulation is not supported for Firewood")
+	errFirewoodStateSyncUnsupported               = errors.New("state sync is not yet supported for Firewood, since range proofs are not verified")
 )
 
 // legacyApiNames maps pre geth v1.10.20 api names to their updated counterparts.
@@ -260,7 +265,8 @@ type VM struct {
 	ethTxPushGossiper  avalancheUtils.Atomic[*avalanchegossip.PushGossiper[*GossipEthTx]]
 	ethTxPullGossiper  avalanchegossip.Gossiper
 
-	uptimeTracker *uptimetracker.UptimeTracker
+	uptimeTracker    *uptimetracker.UptimeTracker
+	uptimeSyncStatus avalancheUtils.Atomic[uptimeSyncStatus]
 
 	chainAlias string
 	// RPC handlers (should be stopped before closing chaindb)
@@ -365,9 +371,15 @@ func (vm *VM) Initialize(
 	vm.ethConfig.TxPool.AccountQueue = vm.config.TxPoolAccountQueue
 	vm.ethConfig.TxPool.GlobalQueue = vm.config.TxPoolGlobalQueue
 	vm.ethConfig.TxPool.Lifetime = vm.config.TxPoolLifetime.Duration
+	vm.ethConfig.TxPool.LifecycleRetention = vm.config.TxPoolLifecycleRetention.Duration
 	// If we re-enable txpool journaling, we should also add the saved local
 	// transactions to the p2p gossip on startup.
 	vm.ethConfig.TxPool.Journal = "" // disable journal
+	if vm.config.TxPoolJournalEnabled {
+		vm.ethConfig.TxPool.PoolJournal = filepath.Join(vm.ctx.ChainDataDir, txPoolJournalFile)
+		vm.ethConfig.TxPool.PoolJournalLimit = vm.config.TxPoolJournalMaxSize * units.MiB
+		vm.ethConfig.TxPool.Rejournal = vm.config.TxPoolJournalInterval.Duration
+	}
 
 	vm.ethConfig.AllowUnfinalizedQueries = vm.config.AllowUnfinalizedQueries
 	vm.ethConfig.AllowUnprotectedTxs = vm.config.AllowUnprotectedTxs
@@ -405,19 +417,24 @@ func (vm *VM) Initialize(
 		if vm.config.SnapshotCache > 0 {
 			return errFirewoodSnapshotCacheDisabled
 		}
-		if vm.config.OfflinePruning {
-			return errFirewoodOfflinePruningUnsupported
-		}
-		if vm.config.StateSyncEnabled {
-			return errFirewoodStateSyncUnsupported
-		}
 		if vm.config.PopulateMissingTries != nil {
 			return errFirewoodMissingTrieRepopulationUnsupported
 		}
+		if vm.config.StateSyncEnabled && !firewood.RangeProofsVerified {
+			return errFirewoodStateSyncUnsupported
+		}
 	}
 	if vm.ethConfig.StateScheme == rawdb.PathScheme {
-		log.Error("Path state scheme is not supported. Please use HashDB or Firewood state schemes instead")
-		return errPathStateUnsupported
+		log.Warn("Path state scheme is enabled")
+		log.Warn("This is untested in production, use at your own risk")
+		// Only the state of the last [StateHistory] accepted blocks is kept,
+		// so archive mode is not possible.
+		if !vm.config.Pruning {
+			return errPathStatePruningDisabled
+		}
+		if vm.config.OfflinePruning {
+			return errPathStateOfflinePruningUnsupported
+		}
 	}
 
 	// Create directory for offline pruning
@@ -437,6 +454,9 @@ func (vm *VM) Initialize(
 		log.Info("Config has not specified any coinbase address. Defaulting to the blackhole address.")
 		vm.ethConfig.Miner.Etherbase = constants.BlackholeAddr
 	}
+	vm.ethConfig.Miner.PriorityOperators = vm.config.PriorityLaneOperators
+	vm.ethConfig.Miner.PriorityAddresses = vm.config.PriorityLaneAddresses
+	vm.ethConfig.Miner.PriorityGasShare = vm.config.PriorityLaneGasShare
 
 	vm.chainConfig = g.Config
 
@@ -481,6 +501,12 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	warpVerifiers := messages.NewRegistry()
+	if vm.config.WarpStateAttestationsEnabled {
+		if err := warp.RegisterStateVerifiers(warpVerifiers, vm); err != nil {
+			return fmt.Errorf("failed to register warp state verifiers: %w", err)
+		}
+	}
 	vm.warpBackend, err = warp.NewBackend(
 		vm.ctx.NetworkID,
 		vm.ctx.ChainID,
@@ -490,6 +516,7 @@ func (vm *VM) Initialize(
 		vm.warpDB,
 		meteredCache,
 		offchainWarpMessages,
+		warpVerifiers,
 	)
 	if err != nil {
 		return err
@@ -636,13 +663,15 @@ func (vm *VM) initializeChain(lastAcceptedHash common.Hash, ethConfig ethconfig.
 	vm.txPool = vm.eth.TxPool()
 	vm.blockChain = vm.eth.BlockChain()
 	vm.miner = vm.eth.Miner()
+	vm.miner.SetPredicateContextFn(vm.nextPredicateContext)
 	lastAccepted := vm.blockChain.LastAcceptedBlock()
-	feeConfig, _, err := vm.blockChain.GetFeeConfigAt(lastAccepted.Header())
+	feeConfig, _, err := vm.blockChain.GetFeeConfigAt(lastAccepted.Header(), lastAccepted.Time())
 	if err != nil {
 		return err
 	}
 	vm.txPool.SetMinFee(feeConfig.MinBaseFee)
 	vm.txPool.SetGasTip(big.NewInt(0))
+	vm.txPool.SetAdmissionPolicy(newAdmissionPolicy(vm.config.TxPoolAdmissionPolicy))
 
 	vm.eth.Start()
 	return vm.initChainState(lastAccepted)
@@ -686,6 +715,11 @@ func (vm *VM) initializeStateSync(lastAcceptedHeight uint64) error {
 		leafHandlers,
 		syncStats,
 	)
+	// Firewood nodes serve range proofs of their retained revisions instead of
+	// trie leaves, since their state is not stored as trie nodes in [vm.chaindb].
+	if fw, ok := vm.blockChain.TrieDB().Backend().(*firewood.Database); ok {
+		networkHandler.firewoodLeafsRequestHandler = handlers.NewFirewoodLeafsRequestHandler(fw, vm.networkCodec, syncStats)
+	}
 	vm.Network.SetRequestHandler(networkHandler)
 
 	vm.Server = vmsync.NewServer(vm.blockChain, vm.extensionConfig.SyncSummaryProvider, vm.config.StateSyncCommitInterval) // parse nodeIDs from state sync IDs in vm config
@@ -819,7 +853,9 @@ func (vm *VM) onNormalOperationsStarted() error {
 
 	// Initially sync the uptime tracker so that APIs expose recent data even if
 	// called immediately after bootstrapping.
-	vm.uptimeTracker.Sync(ctx)
+	if err := vm.syncUptimeTracker(ctx); err != nil {
+		log.Error("failed to sync uptime tracker", "err", err)
+	}
 
 	vm.shutdownWg.Add(1)
 	go func() {
@@ -832,13 +868,26 @@ func (vm *VM) onNormalOperationsStarted() error {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				if err := vm.uptimeTracker.Sync(ctx); err != nil {
+				if err := vm.syncUptimeTracker(ctx); err != nil {
 					log.Error("failed to sync uptime tracker", "err", err)
 				}
 			}
 		}
 	}()
 
+	warpRetention := warp.RetentionPolicy{
+		Blocks: vm.config.WarpRetentionBlocks,
+		Period: vm.config.WarpRetentionPeriod.Duration,
+	}
+	if warpRetention.Enabled() {
+		warpPruner := warp.NewPruner(vm.warpBackend, vm.blockChain, warpRetention)
+		vm.shutdownWg.Add(1)
+		go func() {
+			defer vm.shutdownWg.Done()
+			warpPruner.Run(ctx, vm.config.WarpPruneInterval.Duration)
+		}()
+	}
+
 	// Initialize goroutines related to block building
 	// once we enter normal operation as there is no need to handle mempool gossip before this point.
 	ethTxGossipMarshaller := GossipEthTxMarshaller{}
@@ -1006,6 +1055,19 @@ func (vm *VM) Shutdown(context.Context) error {
 	return nil
 }
 
+// nextPredicateContext returns the predicate context of a block built at the
+// current P-chain height.
+func (vm *VM) nextPredicateContext(ctx context.Context) (*precompileconfig.PredicateContext, error) {
+	pChainHeight, err := vm.ctx.ValidatorState.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current P-chain height: %w", err)
+	}
+	return &precompileconfig.PredicateContext{
+		SnowCtx:            vm.ctx,
+		ProposerVMBlockCtx: &block.Context{PChainHeight: pChainHeight},
+	}, nil
+}
+
 // buildBlock builds a block to be wrapped by ChainState
 func (vm *VM) buildBlock(ctx context.Context) (snowman.Block, error) {
 	return vm.buildBlockWithContext(ctx, nil)
@@ -1121,6 +1183,19 @@ func (vm *VM) GetAcceptedBlock(ctx context.Context, blkID ids.ID) (snowman.Block
 	return blk, nil
 }
 
+// AcceptedStateAt returns the state after the accepted block at [height].
+// It implements the warp.StateClient interface.
+func (vm *VM) AcceptedStateAt(height uint64) (warp.StateReader, error) {
+	if lastAccepted := vm.blockChain.LastAcceptedBlock().NumberU64(); height > lastAccepted {
+		return nil, fmt.Errorf("height %d is above last accepted height %d", height, lastAccepted)
+	}
+	header := vm.blockChain.GetHeaderByNumber(height)
+	if header == nil {
+		return nil, fmt.Errorf("%w: height %d", database.ErrNotFound, height)
+	}
+	return vm.blockChain.StateAt(header.Root)
+}
+
 // SetPreference sets what the current tail of the chain is
 func (vm *VM) SetPreference(ctx context.Context, blkID ids.ID) error {
 	// Since each internal handler used by [vm.State] always returns a block
@@ -1209,7 +1284,7 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 
 	if vm.config.WarpAPIEnabled {
 		warpSDKClient := vm.Network.NewClient(p2p.SignatureRequestHandlerID)
-		signatureAggregator := acp118.NewSignatureAggregator(vm.ctx.Log, warpSDKClient)
+		signatureAggregator := warp.NewSignatureAggregator(warpSDKClient)
 
 		if err := handler.RegisterName("warp", warp.NewAPI(vm.ctx, vm.warpBackend, signatureAggregator)); err != nil {
 			return nil, err
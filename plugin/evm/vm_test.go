@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
@@ -35,6 +36,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/evm/predicate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
 	"github.com/ava-labs/libevm/common/math"
 	"github.com/ava-labs/libevm/core/rawdb"
 	"github.com/ava-labs/libevm/core/types"
@@ -48,11 +50,13 @@ import (
 	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/txpool/bundlepool"
 	"github.com/ava-labs/subnet-evm/eth"
 	"github.com/ava-labs/subnet-evm/node"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/params/paramstest"
+	"github.com/ava-labs/subnet-evm/plugin/evm/client"
 	"github.com/ava-labs/subnet-evm/plugin/evm/config"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
@@ -70,6 +74,7 @@ import (
 	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
 	avagoconstants "github.com/ava-labs/avalanchego/utils/constants"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	ethparams "github.com/ava-labs/libevm/params"
 	warpcontract "github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 )
 
@@ -1710,7 +1715,7 @@ func testBuildAllowListActivationBlock(t *testing.T, scheme string) {
 
 	genesisState, err := tvm.vm.blockChain.StateAt(tvm.vm.blockChain.Genesis().Root())
 	require.NoError(t, err)
-	role := deployerallowlist.GetContractDeployerAllowListStatus(genesisState, testEthAddrs[0])
+	role := deployerallowlist.GetContractDeployerAllowListStatus(genesisState, testEthAddrs[0], false, 0)
 	require.Equal(t, allowlist.NoRole, role, "Expected allow list status to be set to no role: %s, but found: %s", allowlist.NoRole, role)
 
 	// Send basic transaction to construct a simple block and confirm that the precompile state configuration in the worker behaves correctly.
@@ -1730,7 +1735,7 @@ func testBuildAllowListActivationBlock(t *testing.T, scheme string) {
 	// Verify that the allow list config activation was handled correctly in the first block.
 	blkState, err := tvm.vm.blockChain.StateAt(blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock.Root())
 	require.NoError(t, err)
-	role = deployerallowlist.GetContractDeployerAllowListStatus(blkState, testEthAddrs[0])
+	role = deployerallowlist.GetContractDeployerAllowListStatus(blkState, testEthAddrs[0], false, 0)
 	require.Equal(t, allowlist.AdminRole, role, "Expected allow list status to be set role %s, but found: %s", allowlist.AdminRole, role)
 }
 
@@ -1785,12 +1790,12 @@ func TestTxAllowListSuccessfulTx(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check that address 0 is whitelisted and address 1 is not
-	role := txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[0])
+	role := txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[0], false, 0)
 	require.Equal(t, allowlist.AdminRole, role, "Expected allow list status to be set to admin: %s, but found: %s", allowlist.AdminRole, role)
-	role = txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[1])
+	role = txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[1], false, 0)
 	require.Equal(t, allowlist.NoRole, role, "Expected allow list status to be set to no role: %s, but found: %s", allowlist.NoRole, role)
 	// Should not be a manager role because Durango has not activated yet
-	role = txallowlist.GetTxAllowListStatus(genesisState, managerAddress)
+	role = txallowlist.GetTxAllowListStatus(genesisState, managerAddress, false, 0)
 	require.Equal(t, allowlist.NoRole, role)
 
 	// Submit a successful transaction
@@ -1850,9 +1855,9 @@ func TestTxAllowListSuccessfulTx(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check that address 0 is admin and address 1 is manager
-	role = txallowlist.GetTxAllowListStatus(blkState, testEthAddrs[0])
+	role = txallowlist.GetTxAllowListStatus(blkState, testEthAddrs[0], false, 0)
 	require.Equal(t, allowlist.AdminRole, role)
-	role = txallowlist.GetTxAllowListStatus(blkState, managerAddress)
+	role = txallowlist.GetTxAllowListStatus(blkState, managerAddress, false, 0)
 	require.Equal(t, allowlist.ManagerRole, role)
 
 	tvm.vm.clock.Set(tvm.vm.clock.Time().Add(2 * time.Second)) // add 2 seconds for gas fee to adjust
@@ -1983,9 +1988,9 @@ func TestTxAllowListDisablePrecompile(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check that address 0 is whitelisted and address 1 is not
-	role := txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[0])
+	role := txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[0], false, 0)
 	require.Equal(t, allowlist.AdminRole, role, "expected allow list status to be set to admin: %s, but found: %s", allowlist.AdminRole, role)
-	role = txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[1])
+	role = txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[1], false, 0)
 	require.Equal(t, allowlist.NoRole, role, "expected allow list status to be set to no role: %s, but found: %s", allowlist.NoRole, role)
 
 	// Submit a successful transaction
@@ -2073,12 +2078,12 @@ func TestFeeManagerChangeFee(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check that address 0 is whitelisted and address 1 is not
-	role := feemanager.GetFeeManagerStatus(genesisState, testEthAddrs[0])
+	role := feemanager.GetFeeManagerStatus(genesisState, testEthAddrs[0], false, 0)
 	require.Equal(t, allowlist.AdminRole, role, "expected fee manager list status to be set to admin: %s, but found: %s", allowlist.AdminRole, role)
-	role = feemanager.GetFeeManagerStatus(genesisState, testEthAddrs[1])
+	role = feemanager.GetFeeManagerStatus(genesisState, testEthAddrs[1], false, 0)
 	require.Equal(t, allowlist.NoRole, role, "expected fee manager list status to be set to no role: %s, but found: %s", allowlist.NoRole, role)
 	// Contract is initialized but no preconfig is given, reader should return genesis fee config
-	feeConfig, lastChangedAt, err := tvm.vm.blockChain.GetFeeConfigAt(tvm.vm.blockChain.Genesis().Header())
+	feeConfig, lastChangedAt, err := tvm.vm.blockChain.GetFeeConfigAt(tvm.vm.blockChain.Genesis().Header(), tvm.vm.blockChain.Genesis().Time())
 	require.NoError(t, err)
 	require.Equal(t, testLowFeeConfig, feeConfig)
 	require.Zero(t, tvm.vm.blockChain.CurrentBlock().Number.Cmp(lastChangedAt))
@@ -2113,7 +2118,7 @@ func TestFeeManagerChangeFee(t *testing.T) {
 
 	block := blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
 
-	feeConfig, lastChangedAt, err = tvm.vm.blockChain.GetFeeConfigAt(block.Header())
+	feeConfig, lastChangedAt, err = tvm.vm.blockChain.GetFeeConfigAt(block.Header(), block.Time())
 	require.NoError(t, err)
 	require.Equal(t, testHighFeeConfig, feeConfig)
 	require.Equal(t, tvm.vm.blockChain.CurrentBlock().Number, lastChangedAt)
@@ -2137,6 +2142,76 @@ func TestFeeManagerChangeFee(t *testing.T) {
 	require.ErrorIs(t, err, txpool.ErrUnderpriced)
 }
 
+// TestFeeManagerScheduledFeeConfigAtActivation tests that a block whose timestamp
+// is the activation timestamp of a scheduled fee config is built and verified with it.
+func TestFeeManagerScheduledFeeConfigAtActivation(t *testing.T) {
+	genesis := &core.Genesis{}
+	require.NoError(t, genesis.UnmarshalJSON([]byte(genesisJSONSubnetEVM)))
+	configExtra := params.GetExtra(genesis.Config)
+	configExtra.HeliconTimestamp = utils.TimeToNewUint64(upgrade.InitiallyActiveTime)
+	configExtra.FeeConfig = params.DefaultFeeConfig
+	configExtra.GenesisPrecompiles = extras.Precompiles{
+		feemanager.ConfigKey: feemanager.NewConfig(utils.NewUint64(0), testEthAddrs[0:1], nil, nil, nil),
+	}
+	genesisJSON, err := genesis.MarshalJSON()
+	require.NoError(t, err)
+	tvm := newVM(t, testVMConfig{
+		genesisJSON: string(genesisJSON),
+	})
+	defer func() {
+		require.NoError(t, tvm.vm.Shutdown(t.Context()))
+	}()
+
+	scheduledFeeConfig := configExtra.FeeConfig
+	scheduledFeeConfig.GasLimit = new(big.Int).Add(configExtra.FeeConfig.GasLimit, big.NewInt(1_000_000))
+	activation := tvm.vm.clock.Time().Add(time.Minute)
+	data, err := feemanager.PackScheduleFeeConfig(feemanager.ScheduleFeeConfigInput{
+		FeeConfig:           scheduledFeeConfig,
+		ActivationTimestamp: uint64(activation.Unix()),
+	})
+	require.NoError(t, err)
+	signedTx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   genesis.Config.ChainID,
+		Nonce:     0,
+		To:        &feemanager.ContractAddress,
+		Gas:       1_000_000,
+		GasFeeCap: big.NewInt(testMinGasPrice * 3),
+		Data:      data,
+	}), types.LatestSigner(genesis.Config), testKeys[0].ToECDSA())
+	require.NoError(t, err)
+	require.NoError(t, tvm.vm.txPool.AddRemotesSync([]*types.Transaction{signedTx})[0])
+	blk := issueAndAccept(t, tvm.vm)
+	parent := blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+	require.Equal(t, configExtra.FeeConfig.GasLimit.Uint64(), parent.GasLimit())
+
+	// A block before the activation timestamp uses the stored fee config.
+	feeConfig, _, err := tvm.vm.blockChain.GetFeeConfigAt(parent.Header(), uint64(activation.Unix())-1)
+	require.NoError(t, err)
+	require.Equal(t, configExtra.FeeConfig, feeConfig)
+
+	// The block at the activation timestamp uses the scheduled fee config.
+	tvm.vm.clock.Set(activation)
+	tx := types.NewTransaction(1, testEthAddrs[1], common.Big1, ethparams.TxGas, big.NewInt(testMinGasPrice*3), nil)
+	signedTx, err = types.SignTx(tx, types.LatestSigner(genesis.Config), testKeys[0].ToECDSA())
+	require.NoError(t, err)
+	require.NoError(t, tvm.vm.txPool.AddRemotesSync([]*types.Transaction{signedTx})[0])
+	blk = issueAndAccept(t, tvm.vm)
+	block := blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+	require.Equal(t, uint64(activation.Unix()), block.Time())
+	require.Equal(t, scheduledFeeConfig.GasLimit.Uint64(), block.GasLimit())
+
+	feeConfig, lastChangedAt, err := tvm.vm.blockChain.GetFeeConfigAt(parent.Header(), block.Time())
+	require.NoError(t, err)
+	require.Equal(t, scheduledFeeConfig, feeConfig)
+	require.Equal(t, block.Number(), lastChangedAt)
+
+	// The fee config stored by the block is the scheduled fee config.
+	feeConfig, lastChangedAt, err = tvm.vm.blockChain.GetFeeConfigAt(block.Header(), block.Time())
+	require.NoError(t, err)
+	require.Equal(t, scheduledFeeConfig, feeConfig)
+	require.Equal(t, block.Number(), lastChangedAt)
+}
+
 // Test Allow Fee Recipients is disabled and, etherbase must be blackhole address
 func TestAllowFeeRecipientDisabled(t *testing.T) {
 	for _, scheme := range schemes {
@@ -2822,7 +2897,7 @@ func TestFeeManagerRegressionMempoolMinFeeAfterRestart(t *testing.T) {
 	// We must query the current block header here (not genesis) because the FeeManager precompile
 	// is only activated at precompileActivationTime, not at genesis. Querying the genesis header would
 	// return the chain config fee config and lastChangedAt as zero, which is not correct after activation.
-	feeConfig, lastChangedAt, err := restartedVM.blockChain.GetFeeConfigAt(restartedVM.blockChain.CurrentBlock())
+	feeConfig, lastChangedAt, err := restartedVM.blockChain.GetFeeConfigAt(restartedVM.blockChain.CurrentBlock(), restartedVM.blockChain.CurrentBlock().Time)
 	require.NoError(t, err)
 	require.Equal(t, testHighFeeConfig, feeConfig)
 	require.Equal(t, restartedVM.blockChain.CurrentBlock().Number, lastChangedAt)
@@ -2853,7 +2928,7 @@ func TestFeeManagerRegressionMempoolMinFeeAfterRestart(t *testing.T) {
 
 	// check that the fee config is updated
 	block := blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
-	feeConfig, lastChangedAt, err = restartedVM.blockChain.GetFeeConfigAt(block.Header())
+	feeConfig, lastChangedAt, err = restartedVM.blockChain.GetFeeConfigAt(block.Header(), block.Time())
 	require.NoError(t, err)
 	require.Equal(t, restartedVM.blockChain.CurrentBlock().Number, lastChangedAt)
 	require.Equal(t, testLowFeeConfig, feeConfig)
@@ -3707,3 +3782,373 @@ func TestInspectDatabases(t *testing.T) {
 	vm.initializeDBs(db)
 	require.NoError(t, vm.inspectDatabases())
 }
+
+func TestPathStateSchemeConfig(t *testing.T) {
+	tests := map[string]struct {
+		config      string
+		expectedErr error
+	}{
+		"pruning disabled": {
+			config:      `"pruning-enabled": false`,
+			expectedErr: errPathStatePruningDisabled,
+		},
+		"offline pruning": {
+			config:      fmt.Sprintf(`"offline-pruning-enabled": true, "offline-pruning-data-directory": %q`, t.TempDir()),
+			expectedErr: errPathStateOfflinePruningUnsupported,
+		},
+		"state history beyond diff layers": {
+			config: `"pruning-enabled": true, "state-history": 1024`,
+		},
+		"pruning enabled": {
+			config: `"pruning-enabled": true`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, db, genesisBytes := setupGenesis(t, upgradetest.Latest)
+			defer ctx.Lock.Unlock()
+			configJSON := fmt.Sprintf(`{"state-scheme": %q, %s}`, rawdb.PathScheme, test.config)
+
+			vm := &VM{}
+			err := vm.Initialize(t.Context(), ctx, db, genesisBytes, []byte{}, []byte(configJSON), []*commonEng.Fx{}, &enginetest.Sender{})
+			require.ErrorIs(t, err, test.expectedErr)
+			if err == nil {
+				require.NoError(t, vm.Shutdown(t.Context()))
+			}
+		})
+	}
+}
+
+func TestAdminPruneFirewood(t *testing.T) {
+	require := require.New(t)
+
+	tvm := newVM(t, testVMConfig{
+		configJSON: getConfig(customrawdb.FirewoodScheme, `"state-history": 4`),
+	})
+	defer func() { require.NoError(tvm.vm.Shutdown(t.Context())) }()
+
+	// issueTransfer accepts a block with a single transfer from testKeys[0].
+	issueTransfer := func(nonce uint64) *types.Block {
+		tx := types.NewTransaction(nonce, testEthAddrs[1], common.Big1, ethparams.TxGas, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(tvm.vm.chainConfig.ChainID), testKeys[0].ToECDSA())
+		require.NoError(err)
+		for _, err := range tvm.vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+			require.NoError(err)
+		}
+		tvm.vm.clock.Set(tvm.vm.clock.Time().Add(2 * time.Second))
+		blk := issueAndAccept(t, tvm.vm)
+		return blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+	}
+	var roots []common.Hash
+	for nonce := uint64(0); nonce < 8; nonce++ {
+		roots = append(roots, issueTransfer(nonce).Root())
+	}
+	tvm.vm.blockChain.DrainAcceptorQueue()
+
+	admin := NewAdminService(tvm.vm, t.TempDir())
+	reply := &client.PruneFirewoodReply{}
+	require.NoError(admin.PruneFirewood(nil, &client.PruneFirewoodArgs{Revisions: 2}, reply))
+	require.Equal(roots[len(roots)-1], reply.Root)
+	require.Equal(2, reply.Revisions)
+	require.Equal(reply.SizeBefore-reply.SizeAfter, reply.Reclaimed)
+	require.True(tvm.vm.blockChain.HasState(roots[len(roots)-1]))
+	require.True(tvm.vm.blockChain.HasState(roots[len(roots)-2]))
+	require.False(tvm.vm.blockChain.HasState(roots[len(roots)-3]))
+
+	// Blocks are accepted on top of the pruned database.
+	issueTransfer(8)
+	state, err := tvm.vm.blockChain.State()
+	require.NoError(err)
+	require.Equal(uint64(9), state.GetNonce(testEthAddrs[0]))
+}
+
+func TestAdminPruneFirewoodHashScheme(t *testing.T) {
+	tvm := newVM(t, testVMConfig{})
+	defer func() { require.NoError(t, tvm.vm.Shutdown(t.Context())) }()
+
+	admin := NewAdminService(tvm.vm, t.TempDir())
+	err := admin.PruneFirewood(nil, &client.PruneFirewoodArgs{}, &client.PruneFirewoodReply{})
+	require.ErrorIs(t, err, errFirewoodNotEnabled)
+}
+
+func TestConditionalTransactionInclusion(t *testing.T) {
+	require := require.New(t)
+
+	tvm := newVM(t, testVMConfig{})
+	defer func() { require.NoError(tvm.vm.Shutdown(t.Context())) }()
+
+	signer := types.NewEIP155Signer(tvm.vm.chainConfig.ChainID)
+	transfer := func(key int, nonce uint64) *types.Transaction {
+		tx := types.NewTransaction(nonce, testEthAddrs[2], common.Big1, ethparams.TxGas, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, testKeys[key].ToECDSA())
+		require.NoError(err)
+		return signedTx
+	}
+	issueBlock := func() *types.Block {
+		tvm.vm.clock.Set(tvm.vm.clock.Time().Add(2 * time.Second))
+		blk := issueAndAccept(t, tvm.vm)
+		return blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+	}
+
+	// The conditional transaction may only be included from block 2 onwards.
+	conditionalTx := transfer(1, 0)
+	require.NoError(tvm.vm.txPool.AddConditional(conditionalTx, &txpool.TransactionConditional{BlockNumberMin: big.NewInt(2)}, true))
+	for _, err := range tvm.vm.txPool.AddRemotesSync([]*types.Transaction{transfer(0, 0)}) {
+		require.NoError(err)
+	}
+	blk := issueBlock()
+	require.Len(blk.Transactions(), 1)
+	require.True(tvm.vm.txPool.Has(conditionalTx.Hash()))
+
+	for _, err := range tvm.vm.txPool.AddRemotesSync([]*types.Transaction{transfer(0, 1)}) {
+		require.NoError(err)
+	}
+	blk = issueBlock()
+	require.Len(blk.Transactions(), 2)
+	require.NotNil(blk.Transaction(conditionalTx.Hash()))
+}
+
+func TestTxPoolAdmissionPolicy(t *testing.T) {
+	require := require.New(t)
+
+	tvm := newVM(t, testVMConfig{
+		configJSON: fmt.Sprintf(`{"tx-pool-admission-policy": {"denied-addresses": ["%s"]}}`, testEthAddrs[1].Hex()),
+	})
+	defer func() { require.NoError(tvm.vm.Shutdown(t.Context())) }()
+
+	tx := types.NewTransaction(0, testEthAddrs[2], common.Big1, ethparams.TxGas, big.NewInt(testMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(tvm.vm.chainConfig.ChainID), testKeys[1].ToECDSA())
+	require.NoError(err)
+
+	// The rejection reason is returned to RPC callers.
+	err = tvm.vm.eth.APIBackend.SendTx(t.Context(), signedTx)
+	require.ErrorIs(err, txpool.ErrAdmissionRejected)
+	require.ErrorContains(err, testEthAddrs[1].Hex())
+
+	// Removing the policy through the admin API admits the transaction.
+	admin := NewAdminService(tvm.vm, t.TempDir())
+	require.NoError(admin.SetTxPoolAdmissionPolicy(nil, &client.SetTxPoolAdmissionPolicyArgs{}, &api.EmptyReply{}))
+	require.Nil(tvm.vm.config.TxPoolAdmissionPolicy)
+	require.NoError(tvm.vm.eth.APIBackend.SendTx(t.Context(), signedTx))
+
+	// Invalid policies are rejected.
+	invalid := &config.TxPoolAdmissionPolicy{SenderRateLimit: &config.TxPoolRateLimit{Transactions: 1}}
+	require.Error(admin.SetTxPoolAdmissionPolicy(nil, &client.SetTxPoolAdmissionPolicyArgs{Policy: invalid}, &api.EmptyReply{}))
+}
+
+func TestPriorityLane(t *testing.T) {
+	tests := []struct {
+		name          string
+		gasShare      float64
+		wantFirstFrom common.Address
+	}{
+		{
+			name:          "priority lane",
+			gasShare:      0.5,
+			wantFirstFrom: testEthAddrs[1],
+		},
+		{
+			// The lane has too little gas for any transaction, so the priority
+			// transaction competes on price with all others.
+			name:          "priority lane exhausted",
+			gasShare:      0.000001,
+			wantFirstFrom: testEthAddrs[0],
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tvm := newVM(t, testVMConfig{
+				configJSON: fmt.Sprintf(`{"priority-lane-addresses": ["%s"], "priority-lane-gas-share": %f}`, testEthAddrs[1].Hex(), test.gasShare),
+			})
+			defer func() { require.NoError(tvm.vm.Shutdown(t.Context())) }()
+
+			signer := types.NewEIP155Signer(tvm.vm.chainConfig.ChainID)
+			txs := make([]*types.Transaction, 2)
+			for i, gasPrice := range []int64{2 * testMinGasPrice, testMinGasPrice} {
+				tx := types.NewTransaction(0, testEthAddrs[2], common.Big1, ethparams.TxGas, big.NewInt(gasPrice), nil)
+				signedTx, err := types.SignTx(tx, signer, testKeys[i].ToECDSA())
+				require.NoError(err)
+				txs[i] = signedTx
+			}
+			for _, err := range tvm.vm.txPool.AddRemotesSync(txs) {
+				require.NoError(err)
+			}
+
+			tvm.vm.clock.Set(tvm.vm.clock.Time().Add(2 * time.Second))
+			blk := issueAndAccept(t, tvm.vm)
+			ethBlock := blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+			require.Len(ethBlock.Transactions(), 2)
+			from, err := types.Sender(signer, ethBlock.Transactions()[0])
+			require.NoError(err)
+			require.Equal(test.wantFirstFrom, from)
+		})
+	}
+}
+
+func TestPriorityLaneOperatorBundles(t *testing.T) {
+	require := require.New(t)
+
+	operatorKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	tvm := newVM(t, testVMConfig{
+		configJSON: fmt.Sprintf(
+			`{"priority-lane-operators": ["%s"], "priority-lane-addresses": ["%s"], "priority-lane-gas-share": 0.5}`,
+			crypto.PubkeyToAddress(operatorKey.PublicKey).Hex(), testEthAddrs[1].Hex(),
+		),
+	})
+	defer func() { require.NoError(tvm.vm.Shutdown(t.Context())) }()
+
+	signer := types.NewEIP155Signer(tvm.vm.chainConfig.ChainID)
+	transfer := func(key int, gasPrice int64) *types.Transaction {
+		tx := types.NewTransaction(0, testEthAddrs[2], common.Big1, ethparams.TxGas, big.NewInt(gasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, testKeys[key].ToECDSA())
+		require.NoError(err)
+		return signedTx
+	}
+	sendBundle := func(tx *types.Transaction, key *ecdsa.PrivateKey) {
+		bundle := &bundlepool.Bundle{Txs: []*types.Transaction{tx}, BlockNumberMax: big.NewInt(1)}
+		if key != nil {
+			require.NoError(bundle.Sign(key, tvm.vm.chainConfig.ChainID))
+		}
+		b, err := tx.MarshalBinary()
+		require.NoError(err)
+		_, err = eth.NewBundleAPI(tvm.vm.eth).SendBundle(t.Context(), eth.SendBundleArgs{
+			Txs:            []hexutil.Bytes{b},
+			BlockNumberMax: (*hexutil.Big)(bundle.BlockNumberMax),
+			Signature:      bundle.Signature,
+		})
+		require.NoError(err)
+	}
+
+	// The bundle signed by the operator is included first, followed by the
+	// transaction of the priority address, and then by the other bundles,
+	// regardless of the order they were submitted in.
+	unsignedTx, operatorTx, priorityTx := transfer(0, 3*testMinGasPrice), transfer(2, testMinGasPrice), transfer(1, 2*testMinGasPrice)
+	sendBundle(unsignedTx, nil)
+	sendBundle(operatorTx, operatorKey)
+	for _, err := range tvm.vm.txPool.AddRemotesSync([]*types.Transaction{priorityTx}) {
+		require.NoError(err)
+	}
+
+	tvm.vm.clock.Set(tvm.vm.clock.Time().Add(2 * time.Second))
+	blk := issueAndAccept(t, tvm.vm)
+	ethBlock := blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+	require.Len(ethBlock.Transactions(), 3)
+	for i, want := range []*types.Transaction{operatorTx, priorityTx, unsignedTx} {
+		require.Equal(want.Hash(), ethBlock.Transactions()[i].Hash(), "transaction %d", i)
+	}
+}
+
+func TestSendBundle(t *testing.T) {
+	require := require.New(t)
+
+	tvm := newVM(t, testVMConfig{})
+	defer func() { require.NoError(tvm.vm.Shutdown(t.Context())) }()
+
+	signer := types.NewEIP155Signer(tvm.vm.chainConfig.ChainID)
+	transfer := func(key int, nonce uint64, gasPrice int64) hexutil.Bytes {
+		tx := types.NewTransaction(nonce, testEthAddrs[2], common.Big1, ethparams.TxGas, big.NewInt(gasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, testKeys[key].ToECDSA())
+		require.NoError(err)
+		b, err := signedTx.MarshalBinary()
+		require.NoError(err)
+		return b
+	}
+	bundleAPI := eth.NewBundleAPI(tvm.vm.eth)
+
+	// Bundles failing on the pending state are rejected.
+	_, err := bundleAPI.SendBundle(t.Context(), eth.SendBundleArgs{
+		Txs:            []hexutil.Bytes{transfer(0, 1, testMinGasPrice)},
+		BlockNumberMax: (*hexutil.Big)(big.NewInt(1)),
+	})
+	require.ErrorIs(err, core.ErrNonceTooHigh)
+
+	hash, err := bundleAPI.SendBundle(t.Context(), eth.SendBundleArgs{
+		Txs:            []hexutil.Bytes{transfer(0, 0, testMinGasPrice), transfer(0, 1, testMinGasPrice)},
+		BlockNumberMax: (*hexutil.Big)(big.NewInt(1)),
+	})
+	require.NoError(err)
+	status, err := bundleAPI.GetBundleStatus(hash)
+	require.NoError(err)
+	require.Equal(bundlepool.StatusPending, status.Status)
+
+	// The bundle is included ahead of higher priced transactions.
+	for _, err := range tvm.vm.txPool.AddRemotesSync([]*types.Transaction{mustDecodeTx(t, transfer(1, 0, 2*testMinGasPrice))}) {
+		require.NoError(err)
+	}
+	tvm.vm.clock.Set(tvm.vm.clock.Time().Add(2 * time.Second))
+	blk := issueAndAccept(t, tvm.vm)
+	ethBlock := blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+	require.Len(ethBlock.Transactions(), 3)
+	for i, tx := range ethBlock.Transactions()[:2] {
+		from, err := types.Sender(signer, tx)
+		require.NoError(err)
+		require.Equal(testEthAddrs[0], from)
+		require.Equal(uint64(i), tx.Nonce())
+	}
+
+	require.Eventually(func() bool {
+		status, err = bundleAPI.GetBundleStatus(hash)
+		return err == nil && status.Status == bundlepool.StatusIncluded
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(ethBlock.Hash(), *status.BlockHash)
+	require.Equal(hexutil.Uint64(1), *status.BlockNumber)
+
+	_, err = bundleAPI.GetBundleStatus(common.Hash{1})
+	require.ErrorIs(err, bundlepool.ErrUnknownBundle)
+}
+
+func TestSendBundleFailingMidway(t *testing.T) {
+	require := require.New(t)
+
+	tvm := newVM(t, testVMConfig{})
+	defer func() { require.NoError(tvm.vm.Shutdown(t.Context())) }()
+
+	signer := types.NewEIP155Signer(tvm.vm.chainConfig.ChainID)
+	transfer := func(key int, nonce uint64) hexutil.Bytes {
+		tx := types.NewTransaction(nonce, testEthAddrs[2], common.Big1, ethparams.TxGas, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, testKeys[key].ToECDSA())
+		require.NoError(err)
+		b, err := signedTx.MarshalBinary()
+		require.NoError(err)
+		return b
+	}
+	bundleAPI := eth.NewBundleAPI(tvm.vm.eth)
+
+	// Both bundles apply on their own, but the second transaction of the
+	// second bundle is invalid once the first bundle is included.
+	included, err := bundleAPI.SendBundle(t.Context(), eth.SendBundleArgs{
+		Txs:            []hexutil.Bytes{transfer(0, 0)},
+		BlockNumberMax: (*hexutil.Big)(big.NewInt(1)),
+	})
+	require.NoError(err)
+	_, err = bundleAPI.SendBundle(t.Context(), eth.SendBundleArgs{
+		Txs:            []hexutil.Bytes{transfer(1, 0), transfer(0, 0)},
+		BlockNumberMax: (*hexutil.Big)(big.NewInt(1)),
+	})
+	require.NoError(err)
+
+	tvm.vm.clock.Set(tvm.vm.clock.Time().Add(2 * time.Second))
+	blk := issueAndAccept(t, tvm.vm)
+	ethBlock := blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+	require.Len(ethBlock.Transactions(), 1)
+	require.Equal(mustDecodeTx(t, transfer(0, 0)).Hash(), ethBlock.Transactions()[0].Hash())
+
+	// The first transaction of the failed bundle is reverted.
+	state, err := tvm.vm.blockChain.StateAt(ethBlock.Root())
+	require.NoError(err)
+	require.Zero(state.GetNonce(testEthAddrs[1]))
+	require.Eventually(func() bool {
+		status, err := bundleAPI.GetBundleStatus(included)
+		return err == nil && status.Status == bundlepool.StatusIncluded
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func mustDecodeTx(t *testing.T, b hexutil.Bytes) *types.Transaction {
+	t.Helper()
+	tx := new(types.Transaction)
+	require.NoError(t, tx.UnmarshalBinary(b))
+	return tx
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/params/paramstest"
+	"github.com/ava-labs/subnet-evm/plugin/evm/client"
 	"github.com/ava-labs/subnet-evm/plugin/evm/vmerrors"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/utils"
@@ -329,6 +330,65 @@ func TestVMStateUpgrade(t *testing.T) {
 	require.Equal(t, newAccountUpgrade.Storage[storageKey], state.GetState(newAccount, storageKey))
 }
 
+func TestAdminSimulateUpgrade(t *testing.T) {
+	tvm := newVM(t, testVMConfig{
+		genesisJSON: genesisJSONSubnetEVM,
+	})
+	defer func() { require.NoError(t, tvm.vm.Shutdown(t.Context())) }()
+
+	upgradeTimestamp := upgrade.InitiallyActiveTime.Add(10 * time.Hour)
+	upgradeConfig := &extras.UpgradeConfig{
+		PrecompileUpgrades: []extras.PrecompileUpgrade{
+			{
+				Config: txallowlist.NewConfig(utils.TimeToNewUint64(upgradeTimestamp), testEthAddrs[0:1], nil, nil),
+			},
+		},
+		StateUpgrades: []extras.StateUpgrade{
+			{
+				BlockTimestamp: utils.TimeToNewUint64(upgradeTimestamp),
+				StateUpgradeAccounts: map[common.Address]extras.StateUpgradeAccount{
+					testEthAddrs[1]: {BalanceChange: (*math.HexOrDecimal256)(big.NewInt(100))},
+				},
+			},
+		},
+	}
+	admin := NewAdminService(tvm.vm, t.TempDir())
+	reply := &SimulateUpgradeReply{}
+	require.NoError(t, admin.SimulateUpgrade(nil, &client.SimulateUpgradeArgs{Upgrade: []byte(mustMarshal(t, upgradeConfig))}, reply))
+
+	// The reply must round trip through JSON to be usable by the client.
+	var decoded client.SimulateUpgradeReply
+	require.NoError(t, json.Unmarshal([]byte(mustMarshal(t, reply)), &decoded))
+	simulation := &core.UpgradeSimulation{}
+	require.NoError(t, json.Unmarshal(decoded.Simulation, simulation))
+	require.Empty(t, simulation.VerifyError)
+	require.Empty(t, simulation.CompatibilityError)
+	require.Empty(t, simulation.ApplyError)
+	require.Len(t, simulation.Activations, 1)
+	activation := simulation.Activations[0]
+	require.Equal(t, uint64(upgradeTimestamp.Unix()), activation.Timestamp)
+	require.Len(t, activation.Precompiles, 1)
+	require.True(t, activation.Precompiles[0].Equal(upgradeConfig.PrecompileUpgrades[0].Config))
+	diff := activation.Accounts[testEthAddrs[1]]
+	require.NotNil(t, diff)
+	require.Equal(t, new(big.Int).Add(diff.BalanceBefore.ToInt(), big.NewInt(100)), diff.BalanceAfter.ToInt())
+	require.Contains(t, simulation.PrecompileConfigs, txallowlist.ConfigKey)
+
+	// Nothing is committed to the chain.
+	require.False(t, tvm.vm.chainConfigExtra().IsPrecompileEnabled(txallowlist.ContractAddress, uint64(upgradeTimestamp.Unix())))
+	state, err := tvm.vm.blockChain.State()
+	require.NoError(t, err)
+	require.Equal(t, uint256.MustFromBig(diff.BalanceBefore.ToInt()), state.GetBalance(testEthAddrs[1]))
+
+	// Invalid upgrades are reported in the simulation.
+	upgradeConfig.StateUpgrades[0].BlockTimestamp = utils.NewUint64(0)
+	require.NoError(t, admin.SimulateUpgrade(nil, &client.SimulateUpgradeArgs{Upgrade: []byte(mustMarshal(t, upgradeConfig))}, reply))
+	require.NotEmpty(t, reply.Simulation.VerifyError)
+
+	// Malformed upgrade bytes are rejected.
+	require.ErrorContains(t, admin.SimulateUpgrade(nil, &client.SimulateUpgradeArgs{Upgrade: []byte(`{"stateUpgrades": 1}`)}, reply), "failed to parse upgrade bytes")
+}
+
 func TestVMEtnaActivatesCancun(t *testing.T) {
 	defaultEtnaTime := uint64(upgrade.InitiallyActiveTime.Unix())
 
@@ -182,6 +182,22 @@ func testSendWarpMessage(t *testing.T, scheme string) {
 	// Verify the produced message signature is valid
 	require.True(bls.Verify(tvm.vm.ctx.PublicKey, blsSignature, unsignedMessage.Bytes()))
 
+	// Verify the message is indexed by the block height and sender.
+	indexed, cursor, err := tvm.vm.warpBackend.GetIndexedMessages(warp.MessageQuery{
+		FromBlock:     ethBlock1.NumberU64(),
+		ToBlock:       ethBlock1.NumberU64(),
+		SourceAddress: &testEthAddrs[0],
+	})
+	require.NoError(err)
+	require.Nil(cursor)
+	require.Equal([]*warp.IndexedMessage{{
+		BlockNumber:   ethBlock1.NumberU64(),
+		BlockHash:     ethBlock1.Hash(),
+		TxHash:        signedTx0.Hash(),
+		SourceAddress: testEthAddrs[0],
+		Message:       unsignedMessage,
+	}}, indexed)
+
 	// Verify the blockID will now be signed by the backend and produces a valid signature.
 	rawSignatureBytes, err = tvm.vm.warpBackend.GetBlockSignature(t.Context(), blk.ID())
 	require.NoError(err)
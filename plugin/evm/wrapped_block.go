@@ -240,7 +240,7 @@ func (b *wrappedBlock) verifyIntrinsicGas() error {
 	}
 
 	// Verify that the claimed GasUsed is within the current capacity.
-	feeConfig, _, err := b.vm.blockChain.GetFeeConfigAt(parent)
+	feeConfig, _, err := b.vm.blockChain.GetFeeConfigAt(parent, b.ethBlock.Time())
 	if err != nil {
 		return fmt.Errorf("failed to get fee config: %w", err)
 	}
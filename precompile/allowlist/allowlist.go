@@ -4,6 +4,7 @@
 package allowlist
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
@@ -26,11 +27,17 @@ const (
 	ReadAllowListGasCost   = contract.ReadGasCostPerSlot
 
 	allowListInputLen = common.HashLength
+
+	// roleSetWithExpiryEvent is the name the ABI parser gives to the RoleSet event
+	// overload that includes the expiry of the role.
+	roleSetWithExpiryEvent = "RoleSet0"
 )
 
 var (
 	// Error returned when an invalid write is attempted
 	ErrCannotModifyAllowList = errors.New("cannot modify allow list")
+	// Error returned when a role is granted with an expiry that is not in the future
+	ErrInvalidExpiry = errors.New("invalid role expiry")
 
 	// AllowListRawABI contains the raw ABI of AllowList library interface.
 	//go:embed allowlist.abi
@@ -39,18 +46,44 @@ var (
 	AllowListABI = contract.ParseABI(AllowListRawABI)
 )
 
+// The role of an address is stored in the last byte of the storage slot of the address.
+// After Helicon, a role granted until a timestamp stores that timestamp in the first
+// [expiryLen] bytes of the slot. A zero expiry means the role does not expire.
+const expiryLen = 8
+
 // GetAllowListStatus returns the allow list role of [address] for the precompile
-// at [precompileAddr]
-func GetAllowListStatus(state contract.StateReader, precompileAddr common.Address, address common.Address) Role {
+// at [precompileAddr] at [timestamp]. An expired role is returned as [NoRole].
+// Roles only expire if [isHelicon] is true.
+func GetAllowListStatus(state contract.StateReader, precompileAddr common.Address, address common.Address, isHelicon bool, timestamp uint64) Role {
+	role, _ := GetAllowListStatusWithExpiry(state, precompileAddr, address, isHelicon, timestamp)
+	return role
+}
+
+// GetAllowListStatusWithExpiry returns the allow list role of [address] for the precompile
+// at [precompileAddr] at [timestamp] and the timestamp at which it expires, or 0 if it does
+// not expire. An expired role is returned as [NoRole] with no expiry.
+// If [isHelicon] is false, the whole storage slot is read as the role, which does not expire.
+func GetAllowListStatusWithExpiry(state contract.StateReader, precompileAddr common.Address, address common.Address, isHelicon bool, timestamp uint64) (Role, uint64) {
 	// Generate the state key for [address]
 	addressKey := common.BytesToHash(address.Bytes())
-	return Role(state.GetState(precompileAddr, addressKey))
+	value := state.GetState(precompileAddr, addressKey)
+	if !isHelicon {
+		return Role(value), 0
+	}
+	expiry := binary.BigEndian.Uint64(value[:expiryLen])
+	if expiry != 0 && timestamp >= expiry {
+		return NoRole, 0
+	}
+	var role Role
+	copy(role[expiryLen:], value[expiryLen:])
+	return role, expiry
 }
 
 // SetAllowListRole sets the permissions of [address] to [role] for the precompile
-// at [precompileAddr].
+// at [precompileAddr], removing any expiry, and updates the index if it is enabled.
 // assumes [role] has already been verified as valid.
 func SetAllowListRole(stateDB contract.StateDB, precompileAddr, address common.Address, role Role) {
+	oldRole := getStoredRole(stateDB, precompileAddr, address)
 	// Generate the state key for [address]
 	addressKey := common.BytesToHash(address.Bytes())
 	// Assign [role] to the address
@@ -59,6 +92,20 @@ func SetAllowListRole(stateDB contract.StateDB, precompileAddr, address common.A
 	// conflicts with the same slot [role] is stored.
 	// Precompile implementations must use a different key than [addressKey]
 	stateDB.SetState(precompileAddr, addressKey, role.Hash())
+	updateIndex(stateDB, precompileAddr, address, oldRole, role)
+}
+
+// SetAllowListRoleWithExpiry sets the permissions of [address] to [role] for the precompile
+// at [precompileAddr] until [expiry], and updates the index if it is enabled.
+// After [expiry], [address] has no role.
+// assumes [role] has already been verified as valid and [expiry] is non-zero.
+func SetAllowListRoleWithExpiry(stateDB contract.StateDB, precompileAddr, address common.Address, role Role, expiry uint64) {
+	oldRole := getStoredRole(stateDB, precompileAddr, address)
+	addressKey := common.BytesToHash(address.Bytes())
+	value := role.Hash()
+	binary.BigEndian.PutUint64(value[:expiryLen], expiry)
+	stateDB.SetState(precompileAddr, addressKey, value)
+	updateIndex(stateDB, precompileAddr, address, oldRole, role)
 }
 
 func PackModifyAllowList(address common.Address, role Role) ([]byte, error) {
@@ -106,12 +153,18 @@ func createAllowListRoleSetter(precompileAddr common.Address, role Role) contrac
 		stateDB := evm.GetStateDB()
 
 		// Verify that the caller is an admin with permission to modify the allow list
-		callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr)
+		isHelicon, timestamp := evm.GetRules().IsHeliconActivated(), evm.GetBlockContext().Timestamp()
+		callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr, isHelicon, timestamp)
 		// Verify that the address we are trying to modify has a status that allows it to be modified
-		modifyStatus := GetAllowListStatus(stateDB, precompileAddr, modifyAddress)
+		modifyStatus := GetAllowListStatus(stateDB, precompileAddr, modifyAddress, isHelicon, timestamp)
 		if !callerStatus.CanModify(modifyStatus, role) {
 			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, callerAddr, modifyStatus, role)
 		}
+		if evm.GetRules().IsHeliconActivated() {
+			if remainingGas, err = contract.DeductGas(remainingGas, UpdateIndexGasCost); err != nil {
+				return nil, 0, err
+			}
+		}
 		if evm.GetRules().IsDurangoActivated() {
 			if remainingGas, err = contract.DeductGas(remainingGas, AllowListEventGasCost); err != nil {
 				return nil, 0, err
@@ -134,6 +187,90 @@ func createAllowListRoleSetter(precompileAddr common.Address, role Role) contrac
 	}
 }
 
+// PackModifyAllowListWithExpiry packs [address] and [expiry] into the input data to the
+// function granting [role] until [expiry].
+func PackModifyAllowListWithExpiry(address common.Address, role Role, expiry uint64) ([]byte, error) {
+	funcName, err := role.GetSetterUntilFunctionName()
+	if err != nil {
+		return nil, err
+	}
+	return AllowListABI.Pack(funcName, address, new(big.Int).SetUint64(expiry))
+}
+
+// UnpackModifyAllowListWithExpiryInput attempts to unpack [input] into the address and the
+// expiry of the function granting [r] until a timestamp.
+// Returns [ErrInvalidExpiry] if the expiry does not fit in a uint64.
+func UnpackModifyAllowListWithExpiryInput(input []byte, r Role) (common.Address, uint64, error) {
+	funcName, err := r.GetSetterUntilFunctionName()
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+	inputStruct := struct {
+		Addr   common.Address
+		Expiry *big.Int
+	}{}
+	if err := AllowListABI.UnpackInputIntoInterface(&inputStruct, funcName, input, false); err != nil {
+		return common.Address{}, 0, err
+	}
+	if !inputStruct.Expiry.IsUint64() {
+		return common.Address{}, 0, fmt.Errorf("%w: %s", ErrInvalidExpiry, inputStruct.Expiry)
+	}
+	return inputStruct.Addr, inputStruct.Expiry.Uint64(), nil
+}
+
+// createAllowListRoleSetterWithExpiry returns an execution function for setting the allow list status
+// of the input address argument to [role] until the input expiry argument.
+// This execution function is specific to [precompileAddr].
+func createAllowListRoleSetterWithExpiry(precompileAddr common.Address, role Role) contract.RunStatefulPrecompileFunc {
+	//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ModifyAllowListGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		modifyAddress, expiry, err := UnpackModifyAllowListWithExpiryInput(input, role)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		if readOnly {
+			return nil, remainingGas, vm.ErrWriteProtection
+		}
+
+		timestamp := evm.GetBlockContext().Timestamp()
+		if expiry <= timestamp {
+			return nil, remainingGas, fmt.Errorf("%w: %d is not after block timestamp %d", ErrInvalidExpiry, expiry, timestamp)
+		}
+
+		stateDB := evm.GetStateDB()
+
+		// Verify that the caller is an admin with permission to modify the allow list
+		callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr, true, timestamp)
+		// Verify that the address we are trying to modify has a status that allows it to be modified
+		modifyStatus := GetAllowListStatus(stateDB, precompileAddr, modifyAddress, true, timestamp)
+		if !callerStatus.CanModify(modifyStatus, role) {
+			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, callerAddr, modifyStatus, role)
+		}
+		if remainingGas, err = contract.DeductGas(remainingGas, UpdateIndexGasCost+AllowListExpiryEventGasCost); err != nil {
+			return nil, 0, err
+		}
+		topics, data, err := PackRoleSetWithExpiryEvent(role, modifyAddress, callerAddr, modifyStatus, expiry)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		stateDB.AddLog(&types.Log{
+			Address:     precompileAddr,
+			Topics:      topics,
+			Data:        data,
+			BlockNumber: evm.GetBlockContext().Number().Uint64(),
+		})
+
+		SetAllowListRoleWithExpiry(stateDB, precompileAddr, modifyAddress, role, expiry)
+
+		return []byte{}, remainingGas, nil
+	}
+}
+
 // PackReadAllowList packs [address] into the input data to the read allow list function
 func PackReadAllowList(address common.Address) ([]byte, error) {
 	return AllowListABI.Pack("readAllowList", address)
@@ -149,13 +286,37 @@ func UnpackReadAllowListInput(input []byte, useStrictMode bool) (common.Address,
 	return modifyAddress, err
 }
 
+// PackReadAllowListOutput packs [roleNumber] into the output of readAllowList before Helicon.
 func PackReadAllowListOutput(roleNumber *big.Int) ([]byte, error) {
-	return AllowListABI.PackOutput("readAllowList", roleNumber)
+	return AllowListABI.Methods["readAllowList"].Outputs[:1].Pack(roleNumber)
+}
+
+// PackReadAllowListWithExpiryOutput packs [roleNumber] and [expiry] into the output of
+// readAllowList after Helicon.
+func PackReadAllowListWithExpiryOutput(roleNumber *big.Int, expiry uint64) ([]byte, error) {
+	return AllowListABI.PackOutput("readAllowList", roleNumber, new(big.Int).SetUint64(expiry))
+}
+
+// UnpackReadAllowListWithExpiryOutput attempts to unpack [output] into the role and
+// the expiry returned by readAllowList after Helicon.
+func UnpackReadAllowListWithExpiryOutput(output []byte) (Role, uint64, error) {
+	outputStruct := struct {
+		Role   *big.Int
+		Expiry *big.Int
+	}{}
+	if err := AllowListABI.UnpackIntoInterface(&outputStruct, "readAllowList", output); err != nil {
+		return Role{}, 0, err
+	}
+	role, err := FromBig(outputStruct.Role)
+	if err != nil {
+		return Role{}, 0, err
+	}
+	return role, outputStruct.Expiry.Uint64(), nil
 }
 
 // createReadAllowList returns an execution function that reads the allow list for the given [precompileAddr].
 // The execution function parses the input into a single address and returns the 32 byte hash that specifies the
-// designated role of that address
+// designated role of that address. After Helicon, it also returns the expiry of the role.
 func createReadAllowList(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
 	//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
 	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
@@ -170,8 +331,13 @@ func createReadAllowList(precompileAddr common.Address) contract.RunStatefulPrec
 			return nil, remainingGas, err
 		}
 
-		role := GetAllowListStatus(evm.GetStateDB(), precompileAddr, readAddress)
-		packedOutput, err := PackReadAllowListOutput(role.Big())
+		role, expiry := GetAllowListStatusWithExpiry(evm.GetStateDB(), precompileAddr, readAddress, evm.GetRules().IsHeliconActivated(), evm.GetBlockContext().Timestamp())
+		var packedOutput []byte
+		if evm.GetRules().IsHeliconActivated() {
+			packedOutput, err = PackReadAllowListWithExpiryOutput(role.Big(), expiry)
+		} else {
+			packedOutput, err = PackReadAllowListOutput(role.Big())
+		}
 		if err != nil {
 			return nil, remainingGas, err
 		}
@@ -192,6 +358,9 @@ func CreateAllowListPrecompile(precompileAddr common.Address) contract.StatefulP
 
 func CreateAllowListFunctions(precompileAddr common.Address) []*contract.StatefulPrecompileFunction {
 	functions := make([]*contract.StatefulPrecompileFunction, 0, len(AllowListABI.Methods))
+	heliconActivationFunc := func(evm contract.AccessibleState) bool {
+		return evm.GetRules().IsHeliconActivated()
+	}
 
 	for name, method := range AllowListABI.Methods {
 		var fn *contract.StatefulPrecompileFunction
@@ -208,6 +377,18 @@ func CreateAllowListFunctions(precompileAddr common.Address) []*contract.Statefu
 				return evm.GetRules().IsDurangoActivated()
 			}
 			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createAllowListRoleSetter(precompileAddr, ManagerRole), durangoActivationFunc)
+		} else if adminUntilFnName, _ := AdminRole.GetSetterUntilFunctionName(); name == adminUntilFnName {
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createAllowListRoleSetterWithExpiry(precompileAddr, AdminRole), heliconActivationFunc)
+		} else if enabledUntilFnName, _ := EnabledRole.GetSetterUntilFunctionName(); name == enabledUntilFnName {
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createAllowListRoleSetterWithExpiry(precompileAddr, EnabledRole), heliconActivationFunc)
+		} else if managerUntilFnName, _ := ManagerRole.GetSetterUntilFunctionName(); name == managerUntilFnName {
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createAllowListRoleSetterWithExpiry(precompileAddr, ManagerRole), heliconActivationFunc)
+		} else if name == "getAdmins" {
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createGetAdmins(precompileAddr), heliconActivationFunc)
+		} else if name == "getEnabledCount" {
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createGetEnabledCount(precompileAddr), heliconActivationFunc)
+		} else if name == "list" {
+			fn = contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createList(precompileAddr), heliconActivationFunc)
 		} else {
 			panic("unexpected method name: " + name)
 		}
@@ -7,7 +7,11 @@ import (
 	"testing"
 
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/subnet-evm/core/extstate"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
@@ -65,3 +69,70 @@ func TestAllowListRun(t *testing.T) {
 	}
 	RunPrecompileWithAllowListTests(t, dummyModule, nil)
 }
+
+func TestAllowListIndex(t *testing.T) {
+	require := require.New(t)
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	stateDB := extstate.New(statedb)
+
+	var (
+		addr1 = common.Address{0x11}
+		addr2 = common.Address{0x22}
+		addr3 = common.Address{0x33}
+		addr4 = common.Address{0x44}
+	)
+	// Roles set before the index is enabled are only indexed if given to EnableIndex.
+	allowlist.SetAllowListRole(stateDB, dummyAddr, addr1, allowlist.EnabledRole)
+	allowlist.SetAllowListRole(stateDB, dummyAddr, addr4, allowlist.AdminRole)
+	require.Zero(allowlist.GetRoleCount(stateDB, dummyAddr, allowlist.EnabledRole))
+	allowlist.EnableIndex(stateDB, dummyAddr, []common.Address{addr1, addr2, addr1})
+	require.True(allowlist.IsIndexEnabled(stateDB, dummyAddr))
+	require.Equal([]common.Address{addr1}, allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.EnabledRole, 0, 10))
+	require.Zero(allowlist.GetRoleCount(stateDB, dummyAddr, allowlist.AdminRole))
+
+	allowlist.SetAllowListRole(stateDB, dummyAddr, addr2, allowlist.EnabledRole)
+	allowlist.SetAllowListRoleWithExpiry(stateDB, dummyAddr, addr3, allowlist.EnabledRole, 100)
+	require.Equal([]common.Address{addr1, addr2, addr3}, allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.EnabledRole, 0, 10))
+	require.Equal([]common.Address{addr2, addr3}, allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.EnabledRole, 1, 10))
+	require.Equal([]common.Address{addr2}, allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.EnabledRole, 1, 1))
+	require.Empty(allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.EnabledRole, 3, 10))
+
+	// Setting the same role again does not change the index.
+	allowlist.SetAllowListRole(stateDB, dummyAddr, addr3, allowlist.EnabledRole)
+	require.Equal(uint64(3), allowlist.GetRoleCount(stateDB, dummyAddr, allowlist.EnabledRole))
+
+	// Removing an address moves the last address into its position.
+	allowlist.SetAllowListRole(stateDB, dummyAddr, addr1, allowlist.NoRole)
+	require.Equal([]common.Address{addr3, addr2}, allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.EnabledRole, 0, 10))
+
+	allowlist.SetAllowListRole(stateDB, dummyAddr, addr3, allowlist.AdminRole)
+	require.Equal([]common.Address{addr2}, allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.EnabledRole, 0, 10))
+	require.Equal([]common.Address{addr3}, allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.AdminRole, 0, 10))
+
+	// An address not indexed before is indexed once its role is set again.
+	allowlist.SetAllowListRole(stateDB, dummyAddr, addr4, allowlist.ManagerRole)
+	require.Equal([]common.Address{addr4}, allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.ManagerRole, 0, 10))
+	require.Equal([]common.Address{addr3}, allowlist.GetRoleMembers(stateDB, dummyAddr, allowlist.AdminRole, 0, 10))
+}
+
+func TestAllowListStatusExpiryBeforeHelicon(t *testing.T) {
+	require := require.New(t)
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	stateDB := extstate.New(statedb)
+
+	addr := common.Address{0x11}
+	allowlist.SetAllowListRoleWithExpiry(stateDB, dummyAddr, addr, allowlist.EnabledRole, 100)
+
+	// Before Helicon, the expiry is not parsed and the slot is not a valid role.
+	role, expiry := allowlist.GetAllowListStatusWithExpiry(stateDB, dummyAddr, addr, false, 0)
+	require.Zero(expiry)
+	require.False(role.IsEnabled())
+	require.False(role.IsNoRole())
+
+	role, expiry = allowlist.GetAllowListStatusWithExpiry(stateDB, dummyAddr, addr, true, 0)
+	require.Equal(allowlist.EnabledRole, role)
+	require.Equal(uint64(100), expiry)
+	require.Equal(allowlist.NoRole, allowlist.GetAllowListStatus(stateDB, dummyAddr, addr, true, 100))
+}
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -25,6 +26,11 @@ var (
 	TestEnabledAddr = common.HexToAddress("0x0000000000000000000000000000000000000022")
 	TestNoRoleAddr  = common.HexToAddress("0x0000000000000000000000000000000000000033")
 	TestManagerAddr = common.HexToAddress("0x0000000000000000000000000000000000000044")
+
+	// testExpiry is a role expiry after the timestamp of the test block context.
+	testExpiry uint64 = 1 << 40
+
+	heliconRules = extras.AvalancheRules{IsDurango: true, IsHelicon: true}
 )
 
 func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.PrecompileTest {
@@ -44,7 +50,7 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0)
 				require.Equal(t, allowlist.AdminRole, res)
 				// Check logs are stored in state
 				logs := state.Logs()
@@ -65,7 +71,7 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0)
 				require.Equal(t, allowlist.EnabledRole, res)
 				// Check logs are stored in state
 				logs := state.Logs()
@@ -86,7 +92,7 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				res := allowlist.GetAllowListStatus(state, contractAddress, TestEnabledAddr)
+				res := allowlist.GetAllowListStatus(state, contractAddress, TestEnabledAddr, false, 0)
 				require.Equal(t, allowlist.NoRole, res)
 				// Check logs are stored in state
 				logs := state.Logs()
@@ -291,7 +297,7 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			SuppliedGas: allowlist.ModifyAllowListGasCost + allowlist.AllowListEventGasCost,
 			ReadOnly:    false,
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0)
 				require.Equal(t, allowlist.ManagerRole, res)
 				// Check logs are stored in state
 				logs := state.Logs()
@@ -313,7 +319,7 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			ExpectedRes: []byte{},
 			ExpectedErr: "",
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0)
 				require.Equal(t, allowlist.NoRole, res)
 				// Check logs are stored in state
 				logs := state.Logs()
@@ -335,7 +341,7 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			ExpectedRes: []byte{},
 			ExpectedErr: "",
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0)
 				require.Equal(t, allowlist.EnabledRole, res)
 
 				// Check logs are stored in state
@@ -423,7 +429,7 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0)
 				require.Equal(t, allowlist.NoRole, res)
 
 				// Check logs are stored in state
@@ -584,8 +590,8 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			SuppliedGas: 0,
 			ReadOnly:    false,
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				require.Equal(t, allowlist.AdminRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
-				require.Equal(t, allowlist.AdminRole, allowlist.GetAllowListStatus(state, contractAddress, TestEnabledAddr))
+				require.Equal(t, allowlist.AdminRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0))
+				require.Equal(t, allowlist.AdminRole, allowlist.GetAllowListStatus(state, contractAddress, TestEnabledAddr, false, 0))
 			},
 		},
 		{
@@ -599,8 +605,8 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			SuppliedGas: 0,
 			ReadOnly:    false,
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				require.Equal(t, allowlist.ManagerRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
-				require.Equal(t, allowlist.ManagerRole, allowlist.GetAllowListStatus(state, contractAddress, TestEnabledAddr))
+				require.Equal(t, allowlist.ManagerRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0))
+				require.Equal(t, allowlist.ManagerRole, allowlist.GetAllowListStatus(state, contractAddress, TestEnabledAddr, false, 0))
 			},
 		},
 		{
@@ -614,8 +620,8 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 			SuppliedGas: 0,
 			ReadOnly:    false,
 			AfterHook: func(t testing.TB, state *extstate.StateDB) {
-				require.Equal(t, allowlist.EnabledRole, allowlist.GetAllowListStatus(state, contractAddress, TestAdminAddr))
-				require.Equal(t, allowlist.EnabledRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
+				require.Equal(t, allowlist.EnabledRole, allowlist.GetAllowListStatus(state, contractAddress, TestAdminAddr, false, 0))
+				require.Equal(t, allowlist.EnabledRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0))
 			},
 		},
 		{
@@ -687,6 +693,502 @@ func AllowListTests(_ testing.TB, module modules.Module) []precompiletest.Precom
 				require.Empty(t, logs)
 			},
 		},
+		{
+			Name:       "admin_set_enabled_until",
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowListWithExpiry(TestNoRoleAddr, allowlist.EnabledRole, testExpiry)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost + allowlist.UpdateIndexGasCost + allowlist.AllowListExpiryEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			Rules:       heliconRules,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				role, expiry := allowlist.GetAllowListStatusWithExpiry(state, contractAddress, TestNoRoleAddr, true, 0)
+				require.Equal(t, allowlist.EnabledRole, role)
+				require.Equal(t, testExpiry, expiry)
+				require.Equal(t, allowlist.EnabledRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, true, testExpiry-1))
+				require.Equal(t, allowlist.NoRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, true, testExpiry))
+
+				logs := state.Logs()
+				assertSetRoleWithExpiryEvent(t, logs, allowlist.EnabledRole, TestNoRoleAddr, TestAdminAddr, allowlist.NoRole, testExpiry)
+			},
+		},
+		{
+			Name:       "admin_set_admin_until",
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowListWithExpiry(TestEnabledAddr, allowlist.AdminRole, testExpiry)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost + allowlist.UpdateIndexGasCost + allowlist.AllowListExpiryEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			Rules:       heliconRules,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				role, expiry := allowlist.GetAllowListStatusWithExpiry(state, contractAddress, TestEnabledAddr, true, 0)
+				require.Equal(t, allowlist.AdminRole, role)
+				require.Equal(t, testExpiry, expiry)
+
+				logs := state.Logs()
+				assertSetRoleWithExpiryEvent(t, logs, allowlist.AdminRole, TestEnabledAddr, TestAdminAddr, allowlist.EnabledRole, testExpiry)
+			},
+		},
+		{
+			Name:       "manager_set_enabled_until",
+			Caller:     TestManagerAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowListWithExpiry(TestNoRoleAddr, allowlist.EnabledRole, testExpiry)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost + allowlist.UpdateIndexGasCost + allowlist.AllowListExpiryEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			Rules:       heliconRules,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				role, expiry := allowlist.GetAllowListStatusWithExpiry(state, contractAddress, TestNoRoleAddr, true, 0)
+				require.Equal(t, allowlist.EnabledRole, role)
+				require.Equal(t, testExpiry, expiry)
+			},
+		},
+		{
+			Name:       "manager_set_manager_until",
+			Caller:     TestManagerAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowListWithExpiry(TestNoRoleAddr, allowlist.ManagerRole, testExpiry)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedErr: allowlist.ErrCannotModifyAllowList.Error(),
+		},
+		{
+			Name:       "no_role_set_enabled_until",
+			Caller:     TestNoRoleAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowListWithExpiry(TestNoRoleAddr, allowlist.EnabledRole, testExpiry)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedErr: allowlist.ErrCannotModifyAllowList.Error(),
+		},
+		{
+			Name:       "set_enabled_until_past_expiry",
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowListWithExpiry(TestNoRoleAddr, allowlist.EnabledRole, 1)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedErr: allowlist.ErrInvalidExpiry.Error(),
+		},
+		{
+			Name:       "set_enabled_until_readOnly",
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowListWithExpiry(TestNoRoleAddr, allowlist.EnabledRole, testExpiry)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ReadOnly:    true,
+			Rules:       heliconRules,
+			ExpectedErr: vm.ErrWriteProtection.Error(),
+		},
+		{
+			Name:       "set_enabled_until_insufficient_gas",
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowListWithExpiry(TestNoRoleAddr, allowlist.EnabledRole, testExpiry)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost + allowlist.UpdateIndexGasCost + allowlist.AllowListExpiryEventGasCost - 1,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedErr: vm.ErrOutOfGas.Error(),
+		},
+		{
+			Name:       "set_enabled_until_pre_Helicon",
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowListWithExpiry(TestNoRoleAddr, allowlist.EnabledRole, testExpiry)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		{
+			Name:   "set_enabled_removes_expiry",
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				SetDefaultRoles(contractAddress)(t, state)
+				allowlist.SetAllowListRoleWithExpiry(state, contractAddress, TestNoRoleAddr, allowlist.EnabledRole, testExpiry)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowList(TestNoRoleAddr, allowlist.EnabledRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost + allowlist.UpdateIndexGasCost + allowlist.AllowListEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			Rules:       heliconRules,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				role, expiry := allowlist.GetAllowListStatusWithExpiry(state, contractAddress, TestNoRoleAddr, true, testExpiry)
+				require.Equal(t, allowlist.EnabledRole, role)
+				require.Zero(t, expiry)
+			},
+		},
+		{
+			Name:   "expired_admin_cannot_modify",
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				SetDefaultRoles(contractAddress)(t, state)
+				allowlist.SetAllowListRoleWithExpiry(state, contractAddress, TestNoRoleAddr, allowlist.AdminRole, 1)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowList(TestEnabledAddr, allowlist.AdminRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedErr: allowlist.ErrCannotModifyAllowList.Error(),
+		},
+		{
+			Name:   "read_allow_list_with_expiry",
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				SetDefaultRoles(contractAddress)(t, state)
+				allowlist.SetAllowListRoleWithExpiry(state, contractAddress, TestNoRoleAddr, allowlist.EnabledRole, testExpiry)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackReadAllowList(TestNoRoleAddr)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := allowlist.PackReadAllowListWithExpiryOutput(allowlist.EnabledRole.Big(), testExpiry)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			Rules: heliconRules,
+		},
+		{
+			Name:   "read_allow_list_expired_role",
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				SetDefaultRoles(contractAddress)(t, state)
+				allowlist.SetAllowListRoleWithExpiry(state, contractAddress, TestNoRoleAddr, allowlist.EnabledRole, 1)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackReadAllowList(TestNoRoleAddr)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := allowlist.PackReadAllowListWithExpiryOutput(allowlist.NoRole.Big(), 0)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			Rules: heliconRules,
+		},
+		{
+			Name:       "set_admin_updates_index",
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultIndexedRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowList(TestEnabledAddr, allowlist.AdminRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost + allowlist.UpdateIndexGasCost + allowlist.AllowListEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			Rules:       heliconRules,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.Equal(t, []common.Address{TestAdminAddr, TestEnabledAddr}, allowlist.GetRoleMembers(state, contractAddress, allowlist.AdminRole, 0, 10))
+				require.Zero(t, allowlist.GetRoleCount(state, contractAddress, allowlist.EnabledRole))
+			},
+		},
+		{
+			Name:       "get_admins",
+			Caller:     TestNoRoleAddr,
+			BeforeHook: SetDefaultIndexedRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackGetAdmins()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadIndexGasCost*2 + allowlist.ReadAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := allowlist.PackGetAdminsOutput([]common.Address{TestAdminAddr})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			Rules: heliconRules,
+		},
+		{
+			Name:       "get_admins_insufficient_gas",
+			Caller:     TestNoRoleAddr,
+			BeforeHook: SetDefaultIndexedRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackGetAdmins()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadIndexGasCost*2 + allowlist.ReadAllowListGasCost - 1,
+			ReadOnly:    true,
+			Rules:       heliconRules,
+			ExpectedErr: vm.ErrOutOfGas.Error(),
+		},
+		{
+			Name:       "get_admins_pre_Helicon",
+			Caller:     TestNoRoleAddr,
+			BeforeHook: SetDefaultIndexedRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackGetAdmins()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 0,
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		{
+			Name:       "get_enabled_count",
+			Caller:     TestNoRoleAddr,
+			BeforeHook: SetDefaultIndexedRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackGetEnabledCount()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadIndexGasCost*2 + allowlist.ReadAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := allowlist.PackGetEnabledCountOutput(1)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			Rules: heliconRules,
+		},
+		{
+			Name:       "list_managers",
+			Caller:     TestNoRoleAddr,
+			BeforeHook: SetDefaultIndexedRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackList(allowlist.ManagerRole, 0, 10)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadIndexGasCost*2 + allowlist.ReadAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := allowlist.PackListOutput([]common.Address{TestManagerAddr})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			Rules: heliconRules,
+		},
+		{
+			Name:   "get_admins_omits_expired_roles",
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				SetDefaultIndexedRoles(contractAddress)(t, state)
+				allowlist.SetAllowListRoleWithExpiry(state, contractAddress, TestEnabledAddr, allowlist.AdminRole, 1)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackGetAdmins()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadIndexGasCost*3 + allowlist.ReadAllowListGasCost*2,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := allowlist.PackGetAdminsOutput([]common.Address{TestAdminAddr})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			Rules: heliconRules,
+		},
+		{
+			Name:   "get_enabled_count_omits_expired_roles",
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				SetDefaultIndexedRoles(contractAddress)(t, state)
+				allowlist.SetAllowListRoleWithExpiry(state, contractAddress, TestNoRoleAddr, allowlist.EnabledRole, 1)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackGetEnabledCount()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadIndexGasCost*3 + allowlist.ReadAllowListGasCost*2,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := allowlist.PackGetEnabledCountOutput(1)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			Rules: heliconRules,
+		},
+		{
+			Name:   "list_omits_expired_roles",
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				SetDefaultIndexedRoles(contractAddress)(t, state)
+				allowlist.SetAllowListRoleWithExpiry(state, contractAddress, TestNoRoleAddr, allowlist.ManagerRole, 1)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackList(allowlist.ManagerRole, 0, 10)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadIndexGasCost*3 + allowlist.ReadAllowListGasCost*2,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := allowlist.PackListOutput([]common.Address{TestManagerAddr})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			Rules: heliconRules,
+		},
+		{
+			Name:   "set_same_role_indexes_unindexed_address",
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				// Roles given before the index is enabled are not indexed.
+				SetDefaultRoles(contractAddress)(t, state)
+				allowlist.EnableIndex(state, contractAddress, nil)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackModifyAllowList(TestEnabledAddr, allowlist.EnabledRole)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ModifyAllowListGasCost + allowlist.UpdateIndexGasCost + allowlist.AllowListEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			Rules:       heliconRules,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.Equal(t, []common.Address{TestEnabledAddr}, allowlist.GetRoleMembers(state, contractAddress, allowlist.EnabledRole, 0, 10))
+				require.Zero(t, allowlist.GetRoleCount(state, contractAddress, allowlist.AdminRole))
+			},
+		},
+		{
+			Name:       "list_offset_past_end",
+			Caller:     TestNoRoleAddr,
+			BeforeHook: SetDefaultIndexedRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackList(allowlist.EnabledRole, 1, 10)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadIndexGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := allowlist.PackListOutput([]common.Address{})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			Rules: heliconRules,
+		},
+		{
+			Name:       "list_no_role",
+			Caller:     TestNoRoleAddr,
+			BeforeHook: SetDefaultIndexedRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := allowlist.PackList(allowlist.NoRole, 0, 10)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: allowlist.ReadIndexGasCost,
+			ReadOnly:    true,
+			Rules:       heliconRules,
+			ExpectedErr: allowlist.ErrInvalidRole.Error(),
+		},
+		{
+			Name: "initial_config_indexes_roles_after_Helicon",
+			Config: mkConfigWithAllowList(
+				module,
+				&allowlist.AllowListConfig{
+					AdminAddresses:   []common.Address{TestAdminAddr},
+					EnabledAddresses: []common.Address{TestNoRoleAddr, TestEnabledAddr},
+				},
+			),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.True(t, allowlist.IsIndexEnabled(state, contractAddress))
+				require.Equal(t, []common.Address{TestAdminAddr}, allowlist.GetRoleMembers(state, contractAddress, allowlist.AdminRole, 0, 10))
+				require.Equal(t, []common.Address{TestNoRoleAddr, TestEnabledAddr}, allowlist.GetRoleMembers(state, contractAddress, allowlist.EnabledRole, 0, 10))
+			},
+		},
+		{
+			Name: "initial_config_not_indexed_pre_Helicon",
+			Config: mkConfigWithAllowList(
+				module,
+				&allowlist.AllowListConfig{
+					AdminAddresses: []common.Address{TestAdminAddr},
+				},
+			),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.False(t, allowlist.IsIndexEnabled(state, contractAddress))
+				require.Zero(t, allowlist.GetRoleCount(state, contractAddress, allowlist.AdminRole))
+			},
+		},
 	}
 }
 
@@ -697,10 +1199,19 @@ func SetDefaultRoles(contractAddress common.Address) func(t testing.TB, state *e
 		allowlist.SetAllowListRole(state, contractAddress, TestAdminAddr, allowlist.AdminRole)
 		allowlist.SetAllowListRole(state, contractAddress, TestManagerAddr, allowlist.ManagerRole)
 		allowlist.SetAllowListRole(state, contractAddress, TestEnabledAddr, allowlist.EnabledRole)
-		require.Equal(t, allowlist.AdminRole, allowlist.GetAllowListStatus(state, contractAddress, TestAdminAddr))
-		require.Equal(t, allowlist.ManagerRole, allowlist.GetAllowListStatus(state, contractAddress, TestManagerAddr))
-		require.Equal(t, allowlist.EnabledRole, allowlist.GetAllowListStatus(state, contractAddress, TestEnabledAddr))
-		require.Equal(t, allowlist.NoRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
+		require.Equal(t, allowlist.AdminRole, allowlist.GetAllowListStatus(state, contractAddress, TestAdminAddr, false, 0))
+		require.Equal(t, allowlist.ManagerRole, allowlist.GetAllowListStatus(state, contractAddress, TestManagerAddr, false, 0))
+		require.Equal(t, allowlist.EnabledRole, allowlist.GetAllowListStatus(state, contractAddress, TestEnabledAddr, false, 0))
+		require.Equal(t, allowlist.NoRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr, false, 0))
+	}
+}
+
+// SetDefaultIndexedRoles returns a BeforeHook that enables the index of the allow list and
+// sets the default roles of [SetDefaultRoles].
+func SetDefaultIndexedRoles(contractAddress common.Address) func(t testing.TB, state *extstate.StateDB) {
+	return func(t testing.TB, state *extstate.StateDB) {
+		allowlist.EnableIndex(state, contractAddress, nil)
+		SetDefaultRoles(contractAddress)(t, state)
 	}
 }
 
@@ -730,3 +1241,17 @@ func assertSetRoleEvent(t testing.TB, logs []*ethtypes.Log, role allowlist.Role,
 	)
 	require.Equal(t, oldRole.Bytes(), log.Data)
 }
+
+func assertSetRoleWithExpiryEvent(t testing.TB, logs []*ethtypes.Log, role allowlist.Role, addr common.Address, caller common.Address, oldRole allowlist.Role, expiry uint64) {
+	require.Len(t, logs, 1)
+	log := logs[0]
+	topics, data, err := allowlist.PackRoleSetWithExpiryEvent(role, addr, caller, oldRole, expiry)
+	require.NoError(t, err)
+	require.Equal(t, topics, log.Topics)
+	require.Equal(t, data, log.Data)
+
+	gotOldRole, gotExpiry, err := allowlist.UnpackRoleSetWithExpiryEventData(log.Data)
+	require.NoError(t, err)
+	require.Equal(t, oldRole, gotOldRole)
+	require.Equal(t, expiry, gotExpiry)
+}
@@ -23,9 +23,19 @@ type AllowListConfig struct {
 	EnabledAddresses []common.Address `json:"enabledAddresses,omitempty"` // initial enabled addresses
 }
 
+// ConfigWithAllowList is implemented by the configs of precompiles built on the allow list,
+// through their embedded [AllowListConfig].
+type ConfigWithAllowList interface {
+	precompileconfig.Config
+	AllowListAddresses() []common.Address
+}
+
 // Configure initializes the address space of [precompileAddr] by initializing the role of each of
-// the addresses in [AllowListAdmins].
-func (c *AllowListConfig) Configure(_ precompileconfig.ChainConfig, precompileAddr common.Address, state contract.StateDB, _ contract.ConfigurationBlockContext) error {
+// the addresses in [AllowListAdmins]. After Helicon, it also enables the index of the allow list.
+func (c *AllowListConfig) Configure(chainConfig precompileconfig.ChainConfig, precompileAddr common.Address, state contract.StateDB, blockContext contract.ConfigurationBlockContext) error {
+	if chainConfig.IsHelicon(blockContext.Timestamp()) {
+		EnableIndex(state, precompileAddr, nil)
+	}
 	for _, enabledAddr := range c.EnabledAddresses {
 		SetAllowListRole(state, precompileAddr, enabledAddr, EnabledRole)
 	}
@@ -40,6 +50,14 @@ func (c *AllowListConfig) Configure(_ precompileconfig.ChainConfig, precompileAd
 	return nil
 }
 
+// AllowListAddresses returns the addresses given a role by [c].
+func (c *AllowListConfig) AllowListAddresses() []common.Address {
+	addresses := make([]common.Address, 0, len(c.AdminAddresses)+len(c.ManagerAddresses)+len(c.EnabledAddresses))
+	addresses = append(addresses, c.AdminAddresses...)
+	addresses = append(addresses, c.ManagerAddresses...)
+	return append(addresses, c.EnabledAddresses...)
+}
+
 // Equal returns true iff [other] has the same admins in the same order in its allow list.
 func (c *AllowListConfig) Equal(other *AllowListConfig) bool {
 	if other == nil {
@@ -19,6 +19,10 @@ const (
 	// It is the base gas cost + the gas cost of the topics (signature, role, account, caller)
 	// and the gas cost of the non-indexed data (oldRole).
 	AllowListEventGasCost = contract.LogGas + contract.LogTopicGas*4 + contract.LogDataGas*common.HashLength
+
+	// AllowListExpiryEventGasCost is the gas cost of the RoleSet event including the expiry.
+	// It is [AllowListEventGasCost] + the gas cost of the additional non-indexed data (expiry).
+	AllowListExpiryEventGasCost = AllowListEventGasCost + contract.LogDataGas*common.HashLength
 )
 
 // PackRoleSetEvent packs the event into the appropriate arguments for RoleSet.
@@ -38,3 +42,28 @@ func UnpackRoleSetEventData(dataBytes []byte) (Role, error) {
 	}
 	return FromBig(eventData.OldRole)
 }
+
+// PackRoleSetWithExpiryEvent packs the event into the appropriate arguments for the RoleSet
+// overload including the expiry of the role.
+// It returns topic hashes and the encoded non-indexed data.
+func PackRoleSetWithExpiryEvent(role Role, account common.Address, caller common.Address, oldRole Role, expiry uint64) ([]common.Hash, []byte, error) {
+	return AllowListABI.PackEvent(roleSetWithExpiryEvent, role.Big(), account, caller, oldRole.Big(), new(big.Int).SetUint64(expiry))
+}
+
+// UnpackRoleSetWithExpiryEventData attempts to unpack non-indexed [dataBytes] of the RoleSet
+// overload including the expiry of the role.
+func UnpackRoleSetWithExpiryEventData(dataBytes []byte) (Role, uint64, error) {
+	eventData := struct {
+		OldRole *big.Int
+		Expiry  *big.Int
+	}{}
+	err := AllowListABI.UnpackIntoInterface(&eventData, roleSetWithExpiryEvent, dataBytes)
+	if err != nil {
+		return Role{}, 0, err
+	}
+	oldRole, err := FromBig(eventData.OldRole)
+	if err != nil {
+		return Role{}, 0, err
+	}
+	return oldRole, eventData.Expiry.Uint64(), nil
+}
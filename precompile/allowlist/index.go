@@ -0,0 +1,313 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ava-labs/libevm/accounts/abi"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/crypto"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+// After Helicon, the allow list keeps an index of the addresses holding each role,
+// so that they can be enumerated. The index of a role is an array of its members and
+// a count, and each indexed address stores its position in the array of its role.
+// The index is only maintained once it has been enabled with [EnableIndex].
+
+const (
+	// UpdateIndexGasCost is the worst case gas cost of updating the index when the role
+	// of an address changes, moving it from the array of its old role to the array of its new role.
+	UpdateIndexGasCost = contract.ReadGasCostPerSlot*5 + contract.WriteGasCostPerSlot*8
+
+	// ReadIndexGasCost is the gas cost of reading the count or one member of a role from the index.
+	ReadIndexGasCost = contract.ReadGasCostPerSlot
+)
+
+// Storage key of the flag set once the index is enabled. This cannot collide with
+// the role keys, which are left padded addresses.
+var indexEnabledKey = common.Hash{'a', 'l', 'i', 'x'}
+
+// roleCountKey returns the storage key of the number of addresses with [role].
+func roleCountKey(role Role) common.Hash {
+	return crypto.Keccak256Hash([]byte("allowListCount"), role.Bytes())
+}
+
+// roleMemberKey returns the storage key of the [i]th address with [role].
+func roleMemberKey(role Role, i uint64) common.Hash {
+	return crypto.Keccak256Hash([]byte("allowListMember"), role.Bytes(), new(big.Int).SetUint64(i).Bytes())
+}
+
+// memberPositionKey returns the storage key of the position of [address] in the array of its role.
+func memberPositionKey(address common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("allowListPosition"), address.Bytes())
+}
+
+// IsIndexEnabled returns true if the allow list of the precompile at [precompileAddr] is indexed.
+func IsIndexEnabled(state contract.StateReader, precompileAddr common.Address) bool {
+	return state.GetState(precompileAddr, indexEnabledKey) != common.Hash{}
+}
+
+// EnableIndex enables the index of the allow list of the precompile at [precompileAddr], and
+// indexes the current role of each of [addresses]. Roles set after this call are indexed as
+// they are set. Does nothing if the index is already enabled.
+func EnableIndex(stateDB contract.StateDB, precompileAddr common.Address, addresses []common.Address) {
+	if IsIndexEnabled(stateDB, precompileAddr) {
+		return
+	}
+	stateDB.SetState(precompileAddr, indexEnabledKey, common.BigToHash(common.Big1))
+	for _, address := range addresses {
+		if role := getStoredRole(stateDB, precompileAddr, address); !role.IsNoRole() && !isIndexed(stateDB, precompileAddr, address) {
+			addToIndex(stateDB, precompileAddr, role, address)
+		}
+	}
+}
+
+// GetRoleCount returns the number of addresses holding [role] in the index of the allow list of
+// the precompile at [precompileAddr]. Addresses whose role has expired are counted until their
+// role is set again.
+func GetRoleCount(state contract.StateReader, precompileAddr common.Address, role Role) uint64 {
+	return state.GetState(precompileAddr, roleCountKey(role)).Big().Uint64()
+}
+
+// GetRoleMembers returns up to [limit] addresses holding [role] in the index of the allow list of
+// the precompile at [precompileAddr], starting at [offset]. The order of the addresses changes when
+// an address is removed from [role].
+func GetRoleMembers(state contract.StateReader, precompileAddr common.Address, role Role, offset uint64, limit uint64) []common.Address {
+	count := GetRoleCount(state, precompileAddr, role)
+	if offset >= count {
+		return []common.Address{}
+	}
+	end := count
+	if limit < count-offset {
+		end = offset + limit
+	}
+	members := make([]common.Address, 0, end-offset)
+	for i := offset; i < end; i++ {
+		members = append(members, common.BytesToAddress(state.GetState(precompileAddr, roleMemberKey(role, i)).Bytes()))
+	}
+	return members
+}
+
+// getStoredRole returns the role stored for [address], ignoring its expiry.
+func getStoredRole(state contract.StateReader, precompileAddr common.Address, address common.Address) Role {
+	role, _ := GetAllowListStatusWithExpiry(state, precompileAddr, address, true, 0)
+	return role
+}
+
+func isIndexed(state contract.StateReader, precompileAddr common.Address, address common.Address) bool {
+	return state.GetState(precompileAddr, memberPositionKey(address)) != common.Hash{}
+}
+
+// updateIndex moves [address] from the array of [oldRole] to the array of [newRole]
+// if the index is enabled. An address given a role before the index was enabled is
+// indexed when its role is set again, even if it is set to the same role.
+func updateIndex(stateDB contract.StateDB, precompileAddr common.Address, address common.Address, oldRole Role, newRole Role) {
+	if !IsIndexEnabled(stateDB, precompileAddr) {
+		return
+	}
+	if oldRole == newRole && (newRole.IsNoRole() || isIndexed(stateDB, precompileAddr, address)) {
+		return
+	}
+	if !oldRole.IsNoRole() {
+		removeFromIndex(stateDB, precompileAddr, oldRole, address)
+	}
+	if !newRole.IsNoRole() {
+		addToIndex(stateDB, precompileAddr, newRole, address)
+	}
+}
+
+func addToIndex(stateDB contract.StateDB, precompileAddr common.Address, role Role, address common.Address) {
+	count := GetRoleCount(stateDB, precompileAddr, role)
+	stateDB.SetState(precompileAddr, roleMemberKey(role, count), common.BytesToHash(address.Bytes()))
+	// Positions are stored 1-indexed, so that a zero value means the address is not indexed.
+	stateDB.SetState(precompileAddr, memberPositionKey(address), common.BigToHash(new(big.Int).SetUint64(count+1)))
+	stateDB.SetState(precompileAddr, roleCountKey(role), common.BigToHash(new(big.Int).SetUint64(count+1)))
+}
+
+// removeFromIndex removes [address] from the array of [role], moving the last address of the
+// array into its position. Does nothing if [address] is not indexed, which is the case for
+// addresses given a role before the index was enabled.
+func removeFromIndex(stateDB contract.StateDB, precompileAddr common.Address, role Role, address common.Address) {
+	position := stateDB.GetState(precompileAddr, memberPositionKey(address)).Big().Uint64()
+	if position == 0 {
+		return
+	}
+	i := position - 1
+	last := GetRoleCount(stateDB, precompileAddr, role) - 1
+	if i != last {
+		lastMember := stateDB.GetState(precompileAddr, roleMemberKey(role, last))
+		stateDB.SetState(precompileAddr, roleMemberKey(role, i), lastMember)
+		stateDB.SetState(precompileAddr, memberPositionKey(common.BytesToAddress(lastMember.Bytes())), common.BigToHash(new(big.Int).SetUint64(position)))
+	}
+	stateDB.SetState(precompileAddr, roleMemberKey(role, last), common.Hash{})
+	stateDB.SetState(precompileAddr, memberPositionKey(address), common.Hash{})
+	stateDB.SetState(precompileAddr, roleCountKey(role), common.BigToHash(new(big.Int).SetUint64(last)))
+}
+
+// ListInput is the input of list.
+type ListInput struct {
+	Role   *big.Int
+	Offset *big.Int
+	Limit  *big.Int
+}
+
+// PackGetAdmins packs the input data to getAdmins.
+func PackGetAdmins() ([]byte, error) {
+	return AllowListABI.Pack("getAdmins")
+}
+
+// PackGetAdminsOutput packs [admins] into the output of getAdmins.
+func PackGetAdminsOutput(admins []common.Address) ([]byte, error) {
+	return AllowListABI.PackOutput("getAdmins", admins)
+}
+
+// UnpackGetAdminsOutput attempts to unpack [output] into the admins returned by getAdmins.
+func UnpackGetAdminsOutput(output []byte) ([]common.Address, error) {
+	res, err := AllowListABI.Unpack("getAdmins", output)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(res[0], new([]common.Address)).(*[]common.Address), nil
+}
+
+// PackGetEnabledCount packs the input data to getEnabledCount.
+func PackGetEnabledCount() ([]byte, error) {
+	return AllowListABI.Pack("getEnabledCount")
+}
+
+// PackGetEnabledCountOutput packs [count] into the output of getEnabledCount.
+func PackGetEnabledCountOutput(count uint64) ([]byte, error) {
+	return AllowListABI.PackOutput("getEnabledCount", new(big.Int).SetUint64(count))
+}
+
+// PackList packs [role], [offset] and [limit] into the input data to list.
+func PackList(role Role, offset uint64, limit uint64) ([]byte, error) {
+	return AllowListABI.Pack("list", role.Big(), new(big.Int).SetUint64(offset), new(big.Int).SetUint64(limit))
+}
+
+// UnpackListInput attempts to unpack [input] into the role, offset and limit of list.
+// Offsets and limits that do not fit in a uint64 are capped to [math.MaxUint64].
+func UnpackListInput(input []byte) (Role, uint64, uint64, error) {
+	inputStruct := ListInput{}
+	if err := AllowListABI.UnpackInputIntoInterface(&inputStruct, "list", input, false); err != nil {
+		return Role{}, 0, 0, err
+	}
+	role, err := FromBig(inputStruct.Role)
+	if err != nil || role.IsNoRole() {
+		return Role{}, 0, 0, fmt.Errorf("%w: %s", ErrInvalidRole, inputStruct.Role)
+	}
+	return role, capToUint64(inputStruct.Offset), capToUint64(inputStruct.Limit), nil
+}
+
+// PackListOutput packs [addrs] into the output of list.
+func PackListOutput(addrs []common.Address) ([]byte, error) {
+	return AllowListABI.PackOutput("list", addrs)
+}
+
+// UnpackListOutput attempts to unpack [output] into the addresses returned by list.
+func UnpackListOutput(output []byte) ([]common.Address, error) {
+	res, err := AllowListABI.Unpack("list", output)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(res[0], new([]common.Address)).(*[]common.Address), nil
+}
+
+func capToUint64(b *big.Int) uint64 {
+	if !b.IsUint64() {
+		return math.MaxUint64
+	}
+	return b.Uint64()
+}
+
+// createGetAdmins returns an execution function that returns the indexed admins of the allow list
+// of [precompileAddr] whose role has not expired.
+func createGetAdmins(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadIndexGasCost); err != nil {
+			return nil, 0, err
+		}
+		return listRoleMembers(evm, precompileAddr, AdminRole, 0, math.MaxUint64, remainingGas)
+	}
+}
+
+// createGetEnabledCount returns an execution function that returns the number of indexed addresses
+// with [EnabledRole] in the allow list of [precompileAddr] whose role has not expired.
+func createGetEnabledCount(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadIndexGasCost); err != nil {
+			return nil, 0, err
+		}
+		members, remainingGas, err := unexpiredRoleMembers(evm, precompileAddr, EnabledRole, 0, math.MaxUint64, remainingGas)
+		if err != nil {
+			return nil, 0, err
+		}
+		packedOutput, err := PackGetEnabledCountOutput(uint64(len(members)))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return packedOutput, remainingGas, nil
+	}
+}
+
+// createList returns an execution function that returns a page of the indexed addresses with the
+// input role in the allow list of [precompileAddr]. Addresses whose role has expired are omitted
+// from the page, so a page may hold fewer addresses than its limit.
+func createList(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadIndexGasCost); err != nil {
+			return nil, 0, err
+		}
+		role, offset, limit, err := UnpackListInput(input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return listRoleMembers(evm, precompileAddr, role, offset, limit, remainingGas)
+	}
+}
+
+// listRoleMembers packs the output of [unexpiredRoleMembers].
+func listRoleMembers(evm contract.AccessibleState, precompileAddr common.Address, role Role, offset uint64, limit uint64, remainingGas uint64) ([]byte, uint64, error) {
+	members, remainingGas, err := unexpiredRoleMembers(evm, precompileAddr, role, offset, limit, remainingGas)
+	if err != nil {
+		return nil, 0, err
+	}
+	packedOutput, err := PackListOutput(members)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// unexpiredRoleMembers returns the addresses among the up to [limit] indexed addresses with [role]
+// starting at [offset] whose role has not expired at the timestamp of the block, charging
+// [ReadIndexGasCost] and [ReadAllowListGasCost] for reading each indexed address and its role.
+// The caller is expected to have charged [ReadIndexGasCost] for reading the count.
+func unexpiredRoleMembers(evm contract.AccessibleState, precompileAddr common.Address, role Role, offset uint64, limit uint64, remainingGas uint64) ([]common.Address, uint64, error) {
+	stateDB := evm.GetStateDB()
+	count := GetRoleCount(stateDB, precompileAddr, role)
+	if offset < count {
+		n := min(limit, count-offset)
+		var err error
+		if remainingGas, err = contract.DeductGas(remainingGas, (ReadIndexGasCost+ReadAllowListGasCost)*n); err != nil {
+			return nil, 0, err
+		}
+	}
+	timestamp := evm.GetBlockContext().Timestamp()
+	members := []common.Address{}
+	for _, address := range GetRoleMembers(stateDB, precompileAddr, role, offset, limit) {
+		if GetAllowListStatus(stateDB, precompileAddr, address, true, timestamp) == role {
+			members = append(members, address)
+		}
+	}
+	return members, remainingGas, nil
+}
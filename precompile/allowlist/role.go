@@ -95,6 +95,20 @@ func (r Role) GetSetterFunctionName() (string, error) {
 	}
 }
 
+// GetSetterUntilFunctionName returns the name of the function granting [r] until a timestamp.
+func (r Role) GetSetterUntilFunctionName() (string, error) {
+	switch r {
+	case AdminRole:
+		return "setAdminUntil", nil
+	case ManagerRole:
+		return "setManagerUntil", nil
+	case EnabledRole:
+		return "setEnabledUntil", nil
+	default:
+		return "", ErrInvalidRole
+	}
+}
+
 // String returns a string representation of [r].
 func (r Role) String() string {
 	switch r {
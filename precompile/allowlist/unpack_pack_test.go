@@ -5,6 +5,7 @@ package allowlist
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/ava-labs/libevm/common"
@@ -20,6 +21,10 @@ var (
 	setEnabledSignature    = contract.CalculateFunctionSelector("setEnabled(address)")
 	setNoneSignature       = contract.CalculateFunctionSelector("setNone(address)")
 	readAllowListSignature = contract.CalculateFunctionSelector("readAllowList(address)")
+
+	setAdminUntilSignature   = contract.CalculateFunctionSelector("setAdminUntil(address,uint256)")
+	setManagerUntilSignature = contract.CalculateFunctionSelector("setManagerUntil(address,uint256)")
+	setEnabledUntilSignature = contract.CalculateFunctionSelector("setEnabledUntil(address,uint256)")
 )
 
 func TestFunctionSignatures(t *testing.T) {
@@ -38,6 +43,46 @@ func TestFunctionSignatures(t *testing.T) {
 
 	readAllowlistABI := AllowListABI.Methods["readAllowList"]
 	require.Equal(readAllowListSignature, readAllowlistABI.ID)
+
+	setAdminUntilABI := AllowListABI.Methods["setAdminUntil"]
+	require.Equal(setAdminUntilSignature, setAdminUntilABI.ID)
+
+	setManagerUntilABI := AllowListABI.Methods["setManagerUntil"]
+	require.Equal(setManagerUntilSignature, setManagerUntilABI.ID)
+
+	setEnabledUntilABI := AllowListABI.Methods["setEnabledUntil"]
+	require.Equal(setEnabledUntilSignature, setEnabledUntilABI.ID)
+
+	require.Equal(crypto.Keccak256Hash([]byte("RoleSet(uint256,address,address,uint256)")), AllowListABI.Events["RoleSet"].ID)
+	require.Equal(crypto.Keccak256Hash([]byte("RoleSet(uint256,address,address,uint256,uint256)")), AllowListABI.Events[roleSetWithExpiryEvent].ID)
+}
+
+func TestPackUnpackModifyAllowListWithExpiry(t *testing.T) {
+	require := require.New(t)
+	address := common.Address{1}
+	for _, role := range []Role{AdminRole, ManagerRole, EnabledRole} {
+		input, err := PackModifyAllowListWithExpiry(address, role, 100)
+		require.NoError(err)
+		gotAddress, gotExpiry, err := UnpackModifyAllowListWithExpiryInput(input[4:], role)
+		require.NoError(err)
+		require.Equal(address, gotAddress)
+		require.Equal(uint64(100), gotExpiry)
+	}
+
+	_, err := PackModifyAllowListWithExpiry(address, NoRole, 100)
+	require.ErrorIs(err, ErrInvalidRole)
+
+	input, err := AllowListABI.Pack("setEnabledUntil", address, new(big.Int).Lsh(common.Big1, 64))
+	require.NoError(err)
+	_, _, err = UnpackModifyAllowListWithExpiryInput(input[4:], EnabledRole)
+	require.ErrorIs(err, ErrInvalidExpiry)
+
+	output, err := PackReadAllowListWithExpiryOutput(EnabledRole.Big(), 100)
+	require.NoError(err)
+	gotRole, gotExpiry, err := UnpackReadAllowListWithExpiryOutput(output)
+	require.NoError(err)
+	require.Equal(EnabledRole, gotRole)
+	require.Equal(uint64(100), gotExpiry)
 }
 
 func FuzzPackReadAllowlistTest(f *testing.F) {
@@ -39,6 +39,7 @@ type StateDB interface {
 
 	GetBalance(common.Address) *uint256.Int
 	AddBalance(common.Address, *uint256.Int)
+	SubBalance(common.Address, *uint256.Int)
 
 	CreateAccount(common.Address)
 	Exist(common.Address) bool
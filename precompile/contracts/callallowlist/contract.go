@@ -0,0 +1,322 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/ava-labs/libevm/crypto"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+const (
+	SetCallAllowedGasCost     uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
+	SetRoleCallAllowedGasCost uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
+	// IsCallAllowedGasCost is the cost of reading the role of the account and both permissions.
+	IsCallAllowedGasCost     uint64 = allowlist.ReadAllowListGasCost + contract.ReadGasCostPerSlot*2
+	IsRoleCallAllowedGasCost uint64 = contract.ReadGasCostPerSlot
+)
+
+var (
+	ErrCannotSetCallAllowed     = errors.New("non-admin/manager cannot call setCallAllowed")
+	ErrCannotSetRoleCallAllowed = errors.New("non-admin/manager cannot call setRoleCallAllowed")
+
+	// CallAllowListRawABI contains the raw ABI of CallAllowList contract.
+	//go:embed contract.abi
+	CallAllowListRawABI string
+
+	CallAllowListABI        = contract.ParseABI(CallAllowListRawABI)
+	CallAllowListPrecompile = createCallAllowListPrecompile()
+
+	// Value stored under the key of an allowed call.
+	allowedValue = common.Hash{31: 1}
+)
+
+// SetCallAllowedInput is the input of setCallAllowed.
+type SetCallAllowedInput struct {
+	Account     common.Address
+	Destination common.Address
+	Allowed     bool
+}
+
+// SetRoleCallAllowedInput is the input of setRoleCallAllowed.
+type SetRoleCallAllowedInput struct {
+	Role        *big.Int
+	Destination common.Address
+	Allowed     bool
+}
+
+// IsCallAllowedInput is the input of isCallAllowed.
+type IsCallAllowedInput struct {
+	Account     common.Address
+	Destination common.Address
+}
+
+// IsRoleCallAllowedInput is the input of isRoleCallAllowed.
+type IsRoleCallAllowedInput struct {
+	Role        *big.Int
+	Destination common.Address
+}
+
+// GetCallAllowListStatus returns the role of [address] for the call allow list at [timestamp].
+func GetCallAllowListStatus(stateDB contract.StateReader, address common.Address, isHelicon bool, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, isHelicon, timestamp)
+}
+
+// SetCallAllowListStatus sets the permissions of [address] to [role] for the
+// call allow list. Assumes [role] has already been verified as valid.
+func SetCallAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// accountPermissionKey returns the storage key of the permission of [account] to call [destination].
+func accountPermissionKey(account common.Address, destination common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("callAllowListAccount"), account.Bytes(), destination.Bytes())
+}
+
+// rolePermissionKey returns the storage key of the permission of [role] to call [destination].
+func rolePermissionKey(role allowlist.Role, destination common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("callAllowListRole"), role.Bytes(), destination.Bytes())
+}
+
+func storePermission(stateDB contract.StateDB, key common.Hash, allowed bool) {
+	value := common.Hash{}
+	if allowed {
+		value = allowedValue
+	}
+	stateDB.SetState(ContractAddress, key, value)
+}
+
+// IsAccountCallAllowed returns true if [account] has been allowed to call [destination].
+func IsAccountCallAllowed(stateDB contract.StateReader, account common.Address, destination common.Address) bool {
+	return stateDB.GetState(ContractAddress, accountPermissionKey(account, destination)) == allowedValue
+}
+
+// SetAccountCallAllowed sets whether [account] is allowed to call [destination].
+func SetAccountCallAllowed(stateDB contract.StateDB, account common.Address, destination common.Address, allowed bool) {
+	storePermission(stateDB, accountPermissionKey(account, destination), allowed)
+}
+
+// IsRoleCallAllowed returns true if addresses with [role] have been allowed to call [destination].
+func IsRoleCallAllowed(stateDB contract.StateReader, role allowlist.Role, destination common.Address) bool {
+	return stateDB.GetState(ContractAddress, rolePermissionKey(role, destination)) == allowedValue
+}
+
+// SetRoleCallAllowed sets whether addresses with [role] are allowed to call [destination].
+func SetRoleCallAllowed(stateDB contract.StateDB, role allowlist.Role, destination common.Address, allowed bool) {
+	storePermission(stateDB, rolePermissionKey(role, destination), allowed)
+}
+
+// IsCallAllowed returns true if [account] may send a transaction calling [destination] at [timestamp].
+// Admins may call any contract. Other addresses may call a contract if they have been allowed
+// to, or if their current role has been allowed to.
+func IsCallAllowed(stateDB contract.StateReader, account common.Address, destination common.Address, isHelicon bool, timestamp uint64) bool {
+	role := GetCallAllowListStatus(stateDB, account, isHelicon, timestamp)
+	if role.IsAdmin() {
+		return true
+	}
+	return IsAccountCallAllowed(stateDB, account, destination) || IsRoleCallAllowed(stateDB, role, destination)
+}
+
+// canSetPermissions returns true if [role] may modify the call permissions.
+func canSetPermissions(role allowlist.Role) bool {
+	return role == allowlist.AdminRole || role == allowlist.ManagerRole
+}
+
+// PackSetCallAllowed packs [account], [destination] and [allowed] into the appropriate arguments for setCallAllowed.
+func PackSetCallAllowed(account common.Address, destination common.Address, allowed bool) ([]byte, error) {
+	return CallAllowListABI.Pack("setCallAllowed", account, destination, allowed)
+}
+
+// UnpackSetCallAllowedInput attempts to unpack [input] into the arguments of setCallAllowed.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetCallAllowedInput(input []byte, useStrictMode bool) (SetCallAllowedInput, error) {
+	inputStruct := SetCallAllowedInput{}
+	err := CallAllowListABI.UnpackInputIntoInterface(&inputStruct, "setCallAllowed", input, useStrictMode)
+	return inputStruct, err
+}
+
+func setCallAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetCallAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+	useStrictMode := !accessibleState.GetRules().IsDurangoActivated()
+	inputStruct, err := UnpackSetCallAllowedInput(input, useStrictMode)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := GetCallAllowListStatus(stateDB, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
+	if !canSetPermissions(callerStatus) {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetCallAllowed, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, CallPermissionSetEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackCallPermissionSetEvent(caller, inputStruct.Account, inputStruct.Destination, inputStruct.Allowed)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+	SetAccountCallAllowed(stateDB, inputStruct.Account, inputStruct.Destination, inputStruct.Allowed)
+	return []byte{}, remainingGas, nil
+}
+
+// PackSetRoleCallAllowed packs [role], [destination] and [allowed] into the appropriate arguments for setRoleCallAllowed.
+func PackSetRoleCallAllowed(role allowlist.Role, destination common.Address, allowed bool) ([]byte, error) {
+	return CallAllowListABI.Pack("setRoleCallAllowed", role.Big(), destination, allowed)
+}
+
+// UnpackSetRoleCallAllowedInput attempts to unpack [input] into the arguments of setRoleCallAllowed.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetRoleCallAllowedInput(input []byte, useStrictMode bool) (allowlist.Role, common.Address, bool, error) {
+	inputStruct := SetRoleCallAllowedInput{}
+	if err := CallAllowListABI.UnpackInputIntoInterface(&inputStruct, "setRoleCallAllowed", input, useStrictMode); err != nil {
+		return allowlist.Role{}, common.Address{}, false, err
+	}
+	role, err := allowlist.FromBig(inputStruct.Role)
+	if err != nil {
+		return allowlist.Role{}, common.Address{}, false, fmt.Errorf("%w: %s", err, inputStruct.Role)
+	}
+	return role, inputStruct.Destination, inputStruct.Allowed, nil
+}
+
+func setRoleCallAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetRoleCallAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+	useStrictMode := !accessibleState.GetRules().IsDurangoActivated()
+	role, destination, allowed, err := UnpackSetRoleCallAllowedInput(input, useStrictMode)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := GetCallAllowListStatus(stateDB, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
+	if !canSetPermissions(callerStatus) {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetRoleCallAllowed, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, RoleCallPermissionSetEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackRoleCallPermissionSetEvent(caller, role, destination, allowed)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+	SetRoleCallAllowed(stateDB, role, destination, allowed)
+	return []byte{}, remainingGas, nil
+}
+
+// PackIsCallAllowed packs [account] and [destination] into the appropriate arguments for isCallAllowed.
+func PackIsCallAllowed(account common.Address, destination common.Address) ([]byte, error) {
+	return CallAllowListABI.Pack("isCallAllowed", account, destination)
+}
+
+// PackIsCallAllowedOutput attempts to pack given [allowed] of type bool
+// to conform the ABI outputs.
+func PackIsCallAllowedOutput(allowed bool) ([]byte, error) {
+	return CallAllowListABI.PackOutput("isCallAllowed", allowed)
+}
+
+func isCallAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsCallAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+	inputStruct := IsCallAllowedInput{}
+	if err := CallAllowListABI.UnpackInputIntoInterface(&inputStruct, "isCallAllowed", input, false); err != nil {
+		return nil, remainingGas, err
+	}
+	allowed := IsCallAllowed(accessibleState.GetStateDB(), inputStruct.Account, inputStruct.Destination, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
+	packedOutput, err := PackIsCallAllowedOutput(allowed)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackIsRoleCallAllowed packs [role] and [destination] into the appropriate arguments for isRoleCallAllowed.
+func PackIsRoleCallAllowed(role allowlist.Role, destination common.Address) ([]byte, error) {
+	return CallAllowListABI.Pack("isRoleCallAllowed", role.Big(), destination)
+}
+
+// PackIsRoleCallAllowedOutput attempts to pack given [allowed] of type bool
+// to conform the ABI outputs.
+func PackIsRoleCallAllowedOutput(allowed bool) ([]byte, error) {
+	return CallAllowListABI.PackOutput("isRoleCallAllowed", allowed)
+}
+
+func isRoleCallAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsRoleCallAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+	inputStruct := IsRoleCallAllowedInput{}
+	if err := CallAllowListABI.UnpackInputIntoInterface(&inputStruct, "isRoleCallAllowed", input, false); err != nil {
+		return nil, remainingGas, err
+	}
+	role, err := allowlist.FromBig(inputStruct.Role)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", err, inputStruct.Role)
+	}
+	packedOutput, err := PackIsRoleCallAllowedOutput(IsRoleCallAllowed(accessibleState.GetStateDB(), role, inputStruct.Destination))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createCallAllowListPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to the setters is controlled by an allow list for [ContractAddress].
+func createCallAllowListPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"isCallAllowed":      isCallAllowed,
+		"isRoleCallAllowed":  isRoleCallAllowed,
+		"setCallAllowed":     setCallAllowed,
+		"setRoleCallAllowed": setRoleCallAllowed,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := CallAllowListABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/subnet-evm/precompile/precompiletest"
+)
+
+var (
+	testDestination = common.HexToAddress("0x0123")
+	tests           = []precompiletest.PrecompileTest{
+		{
+			Name:       "admin_set_call_allowed",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(allowlisttest.TestNoRoleAddr, testDestination, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost + CallPermissionSetEventGasCost,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.True(t, IsAccountCallAllowed(state, allowlisttest.TestNoRoleAddr, testDestination))
+				require.True(t, IsCallAllowed(state, allowlisttest.TestNoRoleAddr, testDestination, false, 0))
+				require.False(t, IsCallAllowed(state, allowlisttest.TestEnabledAddr, testDestination, false, 0))
+
+				logs := state.Logs()
+				require.Len(t, logs, 1)
+				topics, data, err := PackCallPermissionSetEvent(allowlisttest.TestAdminAddr, allowlisttest.TestNoRoleAddr, testDestination, true)
+				require.NoError(t, err)
+				require.Equal(t, topics, logs[0].Topics)
+				require.Equal(t, data, logs[0].Data)
+			},
+		},
+		{
+			Name:   "manager_revoke_call_allowed",
+			Caller: allowlisttest.TestManagerAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(Module.Address)(t, state)
+				SetAccountCallAllowed(state, allowlisttest.TestNoRoleAddr, testDestination, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(allowlisttest.TestNoRoleAddr, testDestination, false)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost + CallPermissionSetEventGasCost,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.False(t, IsAccountCallAllowed(state, allowlisttest.TestNoRoleAddr, testDestination))
+			},
+		},
+		{
+			Name:       "enabled_set_call_allowed_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(allowlisttest.TestEnabledAddr, testDestination, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost,
+			ExpectedErr: ErrCannotSetCallAllowed.Error(),
+		},
+		{
+			Name:       "no_role_set_call_allowed_fails",
+			Caller:     allowlisttest.TestNoRoleAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(allowlisttest.TestNoRoleAddr, testDestination, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost,
+			ExpectedErr: ErrCannotSetCallAllowed.Error(),
+		},
+		{
+			Name:       "set_call_allowed_readOnly",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(allowlisttest.TestNoRoleAddr, testDestination, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vm.ErrWriteProtection.Error(),
+		},
+		{
+			Name:       "set_call_allowed_insufficient_gas",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(allowlisttest.TestNoRoleAddr, testDestination, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost + CallPermissionSetEventGasCost - 1,
+			ExpectedErr: vm.ErrOutOfGas.Error(),
+		},
+		{
+			Name:       "admin_set_role_call_allowed",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRoleCallAllowed(allowlist.EnabledRole, testDestination, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetRoleCallAllowedGasCost + RoleCallPermissionSetEventGasCost,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.True(t, IsRoleCallAllowed(state, allowlist.EnabledRole, testDestination))
+				require.True(t, IsCallAllowed(state, allowlisttest.TestEnabledAddr, testDestination, false, 0))
+				require.False(t, IsCallAllowed(state, allowlisttest.TestManagerAddr, testDestination, false, 0))
+				require.False(t, IsCallAllowed(state, allowlisttest.TestNoRoleAddr, testDestination, false, 0))
+
+				logs := state.Logs()
+				require.Len(t, logs, 1)
+				topics, data, err := PackRoleCallPermissionSetEvent(allowlisttest.TestAdminAddr, allowlist.EnabledRole, testDestination, true)
+				require.NoError(t, err)
+				require.Equal(t, topics, logs[0].Topics)
+				require.Equal(t, data, logs[0].Data)
+			},
+		},
+		{
+			Name:       "set_role_call_allowed_invalid_role",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := CallAllowListABI.Pack("setRoleCallAllowed", big.NewInt(7), testDestination, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetRoleCallAllowedGasCost,
+			ExpectedErr: allowlist.ErrInvalidRole.Error(),
+		},
+		{
+			Name:       "enabled_set_role_call_allowed_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRoleCallAllowed(allowlist.EnabledRole, testDestination, true)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetRoleCallAllowedGasCost,
+			ExpectedErr: ErrCannotSetRoleCallAllowed.Error(),
+		},
+		{
+			Name:       "is_call_allowed_admin",
+			Caller:     allowlisttest.TestNoRoleAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsCallAllowed(allowlisttest.TestAdminAddr, testDestination)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: IsCallAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				output, err := PackIsCallAllowedOutput(true)
+				if err != nil {
+					panic(err)
+				}
+				return output
+			}(),
+		},
+		{
+			Name:   "is_call_allowed_by_role",
+			Caller: allowlisttest.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(Module.Address)(t, state)
+				SetRoleCallAllowed(state, allowlist.NoRole, testDestination, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsCallAllowed(allowlisttest.TestNoRoleAddr, testDestination)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: IsCallAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				output, err := PackIsCallAllowedOutput(true)
+				if err != nil {
+					panic(err)
+				}
+				return output
+			}(),
+		},
+		{
+			Name:       "is_call_allowed_not_allowed",
+			Caller:     allowlisttest.TestNoRoleAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsCallAllowed(allowlisttest.TestEnabledAddr, testDestination)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: IsCallAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				output, err := PackIsCallAllowedOutput(false)
+				if err != nil {
+					panic(err)
+				}
+				return output
+			}(),
+		},
+		{
+			Name:   "is_role_call_allowed",
+			Caller: allowlisttest.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				SetRoleCallAllowed(state, allowlist.ManagerRole, testDestination, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsRoleCallAllowed(allowlist.ManagerRole, testDestination)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: IsRoleCallAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				output, err := PackIsRoleCallAllowedOutput(true)
+				if err != nil {
+					panic(err)
+				}
+				return output
+			}(),
+		},
+		{
+			Name:       "is_call_allowed_insufficient_gas",
+			Caller:     allowlisttest.TestNoRoleAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsCallAllowed(allowlisttest.TestEnabledAddr, testDestination)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: IsCallAllowedGasCost - 1,
+			ReadOnly:    true,
+			ExpectedErr: vm.ErrOutOfGas.Error(),
+		},
+	}
+)
+
+func TestCallAllowListRun(t *testing.T) {
+	allowlisttest.RunPrecompileWithAllowListTests(t, Module, tests)
+}
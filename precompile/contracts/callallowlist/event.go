@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	"github.com/ava-labs/libevm/common"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+const (
+	// CallPermissionSetEventGasCost is the gas cost of the CallPermissionSet event.
+	// It is calculated as the gas cost of the log operation + the gas cost of 4 topic hashes
+	// (signature + sender + account + destination) + the gas cost of the allowed flag.
+	CallPermissionSetEventGasCost = contract.LogGas + contract.LogTopicGas*4 + contract.LogDataGas*common.HashLength
+	// RoleCallPermissionSetEventGasCost is the gas cost of the RoleCallPermissionSet event.
+	// It is calculated as the gas cost of the log operation + the gas cost of 4 topic hashes
+	// (signature + sender + role + destination) + the gas cost of the allowed flag.
+	RoleCallPermissionSetEventGasCost = contract.LogGas + contract.LogTopicGas*4 + contract.LogDataGas*common.HashLength
+)
+
+// PackCallPermissionSetEvent packs the event into the appropriate arguments for CallPermissionSet.
+// It returns topic hashes and the encoded non-indexed data.
+func PackCallPermissionSetEvent(sender common.Address, account common.Address, destination common.Address, allowed bool) ([]common.Hash, []byte, error) {
+	return CallAllowListABI.PackEvent("CallPermissionSet", sender, account, destination, allowed)
+}
+
+// PackRoleCallPermissionSetEvent packs the event into the appropriate arguments for RoleCallPermissionSet.
+// It returns topic hashes and the encoded non-indexed data.
+func PackRoleCallPermissionSetEvent(sender common.Address, role allowlist.Role, destination common.Address, allowed bool) ([]common.Hash, []byte, error) {
+	return CallAllowListABI.PackEvent("RoleCallPermissionSet", sender, role.Big(), destination, allowed)
+}
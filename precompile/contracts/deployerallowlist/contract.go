@@ -14,9 +14,9 @@ import (
 var ContractDeployerAllowListPrecompile contract.StatefulPrecompiledContract = allowlist.CreateAllowListPrecompile(ContractAddress)
 
 // GetContractDeployerAllowListStatus returns the role of [address] for the contract deployer
-// allow list.
-func GetContractDeployerAllowListStatus(stateDB contract.StateReader, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// allow list at [timestamp].
+func GetContractDeployerAllowListStatus(stateDB contract.StateReader, address common.Address, isHelicon bool, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, isHelicon, timestamp)
 }
 
 // SetContractDeployerAllowListStatus sets the permissions of [address] to [role] for the
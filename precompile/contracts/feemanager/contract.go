@@ -41,6 +41,13 @@ const (
 	SetFeeConfigGasCost     uint64 = contract.WriteGasCostPerSlot * (numFeeConfigField + 1) // plus one for setting last changed at
 	GetFeeConfigGasCost     uint64 = contract.ReadGasCostPerSlot * numFeeConfigField
 	GetLastChangedAtGasCost uint64 = contract.ReadGasCostPerSlot
+
+	ScheduleFeeConfigGasCost        uint64 = contract.WriteGasCostPerSlot * (numFeeConfigField + 1)                           // plus one for setting the activation timestamp
+	CancelScheduledFeeConfigGasCost uint64 = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot*(numFeeConfigField+1) // read the activation timestamp, then clear the scheduled config
+	GetScheduledFeeConfigGasCost    uint64 = contract.ReadGasCostPerSlot * (numFeeConfigField + 1)                            // plus one for reading the activation timestamp
+
+	// scheduleFeeConfigInputLen is the length of the fee config fields followed by the activation timestamp.
+	scheduleFeeConfigInputLen = feeConfigInputLen + common.HashLength
 )
 
 var (
@@ -50,8 +57,14 @@ var (
 
 	feeConfigLastChangedAtKey = common.Hash{'l', 'c', 'a'}
 
-	ErrCannotChangeFee = errors.New("non-enabled cannot change fee config")
-	ErrInvalidLen      = errors.New("invalid input length for fee config Input")
+	// scheduledFeeConfigActivationKey stores the activation timestamp of the scheduled fee config.
+	// A zero value means that no fee config is scheduled.
+	scheduledFeeConfigActivationKey = common.Hash{'s', 'f', 'a'}
+
+	ErrCannotChangeFee              = errors.New("non-enabled cannot change fee config")
+	ErrInvalidLen                   = errors.New("invalid input length for fee config Input")
+	ErrActivationTimestampNotFuture = errors.New("activation timestamp must be after the current block timestamp")
+	ErrNoScheduledFeeConfig         = errors.New("no fee config is scheduled")
 
 	// IFeeManagerRawABI contains the raw ABI of FeeManager contract.
 	//go:embed contract.abi
@@ -72,9 +85,9 @@ type FeeConfigABIStruct struct {
 	BlockGasCostStep         *big.Int
 }
 
-// GetFeeManagerStatus returns the role of [address] for the fee config manager list.
-func GetFeeManagerStatus(stateDB contract.StateReader, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// GetFeeManagerStatus returns the role of [address] for the fee config manager list at [timestamp].
+func GetFeeManagerStatus(stateDB contract.StateReader, address common.Address, isHelicon bool, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, isHelicon, timestamp)
 }
 
 // SetFeeManagerStatus sets the permissions of [address] to [role] for the
@@ -83,11 +96,26 @@ func SetFeeManagerStatus(stateDB contract.StateDB, address common.Address, role
 	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
 }
 
+// feeConfigFieldKey returns the storage key of the [i]th field of the active fee config.
+func feeConfigFieldKey(i int) common.Hash {
+	return common.Hash{byte(i)}
+}
+
+// scheduledFeeConfigFieldKey returns the storage key of the [i]th field of the scheduled fee config.
+func scheduledFeeConfigFieldKey(i int) common.Hash {
+	return common.Hash{'s', 'f', 'c', byte(i)}
+}
+
 // GetStoredFeeConfig returns fee config from contract storage in given state
 func GetStoredFeeConfig(stateDB contract.StateReader) commontype.FeeConfig {
+	return readFeeConfig(stateDB, feeConfigFieldKey)
+}
+
+// readFeeConfig reads the fee config fields stored under the keys returned by [fieldKey].
+func readFeeConfig(stateDB contract.StateReader, fieldKey func(int) common.Hash) commontype.FeeConfig {
 	feeConfig := commontype.FeeConfig{}
 	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
-		val := stateDB.GetState(ContractAddress, common.Hash{byte(i)})
+		val := stateDB.GetState(ContractAddress, fieldKey(i))
 		switch i {
 		case gasLimitKey:
 			feeConfig.GasLimit = new(big.Int).Set(val.Big())
@@ -125,6 +153,18 @@ func StoreFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, bl
 		return fmt.Errorf("cannot verify fee config: %w", err)
 	}
 
+	writeFeeConfig(stateDB, feeConfig, feeConfigFieldKey)
+
+	blockNumber := blockContext.Number()
+	if blockNumber == nil {
+		return errors.New("blockNumber cannot be nil")
+	}
+	stateDB.SetState(ContractAddress, feeConfigLastChangedAtKey, common.BigToHash(blockNumber))
+	return nil
+}
+
+// writeFeeConfig writes the fields of [feeConfig] under the keys returned by [fieldKey].
+func writeFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, fieldKey func(int) common.Hash) {
 	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
 		var input common.Hash
 		switch i {
@@ -148,17 +188,67 @@ func StoreFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, bl
 			// This should never encounter an unknown fee config key
 			panic(fmt.Sprintf("unknown fee config key: %d", i))
 		}
-		stateDB.SetState(ContractAddress, common.Hash{byte(i)}, input)
+		stateDB.SetState(ContractAddress, fieldKey(i), input)
 	}
+}
 
-	blockNumber := blockContext.Number()
-	if blockNumber == nil {
-		return errors.New("blockNumber cannot be nil")
+// GetScheduledFeeConfig returns the scheduled fee config and its activation timestamp
+// from contract storage in given state. If no fee config is scheduled, it returns
+// false as the last return value.
+func GetScheduledFeeConfig(stateDB contract.StateReader) (commontype.FeeConfig, uint64, bool) {
+	activation := stateDB.GetState(ContractAddress, scheduledFeeConfigActivationKey).Big()
+	if activation.Sign() == 0 {
+		return commontype.FeeConfig{}, 0, false
 	}
-	stateDB.SetState(ContractAddress, feeConfigLastChangedAtKey, common.BigToHash(blockNumber))
+	return readFeeConfig(stateDB, scheduledFeeConfigFieldKey), activation.Uint64(), true
+}
+
+// StoreScheduledFeeConfig stores given [feeConfig] to be activated at [activationTimestamp],
+// replacing any previously scheduled fee config.
+// A validation on [feeConfig] is done before storing.
+func StoreScheduledFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, activationTimestamp uint64, blockContext contract.ConfigurationBlockContext) error {
+	if err := feeConfig.Verify(); err != nil {
+		return fmt.Errorf("cannot verify fee config: %w", err)
+	}
+	if activationTimestamp <= blockContext.Timestamp() {
+		return fmt.Errorf("%w: %d <= %d", ErrActivationTimestampNotFuture, activationTimestamp, blockContext.Timestamp())
+	}
+
+	writeFeeConfig(stateDB, feeConfig, scheduledFeeConfigFieldKey)
+	stateDB.SetState(ContractAddress, scheduledFeeConfigActivationKey, common.BigToHash(new(big.Int).SetUint64(activationTimestamp)))
 	return nil
 }
 
+// clearScheduledFeeConfig removes the scheduled fee config from contract storage.
+func clearScheduledFeeConfig(stateDB contract.StateDB) {
+	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
+		stateDB.SetState(ContractAddress, scheduledFeeConfigFieldKey(i), common.Hash{})
+	}
+	stateDB.SetState(ContractAddress, scheduledFeeConfigActivationKey, common.Hash{})
+}
+
+// ApplyScheduledFeeConfig stores the scheduled fee config as the active fee config
+// if its activation timestamp is at or before the timestamp in [blockContext].
+// The scheduled fee config is removed once applied, and the block number in
+// [blockContext] is recorded as the last changed at block.
+// Returns true if the scheduled fee config was applied.
+//
+// This is called at the start of every block while the precompile is enabled.
+// Unlike fee configs set with setFeeConfig, the applied fee config is also used
+// by the block applying it, as the fee config of a block is read from the state
+// of its parent, which holds the scheduled fee config.
+func ApplyScheduledFeeConfig(stateDB contract.StateDB, blockContext contract.ConfigurationBlockContext) (bool, error) {
+	feeConfig, activationTimestamp, ok := GetScheduledFeeConfig(stateDB)
+	if !ok || activationTimestamp > blockContext.Timestamp() {
+		return false, nil
+	}
+	clearScheduledFeeConfig(stateDB)
+	if err := StoreFeeConfig(stateDB, feeConfig, blockContext); err != nil {
+		return false, fmt.Errorf("failed to apply scheduled fee config: %w", err)
+	}
+	return true, nil
+}
+
 // PackSetFeeConfig packs [inputStruct] of type SetFeeConfigInput into the appropriate arguments for setFeeConfig.
 func PackSetFeeConfig(input commontype.FeeConfig) ([]byte, error) {
 	inputStruct := FeeConfigABIStruct{
@@ -226,7 +316,7 @@ func setFeeConfig(accessibleState contract.AccessibleState, caller common.Addres
 
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := GetFeeManagerStatus(stateDB, caller)
+	callerStatus := GetFeeManagerStatus(stateDB, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFee, caller)
 	}
@@ -378,6 +468,216 @@ func getFeeConfigLastChangedAt(accessibleState contract.AccessibleState, caller
 	return packedOutput, remainingGas, err
 }
 
+// ScheduleFeeConfigInput is the input of scheduleFeeConfig.
+type ScheduleFeeConfigInput struct {
+	FeeConfig           commontype.FeeConfig
+	ActivationTimestamp uint64
+}
+
+// scheduledFeeConfigABIStruct is the ABI struct for the scheduleFeeConfig input and getScheduledFeeConfig output.
+type scheduledFeeConfigABIStruct struct {
+	FeeConfigABIStruct
+	ActivationTimestamp *big.Int
+}
+
+// PackScheduleFeeConfig packs [input] into the appropriate arguments for scheduleFeeConfig.
+func PackScheduleFeeConfig(input ScheduleFeeConfigInput) ([]byte, error) {
+	feeConfig := input.FeeConfig
+	return FeeManagerABI.Pack("scheduleFeeConfig",
+		feeConfig.GasLimit,
+		new(big.Int).SetUint64(feeConfig.TargetBlockRate),
+		feeConfig.MinBaseFee,
+		feeConfig.TargetGas,
+		feeConfig.BaseFeeChangeDenominator,
+		feeConfig.MinBlockGasCost,
+		feeConfig.MaxBlockGasCost,
+		feeConfig.BlockGasCostStep,
+		new(big.Int).SetUint64(input.ActivationTimestamp),
+	)
+}
+
+// UnpackScheduleFeeConfigInput attempts to unpack [input] as ScheduleFeeConfigInput
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackScheduleFeeConfigInput(input []byte) (ScheduleFeeConfigInput, error) {
+	inputStruct := scheduledFeeConfigABIStruct{}
+	if err := FeeManagerABI.UnpackInputIntoInterface(&inputStruct, "scheduleFeeConfig", input, false); err != nil {
+		return ScheduleFeeConfigInput{}, err
+	}
+	if !inputStruct.ActivationTimestamp.IsUint64() {
+		return ScheduleFeeConfigInput{}, fmt.Errorf("%w: activation timestamp %s overflows uint64", ErrActivationTimestampNotFuture, inputStruct.ActivationTimestamp)
+	}
+	return ScheduleFeeConfigInput{
+		FeeConfig:           convertToCommonConfig(changeFeeConfigEventData(inputStruct.FeeConfigABIStruct)),
+		ActivationTimestamp: inputStruct.ActivationTimestamp.Uint64(),
+	}, nil
+}
+
+// scheduleFeeConfig checks if the caller has permissions to schedule a fee config.
+// The execution function parses [input] into a fee config and an activation timestamp
+// and stores them as the scheduled fee config, replacing any previously scheduled one.
+// The scheduled fee config is applied at the start of the first block with a timestamp
+// at or after the activation timestamp.
+func scheduleFeeConfig(accessibleState contract.AccessibleState, caller common.Address, _ common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ScheduleFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+
+	scheduled, err := UnpackScheduleFeeConfigInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := GetFeeManagerStatus(stateDB, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFee, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, FeeConfigScheduledEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	blockContext := accessibleState.GetBlockContext()
+	if err := StoreScheduledFeeConfig(stateDB, scheduled.FeeConfig, scheduled.ActivationTimestamp, blockContext); err != nil {
+		return nil, remainingGas, err
+	}
+
+	topics, data, err := PackFeeConfigScheduledEvent(caller, scheduled.ActivationTimestamp, scheduled.FeeConfig)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: blockContext.Number().Uint64(),
+	})
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackCancelScheduledFeeConfig packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackCancelScheduledFeeConfig() ([]byte, error) {
+	return FeeManagerABI.Pack("cancelScheduledFeeConfig")
+}
+
+// cancelScheduledFeeConfig checks if the caller has permissions to cancel the scheduled fee config.
+// The execution function removes the scheduled fee config, and reverts if no fee config is scheduled.
+//
+//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+func cancelScheduledFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, CancelScheduledFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := GetFeeManagerStatus(stateDB, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFee, caller)
+	}
+
+	_, activationTimestamp, ok := GetScheduledFeeConfig(stateDB)
+	if !ok {
+		return nil, remainingGas, ErrNoScheduledFeeConfig
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, ScheduledFeeConfigCancelledEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	clearScheduledFeeConfig(stateDB)
+
+	topics, data, err := PackScheduledFeeConfigCancelledEvent(caller, activationTimestamp)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackGetScheduledFeeConfig packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetScheduledFeeConfig() ([]byte, error) {
+	return FeeManagerABI.Pack("getScheduledFeeConfig")
+}
+
+// PackGetScheduledFeeConfigOutput attempts to pack given [output] of type ScheduleFeeConfigInput
+// to conform the ABI outputs.
+func PackGetScheduledFeeConfigOutput(output ScheduleFeeConfigInput) ([]byte, error) {
+	feeConfig := convertFromCommonConfig(output.FeeConfig)
+	return FeeManagerABI.PackOutput("getScheduledFeeConfig",
+		feeConfig.GasLimit,
+		feeConfig.TargetBlockRate,
+		feeConfig.MinBaseFee,
+		feeConfig.TargetGas,
+		feeConfig.BaseFeeChangeDenominator,
+		feeConfig.MinBlockGasCost,
+		feeConfig.MaxBlockGasCost,
+		feeConfig.BlockGasCostStep,
+		new(big.Int).SetUint64(output.ActivationTimestamp),
+	)
+}
+
+// UnpackGetScheduledFeeConfigOutput attempts to unpack [output] as ScheduleFeeConfigInput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetScheduledFeeConfigOutput(output []byte) (ScheduleFeeConfigInput, error) {
+	if len(output) != scheduleFeeConfigInputLen {
+		return ScheduleFeeConfigInput{}, fmt.Errorf("%w: %d", ErrInvalidLen, len(output))
+	}
+	outputStruct := scheduledFeeConfigABIStruct{}
+	if err := FeeManagerABI.UnpackIntoInterface(&outputStruct, "getScheduledFeeConfig", output); err != nil {
+		return ScheduleFeeConfigInput{}, err
+	}
+	return ScheduleFeeConfigInput{
+		FeeConfig:           convertToCommonConfig(changeFeeConfigEventData(outputStruct.FeeConfigABIStruct)),
+		ActivationTimestamp: outputStruct.ActivationTimestamp.Uint64(),
+	}, nil
+}
+
+// getScheduledFeeConfig returns the scheduled fee config and its activation timestamp as an output.
+// If no fee config is scheduled, all the returned values are zero.
+//
+//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+func getScheduledFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetScheduledFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	feeConfig, activationTimestamp, _ := GetScheduledFeeConfig(accessibleState.GetStateDB())
+	output, err := PackGetScheduledFeeConfigOutput(ScheduleFeeConfigInput{
+		FeeConfig:           feeConfig,
+		ActivationTimestamp: activationTimestamp,
+	})
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	return output, remainingGas, nil
+}
+
+// heliconActivationFunc returns true if the Helicon upgrade is activated.
+// Functions added in Helicon are not available before it activates.
+func heliconActivationFunc(accessibleState contract.AccessibleState) bool {
+	return accessibleState.GetRules().IsHeliconActivated()
+}
+
 // createFeeManagerPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
 // Access to the getters/setters is controlled by an allow list for ContractAddress.
 func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
@@ -386,7 +686,12 @@ func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
 		"getFeeConfigLastChangedAt": getFeeConfigLastChangedAt,
 		"setFeeConfig":              setFeeConfig,
 	}
-	functions := make([]*contract.StatefulPrecompileFunction, 0, len(abiFunctionMap)+len(allowlist.AllowListABI.Methods))
+	heliconFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"scheduleFeeConfig":        scheduleFeeConfig,
+		"cancelScheduledFeeConfig": cancelScheduledFeeConfig,
+		"getScheduledFeeConfig":    getScheduledFeeConfig,
+	}
+	functions := make([]*contract.StatefulPrecompileFunction, 0, len(abiFunctionMap)+len(heliconFunctionMap)+len(allowlist.AllowListABI.Methods))
 	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
 
 	for name, function := range abiFunctionMap {
@@ -396,6 +701,13 @@ func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
 		}
 		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
 	}
+	for name, function := range heliconFunctionMap {
+		method, ok := FeeManagerABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, heliconActivationFunc))
+	}
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
 	if err != nil {
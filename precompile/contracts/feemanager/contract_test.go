@@ -8,12 +8,15 @@ import (
 	"testing"
 
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/vm"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -57,7 +60,11 @@ var (
 		BlockGasCostStep: new(big.Int),
 	}
 	testBlockNumber = big.NewInt(7)
-	tests           = []precompiletest.PrecompileTest{
+	// testActivationTimestamp is far enough in the future to always be after
+	// the block timestamp used by the precompile tests.
+	testActivationTimestamp = uint64(1) << 40
+	heliconRules            = extras.AvalancheRules{IsDurango: true, IsHelicon: true}
+	tests                   = []precompiletest.PrecompileTest{
 		{
 			Name:       "set_config_from_no_role_fails",
 			Caller:     allowlisttest.TestNoRoleAddr,
@@ -439,9 +446,225 @@ var (
 				require.Empty(t, logs)
 			},
 		},
+		{
+			Name:       "schedule_config_before_Helicon_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(ScheduleFeeConfigInput{FeeConfig: testFeeConfig, ActivationTimestamp: testActivationTimestamp})
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		{
+			Name:       "schedule_config_from_no_role_fails",
+			Caller:     allowlisttest.TestNoRoleAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(ScheduleFeeConfigInput{FeeConfig: testFeeConfig, ActivationTimestamp: testActivationTimestamp})
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedErr: ErrCannotChangeFee.Error(),
+		},
+		{
+			Name:       "schedule_config_from_enabled_address_succeeds_and_emits_logs",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(ScheduleFeeConfigInput{FeeConfig: testFeeConfig, ActivationTimestamp: testActivationTimestamp})
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost + FeeConfigScheduledEventGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				feeConfig, activationTimestamp, ok := GetScheduledFeeConfig(state)
+				require.True(t, ok)
+				require.Equal(t, testFeeConfig, feeConfig)
+				require.Equal(t, testActivationTimestamp, activationTimestamp)
+				// The active fee config is unchanged until the activation timestamp.
+				require.Equal(t, zeroFeeConfig, GetStoredFeeConfig(state))
+
+				logs := state.Logs()
+				require.Len(t, logs, 1)
+				require.Equal(t,
+					[]common.Hash{
+						FeeManagerABI.Events["FeeConfigScheduled"].ID,
+						common.BytesToHash(allowlisttest.TestEnabledAddr[:]),
+						common.BigToHash(new(big.Int).SetUint64(testActivationTimestamp)),
+					},
+					logs[0].Topics,
+				)
+				eventFeeConfig, err := UnpackFeeConfigScheduledEventData(logs[0].Data)
+				require.NoError(t, err)
+				require.True(t, testFeeConfig.Equal(&eventFeeConfig))
+			},
+		},
+		{
+			Name:       "schedule_config_in_the_past_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(ScheduleFeeConfigInput{FeeConfig: testFeeConfig, ActivationTimestamp: 1})
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost + FeeConfigScheduledEventGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedErr: ErrActivationTimestampNotFuture.Error(),
+		},
+		{
+			Name:       "schedule_invalid_config_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				feeConfig := testFeeConfig
+				feeConfig.MinBlockGasCost = new(big.Int).Mul(feeConfig.MaxBlockGasCost, common.Big2)
+				input, err := PackScheduleFeeConfig(ScheduleFeeConfigInput{FeeConfig: feeConfig, ActivationTimestamp: testActivationTimestamp})
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost + FeeConfigScheduledEventGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedErr: "cannot be greater than maxBlockGasCost",
+		},
+		{
+			Name:       "readOnly_scheduleFeeConfig_with_admin_role_fails",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackScheduleFeeConfig(ScheduleFeeConfigInput{FeeConfig: testFeeConfig, ActivationTimestamp: testActivationTimestamp})
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ScheduleFeeConfigGasCost,
+			ReadOnly:    true,
+			Rules:       heliconRules,
+			ExpectedErr: vm.ErrWriteProtection.Error(),
+		},
+		{
+			Name:   "get_scheduled_fee_config",
+			Caller: allowlisttest.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				blockContext := contract.NewMockBlockContext(gomock.NewController(t))
+				blockContext.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+				require.NoError(t, StoreScheduledFeeConfig(state, testFeeConfig, testActivationTimestamp, blockContext))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetScheduledFeeConfig()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: GetScheduledFeeConfigGasCost,
+			ReadOnly:    true,
+			Rules:       heliconRules,
+			ExpectedRes: func() []byte {
+				res, err := PackGetScheduledFeeConfigOutput(ScheduleFeeConfigInput{FeeConfig: testFeeConfig, ActivationTimestamp: testActivationTimestamp})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		{
+			Name:   "cancel_scheduled_config_succeeds_and_emits_logs",
+			Caller: allowlisttest.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(Module.Address)(t, state)
+				blockContext := contract.NewMockBlockContext(gomock.NewController(t))
+				blockContext.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+				require.NoError(t, StoreScheduledFeeConfig(state, testFeeConfig, testActivationTimestamp, blockContext))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackCancelScheduledFeeConfig()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: CancelScheduledFeeConfigGasCost + ScheduledFeeConfigCancelledEventGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				_, _, ok := GetScheduledFeeConfig(state)
+				require.False(t, ok)
+
+				logs := state.Logs()
+				require.Len(t, logs, 1)
+				require.Equal(t,
+					[]common.Hash{
+						FeeManagerABI.Events["ScheduledFeeConfigCancelled"].ID,
+						common.BytesToHash(allowlisttest.TestEnabledAddr[:]),
+						common.BigToHash(new(big.Int).SetUint64(testActivationTimestamp)),
+					},
+					logs[0].Topics,
+				)
+			},
+		},
+		{
+			Name:       "cancel_without_scheduled_config_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackCancelScheduledFeeConfig()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: CancelScheduledFeeConfigGasCost,
+			ReadOnly:    false,
+			Rules:       heliconRules,
+			ExpectedErr: ErrNoScheduledFeeConfig.Error(),
+		},
 	}
 )
 
+func TestApplyScheduledFeeConfig(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	stateDB := extstate.New(statedb)
+
+	scheduleContext := contract.NewMockBlockContext(ctrl)
+	scheduleContext.EXPECT().Timestamp().Return(uint64(10)).AnyTimes()
+	require.NoError(StoreScheduledFeeConfig(stateDB, testFeeConfig, 20, scheduleContext))
+
+	// Not applied before the activation timestamp.
+	beforeContext := contract.NewMockBlockContext(ctrl)
+	beforeContext.EXPECT().Timestamp().Return(uint64(19)).AnyTimes()
+	applied, err := ApplyScheduledFeeConfig(stateDB, beforeContext)
+	require.NoError(err)
+	require.False(applied)
+	require.Equal(zeroFeeConfig, GetStoredFeeConfig(stateDB))
+
+	// Applied at the first block at or after the activation timestamp.
+	activationContext := contract.NewMockBlockContext(ctrl)
+	activationContext.EXPECT().Timestamp().Return(uint64(25)).AnyTimes()
+	activationContext.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+	applied, err = ApplyScheduledFeeConfig(stateDB, activationContext)
+	require.NoError(err)
+	require.True(applied)
+	require.Equal(testFeeConfig, GetStoredFeeConfig(stateDB))
+	require.Equal(testBlockNumber, GetFeeConfigLastChangedAt(stateDB))
+	_, _, ok := GetScheduledFeeConfig(stateDB)
+	require.False(ok)
+
+	// Applying again is a no-op.
+	applied, err = ApplyScheduledFeeConfig(stateDB, activationContext)
+	require.NoError(err)
+	require.False(applied)
+}
+
 func TestFeeManager(t *testing.T) {
 	allowlisttest.RunPrecompileWithAllowListTests(t, Module, tests)
 }
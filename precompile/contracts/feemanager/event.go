@@ -20,6 +20,15 @@ import (
 // and the gas cost of the non-indexed data len(oldConfig) + len(newConfig).
 const FeeConfigChangedEventGasCost = GetFeeConfigGasCost + contract.LogGas + contract.LogTopicGas*2 + 2*(feeConfigInputLen)*contract.LogDataGas
 
+// FeeConfigScheduledEventGasCost is the gas cost of a FeeConfigScheduled event.
+// It is the base gas cost + the gas cost of the topics (signature, sender, activationTimestamp)
+// and the gas cost of the non-indexed data len(feeConfig).
+const FeeConfigScheduledEventGasCost = contract.LogGas + contract.LogTopicGas*3 + feeConfigInputLen*contract.LogDataGas
+
+// ScheduledFeeConfigCancelledEventGasCost is the gas cost of a ScheduledFeeConfigCancelled event.
+// It is the base gas cost + the gas cost of the topics (signature, sender, activationTimestamp).
+const ScheduledFeeConfigCancelledEventGasCost = contract.LogGas + contract.LogTopicGas*3
+
 // changeFeeConfigEventData represents a ChangeFeeConfig non-indexed event data raised by the contract.
 // This represents a different struct than commontype.FeeConfig, because in the contract TargetBlockRate is defined as uint256.
 // uint256 must be unpacked into *big.Int
@@ -52,6 +61,28 @@ func UnpackFeeConfigChangedEventData(dataBytes []byte) (commontype.FeeConfig, co
 	return convertToCommonConfig(eventData[0]), convertToCommonConfig(eventData[1]), err
 }
 
+// PackFeeConfigScheduledEvent packs the event into the appropriate arguments for FeeConfigScheduled.
+// It returns topic hashes and the encoded non-indexed data.
+func PackFeeConfigScheduledEvent(sender common.Address, activationTimestamp uint64, feeConfig commontype.FeeConfig) ([]common.Hash, []byte, error) {
+	return FeeManagerABI.PackEvent("FeeConfigScheduled", sender, new(big.Int).SetUint64(activationTimestamp), convertFromCommonConfig(feeConfig))
+}
+
+// UnpackFeeConfigScheduledEventData attempts to unpack non-indexed [dataBytes].
+func UnpackFeeConfigScheduledEventData(dataBytes []byte) (commontype.FeeConfig, error) {
+	eventData := make([]changeFeeConfigEventData, 1)
+	err := FeeManagerABI.UnpackIntoInterface(&eventData, "FeeConfigScheduled", dataBytes)
+	if err != nil {
+		return commontype.FeeConfig{}, err
+	}
+	return convertToCommonConfig(eventData[0]), nil
+}
+
+// PackScheduledFeeConfigCancelledEvent packs the event into the appropriate arguments for ScheduledFeeConfigCancelled.
+// It returns topic hashes and the encoded non-indexed data.
+func PackScheduledFeeConfigCancelledEvent(sender common.Address, activationTimestamp uint64) ([]common.Hash, []byte, error) {
+	return FeeManagerABI.PackEvent("ScheduledFeeConfigCancelled", sender, new(big.Int).SetUint64(activationTimestamp))
+}
+
 func convertFromCommonConfig(config commontype.FeeConfig) changeFeeConfigEventData {
 	return changeFeeConfigEventData{
 		GasLimit:                 config.GasLimit,
@@ -21,6 +21,9 @@ var (
 	setFeeConfigSignature              = contract.CalculateFunctionSelector("setFeeConfig(uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256)")
 	getFeeConfigSignature              = contract.CalculateFunctionSelector("getFeeConfig()")
 	getFeeConfigLastChangedAtSignature = contract.CalculateFunctionSelector("getFeeConfigLastChangedAt()")
+	scheduleFeeConfigSignature         = contract.CalculateFunctionSelector("scheduleFeeConfig(uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256)")
+	cancelScheduledFeeConfigSignature  = contract.CalculateFunctionSelector("cancelScheduledFeeConfig()")
+	getScheduledFeeConfigSignature     = contract.CalculateFunctionSelector("getScheduledFeeConfig()")
 )
 
 func FuzzPackGetFeeConfigOutputEqualTest(f *testing.F) {
@@ -318,6 +321,50 @@ func TestFunctionSignatures(t *testing.T) {
 
 	abiGetFeeConfigLastChangedAt := FeeManagerABI.Methods["getFeeConfigLastChangedAt"]
 	require.Equal(t, getFeeConfigLastChangedAtSignature, abiGetFeeConfigLastChangedAt.ID)
+
+	abiScheduleFeeConfig := FeeManagerABI.Methods["scheduleFeeConfig"]
+	require.Equal(t, scheduleFeeConfigSignature, abiScheduleFeeConfig.ID)
+
+	abiCancelScheduledFeeConfig := FeeManagerABI.Methods["cancelScheduledFeeConfig"]
+	require.Equal(t, cancelScheduledFeeConfigSignature, abiCancelScheduledFeeConfig.ID)
+
+	abiGetScheduledFeeConfig := FeeManagerABI.Methods["getScheduledFeeConfig"]
+	require.Equal(t, getScheduledFeeConfigSignature, abiGetScheduledFeeConfig.ID)
+}
+
+func TestPackUnpackScheduleFeeConfig(t *testing.T) {
+	input := ScheduleFeeConfigInput{FeeConfig: testFeeConfig, ActivationTimestamp: 1_700_000_000}
+
+	packed, err := PackScheduleFeeConfig(input)
+	require.NoError(t, err)
+	require.Equal(t, scheduleFeeConfigSignature, packed[:4])
+	unpacked, err := UnpackScheduleFeeConfigInput(packed[4:])
+	require.NoError(t, err)
+	require.True(t, input.FeeConfig.Equal(&unpacked.FeeConfig), "expected %v, got %v", input.FeeConfig, unpacked.FeeConfig)
+	require.Equal(t, input.ActivationTimestamp, unpacked.ActivationTimestamp)
+
+	packed, err = PackGetScheduledFeeConfigOutput(input)
+	require.NoError(t, err)
+	unpacked, err = UnpackGetScheduledFeeConfigOutput(packed)
+	require.NoError(t, err)
+	require.True(t, input.FeeConfig.Equal(&unpacked.FeeConfig), "expected %v, got %v", input.FeeConfig, unpacked.FeeConfig)
+	require.Equal(t, input.ActivationTimestamp, unpacked.ActivationTimestamp)
+
+	// An activation timestamp that does not fit in uint64 is rejected.
+	packed, err = FeeManagerABI.Pack("scheduleFeeConfig",
+		testFeeConfig.GasLimit,
+		new(big.Int).SetUint64(testFeeConfig.TargetBlockRate),
+		testFeeConfig.MinBaseFee,
+		testFeeConfig.TargetGas,
+		testFeeConfig.BaseFeeChangeDenominator,
+		testFeeConfig.MinBlockGasCost,
+		testFeeConfig.MaxBlockGasCost,
+		testFeeConfig.BlockGasCostStep,
+		math.MaxBig256,
+	)
+	require.NoError(t, err)
+	_, err = UnpackScheduleFeeConfigInput(packed[4:])
+	require.ErrorIs(t, err, ErrActivationTimestampNotFuture)
 }
 
 func testOldPackGetFeeConfigOutputEqual(t *testing.T, feeConfig commontype.FeeConfig, checkOutputs bool) {
@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feesponsor
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/evm/predicate"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/holiman/uint256"
+)
+
+// AuthorizationLen is the length of an encoded [Authorization]:
+// sponsor (20) + sender (20) + nonce (8) + expiry (8) + max fee (32) + signature (65).
+const AuthorizationLen = common.AddressLength*2 + wrappers.LongLen*2 + common.HashLength + crypto.SignatureLength
+
+var (
+	ErrInvalidAuthorizationBytes = errors.New("cannot unpack fee sponsorship authorization")
+	ErrInvalidAuthorizationLen   = errors.New("invalid fee sponsorship authorization length")
+	ErrInvalidSponsorSignature   = errors.New("fee sponsorship authorization not signed by sponsor")
+
+	// authorizationDomain separates the digest of an authorization from any
+	// other message signed by the sponsor's key.
+	authorizationDomain = []byte("feeSponsorAuthorization")
+)
+
+// Authorization is a sponsor's signed permission to pay for the gas of the
+// transaction sent by [Sender] with [Nonce]. It is carried as a predicate in
+// the access list of the transaction, under [ContractAddress].
+type Authorization struct {
+	Sponsor common.Address
+	Sender  common.Address
+	Nonce   uint64
+	// Expiry is the last block timestamp at which the authorization may be used.
+	Expiry uint64
+	// MaxFee is the maximum amount the sponsor pays for the transaction. It
+	// bounds the gas limit multiplied by the gas fee cap of the transaction.
+	MaxFee    *uint256.Int
+	Signature [crypto.SignatureLength]byte
+}
+
+// Digest returns the hash signed by the sponsor to authorize [a] on the
+// chain with [chainID].
+func (a *Authorization) Digest(chainID ids.ID) common.Hash {
+	var nonce, expiry [wrappers.LongLen]byte
+	binary.BigEndian.PutUint64(nonce[:], a.Nonce)
+	binary.BigEndian.PutUint64(expiry[:], a.Expiry)
+	maxFee := a.MaxFee.Bytes32()
+	return crypto.Keccak256Hash(authorizationDomain, chainID[:], a.Sponsor.Bytes(), a.Sender.Bytes(), nonce[:], expiry[:], maxFee[:])
+}
+
+// Sign sets the signature of [a] to the signature of [key] on the chain with [chainID].
+func (a *Authorization) Sign(chainID ids.ID, key *ecdsa.PrivateKey) error {
+	digest := a.Digest(chainID)
+	sig, err := crypto.Sign(digest[:], key)
+	if err != nil {
+		return err
+	}
+	copy(a.Signature[:], sig)
+	return nil
+}
+
+// Verify returns an error if [a] was not signed by its sponsor on the chain with [chainID].
+func (a *Authorization) Verify(chainID ids.ID) error {
+	digest := a.Digest(chainID)
+	pubKey, err := crypto.SigToPub(digest[:], a.Signature[:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSponsorSignature, err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubKey); signer != a.Sponsor {
+		return fmt.Errorf("%w: expected %s, recovered %s", ErrInvalidSponsorSignature, a.Sponsor, signer)
+	}
+	return nil
+}
+
+// Bytes returns the encoding of [a].
+func (a *Authorization) Bytes() []byte {
+	b := make([]byte, 0, AuthorizationLen)
+	b = append(b, a.Sponsor.Bytes()...)
+	b = append(b, a.Sender.Bytes()...)
+	b = binary.BigEndian.AppendUint64(b, a.Nonce)
+	b = binary.BigEndian.AppendUint64(b, a.Expiry)
+	maxFee := a.MaxFee.Bytes32()
+	b = append(b, maxFee[:]...)
+	return append(b, a.Signature[:]...)
+}
+
+// Predicate returns [a] encoded as a predicate to be included in the storage
+// keys of an access list tuple for [ContractAddress].
+func (a *Authorization) Predicate() predicate.Predicate {
+	return predicate.New(a.Bytes())
+}
+
+// ParseAuthorization parses an [Authorization] from its encoding [b].
+func ParseAuthorization(b []byte) (*Authorization, error) {
+	if len(b) != AuthorizationLen {
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrInvalidAuthorizationLen, AuthorizationLen, len(b))
+	}
+	a := &Authorization{
+		Sponsor: common.BytesToAddress(b[:common.AddressLength]),
+		Sender:  common.BytesToAddress(b[common.AddressLength : 2*common.AddressLength]),
+	}
+	b = b[2*common.AddressLength:]
+	a.Nonce = binary.BigEndian.Uint64(b[:wrappers.LongLen])
+	a.Expiry = binary.BigEndian.Uint64(b[wrappers.LongLen : 2*wrappers.LongLen])
+	b = b[2*wrappers.LongLen:]
+	a.MaxFee = new(uint256.Int).SetBytes(b[:common.HashLength])
+	copy(a.Signature[:], b[common.HashLength:])
+	return a, nil
+}
+
+// ParsePredicate parses an [Authorization] from [pred].
+func ParsePredicate(pred predicate.Predicate) (*Authorization, error) {
+	b, err := pred.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAuthorizationBytes, err)
+	}
+	return ParseAuthorization(b)
+}
+
+// AuthorizationFromAccessList returns the first fee sponsorship authorization
+// included in [accessList]. Only the first access tuple for [ContractAddress]
+// is considered, as only one sponsor can pay for a transaction.
+func AuthorizationFromAccessList(accessList types.AccessList) (*Authorization, bool) {
+	for _, tuple := range accessList {
+		if tuple.Address != ContractAddress {
+			continue
+		}
+		auth, err := ParsePredicate(predicate.Predicate(tuple.StorageKeys))
+		if err != nil {
+			return nil, false
+		}
+		return auth, true
+	}
+	return nil, false
+}
+
+// Covers returns true if [a] authorizes the sponsor to pay up to [gasCost]
+// for the transaction sent by [sender] with [nonce] in a block at [timestamp].
+// The signature of [a] is verified separately, as a predicate.
+func (a *Authorization) Covers(sender common.Address, nonce uint64, gasCost *uint256.Int, timestamp uint64) bool {
+	return a.Sender == sender && a.Nonce == nonce && timestamp <= a.Expiry && gasCost.Cmp(a.MaxFee) <= 0
+}
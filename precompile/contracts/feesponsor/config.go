@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feesponsor
+
+import (
+	"github.com/ava-labs/avalanchego/vms/evm/predicate"
+	"github.com/ava-labs/libevm/common"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+)
+
+var (
+	_ precompileconfig.Config     = (*Config)(nil)
+	_ precompileconfig.Predicater = (*Config)(nil)
+)
+
+// Config implements the StatefulPrecompileConfig interface while adding in the
+// FeeSponsor specific precompile config.
+// Enabled addresses of the allow list are the sponsors that may pay for the
+// gas of other transactions.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// FeeSponsor with the given [admins], [enableds] and [managers] as members of the allowlist.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade: precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables FeeSponsor.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+func (*Config) Key() string { return ConfigKey }
+
+// Equal returns true if [cfg] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade) && c.AllowListConfig.Equal(&other.AllowListConfig)
+}
+
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
+}
+
+// PredicateGas returns the amount of gas necessary to verify the fee
+// sponsorship authorization in [pred].
+//
+// If [pred] cannot be parsed as an authorization, return a non-nil error
+// invalidating the transaction.
+func (*Config) PredicateGas(pred predicate.Predicate, _ precompileconfig.Rules) (uint64, error) {
+	if _, err := ParsePredicate(pred); err != nil {
+		return 0, err
+	}
+	return VerifyAuthorizationGasCost, nil
+}
+
+// VerifyPredicate returns an error if the fee sponsorship authorization in
+// [pred] was not signed by its sponsor.
+func (*Config) VerifyPredicate(predicateContext *precompileconfig.PredicateContext, pred predicate.Predicate) error {
+	// Note: PredicateGas should be called before VerifyPredicate, so we should never reach an error case here.
+	auth, err := ParsePredicate(pred)
+	if err != nil {
+		return err
+	}
+	return auth.Verify(predicateContext.SnowCtx.ChainID)
+}
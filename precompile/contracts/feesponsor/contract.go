@@ -0,0 +1,238 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feesponsor
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/holiman/uint256"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+const (
+	// VerifyAuthorizationGasCost is the intrinsic gas charged for an authorization in the access list.
+	// It covers recovering the signer of the authorization and the access list address of the tuple.
+	VerifyAuthorizationGasCost uint64 = ethparams.EcrecoverGas + ethparams.TxAccessListAddressGas
+	SetSpendingLimitGasCost    uint64 = contract.WriteGasCostPerSlot*2 + allowlist.ReadAllowListGasCost // write limit and spent + read allow list
+	GetSponsorshipGasCost      uint64 = contract.ReadGasCostPerSlot * 2                                 // read limit and spent
+)
+
+var (
+	ErrCannotSetSpendingLimit = errors.New("non-admin/manager cannot call setSpendingLimit")
+
+	// FeeSponsorRawABI contains the raw ABI of FeeSponsor contract.
+	//go:embed contract.abi
+	FeeSponsorRawABI string
+
+	FeeSponsorABI        = contract.ParseABI(FeeSponsorRawABI)
+	FeeSponsorPrecompile = createFeeSponsorPrecompile()
+)
+
+// SetSpendingLimitInput is the input of setSpendingLimit.
+type SetSpendingLimitInput struct {
+	Sponsor common.Address
+	Limit   *big.Int
+}
+
+// GetSponsorshipOutput is the output of getSponsorship.
+type GetSponsorshipOutput struct {
+	Limit *big.Int
+	Spent *big.Int
+}
+
+// GetFeeSponsorStatus returns the role of [address] for the fee sponsor allow list at [timestamp].
+func GetFeeSponsorStatus(stateDB contract.StateReader, address common.Address, isHelicon bool, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, isHelicon, timestamp)
+}
+
+// SetFeeSponsorStatus sets the permissions of [address] to [role] for the
+// fee sponsor allow list. Assumes [role] has already been verified as valid.
+func SetFeeSponsorStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// spendingLimitKey returns the storage key of the spending limit of [sponsor].
+func spendingLimitKey(sponsor common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("feeSponsorLimit"), sponsor.Bytes())
+}
+
+// spentKey returns the storage key of the amount spent by [sponsor] since its limit was set.
+func spentKey(sponsor common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("feeSponsorSpent"), sponsor.Bytes())
+}
+
+// GetSpendingLimit returns the spending limit of [sponsor]. A zero limit means
+// the sponsor may spend its whole balance.
+func GetSpendingLimit(stateDB contract.StateReader, sponsor common.Address) *uint256.Int {
+	return new(uint256.Int).SetBytes(stateDB.GetState(ContractAddress, spendingLimitKey(sponsor)).Bytes())
+}
+
+// GetSpent returns the amount [sponsor] has paid for sponsored transactions
+// since its spending limit was last set.
+func GetSpent(stateDB contract.StateReader, sponsor common.Address) *uint256.Int {
+	return new(uint256.Int).SetBytes(stateDB.GetState(ContractAddress, spentKey(sponsor)).Bytes())
+}
+
+// SetSpendingLimit sets the spending limit of [sponsor] to [limit] and resets
+// the amount it has spent.
+func SetSpendingLimit(stateDB contract.StateDB, sponsor common.Address, limit *uint256.Int) {
+	stateDB.SetState(ContractAddress, spendingLimitKey(sponsor), limit.Bytes32())
+	stateDB.SetState(ContractAddress, spentKey(sponsor), common.Hash{})
+}
+
+// AddSpent records that [sponsor] paid [amount] for a sponsored transaction.
+func AddSpent(stateDB contract.StateDB, sponsor common.Address, amount *uint256.Int) {
+	spent, overflow := new(uint256.Int).AddOverflow(GetSpent(stateDB, sponsor), amount)
+	if overflow {
+		spent.SetAllOne()
+	}
+	stateDB.SetState(ContractAddress, spentKey(sponsor), spent.Bytes32())
+}
+
+// CanSponsor returns true if [sponsor] may pay [amount] for the gas of a
+// transaction at [timestamp]. The sponsor must be enabled on the allow list,
+// and [amount] must not exceed what remains of its spending limit.
+// The balance of the sponsor is checked separately.
+func CanSponsor(stateDB contract.StateReader, sponsor common.Address, amount *uint256.Int, isHelicon bool, timestamp uint64) bool {
+	if !GetFeeSponsorStatus(stateDB, sponsor, isHelicon, timestamp).IsEnabled() {
+		return false
+	}
+	limit := GetSpendingLimit(stateDB, sponsor)
+	if limit.IsZero() {
+		return true
+	}
+	spent, overflow := new(uint256.Int).AddOverflow(GetSpent(stateDB, sponsor), amount)
+	return !overflow && spent.Cmp(limit) <= 0
+}
+
+// canSetSpendingLimit returns true if [role] may modify spending limits.
+func canSetSpendingLimit(role allowlist.Role) bool {
+	return role == allowlist.AdminRole || role == allowlist.ManagerRole
+}
+
+// PackSetSpendingLimit packs [sponsor] and [limit] into the appropriate arguments for setSpendingLimit.
+func PackSetSpendingLimit(sponsor common.Address, limit *big.Int) ([]byte, error) {
+	return FeeSponsorABI.Pack("setSpendingLimit", sponsor, limit)
+}
+
+// UnpackSetSpendingLimitInput attempts to unpack [input] into the arguments of setSpendingLimit.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetSpendingLimitInput(input []byte, useStrictMode bool) (SetSpendingLimitInput, error) {
+	inputStruct := SetSpendingLimitInput{}
+	err := FeeSponsorABI.UnpackInputIntoInterface(&inputStruct, "setSpendingLimit", input, useStrictMode)
+	return inputStruct, err
+}
+
+func setSpendingLimit(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetSpendingLimitGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+	useStrictMode := !accessibleState.GetRules().IsDurangoActivated()
+	inputStruct, err := UnpackSetSpendingLimitInput(input, useStrictMode)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	// The ABI guarantees the limit fits in 256 bits.
+	limit := uint256.MustFromBig(inputStruct.Limit)
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := GetFeeSponsorStatus(stateDB, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
+	if !canSetSpendingLimit(callerStatus) {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetSpendingLimit, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, SpendingLimitSetEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackSpendingLimitSetEvent(caller, inputStruct.Sponsor, inputStruct.Limit)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+	SetSpendingLimit(stateDB, inputStruct.Sponsor, limit)
+	return []byte{}, remainingGas, nil
+}
+
+// PackGetSponsorship packs [sponsor] into the appropriate arguments for getSponsorship.
+func PackGetSponsorship(sponsor common.Address) ([]byte, error) {
+	return FeeSponsorABI.Pack("getSponsorship", sponsor)
+}
+
+// PackGetSponsorshipOutput attempts to pack given [outputStruct] of type GetSponsorshipOutput
+// to conform the ABI outputs.
+func PackGetSponsorshipOutput(outputStruct GetSponsorshipOutput) ([]byte, error) {
+	return FeeSponsorABI.PackOutput("getSponsorship", outputStruct.Limit, outputStruct.Spent)
+}
+
+// UnpackGetSponsorshipOutput attempts to unpack [output] as GetSponsorshipOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetSponsorshipOutput(output []byte) (GetSponsorshipOutput, error) {
+	outputStruct := GetSponsorshipOutput{}
+	err := FeeSponsorABI.UnpackIntoInterface(&outputStruct, "getSponsorship", output)
+	return outputStruct, err
+}
+
+func getSponsorship(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetSponsorshipGasCost); err != nil {
+		return nil, 0, err
+	}
+	var sponsor common.Address
+	if err := FeeSponsorABI.UnpackInputIntoInterface(&sponsor, "getSponsorship", input, false); err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB := accessibleState.GetStateDB()
+	packedOutput, err := PackGetSponsorshipOutput(GetSponsorshipOutput{
+		Limit: GetSpendingLimit(stateDB, sponsor).ToBig(),
+		Spent: GetSpent(stateDB, sponsor).ToBig(),
+	})
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createFeeSponsorPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to the setters is controlled by an allow list for [ContractAddress].
+func createFeeSponsorPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"getSponsorship":   getSponsorship,
+		"setSpendingLimit": setSpendingLimit,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := FeeSponsorABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feesponsor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/subnet-evm/precompile/precompiletest"
+)
+
+var tests = []precompiletest.PrecompileTest{
+	{
+		Name:   "admin_set_spending_limit",
+		Caller: allowlisttest.TestAdminAddr,
+		BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+			allowlisttest.SetDefaultRoles(Module.Address)(t, state)
+			AddSpent(state, allowlisttest.TestEnabledAddr, uint256.NewInt(5))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetSpendingLimit(allowlisttest.TestEnabledAddr, big.NewInt(100))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetSpendingLimitGasCost + SpendingLimitSetEventGasCost,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state *extstate.StateDB) {
+			require.Equal(t, uint256.NewInt(100), GetSpendingLimit(state, allowlisttest.TestEnabledAddr))
+			// Setting a limit resets the amount spent.
+			require.True(t, GetSpent(state, allowlisttest.TestEnabledAddr).IsZero())
+
+			logs := state.Logs()
+			require.Len(t, logs, 1)
+			topics, data, err := PackSpendingLimitSetEvent(allowlisttest.TestAdminAddr, allowlisttest.TestEnabledAddr, big.NewInt(100))
+			require.NoError(t, err)
+			require.Equal(t, topics, logs[0].Topics)
+			require.Equal(t, data, logs[0].Data)
+		},
+	},
+	{
+		Name:       "manager_set_spending_limit",
+		Caller:     allowlisttest.TestManagerAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetSpendingLimit(allowlisttest.TestEnabledAddr, big.NewInt(100))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetSpendingLimitGasCost + SpendingLimitSetEventGasCost,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state *extstate.StateDB) {
+			require.Equal(t, uint256.NewInt(100), GetSpendingLimit(state, allowlisttest.TestEnabledAddr))
+		},
+	},
+	{
+		Name:       "enabled_set_spending_limit_fails",
+		Caller:     allowlisttest.TestEnabledAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetSpendingLimit(allowlisttest.TestEnabledAddr, big.NewInt(100))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetSpendingLimitGasCost,
+		ExpectedErr: ErrCannotSetSpendingLimit.Error(),
+	},
+	{
+		Name:       "no_role_set_spending_limit_fails",
+		Caller:     allowlisttest.TestNoRoleAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetSpendingLimit(allowlisttest.TestEnabledAddr, big.NewInt(100))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetSpendingLimitGasCost,
+		ExpectedErr: ErrCannotSetSpendingLimit.Error(),
+	},
+	{
+		Name:       "set_spending_limit_readOnly",
+		Caller:     allowlisttest.TestAdminAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetSpendingLimit(allowlisttest.TestEnabledAddr, big.NewInt(100))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetSpendingLimitGasCost,
+		ReadOnly:    true,
+		ExpectedErr: vm.ErrWriteProtection.Error(),
+	},
+	{
+		Name:       "set_spending_limit_insufficient_gas",
+		Caller:     allowlisttest.TestAdminAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetSpendingLimit(allowlisttest.TestEnabledAddr, big.NewInt(100))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetSpendingLimitGasCost + SpendingLimitSetEventGasCost - 1,
+		ExpectedErr: vm.ErrOutOfGas.Error(),
+	},
+	{
+		Name:   "get_sponsorship",
+		Caller: allowlisttest.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+			SetSpendingLimit(state, allowlisttest.TestEnabledAddr, uint256.NewInt(100))
+			AddSpent(state, allowlisttest.TestEnabledAddr, uint256.NewInt(40))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackGetSponsorship(allowlisttest.TestEnabledAddr)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: GetSponsorshipGasCost,
+		ReadOnly:    true,
+		ExpectedRes: func() []byte {
+			output, err := PackGetSponsorshipOutput(GetSponsorshipOutput{Limit: big.NewInt(100), Spent: big.NewInt(40)})
+			if err != nil {
+				panic(err)
+			}
+			return output
+		}(),
+	},
+	{
+		Name:   "get_sponsorship_insufficient_gas",
+		Caller: allowlisttest.TestNoRoleAddr,
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackGetSponsorship(allowlisttest.TestEnabledAddr)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: GetSponsorshipGasCost - 1,
+		ReadOnly:    true,
+		ExpectedErr: vm.ErrOutOfGas.Error(),
+	},
+}
+
+func TestFeeSponsorRun(t *testing.T) {
+	allowlisttest.RunPrecompileWithAllowListTests(t, Module, tests)
+}
+
+func TestCanSponsor(t *testing.T) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	stateDB := extstate.New(statedb)
+	sponsor := allowlisttest.TestEnabledAddr
+
+	require.False(t, CanSponsor(stateDB, sponsor, uint256.NewInt(1), false, 0), "sponsors must be enabled")
+	allowlisttest.SetDefaultRoles(Module.Address)(t, stateDB)
+	require.True(t, CanSponsor(stateDB, sponsor, uint256.NewInt(1_000_000), false, 0), "sponsors without a limit are unlimited")
+
+	SetSpendingLimit(stateDB, sponsor, uint256.NewInt(100))
+	AddSpent(stateDB, sponsor, uint256.NewInt(60))
+	require.True(t, CanSponsor(stateDB, sponsor, uint256.NewInt(40), false, 0))
+	require.False(t, CanSponsor(stateDB, sponsor, uint256.NewInt(41), false, 0))
+
+	// Revoking the sponsor on the allow list stops it from sponsoring transactions.
+	SetFeeSponsorStatus(stateDB, sponsor, allowlist.NoRole)
+	require.False(t, CanSponsor(stateDB, sponsor, uint256.NewInt(1), false, 0))
+}
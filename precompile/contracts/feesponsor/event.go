@@ -0,0 +1,23 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feesponsor
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+// SpendingLimitSetEventGasCost is the gas cost of the SpendingLimitSet event.
+// It is calculated as the gas cost of the log operation + the gas cost of 3 topic hashes
+// (signature + sender + sponsor) + the gas cost of the limit.
+const SpendingLimitSetEventGasCost = contract.LogGas + contract.LogTopicGas*3 + contract.LogDataGas*common.HashLength
+
+// PackSpendingLimitSetEvent packs the event into the appropriate arguments for SpendingLimitSet.
+// It returns topic hashes and the encoded non-indexed data.
+func PackSpendingLimitSetEvent(sender common.Address, sponsor common.Address, limit *big.Int) ([]common.Hash, []byte, error) {
+	return FeeSponsorABI.PackEvent("SpendingLimitSet", sender, sponsor, limit)
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feesponsor
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/evm/predicate"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+)
+
+var testChainID = ids.GenerateTestID()
+
+func newTestAuthorization(t *testing.T, chainID ids.ID) *Authorization {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth := &Authorization{
+		Sponsor: crypto.PubkeyToAddress(key.PublicKey),
+		Sender:  common.HexToAddress("0x0123"),
+		Nonce:   7,
+		Expiry:  100,
+		MaxFee:  uint256.NewInt(1_000_000),
+	}
+	require.NoError(t, auth.Sign(chainID, key))
+	return auth
+}
+
+func TestAuthorizationRoundTrip(t *testing.T) {
+	auth := newTestAuthorization(t, testChainID)
+	b := auth.Bytes()
+	require.Len(t, b, AuthorizationLen)
+
+	parsed, err := ParseAuthorization(b)
+	require.NoError(t, err)
+	require.Equal(t, auth, parsed)
+
+	parsed, err = ParsePredicate(auth.Predicate())
+	require.NoError(t, err)
+	require.Equal(t, auth, parsed)
+
+	_, err = ParseAuthorization(b[1:])
+	require.ErrorIs(t, err, ErrInvalidAuthorizationLen)
+}
+
+func TestAuthorizationFromAccessList(t *testing.T) {
+	auth := newTestAuthorization(t, testChainID)
+	other := newTestAuthorization(t, testChainID)
+
+	_, ok := AuthorizationFromAccessList(types.AccessList{{Address: common.HexToAddress("0x01"), StorageKeys: auth.Predicate()}})
+	require.False(t, ok)
+
+	// Only the first access tuple for the precompile is considered.
+	parsed, ok := AuthorizationFromAccessList(types.AccessList{
+		{Address: common.HexToAddress("0x01")},
+		{Address: ContractAddress, StorageKeys: auth.Predicate()},
+		{Address: ContractAddress, StorageKeys: other.Predicate()},
+	})
+	require.True(t, ok)
+	require.Equal(t, auth, parsed)
+
+	_, ok = AuthorizationFromAccessList(types.AccessList{{Address: ContractAddress, StorageKeys: []common.Hash{{1}}}})
+	require.False(t, ok)
+}
+
+func TestAuthorizationCovers(t *testing.T) {
+	auth := newTestAuthorization(t, testChainID)
+	tests := []struct {
+		name      string
+		sender    common.Address
+		nonce     uint64
+		gasCost   uint64
+		timestamp uint64
+		want      bool
+	}{
+		{name: "covered", sender: auth.Sender, nonce: 7, gasCost: 1_000_000, timestamp: 100, want: true},
+		{name: "wrong sender", sender: common.HexToAddress("0x0456"), nonce: 7, gasCost: 1, timestamp: 0},
+		{name: "wrong nonce", sender: auth.Sender, nonce: 8, gasCost: 1, timestamp: 0},
+		{name: "expired", sender: auth.Sender, nonce: 7, gasCost: 1, timestamp: 101},
+		{name: "max fee exceeded", sender: auth.Sender, nonce: 7, gasCost: 1_000_001, timestamp: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, auth.Covers(test.sender, test.nonce, uint256.NewInt(test.gasCost), test.timestamp))
+		})
+	}
+}
+
+func TestPredicateGas(t *testing.T) {
+	config := NewConfig(nil, nil, nil, nil)
+	auth := newTestAuthorization(t, testChainID)
+
+	gas, err := config.PredicateGas(auth.Predicate(), nil)
+	require.NoError(t, err)
+	require.Equal(t, VerifyAuthorizationGasCost, gas)
+
+	_, err = config.PredicateGas(predicate.New(auth.Bytes()[1:]), nil)
+	require.ErrorIs(t, err, ErrInvalidAuthorizationLen)
+
+	_, err = config.PredicateGas(predicate.Predicate{{1}}, nil)
+	require.ErrorIs(t, err, ErrInvalidAuthorizationBytes)
+}
+
+func TestVerifyPredicate(t *testing.T) {
+	config := NewConfig(nil, nil, nil, nil)
+	predicateContext := &precompileconfig.PredicateContext{
+		SnowCtx: &snow.Context{ChainID: testChainID},
+	}
+
+	auth := newTestAuthorization(t, testChainID)
+	require.NoError(t, config.VerifyPredicate(predicateContext, auth.Predicate()))
+
+	// An authorization signed for another chain cannot be replayed.
+	otherChain := newTestAuthorization(t, ids.GenerateTestID())
+	require.ErrorIs(t, config.VerifyPredicate(predicateContext, otherChain.Predicate()), ErrInvalidSponsorSignature)
+
+	// Modifying the authorization invalidates the signature of the sponsor.
+	tampered := *auth
+	tampered.MaxFee = uint256.NewInt(2_000_000)
+	require.ErrorIs(t, config.VerifyPredicate(predicateContext, tampered.Predicate()), ErrInvalidSponsorSignature)
+}
@@ -4,6 +4,7 @@
 package nativeminter
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -15,7 +16,11 @@ import (
 	"github.com/ava-labs/subnet-evm/utils"
 )
 
-var _ precompileconfig.Config = (*Config)(nil)
+var (
+	_ precompileconfig.Config = (*Config)(nil)
+
+	ErrMaxSupplyBeforeHelicon = errors.New("cannot set max supply before Helicon")
+)
 
 // Config implements the precompileconfig.Config interface while adding in the
 // ContractNativeMinter specific precompile config.
@@ -23,6 +28,7 @@ type Config struct {
 	allowlist.AllowListConfig
 	precompileconfig.Upgrade
 	InitialMint map[common.Address]*math.HexOrDecimal256 `json:"initialMint,omitempty"` // addresses to receive the initial mint mapped to the amount to mint
+	MaxSupply   *math.HexOrDecimal256                    `json:"maxSupply,omitempty"`   // maximum amount the precompile can mint, including the initial mint. Requires Helicon.
 }
 
 // NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
@@ -67,6 +73,10 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 		return false
 	}
 
+	if !utils.BigNumEqual((*big.Int)(c.MaxSupply), (*big.Int)(other.MaxSupply)) {
+		return false
+	}
+
 	if len(c.InitialMint) != len(other.InitialMint) {
 		return false
 	}
@@ -88,6 +98,7 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 
 func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 	// ensure that all of the initial mint values in the map are non-nil positive values
+	totalInitialMint := new(big.Int)
 	for addr, amount := range c.InitialMint {
 		if amount == nil {
 			return fmt.Errorf("initial mint cannot contain nil amount for address %s", addr)
@@ -96,6 +107,19 @@ func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 		if bigIntAmount.Sign() < 1 {
 			return fmt.Errorf("initial mint cannot contain invalid amount %v for address %s", bigIntAmount, addr)
 		}
+		totalInitialMint.Add(totalInitialMint, bigIntAmount)
+	}
+	if c.MaxSupply != nil {
+		maxSupply := (*big.Int)(c.MaxSupply)
+		if maxSupply.Sign() < 1 || maxSupply.Cmp(math.MaxBig256) > 0 {
+			return fmt.Errorf("invalid max supply %v", maxSupply)
+		}
+		if totalInitialMint.Cmp(maxSupply) > 0 {
+			return fmt.Errorf("initial mint %v exceeds max supply %v", totalInitialMint, maxSupply)
+		}
+		if c.Timestamp() != nil && !chainConfig.IsHelicon(*c.Timestamp()) {
+			return ErrMaxSupplyBeforeHelicon
+		}
 	}
 	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
 }
@@ -58,6 +58,33 @@ func TestVerify(t *testing.T) {
 				}),
 			ExpectedError: "initial mint cannot contain invalid amount",
 		},
+		"valid max supply": {
+			Config: withMaxSupply(NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(2),
+				}), 2),
+			ChainConfig:   heliconChainConfig(t, true),
+			ExpectedError: "",
+		},
+		"non-positive max supply": {
+			Config:        withMaxSupply(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), 0),
+			ChainConfig:   heliconChainConfig(t, true),
+			ExpectedError: "invalid max supply",
+		},
+		"initial mint exceeds max supply": {
+			Config: withMaxSupply(NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(2),
+					common.HexToAddress("0x02"): math.NewHexOrDecimal256(2),
+				}), 3),
+			ChainConfig:   heliconChainConfig(t, true),
+			ExpectedError: "exceeds max supply",
+		},
+		"max supply before Helicon": {
+			Config:        withMaxSupply(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), 1),
+			ChainConfig:   heliconChainConfig(t, false),
+			ExpectedError: ErrMaxSupplyBeforeHelicon.Error(),
+		},
 	}
 	allowlisttest.VerifyPrecompileWithAllowListTests(t, Module, tests)
 }
@@ -115,6 +142,28 @@ func TestEqual(t *testing.T) {
 				}),
 			Expected: true,
 		},
+		"different max supply": {
+			Config:   withMaxSupply(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), 1),
+			Other:    withMaxSupply(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), 2),
+			Expected: false,
+		},
+		"max supply and no max supply": {
+			Config:   withMaxSupply(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), 1),
+			Other:    NewConfig(utils.NewUint64(3), admins, nil, nil, nil),
+			Expected: false,
+		},
 	}
 	allowlisttest.EqualPrecompileWithAllowListTests(t, Module, tests)
 }
+
+func withMaxSupply(config *Config, maxSupply int64) *Config {
+	config.MaxSupply = math.NewHexOrDecimal256(maxSupply)
+	return config
+}
+
+func heliconChainConfig(t *testing.T, isHelicon bool) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsHelicon(gomock.Any()).Return(isHelicon).AnyTimes()
+	return config
+}
@@ -15,6 +15,7 @@ import (
 
 	_ "embed"
 
+	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 )
@@ -44,9 +45,9 @@ var (
 	NativeMinterABI = contract.ParseABI(NativeMinterRawABI)
 )
 
-// GetContractNativeMinterStatus returns the role of [address] for the minter list.
-func GetContractNativeMinterStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// GetContractNativeMinterStatus returns the role of [address] for the minter list at [timestamp].
+func GetContractNativeMinterStatus(stateDB contract.StateDB, address common.Address, isHelicon bool, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, isHelicon, timestamp)
 }
 
 // SetContractNativeMinterStatus sets the permissions of [address] to [role] for the
@@ -79,6 +80,7 @@ func UnpackMintNativeCoinInput(input []byte, useStrictMode bool) (common.Address
 
 // mintNativeCoin checks if the caller is permissioned for minting operation.
 // The execution function parses the [input] into native coin amount and receiver address.
+// After Helicon, the mint reverts if it exceeds the quota of the caller or the supply cap.
 func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Address, _ common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
 	if remainingGas, err = contract.DeductGas(suppliedGas, MintGasCost); err != nil {
 		return nil, 0, err
@@ -97,11 +99,22 @@ func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Addr
 
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotMint, caller)
 	}
 
+	var remainingQuota, remainingSupply *big.Int
+	if rules.IsHeliconActivated() {
+		if remainingGas, err = contract.DeductGas(remainingGas, MintLimitsGasCost); err != nil {
+			return nil, 0, err
+		}
+		remainingQuota, remainingSupply, err = applyMintLimits(stateDB, caller, amount, accessibleState.GetBlockContext().Timestamp())
+		if err != nil {
+			return nil, remainingGas, err
+		}
+	}
+
 	if rules.IsDurangoActivated() {
 		if remainingGas, err = contract.DeductGas(remainingGas, NativeCoinMintedEventGasCost); err != nil {
 			return nil, 0, err
@@ -117,6 +130,21 @@ func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Addr
 			BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
 		})
 	}
+	if rules.IsHeliconActivated() {
+		if remainingGas, err = contract.DeductGas(remainingGas, MintAllowanceUpdatedEventGasCost); err != nil {
+			return nil, 0, err
+		}
+		topics, data, err := PackMintAllowanceUpdatedEvent(caller, remainingQuota, remainingSupply)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		stateDB.AddLog(&types.Log{
+			Address:     ContractAddress,
+			Topics:      topics,
+			Data:        data,
+			BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+		})
+	}
 	// if there is no address in the state, create one.
 	if !stateDB.Exist(to) {
 		stateDB.CreateAccount(to)
@@ -128,13 +156,191 @@ func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Addr
 	return []byte{}, remainingGas, nil
 }
 
+// PackSetMintQuota packs [minter], [amount] and [window] into the appropriate arguments for setMintQuota.
+func PackSetMintQuota(minter common.Address, amount *big.Int, window uint64) ([]byte, error) {
+	return NativeMinterABI.Pack("setMintQuota", minter, amount, new(big.Int).SetUint64(window))
+}
+
+// UnpackSetMintQuotaInput attempts to unpack [input] as minter and quota.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetMintQuotaInput(input []byte) (common.Address, MintQuota, error) {
+	inputStruct := SetMintQuotaInput{}
+	if err := NativeMinterABI.UnpackInputIntoInterface(&inputStruct, "setMintQuota", input, false); err != nil {
+		return common.Address{}, MintQuota{}, err
+	}
+	if !inputStruct.Window.IsUint64() {
+		return common.Address{}, MintQuota{}, fmt.Errorf("%w: window %s overflows uint64", ErrInvalidMintQuota, inputStruct.Window)
+	}
+	return inputStruct.Minter, MintQuota{Amount: inputStruct.Amount, Window: inputStruct.Window.Uint64()}, nil
+}
+
+// setMintQuota checks if the caller is an admin of the minter list.
+// The execution function parses [input] into a minter and its quota, and replaces the
+// quota of the minter, resetting the amount it has minted in the current window.
+// A zero amount and window removes the quota of the minter.
+func setMintQuota(accessibleState contract.AccessibleState, caller common.Address, _ common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetMintQuotaGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+
+	minter, quota, err := UnpackSetMintQuotaInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is an admin of the allow list and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetMintQuota, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, MintQuotaSetEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	if err := StoreMintQuota(stateDB, minter, quota); err != nil {
+		return nil, remainingGas, err
+	}
+
+	topics, data, err := PackMintQuotaSetEvent(caller, minter, quota)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackGetMintQuota packs [minter] into the appropriate arguments for getMintQuota.
+func PackGetMintQuota(minter common.Address) ([]byte, error) {
+	return NativeMinterABI.Pack("getMintQuota", minter)
+}
+
+// UnpackGetMintQuotaInput attempts to unpack [input] as the minter address.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetMintQuotaInput(input []byte) (common.Address, error) {
+	res, err := NativeMinterABI.UnpackInput("getMintQuota", input, false)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(res[0], new(common.Address)).(*common.Address), nil
+}
+
+// PackGetMintQuotaOutput attempts to pack given [output] of type GetMintQuotaOutput
+// to conform the ABI outputs.
+func PackGetMintQuotaOutput(output GetMintQuotaOutput) ([]byte, error) {
+	return NativeMinterABI.PackOutput("getMintQuota", output.Amount, output.Window, output.Remaining)
+}
+
+// UnpackGetMintQuotaOutput attempts to unpack [output] as GetMintQuotaOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetMintQuotaOutput(output []byte) (GetMintQuotaOutput, error) {
+	outputStruct := GetMintQuotaOutput{}
+	err := NativeMinterABI.UnpackIntoInterface(&outputStruct, "getMintQuota", output)
+	return outputStruct, err
+}
+
+// getMintQuota returns the quota of the given minter and the amount it can still mint
+// in the current window. If the minter has no quota, the remaining amount is the max uint256.
+//
+//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+func getMintQuota(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetMintQuotaGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	minter, err := UnpackGetMintQuotaInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	quota, remaining := GetMintQuota(accessibleState.GetStateDB(), minter, accessibleState.GetBlockContext().Timestamp())
+	output, err := PackGetMintQuotaOutput(GetMintQuotaOutput{
+		Amount:    quota.Amount,
+		Window:    new(big.Int).SetUint64(quota.Window),
+		Remaining: remaining,
+	})
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// PackGetSupplyCap packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetSupplyCap() ([]byte, error) {
+	return NativeMinterABI.Pack("getSupplyCap")
+}
+
+// PackGetSupplyCapOutput attempts to pack given [output] of type GetSupplyCapOutput
+// to conform the ABI outputs.
+func PackGetSupplyCapOutput(output GetSupplyCapOutput) ([]byte, error) {
+	return NativeMinterABI.PackOutput("getSupplyCap", output.MaxSupply, output.TotalMinted)
+}
+
+// UnpackGetSupplyCapOutput attempts to unpack [output] as GetSupplyCapOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetSupplyCapOutput(output []byte) (GetSupplyCapOutput, error) {
+	outputStruct := GetSupplyCapOutput{}
+	err := NativeMinterABI.UnpackIntoInterface(&outputStruct, "getSupplyCap", output)
+	return outputStruct, err
+}
+
+// getSupplyCap returns the supply cap and the amount minted by the precompile.
+// A zero supply cap means there is no supply cap.
+//
+//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+func getSupplyCap(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetSupplyCapGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	maxSupply := GetMaxSupply(stateDB)
+	if maxSupply == nil {
+		maxSupply = new(big.Int)
+	}
+	output, err := PackGetSupplyCapOutput(GetSupplyCapOutput{
+		MaxSupply:   maxSupply,
+		TotalMinted: GetTotalMinted(stateDB),
+	})
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// heliconActivationFunc returns true if the Helicon upgrade is activated.
+// Functions added in Helicon are not available before it activates.
+func heliconActivationFunc(accessibleState contract.AccessibleState) bool {
+	return accessibleState.GetRules().IsHeliconActivated()
+}
+
 // createNativeMinterPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
 // Access to the getters/setters is controlled by an allow list for ContractAddress.
 func createNativeMinterPrecompile() contract.StatefulPrecompiledContract {
 	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
 		"mintNativeCoin": mintNativeCoin,
 	}
-	functions := make([]*contract.StatefulPrecompileFunction, 0, len(abiFunctionMap)+len(allowlist.AllowListABI.Methods))
+	heliconFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"setMintQuota": setMintQuota,
+		"getMintQuota": getMintQuota,
+		"getSupplyCap": getSupplyCap,
+
+		"burnNativeCoin":    burnNativeCoin,
+		"getSupplyCounters": getSupplyCounters,
+	}
+	functions := make([]*contract.StatefulPrecompileFunction, 0, len(abiFunctionMap)+len(heliconFunctionMap)+len(allowlist.AllowListABI.Methods))
 	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
 
 	for name, function := range abiFunctionMap {
@@ -144,6 +350,13 @@ func createNativeMinterPrecompile() contract.StatefulPrecompiledContract {
 		}
 		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
 	}
+	for name, function := range heliconFunctionMap {
+		method, ok := NativeMinterABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, heliconActivationFunc))
+	}
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
 	if err != nil {
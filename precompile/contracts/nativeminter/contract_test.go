@@ -9,12 +9,15 @@ import (
 
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/common/math"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/vm"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/precompile/precompiletest"
@@ -22,6 +25,8 @@ import (
 	ethtypes "github.com/ava-labs/libevm/core/types"
 )
 
+var heliconRules = extras.AvalancheRules{IsDurango: true, IsHelicon: true}
+
 var tests = []precompiletest.PrecompileTest{
 	{
 		Name:       "calling_mintNativeCoin_from_NoRole_should_fail",
@@ -265,6 +270,408 @@ var tests = []precompiletest.PrecompileTest{
 			assertNativeCoinMintedEvent(t, logs, allowlisttest.TestEnabledAddr, allowlisttest.TestEnabledAddr, common.Big1)
 		},
 	},
+	{
+		Name:   "mint_within_quota_succeeds_and_emits_allowance",
+		Caller: allowlisttest.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+			allowlisttest.SetDefaultRoles(Module.Address)(t, state)
+			require.NoError(t, StoreMintQuota(state, allowlisttest.TestEnabledAddr, MintQuota{Amount: big.NewInt(10), Window: 3600}))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintNativeCoin(allowlisttest.TestNoRoleAddr, big.NewInt(4))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + NativeCoinMintedEventGasCost + MintAllowanceUpdatedEventGasCost,
+		ReadOnly:    false,
+		Rules:       heliconRules,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state *extstate.StateDB) {
+			require.Equal(t, uint256.NewInt(4), state.GetBalance(allowlisttest.TestNoRoleAddr))
+
+			logs := state.Logs()
+			require.Len(t, logs, 2)
+			assertNativeCoinMintedEvent(t, logs[:1], allowlisttest.TestEnabledAddr, allowlisttest.TestNoRoleAddr, big.NewInt(4))
+			assertMintAllowanceUpdatedEvent(t, logs[1], allowlisttest.TestEnabledAddr, big.NewInt(6), math.MaxBig256)
+			require.Equal(t, big.NewInt(4), GetTotalMinted(state))
+		},
+	},
+	{
+		Name:   "mint_exceeding_quota_fails",
+		Caller: allowlisttest.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+			allowlisttest.SetDefaultRoles(Module.Address)(t, state)
+			require.NoError(t, StoreMintQuota(state, allowlisttest.TestEnabledAddr, MintQuota{Amount: big.NewInt(1), Window: 3600}))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintNativeCoin(allowlisttest.TestEnabledAddr, big.NewInt(2))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost,
+		ReadOnly:    false,
+		Rules:       heliconRules,
+		ExpectedErr: ErrMintQuotaExceeded.Error(),
+	},
+	{
+		Name:       "mint_within_max_supply_succeeds",
+		Caller:     allowlisttest.TestEnabledAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		Config: &Config{
+			InitialMint: map[common.Address]*math.HexOrDecimal256{
+				allowlisttest.TestNoRoleAddr: math.NewHexOrDecimal256(2),
+			},
+			MaxSupply: math.NewHexOrDecimal256(3),
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintNativeCoin(allowlisttest.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost + NativeCoinMintedEventGasCost + MintAllowanceUpdatedEventGasCost,
+		ReadOnly:    false,
+		Rules:       heliconRules,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state *extstate.StateDB) {
+			require.Equal(t, big.NewInt(3), GetTotalMinted(state))
+
+			logs := state.Logs()
+			require.Len(t, logs, 2)
+			assertMintAllowanceUpdatedEvent(t, logs[1], allowlisttest.TestEnabledAddr, math.MaxBig256, common.Big0)
+		},
+	},
+	{
+		Name:       "mint_exceeding_max_supply_fails",
+		Caller:     allowlisttest.TestEnabledAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		Config: &Config{
+			InitialMint: map[common.Address]*math.HexOrDecimal256{
+				allowlisttest.TestNoRoleAddr: math.NewHexOrDecimal256(2),
+			},
+			MaxSupply: math.NewHexOrDecimal256(3),
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintNativeCoin(allowlisttest.TestEnabledAddr, common.Big2)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: MintGasCost + MintLimitsGasCost,
+		ReadOnly:    false,
+		Rules:       heliconRules,
+		ExpectedErr: ErrMaxSupplyExceeded.Error(),
+	},
+	{
+		Name:       "mint_does_not_enforce_max_supply_before_Helicon",
+		Caller:     allowlisttest.TestEnabledAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		Config: &Config{
+			MaxSupply: math.NewHexOrDecimal256(1),
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintNativeCoin(allowlisttest.TestEnabledAddr, common.Big2)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: MintGasCost + NativeCoinMintedEventGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state *extstate.StateDB) {
+			require.Equal(t, uint256.NewInt(2), state.GetBalance(allowlisttest.TestEnabledAddr))
+		},
+	},
+	{
+		Name:       "set_mint_quota_from_admin_succeeds_and_emits_logs",
+		Caller:     allowlisttest.TestAdminAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMintQuota(allowlisttest.TestEnabledAddr, big.NewInt(100), 60)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetMintQuotaGasCost + MintQuotaSetEventGasCost,
+		ReadOnly:    false,
+		Rules:       heliconRules,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state *extstate.StateDB) {
+			quota, remaining := GetMintQuota(state, allowlisttest.TestEnabledAddr, 0)
+			require.Equal(t, MintQuota{Amount: big.NewInt(100), Window: 60}, quota)
+			require.Equal(t, big.NewInt(100), remaining)
+
+			logs := state.Logs()
+			require.Len(t, logs, 1)
+			require.Equal(t,
+				[]common.Hash{
+					NativeMinterABI.Events["MintQuotaSet"].ID,
+					common.BytesToHash(allowlisttest.TestAdminAddr[:]),
+					common.BytesToHash(allowlisttest.TestEnabledAddr[:]),
+				},
+				logs[0].Topics,
+			)
+			eventQuota, err := UnpackMintQuotaSetEventData(logs[0].Data)
+			require.NoError(t, err)
+			require.Equal(t, quota, eventQuota)
+		},
+	},
+	{
+		Name:       "set_mint_quota_from_enabled_fails",
+		Caller:     allowlisttest.TestEnabledAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMintQuota(allowlisttest.TestEnabledAddr, big.NewInt(100), 60)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetMintQuotaGasCost,
+		ReadOnly:    false,
+		Rules:       heliconRules,
+		ExpectedErr: ErrCannotSetMintQuota.Error(),
+	},
+	{
+		Name:       "set_mint_quota_without_window_fails",
+		Caller:     allowlisttest.TestAdminAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMintQuota(allowlisttest.TestEnabledAddr, big.NewInt(100), 0)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetMintQuotaGasCost + MintQuotaSetEventGasCost,
+		ReadOnly:    false,
+		Rules:       heliconRules,
+		ExpectedErr: ErrInvalidMintQuota.Error(),
+	},
+	{
+		Name:       "readOnly_set_mint_quota_fails",
+		Caller:     allowlisttest.TestAdminAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMintQuota(allowlisttest.TestEnabledAddr, big.NewInt(100), 60)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: SetMintQuotaGasCost,
+		ReadOnly:    true,
+		Rules:       heliconRules,
+		ExpectedErr: vm.ErrWriteProtection.Error(),
+	},
+	{
+		Name:       "set_mint_quota_before_Helicon_fails",
+		Caller:     allowlisttest.TestAdminAddr,
+		BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMintQuota(allowlisttest.TestEnabledAddr, big.NewInt(100), 60)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: 0,
+		ReadOnly:    false,
+		ExpectedErr: "invalid non-activated function selector",
+	},
+	{
+		Name:   "get_mint_quota",
+		Caller: allowlisttest.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+			require.NoError(t, StoreMintQuota(state, allowlisttest.TestEnabledAddr, MintQuota{Amount: big.NewInt(100), Window: 60}))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackGetMintQuota(allowlisttest.TestEnabledAddr)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: GetMintQuotaGasCost,
+		ReadOnly:    true,
+		Rules:       heliconRules,
+		ExpectedRes: func() []byte {
+			res, err := PackGetMintQuotaOutput(GetMintQuotaOutput{
+				Amount:    big.NewInt(100),
+				Window:    big.NewInt(60),
+				Remaining: big.NewInt(100),
+			})
+			if err != nil {
+				panic(err)
+			}
+			return res
+		}(),
+	},
+	{
+		Name:   "get_supply_cap",
+		Caller: allowlisttest.TestNoRoleAddr,
+		Config: &Config{
+			InitialMint: map[common.Address]*math.HexOrDecimal256{
+				allowlisttest.TestNoRoleAddr: math.NewHexOrDecimal256(2),
+			},
+			MaxSupply: math.NewHexOrDecimal256(3),
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackGetSupplyCap()
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: GetSupplyCapGasCost,
+		ReadOnly:    true,
+		Rules:       heliconRules,
+		ExpectedRes: func() []byte {
+			res, err := PackGetSupplyCapOutput(GetSupplyCapOutput{
+				MaxSupply:   big.NewInt(3),
+				TotalMinted: big.NewInt(2),
+			})
+			if err != nil {
+				panic(err)
+			}
+			return res
+		}(),
+	},
+	{
+		Name:   "burn_succeeds_and_emits_logs",
+		Caller: allowlisttest.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+			state.AddBalance(allowlisttest.TestNoRoleAddr, uint256.NewInt(5))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackBurnNativeCoin(big.NewInt(3))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: BurnGasCost + NativeCoinBurnedEventGasCost,
+		ReadOnly:    false,
+		Rules:       heliconRules,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state *extstate.StateDB) {
+			require.Equal(t, uint256.NewInt(2), state.GetBalance(allowlisttest.TestNoRoleAddr))
+			require.Equal(t, big.NewInt(3), GetTotalBurned(state))
+
+			logs := state.Logs()
+			require.Len(t, logs, 1)
+			require.Equal(t,
+				[]common.Hash{
+					NativeMinterABI.Events["NativeCoinBurned"].ID,
+					common.BytesToHash(allowlisttest.TestNoRoleAddr[:]),
+				},
+				logs[0].Topics,
+			)
+			amount, err := UnpackNativeCoinBurnedEventData(logs[0].Data)
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(3), amount)
+		},
+	},
+	{
+		Name:   "burn_more_than_balance_fails",
+		Caller: allowlisttest.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+			state.AddBalance(allowlisttest.TestNoRoleAddr, uint256.NewInt(1))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackBurnNativeCoin(big.NewInt(2))
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: BurnGasCost,
+		ReadOnly:    false,
+		Rules:       heliconRules,
+		ExpectedErr: ErrInsufficientBalance.Error(),
+	},
+	{
+		Name:   "readOnly_burn_fails",
+		Caller: allowlisttest.TestNoRoleAddr,
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackBurnNativeCoin(common.Big0)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: BurnGasCost,
+		ReadOnly:    true,
+		Rules:       heliconRules,
+		ExpectedErr: vm.ErrWriteProtection.Error(),
+	},
+	{
+		Name:   "burn_before_Helicon_fails",
+		Caller: allowlisttest.TestNoRoleAddr,
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackBurnNativeCoin(common.Big0)
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: 0,
+		ReadOnly:    false,
+		ExpectedErr: "invalid non-activated function selector",
+	},
+	{
+		Name:   "get_supply_counters",
+		Caller: allowlisttest.TestNoRoleAddr,
+		Config: &Config{
+			InitialMint: map[common.Address]*math.HexOrDecimal256{
+				allowlisttest.TestNoRoleAddr: math.NewHexOrDecimal256(5),
+			},
+		},
+		BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+			addTotalBurned(state, big.NewInt(2))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackGetSupplyCounters()
+			require.NoError(t, err)
+			return input
+		},
+		SuppliedGas: GetSupplyCountersGasCost,
+		ReadOnly:    true,
+		Rules:       heliconRules,
+		ExpectedRes: func() []byte {
+			res, err := PackGetSupplyCountersOutput(GetSupplyCountersOutput{
+				TotalMinted: big.NewInt(5),
+				TotalBurned: big.NewInt(2),
+			})
+			if err != nil {
+				panic(err)
+			}
+			return res
+		}(),
+	},
+	{
+		Name:   "initial_mint_not_counted_before_Helicon",
+		Caller: allowlisttest.TestNoRoleAddr,
+		Config: &Config{
+			InitialMint: map[common.Address]*math.HexOrDecimal256{
+				allowlisttest.TestNoRoleAddr: math.NewHexOrDecimal256(5),
+			},
+		},
+		AfterHook: func(t testing.TB, state *extstate.StateDB) {
+			require.Zero(t, GetTotalMinted(state).Sign())
+		},
+	},
+}
+
+func TestMintQuotaWindow(t *testing.T) {
+	require := require.New(t)
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	stateDB := extstate.New(statedb)
+	minter := allowlisttest.TestEnabledAddr
+
+	require.NoError(StoreMintQuota(stateDB, minter, MintQuota{Amount: big.NewInt(10), Window: 100}))
+
+	// The first mint starts a new window.
+	remainingQuota, _, err := applyMintLimits(stateDB, minter, big.NewInt(6), 1000)
+	require.NoError(err)
+	require.Equal(big.NewInt(4), remainingQuota)
+
+	// Mints within the window share the quota.
+	_, _, err = applyMintLimits(stateDB, minter, big.NewInt(5), 1099)
+	require.ErrorIs(err, ErrMintQuotaExceeded)
+	remainingQuota, _, err = applyMintLimits(stateDB, minter, big.NewInt(4), 1099)
+	require.NoError(err)
+	require.Zero(remainingQuota.Sign())
+
+	// The quota is restored once the window has elapsed.
+	_, remaining := GetMintQuota(stateDB, minter, 1100)
+	require.Equal(big.NewInt(10), remaining)
+	remainingQuota, _, err = applyMintLimits(stateDB, minter, big.NewInt(10), 1100)
+	require.NoError(err)
+	require.Zero(remainingQuota.Sign())
+
+	// Removing the quota allows unlimited minting.
+	require.NoError(StoreMintQuota(stateDB, minter, MintQuota{Amount: new(big.Int)}))
+	remainingQuota, _, err = applyMintLimits(stateDB, minter, big.NewInt(1000), 1101)
+	require.NoError(err)
+	require.Equal(math.MaxBig256, remainingQuota)
 }
 
 func TestContractNativeMinterRun(t *testing.T) {
@@ -293,3 +700,23 @@ func assertNativeCoinMintedEvent(t testing.TB,
 	require.NoError(t, err)
 	require.Zero(t, expectedAmount.Cmp(amount), "expected", expectedAmount, "got", amount)
 }
+
+func assertMintAllowanceUpdatedEvent(t testing.TB,
+	log *ethtypes.Log,
+	expectedMinter common.Address,
+	expectedRemainingQuota *big.Int,
+	expectedRemainingSupply *big.Int,
+) {
+	require.Equal(
+		t,
+		[]common.Hash{
+			NativeMinterABI.Events["MintAllowanceUpdated"].ID,
+			common.BytesToHash(expectedMinter[:]),
+		},
+		log.Topics,
+	)
+	remainingQuota, remainingSupply, err := UnpackMintAllowanceUpdatedEventData(log.Data)
+	require.NoError(t, err)
+	require.Zero(t, expectedRemainingQuota.Cmp(remainingQuota), "expected", expectedRemainingQuota, "got", remainingQuota)
+	require.Zero(t, expectedRemainingSupply.Cmp(remainingSupply), "expected", expectedRemainingSupply, "got", remainingSupply)
+}
@@ -19,6 +19,21 @@ const (
 	// It is the base gas cost + the gas cost of the topics (signature, sender, recipient)
 	// and the gas cost of the non-indexed data (32 bytes for amount).
 	NativeCoinMintedEventGasCost = contract.LogGas + contract.LogTopicGas*3 + contract.LogDataGas*common.HashLength
+
+	// MintAllowanceUpdatedEventGasCost is the gas cost of the MintAllowanceUpdated event.
+	// It is the base gas cost + the gas cost of the topics (signature, minter)
+	// and the gas cost of the non-indexed data (32 bytes each for remainingQuota and remainingSupply).
+	MintAllowanceUpdatedEventGasCost = contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*common.HashLength*2
+
+	// MintQuotaSetEventGasCost is the gas cost of the MintQuotaSet event.
+	// It is the base gas cost + the gas cost of the topics (signature, sender, minter)
+	// and the gas cost of the non-indexed data (32 bytes each for amount and window).
+	MintQuotaSetEventGasCost = contract.LogGas + contract.LogTopicGas*3 + contract.LogDataGas*common.HashLength*2
+
+	// NativeCoinBurnedEventGasCost is the gas cost of the NativeCoinBurned event.
+	// It is the base gas cost + the gas cost of the topics (signature, sender)
+	// and the gas cost of the non-indexed data (32 bytes for amount).
+	NativeCoinBurnedEventGasCost = contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*common.HashLength
 )
 
 // PackNativeCoinMintedEvent packs the event into the appropriate arguments for NativeCoinMinted.
@@ -35,3 +50,54 @@ func UnpackNativeCoinMintedEventData(dataBytes []byte) (*big.Int, error) {
 	err := NativeMinterABI.UnpackIntoInterface(&eventData, "NativeCoinMinted", dataBytes)
 	return eventData.Amount, err
 }
+
+// PackMintAllowanceUpdatedEvent packs the event into the appropriate arguments for MintAllowanceUpdated.
+// It returns topic hashes and the encoded non-indexed data.
+func PackMintAllowanceUpdatedEvent(minter common.Address, remainingQuota *big.Int, remainingSupply *big.Int) ([]common.Hash, []byte, error) {
+	return NativeMinterABI.PackEvent("MintAllowanceUpdated", minter, remainingQuota, remainingSupply)
+}
+
+// UnpackMintAllowanceUpdatedEventData attempts to unpack non-indexed [dataBytes]
+// into the remaining quota and the remaining supply.
+func UnpackMintAllowanceUpdatedEventData(dataBytes []byte) (*big.Int, *big.Int, error) {
+	var eventData = struct {
+		RemainingQuota  *big.Int
+		RemainingSupply *big.Int
+	}{}
+	err := NativeMinterABI.UnpackIntoInterface(&eventData, "MintAllowanceUpdated", dataBytes)
+	return eventData.RemainingQuota, eventData.RemainingSupply, err
+}
+
+// PackMintQuotaSetEvent packs the event into the appropriate arguments for MintQuotaSet.
+// It returns topic hashes and the encoded non-indexed data.
+func PackMintQuotaSetEvent(sender common.Address, minter common.Address, quota MintQuota) ([]common.Hash, []byte, error) {
+	return NativeMinterABI.PackEvent("MintQuotaSet", sender, minter, quota.Amount, new(big.Int).SetUint64(quota.Window))
+}
+
+// UnpackMintQuotaSetEventData attempts to unpack non-indexed [dataBytes].
+func UnpackMintQuotaSetEventData(dataBytes []byte) (MintQuota, error) {
+	var eventData = struct {
+		Amount *big.Int
+		Window *big.Int
+	}{}
+	err := NativeMinterABI.UnpackIntoInterface(&eventData, "MintQuotaSet", dataBytes)
+	if err != nil {
+		return MintQuota{}, err
+	}
+	return MintQuota{Amount: eventData.Amount, Window: eventData.Window.Uint64()}, nil
+}
+
+// PackNativeCoinBurnedEvent packs the event into the appropriate arguments for NativeCoinBurned.
+// It returns topic hashes and the encoded non-indexed data.
+func PackNativeCoinBurnedEvent(sender common.Address, amount *big.Int) ([]common.Hash, []byte, error) {
+	return NativeMinterABI.PackEvent("NativeCoinBurned", sender, amount)
+}
+
+// UnpackNativeCoinBurnedEventData attempts to unpack non-indexed [dataBytes].
+func UnpackNativeCoinBurnedEventData(dataBytes []byte) (*big.Int, error) {
+	var eventData = struct {
+		Amount *big.Int
+	}{}
+	err := NativeMinterABI.UnpackIntoInterface(&eventData, "NativeCoinBurned", dataBytes)
+	return eventData.Amount, err
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeminter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/math"
+	"github.com/ava-labs/libevm/crypto"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+const (
+	// MintLimitsGasCost is the additional gas cost of mintNativeCoin after Helicon,
+	// covering reading the minter quota and supply cap and updating their usage.
+	MintLimitsGasCost = contract.ReadGasCostPerSlot*numMintLimitSlots + contract.WriteGasCostPerSlot*3
+
+	SetMintQuotaGasCost = contract.WriteGasCostPerSlot * numMintQuotaFields
+	GetMintQuotaGasCost = contract.ReadGasCostPerSlot * numMintQuotaFields
+	GetSupplyCapGasCost = contract.ReadGasCostPerSlot * 2
+
+	// numMintLimitSlots is the number of storage slots read to enforce the mint limits.
+	numMintLimitSlots = numMintQuotaFields + 2
+)
+
+// Fields of a minter quota stored in contract storage.
+const (
+	mintQuotaAmountField = iota
+	mintQuotaWindowField
+	mintQuotaWindowStartField
+	mintQuotaMintedField
+	numMintQuotaFields
+)
+
+var (
+	// Storage key for the supply cap. This cannot collide with the allow list
+	// keys, which are left padded addresses.
+	maxSupplyKey = common.Hash{'m', 'a', 'x', 's'}
+
+	ErrCannotSetMintQuota = errors.New("non-admin cannot set mint quota")
+	ErrInvalidMintQuota   = errors.New("invalid mint quota")
+	ErrMintQuotaExceeded  = errors.New("mint quota exceeded")
+	ErrMaxSupplyExceeded  = errors.New("max supply exceeded")
+)
+
+// MintQuota is the amount a minter can mint within each window of [Window] seconds.
+// A zero [Amount] means the minter has no quota.
+type MintQuota struct {
+	Amount *big.Int
+	Window uint64
+}
+
+// SetMintQuotaInput is the input of setMintQuota.
+type SetMintQuotaInput struct {
+	Minter common.Address
+	Amount *big.Int
+	Window *big.Int
+}
+
+// GetMintQuotaOutput is the output of getMintQuota.
+type GetMintQuotaOutput struct {
+	Amount    *big.Int
+	Window    *big.Int
+	Remaining *big.Int
+}
+
+// GetSupplyCapOutput is the output of getSupplyCap.
+type GetSupplyCapOutput struct {
+	MaxSupply   *big.Int
+	TotalMinted *big.Int
+}
+
+// mintQuotaKey returns the storage key of [field] of the quota of [minter].
+func mintQuotaKey(minter common.Address, field int) common.Hash {
+	return crypto.Keccak256Hash([]byte("mintQuota"), minter.Bytes(), []byte{byte(field)})
+}
+
+// GetMintQuota returns the quota of [minter] and the amount it can still mint
+// at [timestamp]. If [minter] has no quota, the remaining amount is [math.MaxBig256].
+func GetMintQuota(stateDB contract.StateReader, minter common.Address, timestamp uint64) (MintQuota, *big.Int) {
+	quota := MintQuota{
+		Amount: stateDB.GetState(ContractAddress, mintQuotaKey(minter, mintQuotaAmountField)).Big(),
+		Window: stateDB.GetState(ContractAddress, mintQuotaKey(minter, mintQuotaWindowField)).Big().Uint64(),
+	}
+	if quota.Amount.Sign() == 0 {
+		return quota, new(big.Int).Set(math.MaxBig256)
+	}
+	_, minted := mintQuotaUsage(stateDB, minter, quota, timestamp)
+	return quota, new(big.Int).Sub(quota.Amount, minted)
+}
+
+// mintQuotaUsage returns the start of the quota window of [minter] at [timestamp]
+// and the amount minted within it. A new window starts with the first mint after
+// the previous window has elapsed.
+func mintQuotaUsage(stateDB contract.StateReader, minter common.Address, quota MintQuota, timestamp uint64) (uint64, *big.Int) {
+	windowStart := stateDB.GetState(ContractAddress, mintQuotaKey(minter, mintQuotaWindowStartField)).Big().Uint64()
+	if timestamp-windowStart >= quota.Window {
+		return timestamp, new(big.Int)
+	}
+	return windowStart, stateDB.GetState(ContractAddress, mintQuotaKey(minter, mintQuotaMintedField)).Big()
+}
+
+// StoreMintQuota sets the quota of [minter] to [quota] and resets its usage.
+// A zero [quota.Amount] removes the quota.
+func StoreMintQuota(stateDB contract.StateDB, minter common.Address, quota MintQuota) error {
+	if quota.Amount == nil || quota.Amount.Sign() < 0 {
+		return fmt.Errorf("%w: amount %v", ErrInvalidMintQuota, quota.Amount)
+	}
+	if quota.Amount.Sign() == 0 && quota.Window != 0 {
+		return fmt.Errorf("%w: window %d set without amount", ErrInvalidMintQuota, quota.Window)
+	}
+	if quota.Amount.Sign() > 0 && quota.Window == 0 {
+		return fmt.Errorf("%w: window cannot be zero", ErrInvalidMintQuota)
+	}
+	stateDB.SetState(ContractAddress, mintQuotaKey(minter, mintQuotaAmountField), common.BigToHash(quota.Amount))
+	stateDB.SetState(ContractAddress, mintQuotaKey(minter, mintQuotaWindowField), common.BigToHash(new(big.Int).SetUint64(quota.Window)))
+	stateDB.SetState(ContractAddress, mintQuotaKey(minter, mintQuotaWindowStartField), common.Hash{})
+	stateDB.SetState(ContractAddress, mintQuotaKey(minter, mintQuotaMintedField), common.Hash{})
+	return nil
+}
+
+// GetMaxSupply returns the maximum amount that can be minted by the precompile.
+// Returns nil if there is no supply cap.
+func GetMaxSupply(stateDB contract.StateReader) *big.Int {
+	maxSupply := stateDB.GetState(ContractAddress, maxSupplyKey).Big()
+	if maxSupply.Sign() == 0 {
+		return nil
+	}
+	return maxSupply
+}
+
+// remainingSupply returns the amount that can still be minted before reaching
+// the supply cap, or [math.MaxBig256] if there is no supply cap.
+func remainingSupply(stateDB contract.StateReader) *big.Int {
+	maxSupply := GetMaxSupply(stateDB)
+	if maxSupply == nil {
+		return new(big.Int).Set(math.MaxBig256)
+	}
+	return new(big.Int).Sub(maxSupply, GetTotalMinted(stateDB))
+}
+
+// applyMintLimits checks that minting [amount] by [minter] at [timestamp] does not
+// exceed the quota of [minter] nor the supply cap, and records the minted amount
+// in the quota usage of [minter] and the supply counters.
+// Returns the remaining quota of [minter] and the remaining supply after the mint.
+func applyMintLimits(stateDB contract.StateDB, minter common.Address, amount *big.Int, timestamp uint64) (*big.Int, *big.Int, error) {
+	remainingQuota := new(big.Int).Set(math.MaxBig256)
+	quota, _ := GetMintQuota(stateDB, minter, timestamp)
+	var (
+		windowStart uint64
+		minted      *big.Int
+	)
+	if quota.Amount.Sign() > 0 {
+		windowStart, minted = mintQuotaUsage(stateDB, minter, quota, timestamp)
+		remainingQuota.Sub(quota.Amount, minted)
+		if amount.Cmp(remainingQuota) > 0 {
+			return nil, nil, fmt.Errorf("%w: %s can mint %s, requested %s", ErrMintQuotaExceeded, minter, remainingQuota, amount)
+		}
+		minted = new(big.Int).Add(minted, amount)
+		remainingQuota.Sub(remainingQuota, amount)
+	}
+
+	remaining := remainingSupply(stateDB)
+	if amount.Cmp(remaining) > 0 {
+		return nil, nil, fmt.Errorf("%w: %s can be minted, requested %s", ErrMaxSupplyExceeded, remaining, amount)
+	}
+
+	if quota.Amount.Sign() > 0 {
+		stateDB.SetState(ContractAddress, mintQuotaKey(minter, mintQuotaWindowStartField), common.BigToHash(new(big.Int).SetUint64(windowStart)))
+		stateDB.SetState(ContractAddress, mintQuotaKey(minter, mintQuotaMintedField), common.BigToHash(minted))
+	}
+	addTotalMinted(stateDB, amount)
+	if GetMaxSupply(stateDB) != nil {
+		remaining.Sub(remaining, amount)
+	}
+	return remainingQuota, remaining, nil
+}
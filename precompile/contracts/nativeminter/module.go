@@ -52,13 +52,23 @@ func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg pre
 	if !ok {
 		return fmt.Errorf("expected config type %T, got %T: %v", &Config{}, cfg, cfg)
 	}
+	totalInitialMint := new(big.Int)
 	for to, amount := range config.InitialMint {
 		if amount != nil {
 			amountBig := (*big.Int)(amount)
 			amountU256, _ := uint256.FromBig(amountBig)
 			state.AddBalance(to, amountU256)
+			totalInitialMint.Add(totalInitialMint, amountBig)
 		}
 	}
+	// After Helicon, the initial mint is recorded in the supply counters and
+	// counts towards the supply cap.
+	if chainConfig.IsHelicon(blockContext.Timestamp()) {
+		addTotalMinted(state, totalInitialMint)
+	}
+	if config.MaxSupply != nil {
+		state.SetState(ContractAddress, maxSupplyKey, common.BigToHash((*big.Int)(config.MaxSupply)))
+	}
 
 	return config.AllowListConfig.Configure(chainConfig, ContractAddress, state, blockContext)
 }
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeminter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/holiman/uint256"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+const (
+	// BurnGasCost is the gas cost of burnNativeCoin, including updating the burned supply counter.
+	BurnGasCost = MintGasCost + contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot
+
+	GetSupplyCountersGasCost = contract.ReadGasCostPerSlot * 2
+)
+
+var (
+	// Storage keys for the supply counters. These cannot collide with the allow
+	// list keys, which are left padded addresses.
+	totalMintedKey = common.Hash{'t', 'o', 't', 'm'}
+	totalBurnedKey = common.Hash{'t', 'o', 't', 'b'}
+
+	ErrInsufficientBalance = errors.New("insufficient balance to burn")
+)
+
+// GetSupplyCountersOutput is the output of getSupplyCounters.
+type GetSupplyCountersOutput struct {
+	TotalMinted *big.Int
+	TotalBurned *big.Int
+}
+
+// GetTotalMinted returns the amount minted by the precompile since it was
+// configured, or since Helicon if the precompile was configured before Helicon.
+func GetTotalMinted(stateDB contract.StateReader) *big.Int {
+	return stateDB.GetState(ContractAddress, totalMintedKey).Big()
+}
+
+// GetTotalBurned returns the amount burned with burnNativeCoin since the
+// precompile was configured.
+func GetTotalBurned(stateDB contract.StateReader) *big.Int {
+	return stateDB.GetState(ContractAddress, totalBurnedKey).Big()
+}
+
+func addTotalMinted(stateDB contract.StateDB, amount *big.Int) {
+	stateDB.SetState(ContractAddress, totalMintedKey, common.BigToHash(new(big.Int).Add(GetTotalMinted(stateDB), amount)))
+}
+
+func addTotalBurned(stateDB contract.StateDB, amount *big.Int) {
+	stateDB.SetState(ContractAddress, totalBurnedKey, common.BigToHash(new(big.Int).Add(GetTotalBurned(stateDB), amount)))
+}
+
+// PackBurnNativeCoin packs [amount] into the appropriate arguments for burnNativeCoin.
+func PackBurnNativeCoin(amount *big.Int) ([]byte, error) {
+	return NativeMinterABI.Pack("burnNativeCoin", amount)
+}
+
+// UnpackBurnNativeCoinInput attempts to unpack [input] as the amount to burn.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackBurnNativeCoinInput(input []byte) (*big.Int, error) {
+	res, err := NativeMinterABI.UnpackInput("burnNativeCoin", input, false)
+	if err != nil {
+		return nil, err
+	}
+	return res[0].(*big.Int), nil
+}
+
+// burnNativeCoin removes the given amount of native coin from the balance of the caller.
+// Any caller can burn its own balance. The burned amount is recorded in the supply counters.
+func burnNativeCoin(accessibleState contract.AccessibleState, caller common.Address, _ common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, BurnGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+
+	amount, err := UnpackBurnNativeCoinInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	amountU256, _ := uint256.FromBig(amount)
+	if balance := stateDB.GetBalance(caller); balance.Lt(amountU256) {
+		return nil, remainingGas, fmt.Errorf("%w: %s has %s, requested %s", ErrInsufficientBalance, caller, balance, amount)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, NativeCoinBurnedEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackNativeCoinBurnedEvent(caller, amount)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+
+	stateDB.SubBalance(caller, amountU256)
+	addTotalBurned(stateDB, amount)
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackGetSupplyCounters packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetSupplyCounters() ([]byte, error) {
+	return NativeMinterABI.Pack("getSupplyCounters")
+}
+
+// PackGetSupplyCountersOutput attempts to pack given [output] of type GetSupplyCountersOutput
+// to conform the ABI outputs.
+func PackGetSupplyCountersOutput(output GetSupplyCountersOutput) ([]byte, error) {
+	return NativeMinterABI.PackOutput("getSupplyCounters", output.TotalMinted, output.TotalBurned)
+}
+
+// UnpackGetSupplyCountersOutput attempts to unpack [output] as GetSupplyCountersOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetSupplyCountersOutput(output []byte) (GetSupplyCountersOutput, error) {
+	outputStruct := GetSupplyCountersOutput{}
+	err := NativeMinterABI.UnpackIntoInterface(&outputStruct, "getSupplyCounters", output)
+	return outputStruct, err
+}
+
+// getSupplyCounters returns the amounts minted and burned by the precompile.
+//
+//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+func getSupplyCounters(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetSupplyCountersGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	output, err := PackGetSupplyCountersOutput(GetSupplyCountersOutput{
+		TotalMinted: GetTotalMinted(stateDB),
+		TotalBurned: GetTotalBurned(stateDB),
+	})
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
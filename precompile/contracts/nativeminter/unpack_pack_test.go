@@ -17,7 +17,15 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 )
 
-var mintSignature = contract.CalculateFunctionSelector("mintNativeCoin(address,uint256)") // address, amount
+var (
+	mintSignature         = contract.CalculateFunctionSelector("mintNativeCoin(address,uint256)")       // address, amount
+	setMintQuotaSignature = contract.CalculateFunctionSelector("setMintQuota(address,uint256,uint256)") // minter, amount, window
+	getMintQuotaSignature = contract.CalculateFunctionSelector("getMintQuota(address)")                 // minter
+	getSupplyCapSignature = contract.CalculateFunctionSelector("getSupplyCap()")
+
+	burnSignature              = contract.CalculateFunctionSelector("burnNativeCoin(uint256)") // amount
+	getSupplyCountersSignature = contract.CalculateFunctionSelector("getSupplyCounters()")
+)
 
 func FuzzPackMintNativeCoinEqualTest(f *testing.F) {
 	key, err := crypto.GenerateKey()
@@ -126,6 +134,30 @@ func TestFunctionSignatures(t *testing.T) {
 	// Test that the mintNativeCoin signature is correct
 	abiMintNativeCoin := NativeMinterABI.Methods["mintNativeCoin"]
 	require.Equal(t, mintSignature, abiMintNativeCoin.ID)
+
+	require.Equal(t, setMintQuotaSignature, NativeMinterABI.Methods["setMintQuota"].ID)
+	require.Equal(t, getMintQuotaSignature, NativeMinterABI.Methods["getMintQuota"].ID)
+	require.Equal(t, getSupplyCapSignature, NativeMinterABI.Methods["getSupplyCap"].ID)
+	require.Equal(t, burnSignature, NativeMinterABI.Methods["burnNativeCoin"].ID)
+	require.Equal(t, getSupplyCountersSignature, NativeMinterABI.Methods["getSupplyCounters"].ID)
+}
+
+func TestPackUnpackSetMintQuota(t *testing.T) {
+	minter := common.HexToAddress("0x01")
+	input, err := PackSetMintQuota(minter, big.NewInt(100), 3600)
+	require.NoError(t, err)
+	require.Equal(t, setMintQuotaSignature, input[:4])
+
+	unpackedMinter, quota, err := UnpackSetMintQuotaInput(input[4:])
+	require.NoError(t, err)
+	require.Equal(t, minter, unpackedMinter)
+	require.Equal(t, MintQuota{Amount: big.NewInt(100), Window: 3600}, quota)
+
+	// A window that does not fit in uint64 is rejected.
+	input, err = NativeMinterABI.Pack("setMintQuota", minter, big.NewInt(100), abi.MaxUint256)
+	require.NoError(t, err)
+	_, _, err = UnpackSetMintQuotaInput(input[4:])
+	require.ErrorIs(t, err, ErrInvalidMintQuota)
 }
 
 func testOldPackMintNativeCoinEqual(t *testing.T, addr common.Address, amount *big.Int, checkOutputs bool) {
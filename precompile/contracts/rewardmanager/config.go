@@ -7,6 +7,9 @@
 package rewardmanager
 
 import (
+	"errors"
+	"slices"
+
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -16,9 +19,12 @@ import (
 
 var _ precompileconfig.Config = (*Config)(nil)
 
+var ErrRewardSplitBeforeHelicon = errors.New("cannot set reward split before Helicon")
+
 type InitialRewardConfig struct {
-	AllowFeeRecipients bool           `json:"allowFeeRecipients"`
-	RewardAddress      common.Address `json:"rewardAddress,omitempty"`
+	AllowFeeRecipients bool              `json:"allowFeeRecipients"`
+	RewardAddress      common.Address    `json:"rewardAddress,omitempty"`
+	RewardSplit        []RewardRecipient `json:"rewardSplit,omitempty"` // Requires Helicon.
 }
 
 func (i *InitialRewardConfig) Equal(other *InitialRewardConfig) bool {
@@ -26,19 +32,27 @@ func (i *InitialRewardConfig) Equal(other *InitialRewardConfig) bool {
 		return false
 	}
 
-	return i.AllowFeeRecipients == other.AllowFeeRecipients && i.RewardAddress == other.RewardAddress
+	return i.AllowFeeRecipients == other.AllowFeeRecipients && i.RewardAddress == other.RewardAddress && slices.Equal(i.RewardSplit, other.RewardSplit)
 }
 
 func (i *InitialRewardConfig) Verify() error {
 	switch {
 	case i.AllowFeeRecipients && i.RewardAddress != (common.Address{}):
 		return ErrCannotEnableBothRewards
+	case len(i.RewardSplit) != 0 && (i.AllowFeeRecipients || i.RewardAddress != (common.Address{})):
+		return ErrCannotEnableBothRewards
+	case len(i.RewardSplit) != 0:
+		return VerifyRewardSplit(i.RewardSplit)
 	default:
 		return nil
 	}
 }
 
 func (i *InitialRewardConfig) Configure(state contract.StateDB) error {
+	if len(i.RewardSplit) != 0 {
+		StoreRewardSplit(state, i.RewardSplit)
+		return nil
+	}
 	// enable allow fee recipients
 	if i.AllowFeeRecipients {
 		EnableAllowFeeRecipients(state)
@@ -96,6 +110,9 @@ func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 		if err := c.InitialRewardConfig.Verify(); err != nil {
 			return err
 		}
+		if len(c.InitialRewardConfig.RewardSplit) != 0 && c.Timestamp() != nil && !chainConfig.IsHelicon(*c.Timestamp()) {
+			return ErrRewardSplitBeforeHelicon
+		}
 	}
 	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
 }
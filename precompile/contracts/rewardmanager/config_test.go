@@ -27,6 +27,45 @@ func TestVerify(t *testing.T) {
 			}),
 			ExpectedError: ErrCannotEnableBothRewards.Error(),
 		},
+		"valid reward split": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				RewardSplit: testRewardSplit,
+			}),
+			ChainConfig:   heliconChainConfig(t, true),
+			ExpectedError: "",
+		},
+		"reward split and reward address": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				RewardAddress: common.HexToAddress("0x01"),
+				RewardSplit:   testRewardSplit,
+			}),
+			ExpectedError: ErrCannotEnableBothRewards.Error(),
+		},
+		"reward split weights do not add up": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				RewardSplit: []RewardRecipient{
+					{Address: common.HexToAddress("0x01"), Weight: 5_000},
+					{Address: common.HexToAddress("0x02"), Weight: 4_000},
+				},
+			}),
+			ExpectedError: ErrInvalidRewardSplit.Error(),
+		},
+		"reward split duplicate recipient": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				RewardSplit: []RewardRecipient{
+					{Address: common.HexToAddress("0x01"), Weight: 5_000},
+					{Address: common.HexToAddress("0x01"), Weight: 5_000},
+				},
+			}),
+			ExpectedError: "duplicate recipient",
+		},
+		"reward split before Helicon": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				RewardSplit: testRewardSplit,
+			}),
+			ChainConfig:   heliconChainConfig(t, false),
+			ExpectedError: ErrRewardSplitBeforeHelicon.Error(),
+		},
 	}
 	allowlisttest.VerifyPrecompileWithAllowListTests(t, Module, tests)
 }
@@ -68,6 +107,15 @@ func TestEqual(t *testing.T) {
 				}),
 			Expected: false,
 		},
+		"different reward split": {
+			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
+				RewardSplit: testRewardSplit,
+			}),
+			Other: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
+				RewardSplit: testRewardSplit[:1],
+			}),
+			Expected: false,
+		},
 		"same config": {
 			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
 				RewardAddress: common.HexToAddress("0x01"),
@@ -80,3 +128,10 @@ func TestEqual(t *testing.T) {
 	}
 	allowlisttest.EqualPrecompileWithAllowListTests(t, Module, tests)
 }
+
+func heliconChainConfig(t *testing.T, isHelicon bool) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+	config.EXPECT().IsHelicon(gomock.Any()).Return(isHelicon).AnyTimes()
+	return config
+}
@@ -51,9 +51,9 @@ var (
 	allowFeeRecipientsAddressValue = common.Hash{'a', 'f', 'r', 'a', 'v'}
 )
 
-// GetRewardManagerAllowListStatus returns the role of [address] for the RewardManager list.
-func GetRewardManagerAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// GetRewardManagerAllowListStatus returns the role of [address] for the RewardManager list at [timestamp].
+func GetRewardManagerAllowListStatus(stateDB contract.StateDB, address common.Address, isHelicon bool, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, isHelicon, timestamp)
 }
 
 // SetRewardManagerAllowListStatus sets the permissions of [address] to [role] for the
@@ -68,14 +68,17 @@ func PackAllowFeeRecipients() ([]byte, error) {
 	return RewardManagerABI.Pack("allowFeeRecipients")
 }
 
-// EnableAllowFeeRecipients enables fee recipients.
+// EnableAllowFeeRecipients enables fee recipients and removes the reward split, if any.
 func EnableAllowFeeRecipients(stateDB contract.StateDB) {
 	stateDB.SetState(ContractAddress, rewardAddressStorageKey, allowFeeRecipientsAddressValue)
+	clearRewardSplit(stateDB)
 }
 
 // DisableFeeRewards disables rewards and burns them by sending to Blackhole Address.
+// It removes the reward split, if any.
 func DisableFeeRewards(stateDB contract.StateDB) {
 	stateDB.SetState(ContractAddress, rewardAddressStorageKey, common.BytesToHash(constants.BlackholeAddr.Bytes()))
+	clearRewardSplit(stateDB)
 }
 
 func allowFeeRecipients(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
@@ -92,7 +95,7 @@ func allowFeeRecipients(accessibleState contract.AccessibleState, caller common.
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotAllowFeeRecipients, caller)
 	}
@@ -163,14 +166,16 @@ func PackCurrentRewardAddressOutput(rewardAddress common.Address) ([]byte, error
 
 // GetStoredRewardAddress returns the current value of the address stored under rewardAddressStorageKey.
 // Returns an empty address and true if allow fee recipients is enabled, otherwise returns current reward address and false.
+// The reward address is [ContractAddress] if fees are split with [StoreRewardSplit].
 func GetStoredRewardAddress(stateDB contract.StateReader) (common.Address, bool) {
 	val := stateDB.GetState(ContractAddress, rewardAddressStorageKey)
 	return common.BytesToAddress(val.Bytes()), val == allowFeeRecipientsAddressValue
 }
 
-// StoreRewardAddress stores the given [val] under rewardAddressStorageKey.
+// StoreRewardAddress stores the given [val] under rewardAddressStorageKey and removes the reward split, if any.
 func StoreRewardAddress(stateDB contract.StateDB, val common.Address) {
 	stateDB.SetState(ContractAddress, rewardAddressStorageKey, common.BytesToHash(val.Bytes()))
+	clearRewardSplit(stateDB)
 }
 
 // PackSetRewardAddress packs [addr] of type common.Address into the appropriate arguments for setRewardAddress.
@@ -214,7 +219,7 @@ func setRewardAddress(accessibleState contract.AccessibleState, caller common.Ad
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetRewardAddress, caller)
 	}
@@ -284,7 +289,7 @@ func disableRewards(accessibleState contract.AccessibleState, caller common.Addr
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotDisableRewards, caller)
 	}
@@ -310,6 +315,12 @@ func disableRewards(accessibleState contract.AccessibleState, caller common.Addr
 	return []byte{}, remainingGas, nil
 }
 
+// heliconActivationFunc returns true if the Helicon upgrade is activated.
+// Functions added in Helicon are not available before it activates.
+func heliconActivationFunc(accessibleState contract.AccessibleState) bool {
+	return accessibleState.GetRules().IsHeliconActivated()
+}
+
 // createRewardManagerPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
 // Access to the getters/setters is controlled by an allow list for [precompileAddr].
 func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
@@ -322,6 +333,10 @@ func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
 		"disableRewards":          disableRewards,
 		"setRewardAddress":        setRewardAddress,
 	}
+	heliconFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"currentRewardSplit": currentRewardSplit,
+		"setRewardSplit":     setRewardSplit,
+	}
 
 	for name, function := range abiFunctionMap {
 		method, ok := RewardManagerABI.Methods[name]
@@ -330,6 +345,13 @@ func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
 		}
 		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
 	}
+	for name, function := range heliconFunctionMap {
+		method, ok := RewardManagerABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, heliconActivationFunc))
+	}
 
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
@@ -4,17 +4,23 @@
 package rewardmanager
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/vm"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/precompile/precompiletest"
 
@@ -22,6 +28,13 @@ import (
 )
 
 var (
+	heliconRules    = extras.AvalancheRules{IsDurango: true, IsHelicon: true}
+	testRewardSplit = []RewardRecipient{
+		{Address: common.HexToAddress("0x0a"), Weight: 5_000},
+		{Address: common.HexToAddress("0x0b"), Weight: 3_000},
+		{Address: common.HexToAddress("0x0c"), Weight: 2_000},
+	}
+
 	rewardAddress = common.HexToAddress("0x0123")
 	tests         = []precompiletest.PrecompileTest{
 		{
@@ -451,9 +464,218 @@ var (
 			ReadOnly:    false,
 			ExpectedErr: vm.ErrOutOfGas.Error(),
 		},
+		{
+			Name:       "set_reward_split_from_enabled_succeeds",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testRewardSplit)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: setRewardSplitGas(testRewardSplit),
+			ExpectedRes: []byte{},
+			Rules:       heliconRules,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.Equal(t, testRewardSplit, GetRewardSplit(state))
+				address, isFeeRecipients := GetStoredRewardAddress(state)
+				require.Equal(t, ContractAddress, address)
+				require.False(t, isFeeRecipients)
+
+				logs := state.Logs()
+				require.Len(t, logs, 1)
+				topics, data, err := PackRewardSplitChangedEvent(allowlisttest.TestEnabledAddr, testRewardSplit)
+				require.NoError(t, err)
+				require.Equal(t, topics, logs[0].Topics)
+				require.Equal(t, data, logs[0].Data)
+			},
+		},
+		{
+			Name:       "set_reward_split_from_no_role_fails",
+			Caller:     allowlisttest.TestNoRoleAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testRewardSplit)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetRewardSplitGasCost + SetRewardSplitGasCostPerRecipient*uint64(len(testRewardSplit)),
+			Rules:       heliconRules,
+			ExpectedErr: ErrCannotSetRewardSplit.Error(),
+		},
+		{
+			Name:       "set_reward_split_invalid_weights_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testRewardSplit[:2])
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetRewardSplitGasCost + SetRewardSplitGasCostPerRecipient*2,
+			Rules:       heliconRules,
+			ExpectedErr: ErrInvalidRewardSplit.Error(),
+		},
+		{
+			Name:       "set_reward_split_mismatched_lengths_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := RewardManagerABI.Pack("setRewardSplit", []common.Address{rewardAddress}, []*big.Int{})
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetRewardSplitGasCost,
+			Rules:       heliconRules,
+			ExpectedErr: ErrInvalidRewardSplit.Error(),
+		},
+		{
+			Name:       "set_reward_split_readOnly",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testRewardSplit)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetRewardSplitGasCost,
+			ReadOnly:    true,
+			Rules:       heliconRules,
+			ExpectedErr: vm.ErrWriteProtection.Error(),
+		},
+		{
+			Name:       "set_reward_split_insufficient_gas",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testRewardSplit)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: setRewardSplitGas(testRewardSplit) - 1,
+			Rules:       heliconRules,
+			ExpectedErr: vm.ErrOutOfGas.Error(),
+		},
+		{
+			Name:       "set_reward_split_pre_Helicon",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardSplit(testRewardSplit)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 0,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		{
+			Name:   "current_reward_split",
+			Caller: allowlisttest.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				StoreRewardSplit(state, testRewardSplit)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackCurrentRewardSplit()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: CurrentRewardSplitGasCost + CurrentRewardSplitGasCostPerRecipient*uint64(len(testRewardSplit)),
+			ReadOnly:    true,
+			Rules:       heliconRules,
+			ExpectedRes: func() []byte {
+				output, err := PackCurrentRewardSplitOutput(testRewardSplit)
+				if err != nil {
+					panic(err)
+				}
+				return output
+			}(),
+		},
+		{
+			Name:   "set_reward_address_removes_reward_split",
+			Caller: allowlisttest.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(Module.Address)(t, state)
+				StoreRewardSplit(state, testRewardSplit)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardAddress(rewardAddress)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: SetRewardAddressGasCost + RewardAddressChangedEventGasCost,
+			ExpectedRes: []byte{},
+			Rules:       heliconRules,
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.Empty(t, GetRewardSplit(state))
+				address, _ := GetStoredRewardAddress(state)
+				require.Equal(t, rewardAddress, address)
+			},
+		},
 	}
 )
 
+func setRewardSplitGas(split []RewardRecipient) uint64 {
+	_, data, err := PackRewardSplitChangedEvent(common.Address{}, split)
+	if err != nil {
+		panic(err)
+	}
+	return SetRewardSplitGasCost + SetRewardSplitGasCostPerRecipient*uint64(len(split)) + RewardSplitChangedEventGasCost(data)
+}
+
+func TestDistributeRewardSplit(t *testing.T) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	stateDB := extstate.New(statedb)
+
+	// Nothing is distributed without a reward split.
+	stateDB.AddBalance(ContractAddress, uint256.NewInt(1_001))
+	DistributeRewardSplit(stateDB)
+	require.Equal(t, uint256.NewInt(1_001), stateDB.GetBalance(ContractAddress))
+
+	StoreRewardSplit(stateDB, testRewardSplit)
+	DistributeRewardSplit(stateDB)
+	require.True(t, stateDB.GetBalance(ContractAddress).IsZero())
+	// The remainder of the division goes to the first recipient.
+	require.Equal(t, uint256.NewInt(501), stateDB.GetBalance(testRewardSplit[0].Address))
+	require.Equal(t, uint256.NewInt(300), stateDB.GetBalance(testRewardSplit[1].Address))
+	require.Equal(t, uint256.NewInt(200), stateDB.GetBalance(testRewardSplit[2].Address))
+}
+
+func TestDistributeRemovedRewardSplit(t *testing.T) {
+	tests := map[string]struct {
+		remove    func(contract.StateDB)
+		recipient common.Address
+	}{
+		"reward address": {
+			remove:    func(stateDB contract.StateDB) { StoreRewardAddress(stateDB, rewardAddress) },
+			recipient: rewardAddress,
+		},
+		"fee recipients": {
+			remove:    EnableAllowFeeRecipients,
+			recipient: constants.BlackholeAddr,
+		},
+		"disabled rewards": {
+			remove:    DisableFeeRewards,
+			recipient: constants.BlackholeAddr,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(t, err)
+			stateDB := extstate.New(statedb)
+
+			// The fees of the block were directed to the precompile before the split was removed.
+			StoreRewardSplit(stateDB, testRewardSplit)
+			stateDB.AddBalance(ContractAddress, uint256.NewInt(1_001))
+			test.remove(stateDB)
+			DistributeRewardSplit(stateDB)
+			require.True(t, stateDB.GetBalance(ContractAddress).IsZero())
+			require.Equal(t, uint256.NewInt(1_001), stateDB.GetBalance(test.recipient))
+		})
+	}
+}
+
 func TestRewardManagerRun(t *testing.T) {
 	allowlisttest.RunPrecompileWithAllowListTests(t, Module, tests)
 }
@@ -42,3 +42,17 @@ func PackRewardAddressChangedEvent(sender common.Address, oldRewardAddress commo
 func PackRewardsDisabledEvent(sender common.Address) ([]common.Hash, []byte, error) {
 	return RewardManagerABI.PackEvent("RewardsDisabled", sender)
 }
+
+// RewardSplitChangedEventGasCost returns the gas cost of the RewardSplitChanged event with [data].
+// It is calculated as the gas cost of the log operation + the gas cost of 2 topic hashes (signature + sender)
+// + the gas cost of the encoded recipients and weights.
+func RewardSplitChangedEventGasCost(data []byte) uint64 {
+	return contract.LogGas + contract.LogTopicGas*2 + contract.LogDataGas*uint64(len(data))
+}
+
+// PackRewardSplitChangedEvent packs the event into the appropriate arguments for RewardSplitChanged.
+// It returns topic hashes and the encoded non-indexed data.
+func PackRewardSplitChangedEvent(sender common.Address, split []RewardRecipient) ([]common.Hash, []byte, error) {
+	output := rewardSplitToOutput(split)
+	return RewardManagerABI.PackEvent("RewardSplitChanged", sender, output.Recipients, output.Weights)
+}
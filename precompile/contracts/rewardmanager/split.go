@@ -0,0 +1,296 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rewardmanager
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/holiman/uint256"
+
+	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+const (
+	// MaxRewardSplitRecipients is the maximum number of recipients of a reward split.
+	MaxRewardSplitRecipients = 16
+	// TotalRewardSplitWeight is the sum of the weights of a reward split, in basis points.
+	TotalRewardSplitWeight = 10_000
+
+	// SetRewardSplitGasCost is the base gas cost of setRewardSplit: reading the allow list and
+	// writing the reward address and the number of recipients.
+	SetRewardSplitGasCost uint64 = contract.WriteGasCostPerSlot*2 + allowlist.ReadAllowListGasCost
+	// SetRewardSplitGasCostPerRecipient is the gas cost of writing each recipient of the split.
+	SetRewardSplitGasCostPerRecipient uint64 = contract.WriteGasCostPerSlot
+	// CurrentRewardSplitGasCost is the base gas cost of currentRewardSplit.
+	CurrentRewardSplitGasCost uint64 = contract.ReadGasCostPerSlot
+	// CurrentRewardSplitGasCostPerRecipient is the gas cost of reading each recipient of the split.
+	CurrentRewardSplitGasCostPerRecipient uint64 = contract.ReadGasCostPerSlot
+)
+
+var (
+	ErrCannotSetRewardSplit = errors.New("non-enabled cannot call setRewardSplit")
+	ErrInvalidRewardSplit   = errors.New("invalid reward split")
+
+	// Storage key of the number of recipients of the reward split. This cannot collide
+	// with the allow list keys, which are left padded addresses.
+	rewardSplitCountKey = common.Hash{'r', 's', 'c'}
+)
+
+// RewardRecipient is a recipient of a share of the fees, weighted in basis points.
+type RewardRecipient struct {
+	Address common.Address `json:"address"`
+	Weight  uint64         `json:"weight"`
+}
+
+// RewardSplitOutput is the output of currentRewardSplit.
+type RewardSplitOutput struct {
+	Recipients []common.Address
+	Weights    []*big.Int
+}
+
+// VerifyRewardSplit returns an error if [split] is not a list of distinct non-zero
+// addresses with positive weights adding up to [TotalRewardSplitWeight].
+func VerifyRewardSplit(split []RewardRecipient) error {
+	if len(split) == 0 || len(split) > MaxRewardSplitRecipients {
+		return fmt.Errorf("%w: must have between 1 and %d recipients, got %d", ErrInvalidRewardSplit, MaxRewardSplitRecipients, len(split))
+	}
+	seen := make(map[common.Address]struct{}, len(split))
+	var total uint64
+	for _, recipient := range split {
+		if recipient.Address == (common.Address{}) {
+			return fmt.Errorf("%w: empty recipient address", ErrInvalidRewardSplit)
+		}
+		if _, ok := seen[recipient.Address]; ok {
+			return fmt.Errorf("%w: duplicate recipient %s", ErrInvalidRewardSplit, recipient.Address)
+		}
+		seen[recipient.Address] = struct{}{}
+		if recipient.Weight == 0 || recipient.Weight > TotalRewardSplitWeight {
+			return fmt.Errorf("%w: invalid weight %d for %s", ErrInvalidRewardSplit, recipient.Weight, recipient.Address)
+		}
+		total += recipient.Weight
+	}
+	if total != TotalRewardSplitWeight {
+		return fmt.Errorf("%w: weights add up to %d, expected %d", ErrInvalidRewardSplit, total, TotalRewardSplitWeight)
+	}
+	return nil
+}
+
+// rewardRecipientKey returns the storage key of the [i]th recipient of the reward split.
+func rewardRecipientKey(i uint64) common.Hash {
+	return crypto.Keccak256Hash([]byte("rewardSplit"), common.BigToHash(new(big.Int).SetUint64(i)).Bytes())
+}
+
+// GetRewardSplit returns the reward split, or nil if fees are not split.
+func GetRewardSplit(stateDB contract.StateReader) []RewardRecipient {
+	count := stateDB.GetState(ContractAddress, rewardSplitCountKey).Big().Uint64()
+	if count == 0 {
+		return nil
+	}
+	split := make([]RewardRecipient, count)
+	for i := range split {
+		value := stateDB.GetState(ContractAddress, rewardRecipientKey(uint64(i)))
+		split[i] = RewardRecipient{
+			Address: common.BytesToAddress(value[common.HashLength-common.AddressLength:]),
+			Weight:  binary.BigEndian.Uint64(value[:8]),
+		}
+	}
+	return split
+}
+
+// StoreRewardSplit stores [split] and sets the reward address to the precompile address,
+// where fees are collected until they are distributed with [DistributeRewardSplit].
+// Assumes [split] has already been verified.
+func StoreRewardSplit(stateDB contract.StateDB, split []RewardRecipient) {
+	for i, recipient := range split {
+		var value common.Hash
+		binary.BigEndian.PutUint64(value[:8], recipient.Weight)
+		copy(value[common.HashLength-common.AddressLength:], recipient.Address.Bytes())
+		stateDB.SetState(ContractAddress, rewardRecipientKey(uint64(i)), value)
+	}
+	stateDB.SetState(ContractAddress, rewardSplitCountKey, common.BigToHash(new(big.Int).SetUint64(uint64(len(split)))))
+	stateDB.SetState(ContractAddress, rewardAddressStorageKey, common.BytesToHash(ContractAddress.Bytes()))
+}
+
+// clearRewardSplit removes the reward split, if any.
+func clearRewardSplit(stateDB contract.StateDB) {
+	stateDB.SetState(ContractAddress, rewardSplitCountKey, common.Hash{})
+}
+
+// DistributeRewardSplit transfers the balance of the precompile to the recipients of the
+// reward split, in proportion to their weights. The remainder of the division is sent to
+// the first recipient.
+// If the reward split was removed after the fees were directed to the precompile, the
+// balance is forwarded to the reward address that replaced it instead, or burned if fee
+// recipients are allowed. It does nothing if no reward address is set.
+func DistributeRewardSplit(stateDB contract.StateDB) {
+	balance := stateDB.GetBalance(ContractAddress)
+	if balance.IsZero() {
+		return
+	}
+	split := GetRewardSplit(stateDB)
+	if len(split) == 0 {
+		rewardAddress, allowFeeRecipients := GetStoredRewardAddress(stateDB)
+		switch {
+		case allowFeeRecipients:
+			rewardAddress = constants.BlackholeAddr
+		case rewardAddress == (common.Address{}) || rewardAddress == ContractAddress:
+			return
+		}
+		stateDB.SubBalance(ContractAddress, balance)
+		stateDB.AddBalance(rewardAddress, balance)
+		return
+	}
+	stateDB.SubBalance(ContractAddress, balance)
+
+	var (
+		total     = uint256.NewInt(TotalRewardSplitWeight)
+		remainder = new(uint256.Int).Set(balance)
+		shares    = make([]*uint256.Int, len(split))
+	)
+	for i := len(split) - 1; i >= 0; i-- {
+		if i == 0 {
+			shares[i] = remainder
+			break
+		}
+		shares[i], _ = new(uint256.Int).MulDivOverflow(balance, uint256.NewInt(split[i].Weight), total)
+		remainder.Sub(remainder, shares[i])
+	}
+	for i, recipient := range split {
+		stateDB.AddBalance(recipient.Address, shares[i])
+	}
+}
+
+// PackSetRewardSplit packs [split] into the appropriate arguments for setRewardSplit.
+func PackSetRewardSplit(split []RewardRecipient) ([]byte, error) {
+	output := rewardSplitToOutput(split)
+	return RewardManagerABI.Pack("setRewardSplit", output.Recipients, output.Weights)
+}
+
+// UnpackSetRewardSplitInput attempts to unpack [input] into the reward split.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetRewardSplitInput(input []byte) ([]RewardRecipient, error) {
+	inputStruct := RewardSplitOutput{}
+	if err := RewardManagerABI.UnpackInputIntoInterface(&inputStruct, "setRewardSplit", input, false); err != nil {
+		return nil, err
+	}
+	if len(inputStruct.Recipients) != len(inputStruct.Weights) {
+		return nil, fmt.Errorf("%w: %d recipients and %d weights", ErrInvalidRewardSplit, len(inputStruct.Recipients), len(inputStruct.Weights))
+	}
+	split := make([]RewardRecipient, len(inputStruct.Recipients))
+	for i, recipient := range inputStruct.Recipients {
+		weight := inputStruct.Weights[i]
+		if !weight.IsUint64() {
+			return nil, fmt.Errorf("%w: invalid weight %s for %s", ErrInvalidRewardSplit, weight, recipient)
+		}
+		split[i] = RewardRecipient{Address: recipient, Weight: weight.Uint64()}
+	}
+	return split, nil
+}
+
+func rewardSplitToOutput(split []RewardRecipient) RewardSplitOutput {
+	output := RewardSplitOutput{
+		Recipients: make([]common.Address, len(split)),
+		Weights:    make([]*big.Int, len(split)),
+	}
+	for i, recipient := range split {
+		output.Recipients[i] = recipient.Address
+		output.Weights[i] = new(big.Int).SetUint64(recipient.Weight)
+	}
+	return output
+}
+
+// setRewardSplit sets the recipients of the fees and their weights in basis points.
+// Fees are collected by the precompile and distributed at the end of each block.
+func setRewardSplit(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetRewardSplitGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+	split, err := UnpackSetRewardSplitInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	// Bound the number of recipients before charging for them.
+	if len(split) > MaxRewardSplitRecipients {
+		return nil, remainingGas, VerifyRewardSplit(split)
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, SetRewardSplitGasCostPerRecipient*uint64(len(split))); err != nil {
+		return nil, 0, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetRules().IsHeliconActivated(), accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetRewardSplit, caller)
+	}
+	if err := VerifyRewardSplit(split); err != nil {
+		return nil, remainingGas, err
+	}
+
+	topics, data, err := PackRewardSplitChangedEvent(caller, split)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, RewardSplitChangedEventGasCost(data)); err != nil {
+		return nil, 0, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+	StoreRewardSplit(stateDB, split)
+	// Return the packed output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackCurrentRewardSplit packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackCurrentRewardSplit() ([]byte, error) {
+	return RewardManagerABI.Pack("currentRewardSplit")
+}
+
+// PackCurrentRewardSplitOutput attempts to pack [split] to conform the ABI outputs.
+func PackCurrentRewardSplitOutput(split []RewardRecipient) ([]byte, error) {
+	output := rewardSplitToOutput(split)
+	return RewardManagerABI.PackOutput("currentRewardSplit", output.Recipients, output.Weights)
+}
+
+// UnpackCurrentRewardSplitOutput attempts to unpack [output] as RewardSplitOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackCurrentRewardSplitOutput(output []byte) (RewardSplitOutput, error) {
+	outputStruct := RewardSplitOutput{}
+	err := RewardManagerABI.UnpackIntoInterface(&outputStruct, "currentRewardSplit", output)
+	return outputStruct, err
+}
+
+// currentRewardSplit returns the recipients of the fees and their weights, which are
+// empty if fees are not split.
+func currentRewardSplit(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, CurrentRewardSplitGasCost); err != nil {
+		return nil, 0, err
+	}
+	split := GetRewardSplit(accessibleState.GetStateDB())
+	if remainingGas, err = contract.DeductGas(remainingGas, CurrentRewardSplitGasCostPerRecipient*uint64(len(split))); err != nil {
+		return nil, 0, err
+	}
+	packedOutput, err := PackCurrentRewardSplitOutput(split)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
@@ -13,9 +13,9 @@ import (
 // Singleton StatefulPrecompiledContract for W/R access to the tx allow list.
 var TxAllowListPrecompile contract.StatefulPrecompiledContract = allowlist.CreateAllowListPrecompile(ContractAddress)
 
-// GetTxAllowListStatus returns the role of [address] for the tx allow list.
-func GetTxAllowListStatus(stateDB contract.StateReader, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// GetTxAllowListStatus returns the role of [address] for the tx allow list at [timestamp].
+func GetTxAllowListStatus(stateDB contract.StateReader, address common.Address, isHelicon bool, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, isHelicon, timestamp)
 }
 
 // SetTxAllowListStatus sets the permissions of [address] to [role] for the
@@ -133,6 +133,9 @@ func (*Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash commo
 	if err := acceptCtx.Warp.AddMessage(unsignedMessage); err != nil {
 		return fmt.Errorf("failed to add warp message during accept (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
 	}
+	if err := acceptCtx.Warp.IndexMessage(blockNumber, blockHash, txHash, logIndex, unsignedMessage); err != nil {
+		return fmt.Errorf("failed to index warp message during accept (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
+	}
 	return nil
 }
 
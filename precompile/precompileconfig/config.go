@@ -56,6 +56,7 @@ type Predicater interface {
 
 type WarpMessageWriter interface {
 	AddMessage(unsignedMessage *warp.UnsignedMessage) error
+	IndexMessage(blockNumber uint64, blockHash common.Hash, txHash common.Hash, logIndex int, unsignedMessage *warp.UnsignedMessage) error
 }
 
 // AcceptContext defines the context passed in to a precompileconfig's Accepter
@@ -83,10 +84,13 @@ type ChainConfig interface {
 	AllowedFeeRecipients() bool
 	// IsDurango returns true if the time is after Durango.
 	IsDurango(time uint64) bool
+	// IsHelicon returns true if the time is after Helicon.
+	IsHelicon(time uint64) bool
 }
 
 // Rules defines the interface that provides information about the current rules of the chain.
 type Rules interface {
+	IsHeliconActivated() bool
 	IsGraniteActivated() bool
 	IsDurangoActivated() bool
 }
@@ -178,6 +178,7 @@ func (test PrecompileTest) setup(t testing.TB, module modules.Module, state *tes
 			mockChainConfig.EXPECT().GetFeeConfig().AnyTimes().Return(commontype.ValidTestFeeConfig)
 			mockChainConfig.EXPECT().AllowedFeeRecipients().AnyTimes().Return(false)
 			mockChainConfig.EXPECT().IsDurango(gomock.Any()).AnyTimes().Return(true)
+			mockChainConfig.EXPECT().IsHelicon(gomock.Any()).AnyTimes().Return(test.Rules.IsHelicon)
 			return mockChainConfig
 		}
 	}
@@ -7,8 +7,10 @@ package registry
 // Force imports of each precompile to ensure each precompile's init function runs and registers itself
 // with the registry.
 import (
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/callallowlist"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/feesponsor"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
@@ -33,5 +35,7 @@ import (
 // FeeManagerAddress                = common.HexToAddress("0x0200000000000000000000000000000000000003")
 // RewardManagerAddress             = common.HexToAddress("0x0200000000000000000000000000000000000004")
 // WarpAddress                      = common.HexToAddress("0x0200000000000000000000000000000000000005")
+// CallAllowListAddress             = common.HexToAddress("0x0200000000000000000000000000000000000006")
+// FeeSponsorAddress                = common.HexToAddress("0x0200000000000000000000000000000000000007")
 // ADD YOUR PRECOMPILE HERE
 // {YourPrecompile}Address          = common.HexToAddress("0x03000000000000000000000000000000000000??")
@@ -25,6 +25,7 @@ import (
 	"github.com/ava-labs/subnet-evm/network"
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	"github.com/ava-labs/subnet-evm/sync/client/stats"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
 
 	ethparams "github.com/ava-labs/libevm/params"
 )
@@ -65,6 +66,11 @@ type Client interface {
 
 	// GetCode synchronously retrieves code associated with the given hashes
 	GetCode(ctx context.Context, hashes []common.Hash) ([][]byte, error)
+
+	// GetFirewoodLeafs synchronously sends the given request, returning the serialized range proof
+	// Note: the range proof is only verified against the requested root once Firewood implements
+	// verification, see [firewood.RangeProofsVerified].
+	GetFirewoodLeafs(ctx context.Context, request message.FirewoodLeafsRequest) ([]byte, error)
 }
 
 // parseResponseFn parses given response bytes in context of specified request
@@ -183,6 +189,37 @@ func parseLeafsResponse(codec codec.Manager, reqIntf message.Request, data []byt
 	return leafsResponse, len(leafsResponse.Keys), nil
 }
 
+// GetFirewoodLeafs synchronously retrieves a range proof as per given [message.FirewoodLeafsRequest]
+// Retries when:
+// - response bytes could not be unmarshalled to [message.FirewoodLeafsResponse]
+// - response does not contain a valid range proof.
+func (c *client) GetFirewoodLeafs(ctx context.Context, req message.FirewoodLeafsRequest) ([]byte, error) {
+	data, err := c.get(ctx, req, parseFirewoodLeafsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.([]byte), nil
+}
+
+// parseFirewoodLeafsResponse validates given object as message.FirewoodLeafsResponse
+// assumes reqIntf is of type message.FirewoodLeafsRequest
+// returns the serialized range proof and its size in bytes
+// returns a non-nil error if the request should be retried
+func parseFirewoodLeafsResponse(codec codec.Manager, reqIntf message.Request, data []byte) (interface{}, int, error) {
+	var leafsResponse message.FirewoodLeafsResponse
+	if _, err := codec.Unmarshal(data, &leafsResponse); err != nil {
+		return nil, 0, err
+	}
+
+	leafsRequest := reqIntf.(message.FirewoodLeafsRequest)
+	if err := firewood.VerifyRangeProof(leafsResponse.Proof, leafsRequest.Root, leafsRequest.Start, uint32(leafsRequest.Limit)); err != nil {
+		return nil, 0, fmt.Errorf("%w due to %w", errInvalidRangeProof, err)
+	}
+
+	return leafsResponse.Proof, len(leafsResponse.Proof), nil
+}
+
 func (c *client) GetBlocks(ctx context.Context, hash common.Hash, height uint64, parents uint16) ([]*types.Block, error) {
 	req := message.BlockRequest{
 		Hash:    hash,
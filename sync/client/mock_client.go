@@ -32,6 +32,9 @@ type MockClient struct {
 	codeReceived   int32
 	blocksHandler  *handlers.BlockRequestHandler
 	blocksReceived int32
+	// FirewoodLeafsHandler serves GetFirewoodLeafs requests. It is only
+	// needed when syncing into a Firewood database.
+	FirewoodLeafsHandler *handlers.FirewoodLeafsRequestHandler
 	// GetLeafsIntercept is called on every GetLeafs request if set to a non-nil callback.
 	// The returned response will be returned by MockClient to the caller.
 	GetLeafsIntercept func(req message.LeafsRequest, res message.LeafsResponse) (message.LeafsResponse, error)
@@ -139,6 +142,22 @@ func (ml *MockClient) BlocksReceived() int32 {
 	return atomic.LoadInt32(&ml.blocksReceived)
 }
 
+func (ml *MockClient) GetFirewoodLeafs(ctx context.Context, request message.FirewoodLeafsRequest) ([]byte, error) {
+	if ml.FirewoodLeafsHandler == nil {
+		panic("no firewood leafs handler for mock client")
+	}
+	response, err := ml.FirewoodLeafsHandler.OnFirewoodLeafsRequest(ctx, ids.GenerateTestNodeID(), 1, request)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, _, err := parseFirewoodLeafsResponse(ml.codec, request, response)
+	if err != nil {
+		return nil, err
+	}
+	return proof.([]byte), nil
+}
+
 type testBlockParser struct{}
 
 func (*testBlockParser) ParseEthBlock(b []byte) (*types.Block, error) {
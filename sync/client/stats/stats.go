@@ -76,9 +76,10 @@ func (m *messageMetric) UpdateRequestLatency(duration time.Duration) {
 }
 
 type clientSyncerStats struct {
-	leafMetrics        map[message.NodeType]MessageMetric
-	codeRequestMetric  MessageMetric
-	blockRequestMetric MessageMetric
+	leafMetrics                map[message.NodeType]MessageMetric
+	codeRequestMetric          MessageMetric
+	blockRequestMetric         MessageMetric
+	firewoodLeafsRequestMetric MessageMetric
 }
 
 // NewClientSyncerStats returns stats for the client syncer
@@ -88,9 +89,10 @@ func NewClientSyncerStats(leafMetricNames map[message.NodeType]string) *clientSy
 		leafMetrics[nodeType] = NewMessageMetric(name)
 	}
 	return &clientSyncerStats{
-		leafMetrics:        leafMetrics,
-		codeRequestMetric:  NewMessageMetric("sync_code"),
-		blockRequestMetric: NewMessageMetric("sync_blocks"),
+		leafMetrics:                leafMetrics,
+		codeRequestMetric:          NewMessageMetric("sync_code"),
+		blockRequestMetric:         NewMessageMetric("sync_blocks"),
+		firewoodLeafsRequestMetric: NewMessageMetric("sync_firewood_leaves"),
 	}
 }
 
@@ -107,6 +109,8 @@ func (c *clientSyncerStats) GetMetric(msgIntf message.Request) (MessageMetric, e
 			return nil, fmt.Errorf("invalid leafs request for node type: %T", msg.NodeType)
 		}
 		return metric, nil
+	case message.FirewoodLeafsRequest:
+		return c.firewoodLeafsRequestMetric, nil
 	default:
 		return nil, fmt.Errorf("attempted to get metric for invalid request with type %T", msg)
 	}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/log"
+
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ava-labs/subnet-evm/sync/handlers/stats"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
+)
+
+// FirewoodLeafsRequestHandler is a peer.RequestHandler for message.FirewoodLeafsRequest
+// serving range proofs of Firewood revisions
+type FirewoodLeafsRequestHandler struct {
+	db    *firewood.Database
+	codec codec.Manager
+	stats stats.LeafsRequestHandlerStats
+}
+
+func NewFirewoodLeafsRequestHandler(db *firewood.Database, codec codec.Manager, syncerStats stats.LeafsRequestHandlerStats) *FirewoodLeafsRequestHandler {
+	return &FirewoodLeafsRequestHandler{
+		db:    db,
+		codec: codec,
+		stats: syncerStats,
+	}
+}
+
+// OnFirewoodLeafsRequest returns encoded message.FirewoodLeafsResponse for a given message.FirewoodLeafsRequest
+// Returns a range proof of the leaves of the requested revision from Start
+// Specified Limit in message.FirewoodLeafsRequest is overridden to maxLeavesLimit if it is greater than maxLeavesLimit
+// Expects returned errors to be treated as FATAL
+// Never returns errors
+// Returns nothing if the request is invalid or the requested revision is not available
+func (h *FirewoodLeafsRequestHandler) OnFirewoodLeafsRequest(_ context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest message.FirewoodLeafsRequest) ([]byte, error) {
+	startTime := time.Now()
+	h.stats.IncLeafsRequest()
+	defer func() {
+		h.stats.UpdateLeafsRequestProcessingTime(time.Since(startTime))
+	}()
+
+	if leafsRequest.Root == (common.Hash{}) ||
+		leafsRequest.Root == types.EmptyRootHash ||
+		leafsRequest.Limit < firewood.MinRangeProofLength {
+		log.Debug("invalid firewood leafs request, dropping request", "nodeID", nodeID, "requestID", requestID, "request", leafsRequest)
+		h.stats.IncInvalidLeafsRequest()
+		return nil, nil
+	}
+
+	// Revisions are only retained for a limited number of blocks unless the
+	// node is an archive node.
+	if _, err := h.db.Reader(leafsRequest.Root); err != nil {
+		log.Debug("firewood revision not available, dropping request", "nodeID", nodeID, "requestID", requestID, "root", leafsRequest.Root, "err", err)
+		h.stats.IncMissingRoot()
+		return nil, nil
+	}
+
+	// override limit if it is greater than the configured maxLeavesLimit
+	limit := leafsRequest.Limit
+	if limit > maxLeavesLimit {
+		limit = maxLeavesLimit
+	}
+
+	proofStart := time.Now()
+	proof, err := h.db.RangeProof(leafsRequest.Root, leafsRequest.Start, uint32(limit))
+	h.stats.UpdateGenerateRangeProofTime(time.Since(proofStart))
+	if err != nil {
+		log.Debug("failed to generate firewood range proof, dropping request", "nodeID", nodeID, "requestID", requestID, "request", leafsRequest, "err", err)
+		h.stats.IncProofError()
+		return nil, nil
+	}
+
+	responseBytes, err := h.codec.Marshal(message.Version, message.FirewoodLeafsResponse{Proof: proof})
+	if err != nil {
+		log.Error("could not marshal FirewoodLeafsResponse, dropping request", "nodeID", nodeID, "requestID", requestID, "request", leafsRequest, "err", err)
+		return nil, nil
+	}
+	return responseBytes, nil
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ava-labs/subnet-evm/sync/handlers/stats"
+	"github.com/ava-labs/subnet-evm/sync/statesync/statesynctest"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
+)
+
+func TestFirewoodLeafsRequestHandler_OnFirewoodLeafsRequest(t *testing.T) {
+	fwState := statesynctest.NewFirewoodState(t)
+	root := fwState.Commit(t, func(statedb *state.StateDB) {
+		for i := 0; i < 2000; i++ {
+			statedb.SetBalance(common.BigToAddress(big.NewInt(int64(i+1))), uint256.NewInt(uint64(i+1)))
+		}
+	})
+	mockHandlerStats := &stats.MockHandlerStats{}
+	handler := NewFirewoodLeafsRequestHandler(fwState.Firewood, message.Codec, mockHandlerStats)
+
+	tests := map[string]struct {
+		request     message.FirewoodLeafsRequest
+		expectProof bool
+		assertStats func(t *testing.T)
+	}{
+		"empty root dropped": {
+			request: message.FirewoodLeafsRequest{Root: types.EmptyRootHash, Limit: 100},
+			assertStats: func(t *testing.T) {
+				require.Equal(t, uint32(1), mockHandlerStats.InvalidLeafsRequestCount)
+			},
+		},
+		"limit too small dropped": {
+			request: message.FirewoodLeafsRequest{Root: root, Limit: firewood.MinRangeProofLength - 1},
+			assertStats: func(t *testing.T) {
+				require.Equal(t, uint32(1), mockHandlerStats.InvalidLeafsRequestCount)
+			},
+		},
+		"missing root dropped": {
+			request: message.FirewoodLeafsRequest{Root: common.Hash{1}, Limit: 100},
+			assertStats: func(t *testing.T) {
+				require.Equal(t, uint32(1), mockHandlerStats.MissingRootCount)
+			},
+		},
+		"first range": {
+			request:     message.FirewoodLeafsRequest{Root: root, Limit: 100},
+			expectProof: true,
+		},
+		"range from start key": {
+			request:     message.FirewoodLeafsRequest{Root: root, Start: common.FromHex("0x80"), Limit: 100},
+			expectProof: true,
+		},
+		"limit capped": {
+			request:     message.FirewoodLeafsRequest{Root: root, Limit: maxLeavesLimit + 1},
+			expectProof: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockHandlerStats.Reset()
+			responseBytes, err := handler.OnFirewoodLeafsRequest(context.Background(), ids.GenerateTestNodeID(), 1, test.request)
+			require.NoError(t, err)
+			require.Equal(t, uint32(1), mockHandlerStats.LeafsRequestCount)
+			if test.assertStats != nil {
+				test.assertStats(t)
+			}
+			if !test.expectProof {
+				require.Nil(t, responseBytes)
+				return
+			}
+
+			var response message.FirewoodLeafsResponse
+			_, err = message.Codec.Unmarshal(responseBytes, &response)
+			require.NoError(t, err)
+			limit := min(test.request.Limit, maxLeavesLimit)
+			require.NoError(t, firewood.VerifyRangeProof(response.Proof, test.request.Root, test.request.Start, uint32(limit)))
+		})
+	}
+}
@@ -6,6 +6,7 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -65,6 +66,32 @@ func NewLeafsRequestHandler(trieDB *triedb.Database, trieKeyLength int, snapshot
 	}
 }
 
+// stateRootsProvider is implemented by the path-based trie database, which
+// needs the state root of a storage trie to read its nodes.
+type stateRootsProvider interface {
+	StateRoots() []common.Hash
+}
+
+// openTrie opens the trie requested by [leafsRequest]. The request does not
+// include the state root of a storage trie, so with the path-based trie
+// database, the storage trie is opened in the first retained state where the
+// root of the storage trie of [leafsRequest.Account] matches.
+func (lrh *leafsRequestHandler) openTrie(leafsRequest message.LeafsRequest) (*trie.Trie, error) {
+	provider, ok := lrh.trieDB.Backend().(stateRootsProvider)
+	if !ok || leafsRequest.Account == (common.Hash{}) {
+		return trie.New(trie.TrieID(leafsRequest.Root), lrh.trieDB)
+	}
+	var err error
+	for _, stateRoot := range provider.StateRoots() {
+		var t *trie.Trie
+		t, err = trie.New(trie.StorageTrieID(stateRoot, leafsRequest.Account, leafsRequest.Root), lrh.trieDB)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("storage trie %s of account %s not found: %w", leafsRequest.Root, leafsRequest.Account, err)
+}
+
 // OnLeafsRequest returns encoded message.LeafsResponse for a given message.LeafsRequest
 // Returns leaves with proofs for specified (Start-End) (both inclusive) ranges
 // Returned message.LeafsResponse may contain partial leaves within requested Start and End range if:
@@ -94,11 +121,7 @@ func (lrh *leafsRequestHandler) OnLeafsRequest(ctx context.Context, nodeID ids.N
 		return nil, nil
 	}
 
-	// TODO: We should know the state root that accounts correspond to,
-	// as this information will be necessary to access storage tries when
-	// the trie is path based.
-	// stateRoot := common.Hash{}
-	t, err := trie.New(trie.TrieID(leafsRequest.Root), lrh.trieDB)
+	t, err := lrh.openTrie(leafsRequest)
 	if err != nil {
 		log.Debug("error opening trie when processing request, dropping request", "nodeID", nodeID, "requestID", requestID, "root", leafsRequest.Root, "err", err)
 		lrh.stats.IncMissingRoot()
@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/ethdb"
+	"github.com/ava-labs/libevm/log"
+	"github.com/ava-labs/libevm/rlp"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
+
+	syncclient "github.com/ava-labs/subnet-evm/sync/client"
+)
+
+const firewoodSyncLogInterval = 30 * time.Second
+
+type FirewoodSyncerConfig struct {
+	Root                     common.Hash
+	Client                   syncclient.Client
+	DB                       ethdb.Database     // database code and sync progress are written to
+	Firewood                 *firewood.Database // database the leaves are committed to
+	MaxOutstandingCodeHashes int                // Maximum number of code hashes in the code syncer queue
+	NumCodeFetchingWorkers   int                // Number of code syncing threads
+	RequestSize              uint16             // Number of leafs to request from a peer at a time
+}
+
+// firewoodSync syncs the state at a root into a Firewood database.
+//
+// Firewood stores accounts and storage slots in a single key space, so the
+// whole state is fetched as a sequence of range proofs, each starting at the
+// last key of the previous one. Each proof is committed as it is received,
+// removing any leaves of the database in its range that are not part of the
+// synced state, and the sync is complete once the root of the database
+// matches the synced root.
+type firewoodSync struct {
+	db          ethdb.Database
+	fw          *firewood.Database
+	root        common.Hash
+	client      syncclient.Client
+	requestSize uint16
+	codeSyncer  *codeSyncer
+
+	done       chan error
+	cancelFunc context.CancelFunc
+}
+
+func NewFirewoodSyncer(config *FirewoodSyncerConfig) (*firewoodSync, error) {
+	if config.RequestSize < firewood.MinRangeProofLength {
+		return nil, fmt.Errorf("request size %d must be at least %d for firewood state sync", config.RequestSize, firewood.MinRangeProofLength)
+	}
+	return &firewoodSync{
+		db:          config.DB,
+		fw:          config.Firewood,
+		root:        config.Root,
+		client:      config.Client,
+		requestSize: config.RequestSize,
+		codeSyncer: newCodeSyncer(CodeSyncerConfig{
+			DB:                       config.DB,
+			Client:                   config.Client,
+			MaxOutstandingCodeHashes: config.MaxOutstandingCodeHashes,
+			NumCodeFetchingWorkers:   config.NumCodeFetchingWorkers,
+		}),
+		done: make(chan error, 1),
+	}, nil
+}
+
+func (f *firewoodSync) Start(ctx context.Context) error {
+	syncCtx, cancel := context.WithCancel(ctx)
+	f.cancelFunc = cancel
+
+	eg, egCtx := errgroup.WithContext(syncCtx)
+	f.codeSyncer.start(egCtx) // start the code syncer first since the leaves may add code tasks
+	eg.Go(func() error {
+		if err := f.syncLeaves(egCtx); err != nil {
+			return err
+		}
+		f.codeSyncer.notifyAccountTrieCompleted()
+		return nil
+	})
+	eg.Go(func() error {
+		return <-f.codeSyncer.Done()
+	})
+
+	go func() {
+		f.done <- eg.Wait()
+	}()
+	return nil
+}
+
+func (f *firewoodSync) Wait(ctx context.Context) error {
+	// This should only be called after Start, so we can assume cancelFunc is set.
+	if f.cancelFunc == nil {
+		return errWaitBeforeStart
+	}
+
+	select {
+	case err := <-f.done:
+		return err
+	case <-ctx.Done():
+		f.cancelFunc() // cancel the sync operations if the context is done
+		<-f.done       // wait for the sync operations to finish
+		return ctx.Err()
+	}
+}
+
+// syncLeaves fetches and commits range proofs until the Firewood database
+// matches the synced root, resuming from the progress of a previous sync to
+// the same root if any.
+func (f *firewoodSync) syncLeaves(ctx context.Context) error {
+	persistedRoot, start, err := customrawdb.ReadFirewoodSyncProgress(f.db)
+	if err != nil {
+		return err
+	}
+	if persistedRoot != f.root {
+		// Leaves from the previous sync are replaced as the range proofs
+		// of the new root are committed, so there is no need to clear them.
+		start = nil
+	} else {
+		log.Info("resuming firewood state sync", "root", f.root, "start", common.Bytes2Hex(start))
+	}
+
+	var (
+		requests = 0
+		lastLog  = time.Now()
+	)
+	for {
+		proof, err := f.client.GetFirewoodLeafs(ctx, message.FirewoodLeafsRequest{
+			Root:  f.root,
+			Start: start,
+			Limit: f.requestSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch leaves from %x: %w", start, err)
+		}
+		next, err := f.fw.CommitRangeProof(proof, f.root, start, uint32(f.requestSize))
+		if err != nil {
+			return err
+		}
+		if err := f.addCode(start, next); err != nil {
+			return err
+		}
+		requests++
+
+		if next == nil {
+			log.Info("firewood state sync leaves complete", "root", f.root, "requests", requests)
+			return customrawdb.DeleteFirewoodSyncProgress(f.db)
+		}
+		if err := customrawdb.WriteFirewoodSyncProgress(f.db, f.root, next); err != nil {
+			return err
+		}
+		if time.Since(lastLog) > firewoodSyncLogInterval {
+			log.Info("firewood state sync in progress", "root", f.root, "requests", requests, "next", common.Bytes2Hex(next))
+			lastLog = time.Now()
+		}
+		start = next
+	}
+}
+
+// addCode queues the code of the accounts committed from [start] to [end]
+// (exclusive) to be fetched. A nil [end] reads to the last account.
+func (f *firewoodSync) addCode(start, end []byte) error {
+	var codeHashes []common.Hash
+	err := f.fw.IterateLeaves(start, end, func(key, value []byte) error {
+		// Storage keys are prefixed with the account hash, so only keys of
+		// the account hash length are accounts.
+		if len(key) != common.HashLength {
+			return nil
+		}
+		var account types.StateAccount
+		if err := rlp.DecodeBytes(value, &account); err != nil {
+			return fmt.Errorf("failed to decode account %x: %w", key, err)
+		}
+		if codeHash := common.BytesToHash(account.CodeHash); codeHash != types.EmptyCodeHash {
+			codeHashes = append(codeHashes, codeHash)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+	return f.codeSyncer.addCode(codeHashes)
+}
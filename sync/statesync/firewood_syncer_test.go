@@ -0,0 +1,176 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ava-labs/subnet-evm/sync/handlers"
+	"github.com/ava-labs/subnet-evm/sync/statesync/statesynctest"
+
+	statesyncclient "github.com/ava-labs/subnet-evm/sync/client"
+	handlerstats "github.com/ava-labs/subnet-evm/sync/handlers/stats"
+)
+
+// interruptedFirewoodClient fails GetFirewoodLeafs after [limit] requests and
+// records the start key of each request.
+type interruptedFirewoodClient struct {
+	*statesyncclient.MockClient
+	limit  int
+	starts [][]byte
+}
+
+func (c *interruptedFirewoodClient) GetFirewoodLeafs(ctx context.Context, request message.FirewoodLeafsRequest) ([]byte, error) {
+	if len(c.starts) == c.limit {
+		return nil, errInterrupted
+	}
+	c.starts = append(c.starts, request.Start)
+	return c.MockClient.GetFirewoodLeafs(ctx, request)
+}
+
+func fillFirewoodState(t *testing.T, fwState *statesynctest.FirewoodState, numAccounts int, salt byte) (common.Hash, []common.Hash) {
+	var codeHashes []common.Hash
+	root := fwState.Commit(t, func(statedb *state.StateDB) {
+		for i := 0; i < numAccounts; i++ {
+			addr := common.BigToAddress(big.NewInt(int64(i + 1)))
+			statedb.SetBalance(addr, uint256.NewInt(uint64(i)+uint64(salt)))
+			if i%10 == 0 {
+				statedb.SetState(addr, common.Hash{salt}, common.BigToHash(big.NewInt(int64(i+1))))
+				statedb.SetState(addr, common.Hash{salt, 1}, common.Hash{salt})
+			}
+			if i%25 == 0 {
+				code := []byte{salt, byte(i), byte(i >> 8)}
+				statedb.SetCode(addr, code)
+				codeHashes = append(codeHashes, crypto.Keccak256Hash(code))
+			}
+		}
+	})
+	return root, codeHashes
+}
+
+func newFirewoodTestClient(serverState *statesynctest.FirewoodState) *statesyncclient.MockClient {
+	codeRequestHandler := handlers.NewCodeRequestHandler(serverState.DiskDB, message.Codec, handlerstats.NewNoopHandlerStats())
+	mockClient := statesyncclient.NewMockClient(message.Codec, nil, codeRequestHandler, nil)
+	mockClient.FirewoodLeafsHandler = handlers.NewFirewoodLeafsRequestHandler(serverState.Firewood, message.Codec, handlerstats.NewNoopHandlerStats())
+	return mockClient
+}
+
+func TestFirewoodSync(t *testing.T) {
+	tests := map[string]struct {
+		// clientAccounts are committed to the client before syncing, so stale
+		// leaves must be removed by the sync.
+		clientAccounts int
+	}{
+		"empty client":      {},
+		"stale client":      {clientAccounts: 300},
+		"stale client tail": {clientAccounts: 1000},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			serverState := statesynctest.NewFirewoodState(t)
+			root, codeHashes := fillFirewoodState(t, serverState, 500, 1)
+
+			clientState := statesynctest.NewFirewoodState(t)
+			if test.clientAccounts > 0 {
+				fillFirewoodState(t, clientState, test.clientAccounts, 2)
+			}
+
+			syncer, err := NewFirewoodSyncer(&FirewoodSyncerConfig{
+				Client:                   newFirewoodTestClient(serverState),
+				Root:                     root,
+				DB:                       clientState.DiskDB,
+				Firewood:                 clientState.Firewood,
+				MaxOutstandingCodeHashes: DefaultMaxOutstandingCodeHashes,
+				NumCodeFetchingWorkers:   DefaultNumCodeFetchingWorkers,
+				RequestSize:              32, // Use a small request size to commit many proofs.
+			})
+			require.NoError(err)
+			require.NoError(syncer.Start(t.Context()))
+			waitFor(t, t.Context(), syncer.Wait, nil, testSyncTimeout)
+
+			// The client state matches the server state.
+			clientStateDB, err := state.New(root, clientState.StateDB, nil)
+			require.NoError(err)
+			serverStateDB, err := state.New(root, serverState.StateDB, nil)
+			require.NoError(err)
+			for i := 0; i < 500; i++ {
+				addr := common.BigToAddress(big.NewInt(int64(i + 1)))
+				require.Equal(serverStateDB.GetBalance(addr), clientStateDB.GetBalance(addr))
+				require.Equal(serverStateDB.GetState(addr, common.Hash{1}), clientStateDB.GetState(addr, common.Hash{1}))
+				require.Equal(serverStateDB.GetCode(addr), clientStateDB.GetCode(addr))
+			}
+			for _, codeHash := range codeHashes {
+				require.True(rawdb.HasCode(clientState.DiskDB, codeHash))
+			}
+			progressRoot, _, err := customrawdb.ReadFirewoodSyncProgress(clientState.DiskDB)
+			require.NoError(err)
+			require.Equal(common.Hash{}, progressRoot)
+
+			// Blocks can be committed on top of the synced state.
+			clientState.CommitAt(t, root, func(statedb *state.StateDB) {
+				statedb.SetBalance(common.Address{1}, uint256.NewInt(1))
+			})
+		})
+	}
+}
+
+func TestFirewoodSyncResume(t *testing.T) {
+	require := require.New(t)
+
+	serverState := statesynctest.NewFirewoodState(t)
+	root, _ := fillFirewoodState(t, serverState, 500, 1)
+	clientState := statesynctest.NewFirewoodState(t)
+
+	newSyncer := func(client statesyncclient.Client) *firewoodSync {
+		syncer, err := NewFirewoodSyncer(&FirewoodSyncerConfig{
+			Client:                   client,
+			Root:                     root,
+			DB:                       clientState.DiskDB,
+			Firewood:                 clientState.Firewood,
+			MaxOutstandingCodeHashes: DefaultMaxOutstandingCodeHashes,
+			NumCodeFetchingWorkers:   DefaultNumCodeFetchingWorkers,
+			RequestSize:              32,
+		})
+		require.NoError(err)
+		return syncer
+	}
+
+	interrupted := &interruptedFirewoodClient{MockClient: newFirewoodTestClient(serverState), limit: 5}
+	syncer := newSyncer(interrupted)
+	require.NoError(syncer.Start(t.Context()))
+	err := syncer.Wait(t.Context())
+	require.ErrorIs(err, errInterrupted)
+
+	progressRoot, next, err := customrawdb.ReadFirewoodSyncProgress(clientState.DiskDB)
+	require.NoError(err)
+	require.Equal(root, progressRoot)
+	require.NotEmpty(next)
+
+	resumed := &interruptedFirewoodClient{MockClient: newFirewoodTestClient(serverState), limit: -1}
+	syncer = newSyncer(resumed)
+	require.NoError(syncer.Start(t.Context()))
+	waitFor(t, t.Context(), syncer.Wait, nil, testSyncTimeout)
+	require.Equal(next, resumed.starts[0])
+
+	_, err = clientState.Firewood.Reader(root)
+	require.NoError(err)
+}
+
+func TestFirewoodSyncerRequestSize(t *testing.T) {
+	_, err := NewFirewoodSyncer(&FirewoodSyncerConfig{RequestSize: 1})
+	require.ErrorContains(t, err, "request size")
+}
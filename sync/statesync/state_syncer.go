@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
 	"github.com/ava-labs/libevm/ethdb"
 	"github.com/ava-labs/libevm/triedb"
 	"golang.org/x/sync/errgroup"
@@ -36,11 +37,13 @@ type StateSyncerConfig struct {
 	MaxOutstandingCodeHashes int    // Maximum number of code hashes in the code syncer queue
 	NumCodeFetchingWorkers   int    // Number of code syncing threads
 	RequestSize              uint16 // Number of leafs to request from a peer at a time
+	Scheme                   string // Scheme of the trie nodes written to DB, defaults to rawdb.HashScheme
 }
 
 // stateSync keeps the state of the entire state sync operation.
 type stateSync struct {
 	db        ethdb.Database            // database we are syncing
+	scheme    string                    // scheme of the trie nodes written to [db]
 	root      common.Hash               // root of the EVM state we are syncing to
 	trieDB    *triedb.Database          // trieDB on top of db we are syncing. used to restore any existing tries.
 	snapshot  snapshot.SnapshotIterable // used to access the database we are syncing as a snapshot.
@@ -70,9 +73,14 @@ type stateSync struct {
 }
 
 func NewStateSyncer(config *StateSyncerConfig) (*stateSync, error) {
+	scheme := config.Scheme
+	if scheme == "" {
+		scheme = rawdb.HashScheme
+	}
 	ss := &stateSync{
 		batchSize:       config.BatchSize,
 		db:              config.DB,
+		scheme:          scheme,
 		root:            config.Root,
 		trieDB:          triedb.NewDatabase(config.DB, nil),
 		snapshot:        snapshot.NewDiskLayer(config.DB),
@@ -106,7 +114,7 @@ func NewStateSyncer(config *StateSyncerConfig) (*stateSync, error) {
 
 	// create a trieToSync for the main trie and mark it as in progress.
 	var err error
-	ss.mainTrie, err = NewTrieToSync(ss, ss.root, common.Hash{}, NewMainTrieTask(ss))
+	ss.mainTrie, err = NewTrieToSync(ss, ss.root, []common.Hash{{}}, NewMainTrieTask(ss))
 	if err != nil {
 		return nil, err
 	}
@@ -200,13 +208,10 @@ func (t *stateSync) storageTrieProducer(ctx context.Context) error {
 			return ctx.Err()
 		}
 
-		// Arbitrarily use the first account for making requests to the server.
+		// create a trieToSync for the storage trie and mark it as in progress.
 		// Note: getNextTrie guarantees that if a non-nil storage root is returned, then the
 		// slice of account hashes is non-empty.
-		syncAccount := accounts[0]
-
-		// create a trieToSync for the storage trie and mark it as in progress.
-		storageTrie, err := NewTrieToSync(t, root, syncAccount, NewStorageTrieTask(t, root, accounts))
+		storageTrie, err := NewTrieToSync(t, root, accounts, NewStorageTrieTask(t, root, accounts))
 		if err != nil {
 			return err
 		}
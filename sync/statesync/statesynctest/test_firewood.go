@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesynctest
+
+import (
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/ethdb"
+	"github.com/ava-labs/libevm/libevm/stateconf"
+	"github.com/ava-labs/libevm/triedb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
+)
+
+// FirewoodState is a Firewood database in a temporary directory, along with
+// the database code is written to.
+type FirewoodState struct {
+	Config   firewood.Config
+	DiskDB   ethdb.Database
+	StateDB  state.Database
+	Firewood *firewood.Database
+
+	root   common.Hash
+	blocks uint64
+}
+
+// NewFirewoodState returns an empty Firewood database, closed when the test ends.
+func NewFirewoodState(t *testing.T) *FirewoodState {
+	config := firewood.Defaults
+	config.ChainDataDir = t.TempDir()
+	diskDB := rawdb.NewMemoryDatabase()
+	stateDB := extstate.NewDatabaseWithConfig(diskDB, &triedb.Config{
+		DBOverride: config.BackendConstructor,
+	})
+	t.Cleanup(func() {
+		require.NoError(t, stateDB.TrieDB().Close())
+	})
+	return &FirewoodState{
+		Config:   config,
+		DiskDB:   diskDB,
+		StateDB:  stateDB,
+		Firewood: stateDB.TrieDB().Backend().(*firewood.Database),
+		root:     types.EmptyRootHash,
+	}
+}
+
+// Commit applies [fn] to the latest state and commits the result as the next
+// block, returning its root.
+func (f *FirewoodState) Commit(t *testing.T, fn func(*state.StateDB)) common.Hash {
+	return f.CommitAt(t, f.root, fn)
+}
+
+// CommitAt applies [fn] to the state at [parent], which must be the root of
+// the database, and commits the result as the next block, returning its root.
+func (f *FirewoodState) CommitAt(t *testing.T, parent common.Hash, fn func(*state.StateDB)) common.Hash {
+	root := f.Propose(t, parent, fn)
+	require.NoError(t, f.StateDB.TrieDB().Commit(root, false))
+	f.root = root
+	return root
+}
+
+// Propose applies [fn] to the state at [parent], which must be the root of
+// the database, and proposes the result as the next block without committing
+// it, returning its root.
+func (f *FirewoodState) Propose(t *testing.T, parent common.Hash, fn func(*state.StateDB)) common.Hash {
+	statedb, err := state.New(parent, f.StateDB, nil)
+	require.NoError(t, err)
+	fn(statedb)
+
+	f.blocks++
+	triedbOpt := stateconf.WithTrieDBUpdatePayload(common.Hash{byte(f.blocks - 1)}, common.Hash{byte(f.blocks)})
+	root, err := statedb.Commit(f.blocks, true, stateconf.WithTrieDBUpdateOpts(triedbOpt))
+	require.NoError(t, err)
+	return root
+}
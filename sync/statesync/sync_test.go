@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/libevm/ethdb"
 	"github.com/ava-labs/libevm/rlp"
 	"github.com/ava-labs/libevm/trie"
+	"github.com/ava-labs/libevm/trie/trienode"
 	"github.com/ava-labs/libevm/triedb"
 	"github.com/stretchr/testify/require"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	"github.com/ava-labs/subnet-evm/sync/handlers"
 	"github.com/ava-labs/subnet-evm/sync/statesync/statesynctest"
+	"github.com/ava-labs/subnet-evm/triedb/pathdb"
 
 	statesyncclient "github.com/ava-labs/subnet-evm/sync/client"
 	handlerstats "github.com/ava-labs/subnet-evm/sync/handlers/stats"
@@ -568,3 +570,159 @@ func TestDifferentWaitContext(t *testing.T) {
 	requestsAfterWait := atomic.LoadInt64(&requestCount)
 	require.Equal(t, requestsWhenWaitReturned, requestsAfterWait, "Sync should not continue after Wait returned with different context")
 }
+
+func TestSyncPathScheme(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	serverDB := rawdb.NewMemoryDatabase()
+	serverTrieDB := triedb.NewDatabase(serverDB, nil)
+	root, _ := statesynctest.FillAccountsWithOverlappingStorage(t, r, serverTrieDB, common.Hash{}, 1000, 3)
+
+	leafsRequestHandler := handlers.NewLeafsRequestHandler(serverTrieDB, message.StateTrieKeyLength, nil, message.Codec, handlerstats.NewNoopHandlerStats())
+	codeRequestHandler := handlers.NewCodeRequestHandler(serverDB, message.Codec, handlerstats.NewNoopHandlerStats())
+	mockClient := statesyncclient.NewMockClient(message.Codec, leafsRequestHandler, codeRequestHandler, nil)
+
+	clientDB := rawdb.NewMemoryDatabase()
+	s, err := NewStateSyncer(&StateSyncerConfig{
+		Client:                   mockClient,
+		Root:                     root,
+		DB:                       clientDB,
+		BatchSize:                1000,
+		NumCodeFetchingWorkers:   DefaultNumCodeFetchingWorkers,
+		MaxOutstandingCodeHashes: DefaultMaxOutstandingCodeHashes,
+		RequestSize:              1024,
+		Scheme:                   rawdb.PathScheme,
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Start(t.Context()))
+	waitFor(t, t.Context(), s.Wait, nil, testSyncTimeout)
+
+	// Storage tries are read by owner in the path scheme, so each account
+	// sharing a storage root must have its own copy of the trie nodes.
+	clientTrieDB := triedb.NewDatabase(clientDB, &triedb.Config{DBOverride: pathdb.Defaults.BackendConstructor})
+	defer clientTrieDB.Close()
+	accountTrie, err := trie.New(trie.StateTrieID(root), clientTrieDB)
+	require.NoError(t, err)
+	nodeIt, err := accountTrie.NodeIterator(nil)
+	require.NoError(t, err)
+	it := trie.NewIterator(nodeIt)
+	accountsWithStorage := 0
+	for it.Next() {
+		var acc types.StateAccount
+		require.NoError(t, rlp.DecodeBytes(it.Value, &acc))
+		if acc.Root == types.EmptyRootHash {
+			continue
+		}
+		accountsWithStorage++
+		storageTrie, err := trie.New(trie.StorageTrieID(root, common.BytesToHash(it.Key), acc.Root), clientTrieDB)
+		require.NoError(t, err)
+		serverStorageTrie, err := trie.New(trie.TrieID(acc.Root), serverTrieDB)
+		require.NoError(t, err)
+		clientIt, err := storageTrie.NodeIterator(nil)
+		require.NoError(t, err)
+		serverIt, err := serverStorageTrie.NodeIterator(nil)
+		require.NoError(t, err)
+		for clientIt.Next(true) {
+			require.True(t, serverIt.Next(true))
+			require.Equal(t, serverIt.Hash(), clientIt.Hash())
+		}
+		require.NoError(t, clientIt.Error())
+		require.False(t, serverIt.Next(true))
+	}
+	require.NoError(t, it.Err)
+	require.Positive(t, accountsWithStorage)
+}
+
+// updatePathState writes random storage for each of [addrs] in a new state on
+// top of [parent] in the path-based [trieDB], returning the new state root.
+func updatePathState(t *testing.T, r *rand.Rand, trieDB *triedb.Database, parent common.Hash, block uint64, addrs []common.Address) common.Hash {
+	accountTrie, err := trie.NewStateTrie(trie.StateTrieID(parent), trieDB)
+	require.NoError(t, err)
+	nodes := trienode.NewMergedNodeSet()
+	for _, addr := range addrs {
+		acc, err := accountTrie.GetAccount(addr)
+		require.NoError(t, err)
+		if acc == nil {
+			acc = types.NewEmptyStateAccount()
+		}
+		storageTrie, err := trie.NewStateTrie(trie.StorageTrieID(parent, crypto.Keccak256Hash(addr[:]), acc.Root), trieDB)
+		require.NoError(t, err)
+		for i := 0; i < 16; i++ {
+			var key, value common.Hash
+			_, err := r.Read(key[:])
+			require.NoError(t, err)
+			_, err = r.Read(value[:])
+			require.NoError(t, err)
+			storageTrie.MustUpdate(key[:], value[:])
+		}
+		var storageNodes *trienode.NodeSet
+		acc.Root, storageNodes, err = storageTrie.Commit(false)
+		require.NoError(t, err)
+		require.NoError(t, nodes.Merge(storageNodes))
+		require.NoError(t, accountTrie.UpdateAccount(addr, acc))
+	}
+	root, accountNodes, err := accountTrie.Commit(false)
+	require.NoError(t, err)
+	require.NoError(t, nodes.Merge(accountNodes))
+	require.NoError(t, trieDB.Update(root, parent, block, nodes, nil))
+	return root
+}
+
+func TestSyncFromPathScheme(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	serverDB := rawdb.NewMemoryDatabase()
+	serverTrieDB := triedb.NewDatabase(serverDB, &triedb.Config{DBOverride: pathdb.Defaults.BackendConstructor})
+	defer serverTrieDB.Close()
+
+	addrs := make([]common.Address, 10)
+	for i := range addrs {
+		addrs[i] = common.Address{byte(i + 1)}
+	}
+	root := updatePathState(t, r, serverTrieDB, types.EmptyRootHash, 1, addrs)
+	// The storage trie of the first account is modified in the next state, so
+	// the server must find the synced state to serve it.
+	updatePathState(t, r, serverTrieDB, root, 2, addrs[:1])
+
+	leafsRequestHandler := handlers.NewLeafsRequestHandler(serverTrieDB, message.StateTrieKeyLength, nil, message.Codec, handlerstats.NewNoopHandlerStats())
+	codeRequestHandler := handlers.NewCodeRequestHandler(serverDB, message.Codec, handlerstats.NewNoopHandlerStats())
+	mockClient := statesyncclient.NewMockClient(message.Codec, leafsRequestHandler, codeRequestHandler, nil)
+
+	clientDB := rawdb.NewMemoryDatabase()
+	s, err := NewStateSyncer(&StateSyncerConfig{
+		Client:                   mockClient,
+		Root:                     root,
+		DB:                       clientDB,
+		BatchSize:                1000,
+		NumCodeFetchingWorkers:   DefaultNumCodeFetchingWorkers,
+		MaxOutstandingCodeHashes: DefaultMaxOutstandingCodeHashes,
+		RequestSize:              1024,
+		Scheme:                   rawdb.PathScheme,
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Start(t.Context()))
+	waitFor(t, t.Context(), s.Wait, nil, testSyncTimeout)
+
+	clientTrieDB := triedb.NewDatabase(clientDB, &triedb.Config{DBOverride: pathdb.Defaults.BackendConstructor})
+	defer clientTrieDB.Close()
+	for _, addr := range addrs {
+		owner := crypto.Keccak256Hash(addr[:])
+		serverAccountTrie, err := trie.NewStateTrie(trie.StateTrieID(root), serverTrieDB)
+		require.NoError(t, err)
+		acc, err := serverAccountTrie.GetAccount(addr)
+		require.NoError(t, err)
+		serverStorageTrie, err := trie.New(trie.StorageTrieID(root, owner, acc.Root), serverTrieDB)
+		require.NoError(t, err)
+		clientStorageTrie, err := trie.New(trie.StorageTrieID(root, owner, acc.Root), clientTrieDB)
+		require.NoError(t, err)
+		serverIt, err := serverStorageTrie.NodeIterator(nil)
+		require.NoError(t, err)
+		clientIt, err := clientStorageTrie.NodeIterator(nil)
+		require.NoError(t, err)
+		for serverIt.Next(true) {
+			require.True(t, clientIt.Next(true))
+			require.Equal(t, serverIt.Hash(), clientIt.Hash())
+		}
+		require.NoError(t, serverIt.Error())
+		require.NoError(t, clientIt.Error())
+		require.False(t, clientIt.Next(true))
+	}
+}
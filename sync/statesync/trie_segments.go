@@ -64,15 +64,25 @@ type trieToSync struct {
 }
 
 // NewTrieToSync initializes a trieToSync and restores any previously started segments.
-func NewTrieToSync(sync *stateSync, root common.Hash, account common.Hash, syncTask syncTask) (*trieToSync, error) {
-	batch := sync.db.NewBatch() // TODO: migrate state sync to use database schemes.
+// [accounts] are the accounts with the storage trie at [root], or the empty hash
+// for the main trie. The first account is used to request the leafs of the trie.
+func NewTrieToSync(sync *stateSync, root common.Hash, accounts []common.Hash, syncTask syncTask) (*trieToSync, error) {
+	batch := sync.db.NewBatch()
 	writeFn := func(path []byte, hash common.Hash, blob []byte) {
-		rawdb.WriteTrieNode(batch, account, path, hash, blob, rawdb.HashScheme)
+		if sync.scheme != rawdb.PathScheme {
+			rawdb.WriteTrieNode(batch, accounts[0], path, hash, blob, sync.scheme)
+			return
+		}
+		// Path scheme trie nodes are keyed by the account owning the trie,
+		// so a storage trie shared by several accounts is written for each.
+		for _, account := range accounts {
+			rawdb.WriteTrieNode(batch, account, path, hash, blob, sync.scheme)
+		}
 	}
 	trieToSync := &trieToSync{
 		sync:         sync,
 		root:         root,
-		account:      account,
+		account:      accounts[0],
 		batch:        batch,
 		stackTrie:    trie.NewStackTrie(&trie.StackTrieOptions{Writer: writeFn}),
 		isMainTrie:   (root == sync.root),
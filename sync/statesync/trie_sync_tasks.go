@@ -110,6 +110,11 @@ func (s *storageTrieTask) IterateLeafs(seek common.Hash) ethdb.Iterator {
 }
 
 func (s *storageTrieTask) OnStart() (bool, error) {
+	// Path scheme trie nodes cannot be looked up by hash, so existing
+	// storage tries are not reused.
+	if s.sync.scheme == rawdb.PathScheme {
+		return false, nil
+	}
 	// check if this storage root is on disk
 	var firstAccount common.Hash
 	if len(s.accounts) > 0 {
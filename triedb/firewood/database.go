@@ -66,6 +66,11 @@ type ProposalContext struct {
 	Block    uint64
 	Parent   *ProposalContext
 	Children []*ProposalContext
+
+	// The keys and values proposed on top of the parent, kept so the proposal
+	// can be recreated when the database is pruned.
+	keys   [][]byte
+	values [][]byte
 }
 
 type Config struct {
@@ -94,6 +99,11 @@ func (c Config) BackendConstructor(ethdb.Database) triedb.DBOverride {
 }
 
 type Database struct {
+	config   Config
+	filePath string
+	// diskLock must be held to use fwDisk without holding the proposal lock,
+	// since it is replaced when the database is pruned.
+	diskLock     sync.RWMutex
 	fwDisk       *ffi.Database // The underlying Firewood database, used for storing proposals and revisions.
 	proposalLock sync.RWMutex
 	// proposalMap provides O(1) access by state root to all proposals stored in the proposalTree
@@ -103,6 +113,17 @@ type Database struct {
 	// in the case of duplicate state roots.
 	// The root of the tree is stored here, and represents the top-most layer on disk.
 	proposalTree *ProposalContext
+	// history holds the changes of the most recently committed revisions,
+	// oldest first, so they can be copied when the database is pruned.
+	// Should only be accessed with the proposal lock held.
+	history     []revisionChanges
+	historySize int // Size in bytes of the changes in history
+
+	// pruneLock is held while the database is pruned, and pruning is set to
+	// collect the changes committed until the pruned database has caught up.
+	pruneLock    sync.Mutex
+	pruning      bool
+	pruneChanges []revisionChanges
 }
 
 // New creates a new Firewood database with the given disk database and configuration.
@@ -114,18 +135,12 @@ func New(config Config) (*Database, error) {
 		return nil, err
 	}
 
-	var rootStoreDir string
-	if config.ArchiveMode {
-		rootStoreDir = filepath.Join(firewoodDir, firewoodRootStoreDir)
+	// Remove the database of an interrupted pruning run, if any.
+	if err := os.Remove(filePath + pruneFileSuffix); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error removing interrupted pruning database: %w", err)
 	}
 
-	fw, err := ffi.New(filePath, &ffi.Config{
-		NodeCacheEntries:     uint(config.CleanCacheSize) / 256, // TODO: estimate 256 bytes per node
-		FreeListCacheEntries: config.FreeListCacheEntries,
-		Revisions:            config.Revisions,
-		ReadCacheStrategy:    config.ReadCacheStrategy,
-		RootStoreDir:         rootStoreDir,
-	})
+	fw, err := ffi.New(filePath, config.ffiConfig(false))
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +151,8 @@ func New(config Config) (*Database, error) {
 	}
 
 	return &Database{
+		config:      config,
+		filePath:    filePath,
 		fwDisk:      fw,
 		proposalMap: make(map[common.Hash][]*ProposalContext),
 		proposalTree: &ProposalContext{
@@ -144,6 +161,23 @@ func New(config Config) (*Database, error) {
 	}, nil
 }
 
+// ffiConfig returns the configuration to open the Firewood database with,
+// creating an empty database if [truncate] is set.
+func (c Config) ffiConfig(truncate bool) *ffi.Config {
+	var rootStoreDir string
+	if c.ArchiveMode {
+		rootStoreDir = filepath.Join(c.ChainDataDir, firewoodDir, firewoodRootStoreDir)
+	}
+	return &ffi.Config{
+		Truncate:             truncate,
+		NodeCacheEntries:     uint(c.CleanCacheSize) / 256, // TODO: estimate 256 bytes per node
+		FreeListCacheEntries: c.FreeListCacheEntries,
+		Revisions:            c.Revisions,
+		ReadCacheStrategy:    c.ReadCacheStrategy,
+		RootStoreDir:         rootStoreDir,
+	}
+}
+
 func validatePath(path string) error {
 	if path == "" {
 		return errors.New("firewood database file path must be set")
@@ -180,6 +214,9 @@ func (*Database) Scheme() string {
 
 // Initialized checks whether a non-empty genesis block has been written.
 func (db *Database) Initialized(common.Hash) bool {
+	db.diskLock.RLock()
+	defer db.diskLock.RUnlock()
+
 	root, err := db.fwDisk.Root()
 	if err != nil {
 		log.Error("firewood: error getting current root", "error", err)
@@ -259,6 +296,8 @@ func (db *Database) propose(root common.Hash, parentRoot common.Hash, hash commo
 			Root:     root,
 			Block:    block,
 			Parent:   parentProposal,
+			keys:     keys,
+			values:   values,
 		}
 
 		db.proposalMap[root] = append(db.proposalMap[root], pCtx)
@@ -286,6 +325,8 @@ func (db *Database) propose(root common.Hash, parentRoot common.Hash, hash commo
 		Root:     root,
 		Block:    block,
 		Parent:   db.proposalTree,
+		keys:     keys,
+		values:   values,
 	}
 	db.proposalMap[root] = append(db.proposalMap[root], pCtx)
 	db.proposalTree.Children = append(db.proposalTree.Children, pCtx)
@@ -345,6 +386,7 @@ func (db *Database) Commit(root common.Hash, report bool) error {
 	if currentRootHash != root {
 		return fmt.Errorf("firewood: current root %s does not match expected root %s", currentRootHash.Hex(), root.Hex())
 	}
+	db.recordCommit(pCtx)
 
 	if report {
 		log.Info("Persisted proposal to firewood database", "root", root)
@@ -374,7 +416,11 @@ func (*Database) Cap(common.StorageSize) error {
 	return nil
 }
 
+// Close waits for any pruning run to complete before closing the database.
 func (db *Database) Close() error {
+	db.pruneLock.Lock()
+	defer db.pruneLock.Unlock()
+
 	db.proposalLock.Lock()
 	defer db.proposalLock.Unlock()
 
@@ -386,6 +432,7 @@ func (db *Database) Close() error {
 
 	db.proposalMap = nil
 	db.proposalTree.Children = nil
+	db.resetHistory()
 
 	// Close the database
 	// This may block momentarily while finalizers for Firewood objects run.
@@ -493,6 +540,9 @@ func (db *Database) removeProposalFromMap(pCtx *ProposalContext) {
 // Reader retrieves a node reader belonging to the given state root.
 // An error will be returned if the requested state is not available.
 func (db *Database) Reader(root common.Hash) (database.Reader, error) {
+	db.diskLock.RLock()
+	defer db.diskLock.RUnlock()
+
 	if _, err := db.fwDisk.GetFromRoot(ffi.Hash(root), []byte{}); err != nil {
 		return nil, fmt.Errorf("firewood: unable to retrieve from root %s: %w", root.Hex(), err)
 	}
@@ -510,6 +560,9 @@ type reader struct {
 func (reader *reader) Node(_ common.Hash, path []byte, _ common.Hash) ([]byte, error) {
 	// This function relies on Firewood's internal locking to ensure concurrent reads are safe.
 	// This is safe even if a proposal is being committed concurrently.
+	reader.db.diskLock.RLock()
+	defer reader.db.diskLock.RUnlock()
+
 	start := time.Now()
 	result, err := reader.db.fwDisk.GetFromRoot(reader.root, path)
 	if metrics.EnabledExpensive {
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package firewood
+
+import (
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrimHistory(t *testing.T) {
+	require := require.New(t)
+
+	// The value is shared by every revision, so the history is only as large
+	// as it appears.
+	value := make([]byte, maxHistorySize/4)
+	commit := func(db *Database, values int) {
+		pCtx := &ProposalContext{Root: common.Hash{byte(len(db.history) + 1)}}
+		for i := 0; i < values; i++ {
+			pCtx.keys = append(pCtx.keys, []byte{byte(i)})
+			pCtx.values = append(pCtx.values, value)
+		}
+		db.recordCommit(pCtx)
+	}
+	historySize := func(db *Database) int {
+		size := 0
+		for _, c := range db.history {
+			size += c.size()
+		}
+		return size
+	}
+
+	// The history is bounded by the number of revisions retained.
+	db := &Database{config: Config{Revisions: 2}}
+	for i := 0; i < 4; i++ {
+		commit(db, 0)
+	}
+	require.Len(db.history, 2)
+
+	// The history is bounded by size.
+	db = &Database{config: Config{Revisions: 100}}
+	for i := 0; i < 8; i++ {
+		commit(db, 1)
+		require.Equal(historySize(db), db.historySize)
+		require.LessOrEqual(db.historySize, maxHistorySize)
+	}
+	require.Len(db.history, 3)
+
+	// The latest revision is kept regardless of its size.
+	commit(db, 5)
+	require.Len(db.history, 1)
+	require.Equal(historySize(db), db.historySize)
+
+	// Revisions committed while pruning are collected even once trimmed.
+	db.pruning = true
+	for i := 0; i < 4; i++ {
+		commit(db, 1)
+	}
+	require.Len(db.pruneChanges, 4)
+	require.Len(db.history, 3)
+}
@@ -0,0 +1,474 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package firewood
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/firewood-go-ethhash/ffi"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/log"
+	"github.com/ava-labs/libevm/metrics"
+)
+
+const (
+	// The pruned database is written next to the database it replaces.
+	pruneFileSuffix = ".prune"
+	// Number of leaves copied to the pruned database in each update.
+	pruneBatchSize = 10_000
+	// Number of leaves read from the copied revision in each iterator call.
+	pruneIteratorBatchSize = 256
+	// Maximum time to wait for the replaced database to close.
+	pruneCloseTimeout = time.Minute
+	// Maximum number of revisions committed while pruning that are applied to
+	// the pruned database while blocking commits, before replacing the
+	// database. More revisions are applied without blocking commits first.
+	pruneSwapRevisions = 4
+	// Maximum size in bytes of the changes kept in memory to copy revisions
+	// when the database is pruned. The changes of the latest revision are
+	// always kept.
+	maxHistorySize = 256 * 1024 * 1024
+)
+
+var (
+	errPruneArchive    = errors.New("firewood: cannot prune an archive database")
+	errPruneInProgress = errors.New("firewood: pruning already in progress")
+	errPruneRevisions  = errors.New("firewood: at least one revision must be kept when pruning")
+
+	pruneCount     = metrics.GetOrRegisterCounter("firewood/triedb/prune/count", nil)
+	pruneTimer     = metrics.GetOrRegisterCounter("firewood/triedb/prune/time", nil)
+	pruneReclaimed = metrics.GetOrRegisterCounter("firewood/triedb/prune/reclaimed", nil)
+	pruneSize      = metrics.GetOrRegisterGauge("firewood/triedb/prune/size", nil)
+)
+
+// revisionChanges are the keys and values committed on top of the previous
+// revision to create the revision at root.
+type revisionChanges struct {
+	root   common.Hash
+	keys   [][]byte
+	values [][]byte
+}
+
+// size returns the number of bytes of the keys and values.
+func (c *revisionChanges) size() int {
+	size := 0
+	for i := range c.keys {
+		size += len(c.keys[i]) + len(c.values[i])
+	}
+	return size
+}
+
+// PruneResult describes a completed pruning run.
+type PruneResult struct {
+	Root       common.Hash // Root of the latest revision kept
+	Revisions  int         // Number of revisions kept
+	SizeBefore uint64      // Size of the database file in bytes before pruning
+	SizeAfter  uint64      // Size of the database file in bytes after pruning
+	Duration   time.Duration
+}
+
+// Reclaimed returns the number of bytes of disk space reclaimed by pruning.
+func (r *PruneResult) Reclaimed() uint64 {
+	if r.SizeAfter > r.SizeBefore {
+		return 0
+	}
+	return r.SizeBefore - r.SizeAfter
+}
+
+// recordCommit adds the changes of the committed proposal to the history,
+// and to the changes to apply to the pruned database if it is being pruned.
+// Should only be accessed with the proposal lock held.
+func (db *Database) recordCommit(pCtx *ProposalContext) {
+	changes := revisionChanges{
+		root:   pCtx.Root,
+		keys:   pCtx.keys,
+		values: pCtx.values,
+	}
+	pCtx.keys, pCtx.values = nil, nil
+	db.history = append(db.history, changes)
+	db.historySize += changes.size()
+	if db.pruning {
+		db.pruneChanges = append(db.pruneChanges, changes)
+	}
+	db.trimHistory()
+}
+
+// trimHistory drops the changes of revisions no longer retained by Firewood,
+// and of the oldest revisions beyond [maxHistorySize].
+// Should only be accessed with the proposal lock held.
+func (db *Database) trimHistory() {
+	excess := max(len(db.history)-int(db.config.Revisions), 0)
+	for i := range db.history[:excess] {
+		db.historySize -= db.history[i].size()
+	}
+	for ; excess < len(db.history)-1 && db.historySize > maxHistorySize; excess++ {
+		db.historySize -= db.history[excess].size()
+	}
+	if excess > 0 {
+		clear(db.history[:excess])
+		db.history = db.history[excess:]
+	}
+}
+
+// resetHistory drops the changes of all revisions.
+// Should only be accessed with the proposal lock held.
+func (db *Database) resetHistory() {
+	db.history = nil
+	db.historySize = 0
+}
+
+// Prune rebuilds the database from its last [revisions] committed revisions,
+// reclaiming the space used by older revisions and by fragmentation.
+//
+// Firewood only reopens the latest revision, and the changes of at most
+// [maxHistorySize] bytes of revisions are kept in memory, so fewer revisions
+// may be kept. The database is rebuilt while blocks are processed, and
+// outstanding proposals are recreated on top of the rebuilt database before it
+// replaces the current one.
+func (db *Database) Prune(revisions int) (*PruneResult, error) {
+	if db.config.ArchiveMode {
+		return nil, errPruneArchive
+	}
+	if revisions < 1 {
+		return nil, errPruneRevisions
+	}
+	if !db.pruneLock.TryLock() {
+		return nil, errPruneInProgress
+	}
+	defer db.pruneLock.Unlock()
+
+	start := time.Now()
+	sizeBefore, err := fileSize(db.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the revisions to keep, and collect the changes of the revisions
+	// committed from now on until the pruned database replaces this one.
+	db.proposalLock.Lock()
+	if len(db.history) == 0 || db.history[len(db.history)-1].root != db.proposalTree.Root {
+		// The latest revision was not committed from a proposal, so the
+		// changes of older revisions cannot be applied on top of it.
+		db.resetHistory()
+		db.history = []revisionChanges{{root: db.proposalTree.Root}}
+	}
+	kept := min(revisions, len(db.history))
+	base := db.history[len(db.history)-kept].root
+	changes := append([]revisionChanges(nil), db.history[len(db.history)-kept+1:]...)
+	db.pruning = true
+	db.proposalLock.Unlock()
+
+	defer func() {
+		db.proposalLock.Lock()
+		db.pruning = false
+		db.pruneChanges = nil
+		db.proposalLock.Unlock()
+	}()
+
+	prunePath := db.filePath + pruneFileSuffix
+	pruned, err := ffi.New(prunePath, db.config.ffiConfig(true))
+	if err != nil {
+		return nil, fmt.Errorf("firewood: unable to create pruned database: %w", err)
+	}
+	swapped := false
+	defer func() {
+		if swapped {
+			return
+		}
+		if err := pruned.Close(context.Background()); err != nil {
+			log.Error("firewood: error closing pruned database", "error", err)
+		}
+		if err := os.Remove(prunePath); err != nil {
+			log.Error("firewood: error removing pruned database", "error", err)
+		}
+	}()
+
+	db.diskLock.RLock()
+	err = copyRevision(pruned, db.fwDisk, base)
+	db.diskLock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if err := applyChanges(pruned, changes); err != nil {
+		return nil, err
+	}
+
+	// Apply the revisions committed while copying without blocking commits,
+	// until few enough are left to be applied while replacing the database.
+	for {
+		db.proposalLock.Lock()
+		changes, db.pruneChanges = db.pruneChanges, nil
+		if len(changes) <= pruneSwapRevisions {
+			break
+		}
+		db.proposalLock.Unlock()
+		if err := applyChanges(pruned, changes); err != nil {
+			return nil, err
+		}
+		kept += len(changes)
+	}
+	previous, err := db.replaceDatabase(pruned, prunePath, changes)
+	if err != nil {
+		return nil, err
+	}
+	swapped = true
+	kept += len(changes)
+
+	ctx, cancel := context.WithTimeout(context.Background(), pruneCloseTimeout)
+	defer cancel()
+	if err := previous.Close(ctx); err != nil {
+		log.Error("firewood: error closing database replaced by pruning", "error", err)
+	}
+
+	db.proposalLock.RLock()
+	root := db.proposalTree.Root
+	db.proposalLock.RUnlock()
+	result := &PruneResult{
+		Root:       root,
+		Revisions:  min(kept, int(db.config.Revisions)),
+		SizeBefore: sizeBefore,
+		Duration:   time.Since(start),
+	}
+	if result.SizeAfter, err = fileSize(db.filePath); err != nil {
+		return nil, err
+	}
+	recordPrune(result)
+	return result, nil
+}
+
+// replaceDatabase applies the last [changes] to [pruned], recreates the
+// outstanding proposals on top of it and replaces the database with it,
+// returning the replaced database. It must be called with the proposal lock
+// held, which it releases.
+func (db *Database) replaceDatabase(pruned *ffi.Database, prunePath string, changes []revisionChanges) (*ffi.Database, error) {
+	defer db.proposalLock.Unlock()
+	db.diskLock.Lock()
+	defer db.diskLock.Unlock()
+
+	if err := applyChanges(pruned, changes); err != nil {
+		return nil, err
+	}
+	proposals, err := reproposeChildren(pruned, db.proposalTree)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(prunePath, db.filePath); err != nil {
+		dropProposals(proposals)
+		return nil, fmt.Errorf("firewood: unable to replace database with pruned database: %w", err)
+	}
+
+	// The pruned database now holds every revision and proposal.
+	replaceProposals(db.proposalTree, proposals)
+	previous := db.fwDisk
+	db.fwDisk = pruned
+	return previous, nil
+}
+
+// Prune rebuilds the Firewood database of [config] from its latest revision,
+// which must be at [root], reclaiming the space used by older revisions and
+// by fragmentation. The database must not be open.
+//
+// Any root store of a previous archive database is removed.
+func Prune(config Config, root common.Hash) (*PruneResult, error) {
+	if config.ArchiveMode {
+		return nil, errPruneArchive
+	}
+	start := time.Now()
+	firewoodDir := filepath.Join(config.ChainDataDir, firewoodDir)
+	filePath := filepath.Join(firewoodDir, firewoodFileName)
+	sizeBefore, err := fileSize(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := ffi.New(filePath, config.ffiConfig(false))
+	if err != nil {
+		return nil, err
+	}
+	latest, err := fw.Root()
+	if err != nil {
+		return nil, errors.Join(err, fw.Close(context.Background()))
+	}
+	if common.Hash(latest) != root {
+		err := fmt.Errorf("firewood: latest root %s does not match pruning root %s", common.Hash(latest).Hex(), root.Hex())
+		return nil, errors.Join(err, fw.Close(context.Background()))
+	}
+	prunePath := filePath + pruneFileSuffix
+	pruned, err := ffi.New(prunePath, config.ffiConfig(true))
+	if err != nil {
+		return nil, errors.Join(err, fw.Close(context.Background()))
+	}
+
+	err = copyRevision(pruned, fw, root)
+	err = errors.Join(err, pruned.Close(context.Background()), fw.Close(context.Background()))
+	if err != nil {
+		return nil, errors.Join(err, os.Remove(prunePath))
+	}
+	if err := os.Rename(prunePath, filePath); err != nil {
+		return nil, fmt.Errorf("firewood: unable to replace database with pruned database: %w", err)
+	}
+	if err := os.RemoveAll(filepath.Join(firewoodDir, firewoodRootStoreDir)); err != nil {
+		return nil, fmt.Errorf("firewood: unable to remove root store: %w", err)
+	}
+
+	result := &PruneResult{
+		Root:       root,
+		Revisions:  1,
+		SizeBefore: sizeBefore,
+		Duration:   time.Since(start),
+	}
+	if result.SizeAfter, err = fileSize(filePath); err != nil {
+		return nil, err
+	}
+	recordPrune(result)
+	return result, nil
+}
+
+func recordPrune(result *PruneResult) {
+	pruneCount.Inc(1)
+	pruneTimer.Inc(result.Duration.Milliseconds())
+	pruneReclaimed.Inc(int64(result.Reclaimed()))
+	pruneSize.Update(int64(result.SizeAfter))
+	log.Info("Pruned firewood database",
+		"root", result.Root,
+		"revisions", result.Revisions,
+		"reclaimed", common.StorageSize(result.Reclaimed()),
+		"size", common.StorageSize(result.SizeAfter),
+		"elapsed", result.Duration,
+	)
+}
+
+// copyRevision writes the leaves of the revision of [src] at [root] to the
+// empty database [dst], whose root must then be [root].
+func copyRevision(dst, src *ffi.Database, root common.Hash) (err error) {
+	revision, err := src.Revision(ffi.Hash(root))
+	if err != nil {
+		return fmt.Errorf("firewood: unable to get revision %s: %w", root.Hex(), err)
+	}
+	defer func() {
+		err = errors.Join(err, revision.Drop())
+	}()
+
+	it, err := revision.Iter(nil)
+	if err != nil {
+		return fmt.Errorf("firewood: unable to iterate revision %s: %w", root.Hex(), err)
+	}
+	defer func() {
+		err = errors.Join(err, it.Drop())
+	}()
+
+	it.SetBatchSize(pruneIteratorBatchSize)
+
+	var (
+		copiedRoot ffi.Hash
+		keys       = make([][]byte, 0, pruneBatchSize)
+		values     = make([][]byte, 0, pruneBatchSize)
+	)
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		updatedRoot, err := dst.Update(keys, values)
+		if err != nil {
+			return fmt.Errorf("firewood: unable to copy leaves of revision %s: %w", root.Hex(), err)
+		}
+		copiedRoot = updatedRoot
+		keys, values = keys[:0], values[:0]
+		return nil
+	}
+	for it.Next() {
+		keys = append(keys, it.Key())
+		values = append(values, it.Value())
+		if len(keys) == pruneBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("firewood: unable to iterate revision %s: %w", root.Hex(), err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if common.Hash(copiedRoot) != root {
+		return fmt.Errorf("firewood: copied root %s does not match revision root %s", common.Hash(copiedRoot).Hex(), root.Hex())
+	}
+	return nil
+}
+
+// applyChanges commits [changes] to [db] in order.
+func applyChanges(db *ffi.Database, changes []revisionChanges) error {
+	for _, c := range changes {
+		root, err := db.Update(c.keys, c.values)
+		if err != nil {
+			return fmt.Errorf("firewood: unable to apply changes of revision %s: %w", c.root.Hex(), err)
+		}
+		if common.Hash(root) != c.root {
+			return fmt.Errorf("firewood: applied root %s does not match revision root %s", common.Hash(root).Hex(), c.root.Hex())
+		}
+	}
+	return nil
+}
+
+// reproposeChildren recreates the proposals of the descendants of [pCtx] on
+// top of [parent], returning them by proposal context. If any proposal
+// cannot be recreated, the recreated proposals are dropped.
+func reproposeChildren(parent proposable, pCtx *ProposalContext) (map[*ProposalContext]*ffi.Proposal, error) {
+	proposals := make(map[*ProposalContext]*ffi.Proposal)
+	var repropose func(parent proposable, pCtx *ProposalContext) error
+	repropose = func(parent proposable, pCtx *ProposalContext) error {
+		for _, child := range pCtx.Children {
+			p, err := createProposal(parent, child.Root, child.keys, child.values)
+			if err != nil {
+				return err
+			}
+			proposals[child] = p
+			if err := repropose(p, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := repropose(parent, pCtx); err != nil {
+		dropProposals(proposals)
+		return nil, err
+	}
+	return proposals, nil
+}
+
+func dropProposals(proposals map[*ProposalContext]*ffi.Proposal) {
+	for _, p := range proposals {
+		if err := p.Drop(); err != nil {
+			log.Error("firewood: error dropping recreated proposal", "error", err)
+		}
+		ffiOutstandingProposals.Dec(1)
+	}
+}
+
+// replaceProposals drops the proposals of the descendants of [pCtx] in favor
+// of the recreated [proposals].
+func replaceProposals(pCtx *ProposalContext, proposals map[*ProposalContext]*ffi.Proposal) {
+	for _, child := range pCtx.Children {
+		if err := child.Proposal.Drop(); err != nil {
+			log.Error("firewood: error dropping proposal", "root", child.Root.Hex(), "error", err)
+		}
+		ffiOutstandingProposals.Dec(1)
+		child.Proposal = proposals[child]
+		replaceProposals(child, proposals)
+	}
+}
+
+func fileSize(path string) (uint64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("firewood: unable to get size of database: %w", err)
+	}
+	return uint64(info.Size()), nil
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package firewood_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/sync/statesync/statesynctest"
+	"github.com/ava-labs/subnet-evm/triedb/firewood"
+)
+
+// commitBlocks commits [blocks] blocks, each updating the balance and a
+// storage slot of [accounts] accounts, and returns their roots.
+func commitBlocks(t *testing.T, fwState *statesynctest.FirewoodState, blocks int, accounts int) []common.Hash {
+	var roots []common.Hash
+	for block := 0; block < blocks; block++ {
+		roots = append(roots, fwState.Commit(t, func(statedb *state.StateDB) {
+			for i := 0; i < accounts; i++ {
+				addr := common.BigToAddress(big.NewInt(int64(i + 1)))
+				statedb.SetBalance(addr, uint256.NewInt(uint64(block*accounts+i+1)))
+				statedb.SetState(addr, common.Hash{}, common.BigToHash(big.NewInt(int64(block+1))))
+			}
+		}))
+	}
+	return roots
+}
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+
+	fwState := statesynctest.NewFirewoodState(t)
+	roots := commitBlocks(t, fwState, 10, 500)
+	lastRoot := roots[len(roots)-1]
+
+	// An outstanding proposal is recreated on the pruned database.
+	proposalRoot := fwState.Propose(t, lastRoot, func(statedb *state.StateDB) {
+		statedb.SetBalance(common.Address{1}, uint256.NewInt(1))
+	})
+
+	result, err := fwState.Firewood.Prune(4)
+	require.NoError(err)
+	require.Equal(lastRoot, result.Root)
+	require.Equal(4, result.Revisions)
+	require.Less(result.SizeAfter, result.SizeBefore)
+	require.Equal(result.SizeBefore-result.SizeAfter, result.Reclaimed())
+
+	for i, root := range roots {
+		_, err := fwState.Firewood.Reader(root)
+		if i < len(roots)-4 {
+			require.Error(err, "revision %d should be pruned", i)
+		} else {
+			require.NoError(err, "revision %d should be kept", i)
+		}
+	}
+
+	require.NoError(fwState.StateDB.TrieDB().Commit(proposalRoot, false))
+	statedb, err := state.New(proposalRoot, fwState.StateDB, nil)
+	require.NoError(err)
+	require.Equal(uint256.NewInt(1), statedb.GetBalance(common.Address{1}))
+	require.Equal(common.BigToHash(big.NewInt(10)), statedb.GetState(common.BigToAddress(big.NewInt(1)), common.Hash{}))
+
+	// Blocks can be committed on top of the pruned database.
+	fwState.CommitAt(t, proposalRoot, func(statedb *state.StateDB) {
+		statedb.SetBalance(common.Address{2}, uint256.NewInt(2))
+	})
+}
+
+func TestPruneOffline(t *testing.T) {
+	require := require.New(t)
+
+	fwState := statesynctest.NewFirewoodState(t)
+	roots := commitBlocks(t, fwState, 10, 500)
+	lastRoot := roots[len(roots)-1]
+	require.NoError(fwState.Firewood.Close())
+
+	_, err := firewood.Prune(fwState.Config, roots[0])
+	require.ErrorContains(err, "does not match")
+
+	result, err := firewood.Prune(fwState.Config, lastRoot)
+	require.NoError(err)
+	require.Equal(1, result.Revisions)
+	require.Less(result.SizeAfter, result.SizeBefore)
+
+	db, err := firewood.New(fwState.Config)
+	require.NoError(err)
+	defer func() {
+		require.NoError(db.Close())
+	}()
+	_, err = db.Reader(lastRoot)
+	require.NoError(err)
+	_, err = db.Reader(roots[len(roots)-2])
+	require.Error(err)
+}
+
+func TestPruneArchive(t *testing.T) {
+	config := firewood.Defaults
+	config.ChainDataDir = t.TempDir()
+	config.ArchiveMode = true
+	_, err := firewood.Prune(config, common.Hash{})
+	require.ErrorContains(t, err, "archive")
+}
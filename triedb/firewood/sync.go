@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package firewood
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/firewood-go-ethhash/ffi"
+	"github.com/ava-labs/libevm/common"
+)
+
+// RangeProofsVerified reports whether Firewood verifies range proofs against
+// their root. Until it does, a peer could serve the leaves of any state, which
+// would only be detected once the whole state is synced, so nodes must not
+// state sync Firewood from their peers.
+const RangeProofsVerified = false
+
+// MinRangeProofLength is the smallest number of leaves a range proof used for
+// state sync may be limited to. The next range of a truncated proof starts at
+// its last key, so a proof of a single leaf would not make progress.
+const MinRangeProofLength = 2
+
+var errRangeProofTooShort = fmt.Errorf("range proofs must be limited to at least %d leaves", MinRangeProofLength)
+
+// RangeProof returns a serialized proof of at most [maxLength] leaves of the
+// revision at [root], starting at [start] (inclusive).
+// A nil [start] starts the proof at the first leaf.
+func (db *Database) RangeProof(root common.Hash, start []byte, maxLength uint32) ([]byte, error) {
+	db.diskLock.RLock()
+	defer db.diskLock.RUnlock()
+
+	proof, err := db.fwDisk.RangeProof(ffi.Hash(root), startKey(start), maybe.Nothing[[]byte](), maxLength)
+	if err != nil {
+		return nil, fmt.Errorf("firewood: unable to create range proof for root %s: %w", root.Hex(), err)
+	}
+	defer proof.Free()
+
+	return proof.MarshalBinary()
+}
+
+// VerifyRangeProof verifies that the serialized [proof] proves at most
+// [maxLength] leaves of the revision at [root], starting at [start].
+// It does not require a database, so proofs can be verified before committing them.
+//
+// Note that Firewood does not yet implement range proof verification, so this
+// only ensures the proof can be parsed. The synced state is still verified by
+// requiring the root of the database to match the synced root once complete.
+func VerifyRangeProof(proofBytes []byte, root common.Hash, start []byte, maxLength uint32) error {
+	var proof ffi.RangeProof
+	if err := proof.UnmarshalBinary(proofBytes); err != nil {
+		return fmt.Errorf("firewood: unable to parse range proof: %w", err)
+	}
+	defer proof.Free()
+
+	return proof.Verify(ffi.Hash(root), startKey(start), maybe.Nothing[[]byte](), maxLength)
+}
+
+// CommitRangeProof verifies the serialized [proof] of the leaves of the
+// revision at [root] starting at [start], and replaces the leaves of the
+// database from [start] to the end of the proof with them.
+// Leaves of the database in that range missing from the proof are removed.
+//
+// It returns the key the next proof must start at, or nil if the database
+// root now matches [root] and the sync is complete.
+//
+// Any outstanding proposals are dropped, since the committed revision is not
+// the result of a block. This must only be used while state syncing.
+func (db *Database) CommitRangeProof(proofBytes []byte, root common.Hash, start []byte, maxLength uint32) ([]byte, error) {
+	if maxLength < MinRangeProofLength {
+		return nil, errRangeProofTooShort
+	}
+
+	var proof ffi.RangeProof
+	if err := proof.UnmarshalBinary(proofBytes); err != nil {
+		return nil, fmt.Errorf("firewood: unable to parse range proof: %w", err)
+	}
+	defer proof.Free()
+
+	db.proposalLock.Lock()
+	defer db.proposalLock.Unlock()
+
+	for _, pCtx := range db.proposalTree.Children {
+		db.dereference(pCtx)
+	}
+	db.proposalTree.Children = nil
+
+	committed, err := db.fwDisk.VerifyAndCommitRangeProof(&proof, startKey(start), maybe.Nothing[[]byte](), ffi.Hash(root), maxLength)
+	if err != nil {
+		return nil, fmt.Errorf("firewood: unable to commit range proof for root %s: %w", root.Hex(), err)
+	}
+	ffiCommitCount.Inc(1)
+
+	// The committed revision becomes the base of future proposals, as if the
+	// database had been reopened.
+	db.proposalTree = &ProposalContext{Root: common.Hash(committed)}
+	db.resetHistory()
+	if common.Hash(committed) == root {
+		return nil, nil
+	}
+
+	nextRange, err := proof.FindNextKey()
+	if err != nil {
+		return nil, fmt.Errorf("firewood: unable to find next key of range proof for root %s: %w", root.Hex(), err)
+	}
+	if nextRange == nil {
+		return nil, fmt.Errorf("firewood: range proof for root %s is complete but committed root is %s", root.Hex(), common.Hash(committed).Hex())
+	}
+	defer nextRange.Free()
+
+	// Copy the key, since it is owned by firewood and freed with the range.
+	next := bytes.Clone(nextRange.StartKey())
+	if bytes.Compare(next, start) <= 0 {
+		return nil, fmt.Errorf("firewood: range proof for root %s made no progress from %x", root.Hex(), start)
+	}
+	return next, nil
+}
+
+// IterateLeaves calls [fn] with the leaves of the latest revision of the
+// database from [start] (inclusive) to [end] (exclusive), in order.
+// A nil [end] iterates to the last leaf.
+// The key and value must not be retained after [fn] returns.
+func (db *Database) IterateLeaves(start, end []byte, fn func(key, value []byte) error) (err error) {
+	db.diskLock.RLock()
+	defer db.diskLock.RUnlock()
+
+	revision, err := db.fwDisk.LatestRevision()
+	if err != nil {
+		return fmt.Errorf("firewood: unable to get latest revision: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, revision.Drop())
+	}()
+
+	it, err := revision.Iter(start)
+	if err != nil {
+		return fmt.Errorf("firewood: unable to iterate latest revision: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, it.Drop())
+	}()
+
+	for it.NextBorrowed() {
+		key := it.Key()
+		if end != nil && bytes.Compare(key, end) >= 0 {
+			return nil
+		}
+		if err := fn(key, it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+func startKey(start []byte) maybe.Maybe[[]byte] {
+	if len(start) == 0 {
+		return maybe.Nothing[[]byte]()
+	}
+	return maybe.Some(start)
+}
@@ -28,10 +28,13 @@
 package pathdb
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/rawdb"
@@ -100,6 +103,8 @@ type layer interface {
 // Config contains the settings for database.
 type Config struct {
 	StateHistory   uint64 // Number of recent blocks to maintain state history for
+	DiffLayers     int    // Number of diff layers kept in memory on update, 0 to only flatten layers with Cap or Commit
+	AncientDir     string // Directory of the state history freezer, the ancient directory of the disk database if empty
 	CleanCacheSize int    // Maximum memory allowance (in bytes) for caching clean nodes
 	DirtyCacheSize int    // Maximum memory allowance (in bytes) for caching dirty nodes
 	ReadOnly       bool   // Flag whether the database is opened in read only mode.
@@ -123,6 +128,7 @@ func (c *Config) sanitize() *Config {
 // Defaults contains default settings for Ethereum mainnet.
 var Defaults = &Config{
 	StateHistory:   params.FullImmutabilityThreshold,
+	DiffLayers:     maxDiffLayers,
 	CleanCacheSize: defaultCleanSize,
 	DirtyCacheSize: DefaultBufferSize,
 }
@@ -145,16 +151,14 @@ type Database struct {
 	// readOnly is the flag whether the mutation is allowed to be applied.
 	// It will be set automatically when the database is journaled during
 	// the shutdown to reject all following unexpected mutations.
-	readOnly   bool           // Flag if database is opened in read only mode
-	waitSync   bool           // Flag if database is deactivated due to initial state sync
-	bufferSize int            // Memory allowance (in bytes) for caching dirty nodes
-	config     *Config        // Configuration for database
-	diskdb     ethdb.Database // Persistent storage for matured trie nodes
-	tree       *layerTree     // The group for all known layers
-	lock       sync.RWMutex   // Lock to prevent mutations from happening at the same time
-
-	// NOTE(freezer): This is disabled since we do not have a freezer.
-	// freezer    *rawdb.ResettableFreezer // Freezer for storing trie histories, nil possible in tests
+	readOnly   bool                     // Flag if database is opened in read only mode
+	waitSync   bool                     // Flag if database is deactivated due to initial state sync
+	bufferSize int                      // Memory allowance (in bytes) for caching dirty nodes
+	config     *Config                  // Configuration for database
+	diskdb     ethdb.Database           // Persistent storage for matured trie nodes
+	tree       *layerTree               // The group for all known layers
+	freezer    *rawdb.ResettableFreezer // Freezer for storing trie histories, nil possible in tests
+	lock       sync.RWMutex             // Lock to prevent mutations from happening at the same time
 }
 
 // New attempts to load an already existing layer from a persistent key-value
@@ -176,47 +180,52 @@ func New(diskdb ethdb.Database, config *Config) *Database {
 	// and in-memory layer journal.
 	db.tree = newLayerTree(db.loadLayers())
 
-	// NOTE(freezer): This is disabled since we do not have a freezer.
-	// Open the freezer for state history if the passed database contains an
-	// ancient store. Otherwise, all the relevant functionalities are disabled.
+	// Open the freezer for state history in the configured directory, or the
+	// ancient store of the passed database if it has one. Otherwise, all the
+	// relevant functionalities are disabled.
 	//
 	// Because the freezer can only be opened once at the same time, this
 	// mechanism also ensures that at most one **non-readOnly** database
 	// is opened at the same time to prevent accidental mutation.
-	// if ancient, err := diskdb.AncientDatadir(); err == nil && ancient != "" && !db.readOnly {
-	// 	freezer, err := rawdb.NewStateFreezer(ancient, false)
-	// 	if err != nil {
-	// 		log.Crit("Failed to open state history freezer", "err", err)
-	// 	}
-	// 	db.freezer = freezer
-	//
-	// 	diskLayerID := db.tree.bottom().stateID()
-	// 	if diskLayerID == 0 {
-	// 		// Reset the entire state histories in case the trie database is
-	// 		// not initialized yet, as these state histories are not expected.
-	// 		frozen, err := db.freezer.Ancients()
-	// 		if err != nil {
-	// 			log.Crit("Failed to retrieve head of state history", "err", err)
-	// 		}
-	// 		if frozen != 0 {
-	// 			err := db.freezer.Reset()
-	// 			if err != nil {
-	// 				log.Crit("Failed to reset state histories", "err", err)
-	// 			}
-	// 			log.Info("Truncated extraneous state history")
-	// 		}
-	// 	} else {
-	// 		// Truncate the extra state histories above in freezer in case
-	// 		// it's not aligned with the disk layer.
-	// 		pruned, err := truncateFromHead(db.diskdb, freezer, diskLayerID)
-	// 		if err != nil {
-	// 			log.Crit("Failed to truncate extra state histories", "err", err)
-	// 		}
-	// 		if pruned != 0 {
-	// 			log.Warn("Truncated extra state histories", "number", pruned)
-	// 		}
-	// 	}
-	// }
+	ancient := config.AncientDir
+	if ancient == "" {
+		ancient, _ = diskdb.AncientDatadir()
+	}
+	if ancient != "" && !db.readOnly {
+		freezer, err := rawdb.NewStateFreezer(ancient, false)
+		if err != nil {
+			log.Crit("Failed to open state history freezer", "err", err)
+		}
+		db.freezer = freezer
+
+		diskLayerID := db.tree.bottom().stateID()
+		if diskLayerID == 0 {
+			// Reset the entire state histories in case the trie database is
+			// not initialized yet, as these state histories are not expected.
+			frozen, err := db.freezer.Ancients()
+			if err != nil {
+				log.Crit("Failed to retrieve head of state history", "err", err)
+			}
+			if frozen != 0 {
+				err := db.freezer.Reset()
+				if err != nil {
+					log.Crit("Failed to reset state histories", "err", err)
+				}
+				log.Info("Truncated extraneous state history")
+			}
+		} else {
+			// Truncate the extra state histories above in freezer in case
+			// it's not aligned with the disk layer.
+			pruned, err := truncateFromHead(db.diskdb, freezer, diskLayerID)
+			if err != nil {
+				log.Crit("Failed to truncate extra state histories", "err", err)
+			}
+			if pruned != 0 {
+				log.Warn("Truncated extra state histories", "number", pruned)
+			}
+		}
+	}
+	// NOTE: This is disabled since we don't have SnapSyncStatusFlag.
 	// // Disable database in case node is still in the initial state sync stage.
 	// if rawdb.ReadSnapSyncStatusFlag(diskdb) == rawdb.StateSyncRunning && !db.readOnly {
 	// 	if err := db.Disable(); err != nil {
@@ -255,12 +264,44 @@ func (db *Database) Update(root common.Hash, parentRoot common.Hash, block uint6
 	if err := db.tree.add(root, parentRoot, block, nodes, states); err != nil {
 		return err
 	}
-	// Keep 128 diff layers in the memory, persistent layer is 129th.
+	// Keep 128 diff layers in the memory by default, persistent layer is 129th.
 	// - head layer is paired with HEAD state
 	// - head-1 layer is paired with HEAD-1 state
 	// - head-127 layer(bottom-most diff layer) is paired with HEAD-127 state
 	// - head-128 layer(disk layer) is paired with HEAD-128 state
-	return db.tree.cap(root, maxDiffLayers)
+	//
+	// Note: Capping is skipped if DiffLayers is 0, so that only the layers of
+	// accepted blocks are flattened with Cap.
+	if db.config.DiffLayers == 0 {
+		return nil
+	}
+	return db.tree.cap(root, db.config.DiffLayers)
+}
+
+// Cap flattens the diff layers more than [layers] below the layer of [root]
+// into the disk layer, writing the node buffer of the disk layer to disk if
+// [flush] is set. The layers above [root] are kept, as well as the layers of
+// other branches which are not linked to a flattened layer.
+func (db *Database) Cap(root common.Hash, layers int, flush bool) error {
+	// Hold the lock to prevent concurrent mutations.
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Short circuit if the mutation is not allowed.
+	if err := db.modifyAllowed(); err != nil {
+		return err
+	}
+	// The state may be the disk layer, for example if the block did not
+	// modify the state of an already flattened parent.
+	if _, ok := db.tree.get(root).(*diskLayer); !ok {
+		if err := db.tree.cap(root, layers); err != nil {
+			return err
+		}
+	}
+	if !flush {
+		return nil
+	}
+	return db.tree.bottom().flush()
 }
 
 // Commit traverses downwards the layer tree from a specified layer with the
@@ -275,6 +316,12 @@ func (db *Database) Commit(root common.Hash, report bool) error {
 	if err := db.modifyAllowed(); err != nil {
 		return err
 	}
+	// Note: The state may already have been flattened into the disk layer, for
+	// example by a previous commit of the same root, in which case only the
+	// node buffer needs to be written to disk.
+	if dl, ok := db.tree.get(root).(*diskLayer); ok {
+		return dl.flush()
+	}
 	return db.tree.cap(root, 0)
 }
 
@@ -329,16 +376,15 @@ func (db *Database) Enable(root common.Hash) error {
 	if err := batch.Write(); err != nil {
 		return err
 	}
-	// NOTE(freezer): This is disabled since we do not have a freezer.
 	// Clean up all state histories in freezer. Theoretically
 	// all root->id mappings should be removed as well. Since
 	// mappings can be huge and might take a while to clear
 	// them, just leave them in disk and wait for overwriting.
-	// if db.freezer != nil {
-	// 	if err := db.freezer.Reset(); err != nil {
-	// 		return err
-	// 	}
-	// }
+	if db.freezer != nil {
+		if err := db.freezer.Reset(); err != nil {
+			return err
+		}
+	}
 	// Re-construct a new disk layer backed by persistent state
 	// with **empty clean cache and node buffer**.
 	db.tree.reset(newDiskLayer(root, 0, db, nil, newNodeBuffer(db.bufferSize, nil, 0)))
@@ -354,8 +400,47 @@ func (db *Database) Enable(root common.Hash) error {
 // The state is supported as the rollback destination only if it's
 // canonical state and the corresponding trie histories are existent.
 func (db *Database) Recover(root common.Hash, loader triestate.TrieLoader) error {
-	// NOTE(freezer): This is disabled since we do not have a freezer.
-	return errors.New("state rollback is non-supported")
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Short circuit if rollback operation is not supported.
+	if err := db.modifyAllowed(); err != nil {
+		return err
+	}
+	if db.freezer == nil {
+		return errors.New("state rollback is non-supported")
+	}
+	// Short circuit if the target state is not recoverable.
+	root = types.TrieRootHash(root)
+	if !db.Recoverable(root) {
+		return errStateUnrecoverable
+	}
+	// Apply the state histories upon the disk layer in order.
+	var (
+		start = time.Now()
+		dl    = db.tree.bottom()
+	)
+	for dl.rootHash() != root {
+		h, err := readHistory(db.freezer, dl.stateID())
+		if err != nil {
+			return err
+		}
+		dl, err = dl.revert(h, loader)
+		if err != nil {
+			return err
+		}
+		// reset layer with newly created disk layer. It must be
+		// done after each revert operation, otherwise the new
+		// disk layer won't be accessible from outside.
+		db.tree.reset(dl)
+	}
+	rawdb.DeleteTrieJournal(db.diskdb)
+	_, err := truncateFromHead(db.diskdb, db.freezer, dl.stateID())
+	if err != nil {
+		return err
+	}
+	log.Debug("Recovered state", "root", root, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
 }
 
 // Recoverable returns the indicator if the specified state is recoverable.
@@ -373,21 +458,36 @@ func (db *Database) Recoverable(root common.Hash) bool {
 	if *id >= dl.stateID() {
 		return false
 	}
-	return false
-	// NOTE(freezer): This is disabled since we do not have a freezer.
 	// Ensure the requested state is a canonical state and all state
 	// histories in range [id+1, disklayer.ID] are present and complete.
-	// parent := root
-	// return checkHistories(db.freezer, *id+1, dl.stateID()-*id, func(m *meta) error {
-	// 	if m.parent != parent {
-	// 		return errors.New("unexpected state history")
-	// 	}
-	// 	if len(m.incomplete) > 0 {
-	// 		return errors.New("incomplete state history")
-	// 	}
-	// 	parent = m.root
-	// 	return nil
-	// }) == nil
+	parent := root
+	return checkHistories(db.freezer, *id+1, dl.stateID()-*id, func(m *meta) error {
+		if m.parent != parent {
+			return errors.New("unexpected state history")
+		}
+		if len(m.incomplete) > 0 {
+			return errors.New("incomplete state history")
+		}
+		parent = m.root
+		return nil
+	}) == nil
+}
+
+// StateRoots returns the roots of the states kept in the layer tree, from the
+// most recent state to the disk layer.
+func (db *Database) StateRoots() []common.Hash {
+	var layers []layer
+	db.tree.forEach(func(l layer) {
+		layers = append(layers, l)
+	})
+	slices.SortFunc(layers, func(a, b layer) int {
+		return cmp.Compare(b.stateID(), a.stateID())
+	})
+	roots := make([]common.Hash, len(layers))
+	for i, l := range layers {
+		roots[i] = l.rootHash()
+	}
+	return roots
 }
 
 // Close closes the trie database and the held freezer.
@@ -402,13 +502,11 @@ func (db *Database) Close() error {
 	// Release the memory held by clean cache.
 	db.tree.bottom().resetCache()
 
-	// NOTE(freezer): This is disabled since we do not have a freezer.
 	// Close the attached state history freezer.
-	//if db.freezer == nil {
-	//	return nil
-	//}
-	//return db.freezer.Close()
-	return nil
+	if db.freezer == nil {
+		return nil
+	}
+	return db.freezer.Close()
 }
 
 // Size returns the current storage size of the memory cache in front of the
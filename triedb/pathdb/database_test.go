@@ -43,7 +43,6 @@ import (
 	"github.com/ava-labs/libevm/trie/trienode"
 	"github.com/ava-labs/libevm/trie/triestate"
 	"github.com/holiman/uint256"
-	"github.com/stretchr/testify/require"
 )
 
 func updateTrie(addrHash common.Hash, root common.Hash, dirties, cleans map[common.Hash][]byte) (common.Hash, *trienode.NodeSet) {
@@ -110,11 +109,10 @@ type tester struct {
 
 func newTester(t *testing.T, historyLimit uint64) *tester {
 	var (
-		// NOTE(freezer): This is disabled since we do not have a freezer.
-		// disk, _ = rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
-		disk = rawdb.NewMemoryDatabase()
-		db   = New(disk, &Config{
+		disk, _ = rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+		db      = New(disk, &Config{
 			StateHistory:   historyLimit,
+			DiffLayers:     maxDiffLayers,
 			CleanCacheSize: 256 * 1024,
 			DirtyCacheSize: 256 * 1024,
 		})
@@ -354,6 +352,37 @@ func (t *tester) verifyState(root common.Hash) error {
 	return nil
 }
 
+func (t *tester) verifyHistory() error {
+	bottom := t.bottomIndex()
+	for i, root := range t.roots {
+		// The state history related to the state above disk layer should not exist.
+		if i > bottom {
+			_, err := readHistory(t.db.freezer, uint64(i+1))
+			if err == nil {
+				return errors.New("unexpected state history")
+			}
+			continue
+		}
+		// The state history related to the state below or equal to the disk layer
+		// should exist.
+		obj, err := readHistory(t.db.freezer, uint64(i+1))
+		if err != nil {
+			return err
+		}
+		parent := types.EmptyRootHash
+		if i != 0 {
+			parent = t.roots[i-1]
+		}
+		if obj.meta.parent != parent {
+			return fmt.Errorf("unexpected parent, want: %x, got: %x", parent, obj.meta.parent)
+		}
+		if obj.meta.root != root {
+			return fmt.Errorf("unexpected root, want: %x, got: %x", root, obj.meta.root)
+		}
+	}
+	return nil
+}
+
 // bottomIndex returns the index of current disk layer.
 func (t *tester) bottomIndex() int {
 	bottom := t.db.tree.bottom()
@@ -370,10 +399,9 @@ func TestDatabaseRollback(t *testing.T) {
 	tester := newTester(t, 0)
 	defer tester.release()
 
-	// NOTE(freezer): This is disabled since we do not have a freezer.
-	// if err := tester.verifyHistory(); err != nil {
-	// 	t.Fatalf("Invalid state history, err: %v", err)
-	// }
+	if err := tester.verifyHistory(); err != nil {
+		t.Fatalf("Invalid state history, err: %v", err)
+	}
 	// Revert database from top to bottom
 	for i := tester.bottomIndex(); i >= 0; i-- {
 		root := tester.roots[i]
@@ -382,17 +410,14 @@ func TestDatabaseRollback(t *testing.T) {
 			parent = tester.roots[i-1]
 		}
 		loader := newHashLoader(tester.snapAccounts[root], tester.snapStorages[root])
-		// NOTE(freezer): This is disabled since we do not have a freezer.
-		// if err := tester.db.Recover(parent, loader); err != nil {
-		// 	t.Fatalf("Failed to revert db, err: %v", err)
-		// }
-		require.ErrorContains(t, tester.db.Recover(parent, loader), "state rollback is non-supported")
+		if err := tester.db.Recover(parent, loader); err != nil {
+			t.Fatalf("Failed to revert db, err: %v", err)
+		}
 		tester.verifyState(parent)
 	}
-	// NOTE(freezer): This is disabled since we do not have a freezer.
-	// if tester.db.tree.len() != 1 {
-	// 	t.Fatal("Only disk layer is expected")
-	// }
+	if tester.db.tree.len() != 1 {
+		t.Fatal("Only disk layer is expected")
+	}
 }
 
 func TestDatabaseRecoverable(t *testing.T) {
@@ -428,9 +453,7 @@ func TestDatabaseRecoverable(t *testing.T) {
 	}
 	for i, c := range cases {
 		result := tester.db.Recoverable(c.root)
-		// NOTE(freezer): This is disabled since we do not have a freezer.
-		// originally was `result != c.expect`
-		if result != false {
+		if result != c.expect {
 			t.Fatalf("case: %d, unexpected result, want %t, got %t", i, c.expect, result)
 		}
 	}
@@ -455,15 +478,14 @@ func TestDisable(t *testing.T) {
 	if blob := rawdb.ReadTrieJournal(tester.db.diskdb); len(blob) != 0 {
 		t.Fatal("Failed to clean journal")
 	}
-	// NOTE(freezer): This is disabled since we do not have a freezer.
 	// Ensure all trie histories are removed
-	// n, err := tester.db.freezer.Ancients()
-	// if err != nil {
-	// 	t.Fatal("Failed to clean state history")
-	// }
-	// if n != 0 {
-	// 	t.Fatal("Failed to clean state history")
-	// }
+	n, err := tester.db.freezer.Ancients()
+	if err != nil {
+		t.Fatal("Failed to clean state history")
+	}
+	if n != 0 {
+		t.Fatal("Failed to clean state history")
+	}
 	// Verify layer tree structure, single disk layer is expected
 	if tester.db.tree.len() != 1 {
 		t.Fatalf("Extra layer kept %d", tester.db.tree.len())
@@ -491,11 +513,74 @@ func TestCommit(t *testing.T) {
 	if err := tester.verifyState(tester.lastHash()); err != nil {
 		t.Fatalf("State is invalid, err: %v", err)
 	}
-	// NOTE(freezer): This is disabled since we do not have a freezer.
 	// Verify state histories
-	// if err := tester.verifyHistory(); err != nil {
-	// 	t.Fatalf("State history is invalid, err: %v", err)
-	// }
+	if err := tester.verifyHistory(); err != nil {
+		t.Fatalf("State history is invalid, err: %v", err)
+	}
+}
+
+func TestStateRoots(t *testing.T) {
+	tester := newTester(t, 0)
+	defer tester.release()
+
+	roots := tester.db.StateRoots()
+	if len(roots) != tester.db.tree.len() {
+		t.Fatalf("Unexpected number of roots, want %d, got %d", tester.db.tree.len(), len(roots))
+	}
+	bottom := tester.bottomIndex()
+	for i, root := range roots {
+		if want := tester.roots[len(tester.roots)-1-i]; root != want {
+			t.Fatalf("Unexpected root %d, want %x, got %x", i, want, root)
+		}
+	}
+	if roots[len(roots)-1] != tester.roots[bottom] {
+		t.Fatal("Disk layer root is expected last")
+	}
+}
+
+func TestCap(t *testing.T) {
+	tester := newTester(t, 0)
+	defer tester.release()
+
+	if err := tester.db.Cap(tester.lastHash(), 4, true); err != nil {
+		t.Fatalf("Failed to cap database, err: %v", err)
+	}
+	if tester.db.tree.len() != 5 {
+		t.Fatalf("Unexpected number of layers, want %d, got %d", 5, tester.db.tree.len())
+	}
+	bottom := tester.db.tree.bottom()
+	if bottom.rootHash() != tester.roots[len(tester.roots)-5] {
+		t.Fatal("Layer tree structure is invalid")
+	}
+	if id := rawdb.ReadPersistentStateID(tester.db.diskdb); id != bottom.stateID() {
+		t.Fatalf("Unexpected persistent state id, want %d, got %d", bottom.stateID(), id)
+	}
+	for i := len(tester.roots) - 5; i < len(tester.roots); i++ {
+		if err := tester.verifyState(tester.roots[i]); err != nil {
+			t.Fatalf("State is invalid, err: %v", err)
+		}
+	}
+	// Capping the disk layer is a no-op.
+	if err := tester.db.Cap(bottom.rootHash(), 4, true); err != nil {
+		t.Fatalf("Failed to cap disk layer, err: %v", err)
+	}
+}
+
+func TestCommitDiskLayer(t *testing.T) {
+	tester := newTester(t, 0)
+	defer tester.release()
+
+	// Committing the disk layer writes the node buffer to disk.
+	bottom := tester.db.tree.bottom()
+	if err := tester.db.Commit(bottom.rootHash(), false); err != nil {
+		t.Fatalf("Failed to commit disk layer, err: %v", err)
+	}
+	if id := rawdb.ReadPersistentStateID(tester.db.diskdb); id != bottom.stateID() {
+		t.Fatalf("Unexpected persistent state id, want %d, got %d", bottom.stateID(), id)
+	}
+	if tester.db.tree.len() != 129 {
+		t.Fatalf("Unexpected number of layers, want %d, got %d", 129, tester.db.tree.len())
+	}
 }
 
 func TestJournal(t *testing.T) {
@@ -564,22 +649,22 @@ func TestCorruptedJournal(t *testing.T) {
 // In this scenario, it is mandatory to update the persistent state before
 // truncating the tail histories. This ensures that the ID of the persistent state
 // always falls within the range of [oldest-history-id, latest-history-id].
-// func TestTailTruncateHistory(t *testing.T) {
-// 	tester := newTester(t, 10)
-// 	defer tester.release()
-//
-// 	tester.db.Close()
-// 	tester.db = New(tester.db.diskdb, &Config{StateHistory: 10})
-//
-// 	head, err := tester.db.freezer.Ancients()
-// 	if err != nil {
-// 		t.Fatalf("Failed to obtain freezer head")
-// 	}
-// 	stored := rawdb.ReadPersistentStateID(tester.db.diskdb)
-// 	if head != stored {
-// 		t.Fatalf("Failed to truncate excess history object above, stored: %d, head: %d", stored, head)
-// 	}
-// }
+func TestTailTruncateHistory(t *testing.T) {
+	tester := newTester(t, 10)
+	defer tester.release()
+
+	tester.db.Close()
+	tester.db = New(tester.db.diskdb, &Config{StateHistory: 10})
+
+	head, err := tester.db.freezer.Ancients()
+	if err != nil {
+		t.Fatalf("Failed to obtain freezer head")
+	}
+	stored := rawdb.ReadPersistentStateID(tester.db.diskdb)
+	if head != stored {
+		t.Fatalf("Failed to truncate excess history object above, stored: %d, head: %d", stored, head)
+	}
+}
 
 // copyAccounts returns a deep-copied account set of the provided one.
 func copyAccounts(set map[common.Hash][]byte) map[common.Hash][]byte {
@@ -0,0 +1,229 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/cache/lru"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/libevm/log"
+	"google.golang.org/protobuf/proto"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// signatureCacheSize is the number of messages whose validator signatures are
+// cached by a SignatureAggregator.
+const signatureCacheSize = 512
+
+var errFailedVerification = errors.New("failed verification")
+
+// AppRequester sends app requests to nodes. It is implemented by *p2p.Client.
+type AppRequester interface {
+	AppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID], appRequestBytes []byte, onResponse p2p.AppResponseCallback) error
+}
+
+// AggregationProgress is the weight of the validators that signed a message,
+// out of the total weight of the validator set.
+type AggregationProgress struct {
+	SignedWeight uint64
+	TotalWeight  uint64
+}
+
+// SignatureAggregator aggregates the signatures of validators on warp messages.
+// The signature of each validator is cached per message, so aggregating a
+// message again, for example at a higher quorum, only requests the signatures
+// of the validators that did not sign it yet.
+type SignatureAggregator struct {
+	client AppRequester
+
+	lock       sync.Mutex
+	signatures *lru.Cache[ids.ID, *messageSignatures]
+}
+
+func NewSignatureAggregator(client AppRequester) *SignatureAggregator {
+	return &SignatureAggregator{
+		client:     client,
+		signatures: lru.NewCache[ids.ID, *messageSignatures](signatureCacheSize),
+	}
+}
+
+// messageSignatures are the verified signatures of a message, by validator
+// public key.
+type messageSignatures struct {
+	lock       sync.Mutex
+	signatures map[string]*bls.Signature
+}
+
+func (s *messageSignatures) get(publicKey []byte) (*bls.Signature, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	signature, ok := s.signatures[string(publicKey)]
+	return signature, ok
+}
+
+func (s *messageSignatures) put(publicKey []byte, signature *bls.Signature) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.signatures[string(publicKey)] = signature
+}
+
+// messageSignatures returns the cached signatures of the message with
+// messageID.
+func (a *SignatureAggregator) messageSignatures(messageID ids.ID) *messageSignatures {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	signatures, ok := a.signatures.Get(messageID)
+	if !ok {
+		signatures = &messageSignatures{signatures: make(map[string]*bls.Signature)}
+		a.signatures.Put(messageID, signatures)
+	}
+	return signatures
+}
+
+type signatureResult struct {
+	nodeID    ids.NodeID
+	index     int
+	signature *bls.Signature
+	err       error
+}
+
+// Aggregate blocks until validators holding quorumNum/quorumDen of the weight
+// of validatorSet signed message, all of them responded, or ctx is done, and
+// returns message signed by the validators that signed it so far. Cached
+// signatures are reused and only the validators without one are requested to
+// sign. onProgress, if not nil, is called with the initial progress and then
+// each time a validator signs.
+func (a *SignatureAggregator) Aggregate(
+	ctx context.Context,
+	message *avalancheWarp.UnsignedMessage,
+	validatorSet validators.WarpSet,
+	quorumNum uint64,
+	quorumDen uint64,
+	onProgress func(AggregationProgress),
+) (*avalancheWarp.Message, AggregationProgress, error) {
+	var (
+		cached     = a.messageSignatures(message.ID())
+		signers    = set.NewBits()
+		signatures []*bls.Signature
+		progress   = AggregationProgress{TotalWeight: validatorSet.TotalWeight}
+		requested  = make(map[ids.NodeID]int)
+	)
+	for i, validator := range validatorSet.Validators {
+		if signature, ok := cached.get(validator.PublicKeyBytes); ok {
+			signers.Add(i)
+			signatures = append(signatures, signature)
+			progress.SignedWeight += validator.Weight
+			continue
+		}
+		for _, nodeID := range validator.NodeIDs {
+			requested[nodeID] = i
+		}
+	}
+	quorum := func() bool {
+		return avalancheWarp.VerifyWeight(progress.SignedWeight, progress.TotalWeight, quorumNum, quorumDen) == nil
+	}
+	if onProgress != nil {
+		onProgress(progress)
+	}
+	if quorum() || len(requested) == 0 {
+		signedMessage, err := newSignedMessage(message, signers, signatures)
+		return signedMessage, progress, err
+	}
+
+	request, err := proto.Marshal(&sdk.SignatureRequest{Message: message.Bytes()})
+	if err != nil {
+		return nil, progress, fmt.Errorf("failed to marshal signature request: %w", err)
+	}
+	// Responses arriving after the quorum is reached are still cached, so the
+	// channel must hold all of them.
+	results := make(chan signatureResult, len(requested))
+	onResponse := func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		index := requested[nodeID]
+		signature, err := parseSignatureResponse(message, validatorSet.Validators[index], responseBytes, err)
+		if err == nil {
+			cached.put(validatorSet.Validators[index].PublicKeyBytes, signature)
+		}
+		results <- signatureResult{nodeID: nodeID, index: index, signature: signature, err: err}
+	}
+	nodeIDs := set.NewSet[ids.NodeID](len(requested))
+	for nodeID := range requested {
+		nodeIDs.Add(nodeID)
+	}
+	if err := a.client.AppRequest(ctx, nodeIDs, request, onResponse); err != nil {
+		return nil, progress, fmt.Errorf("failed to send signature request: %w", err)
+	}
+
+wait:
+	for pending := len(requested); pending > 0 && !quorum(); pending-- {
+		select {
+		case <-ctx.Done():
+			break wait
+		case result := <-results:
+			if result.err != nil {
+				log.Debug("Dropping signature response", "nodeID", result.nodeID, "err", result.err)
+				continue
+			}
+			// Validators with several node IDs may sign more than once.
+			if signers.Contains(result.index) {
+				continue
+			}
+			signers.Add(result.index)
+			signatures = append(signatures, result.signature)
+			progress.SignedWeight += validatorSet.Validators[result.index].Weight
+			if onProgress != nil {
+				onProgress(progress)
+			}
+		}
+	}
+	signedMessage, err := newSignedMessage(message, signers, signatures)
+	return signedMessage, progress, err
+}
+
+// parseSignatureResponse returns the signature of validator on message in
+// responseBytes, if the request did not fail with err and the signature is
+// valid.
+func parseSignatureResponse(message *avalancheWarp.UnsignedMessage, validator *validators.Warp, responseBytes []byte, err error) (*bls.Signature, error) {
+	if err != nil {
+		return nil, err
+	}
+	response := &sdk.SignatureResponse{}
+	if err := proto.Unmarshal(responseBytes, response); err != nil {
+		return nil, err
+	}
+	signature, err := bls.SignatureFromBytes(response.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !bls.Verify(validator.PublicKey, signature, message.Bytes()) {
+		return nil, errFailedVerification
+	}
+	return signature, nil
+}
+
+// newSignedMessage returns message with the aggregate of the signatures of
+// signers.
+func newSignedMessage(message *avalancheWarp.UnsignedMessage, signers set.Bits, signatures []*bls.Signature) (*avalancheWarp.Message, error) {
+	bitSetSignature := &avalancheWarp.BitSetSignature{Signers: signers.Bytes()}
+	if len(signatures) > 0 {
+		aggregateSignature, err := bls.AggregateSignatures(signatures)
+		if err != nil {
+			return nil, err
+		}
+		copy(bitSetSignature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+	}
+	return avalancheWarp.NewMessage(message, bitSetSignature)
+}
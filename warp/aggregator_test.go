@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var errOffline = errors.New("offline")
+
+// testRequester responds to signature requests with the signatures of the
+// signers of the requested nodes. Nodes without a signer fail.
+type testRequester struct {
+	signers   map[ids.NodeID]bls.Signer
+	requested []set.Set[ids.NodeID]
+}
+
+func (r *testRequester) AppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID], appRequestBytes []byte, onResponse p2p.AppResponseCallback) error {
+	r.requested = append(r.requested, nodeIDs)
+	request := &sdk.SignatureRequest{}
+	if err := proto.Unmarshal(appRequestBytes, request); err != nil {
+		return err
+	}
+	for nodeID := range nodeIDs {
+		signer, ok := r.signers[nodeID]
+		if !ok {
+			onResponse(ctx, nodeID, nil, errOffline)
+			continue
+		}
+		signature, err := signer.Sign(request.Message)
+		if err != nil {
+			return err
+		}
+		response, err := proto.Marshal(&sdk.SignatureResponse{Signature: bls.SignatureToBytes(signature)})
+		if err != nil {
+			return err
+		}
+		onResponse(ctx, nodeID, response, nil)
+	}
+	return nil
+}
+
+func TestSignatureAggregator(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		nodeIDs = make([]ids.NodeID, 4)
+		signers = make([]bls.Signer, 4)
+		weights = make(map[ids.NodeID]*validators.GetValidatorOutput)
+	)
+	for i := range nodeIDs {
+		nodeIDs[i] = ids.GenerateTestNodeID()
+		signer, err := localsigner.New()
+		require.NoError(err)
+		signers[i] = signer
+		weights[nodeIDs[i]] = &validators.GetValidatorOutput{
+			NodeID:    nodeIDs[i],
+			PublicKey: signer.PublicKey(),
+			Weight:    uint64(i + 1),
+		}
+	}
+	validatorSet, err := validators.FlattenValidatorSet(weights)
+	require.NoError(err)
+
+	otherSigner, err := localsigner.New()
+	require.NoError(err)
+	requester := &testRequester{signers: map[ids.NodeID]bls.Signer{
+		nodeIDs[0]: signers[0],
+		nodeIDs[1]: signers[1],
+		nodeIDs[2]: otherSigner, // Invalid signature
+	}}
+	aggregator := NewSignatureAggregator(requester)
+
+	addressedCall, err := payload.NewAddressedCall(nil, []byte("payload"))
+	require.NoError(err)
+	message, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+	require.NoError(err)
+
+	// The first aggregation requests all the validators.
+	signedMessage, progress, err := aggregator.Aggregate(context.Background(), message, validatorSet, 30, 100, nil)
+	require.NoError(err)
+	require.Equal(AggregationProgress{SignedWeight: 3, TotalWeight: 10}, progress)
+	require.NoError(signedMessage.Signature.Verify(message, networkID, validatorSet, 30, 100))
+	require.Equal([]set.Set[ids.NodeID]{set.Of(nodeIDs...)}, requester.requested)
+
+	// A higher quorum only requests the validators that did not sign.
+	requester.signers[nodeIDs[2]] = signers[2]
+	requester.signers[nodeIDs[3]] = signers[3]
+	var updates []AggregationProgress
+	signedMessage, progress, err = aggregator.Aggregate(context.Background(), message, validatorSet, 100, 100, func(progress AggregationProgress) {
+		updates = append(updates, progress)
+	})
+	require.NoError(err)
+	require.Equal(AggregationProgress{SignedWeight: 10, TotalWeight: 10}, progress)
+	require.NoError(signedMessage.Signature.Verify(message, networkID, validatorSet, 100, 100))
+	require.Equal(set.Of(nodeIDs[2], nodeIDs[3]), requester.requested[1])
+	require.Len(updates, 3)
+	require.Equal(AggregationProgress{SignedWeight: 3, TotalWeight: 10}, updates[0])
+	require.Equal(progress, updates[2])
+
+	// All the signatures are cached.
+	cachedMessage, progress, err := aggregator.Aggregate(context.Background(), message, validatorSet, 100, 100, nil)
+	require.NoError(err)
+	require.Equal(AggregationProgress{SignedWeight: 10, TotalWeight: 10}, progress)
+	require.Equal(signedMessage.Bytes(), cachedMessage.Bytes())
+	require.Len(requester.requested, 2)
+}
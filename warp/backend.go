@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/cache/lru"
@@ -16,7 +17,12 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/vms/evm/uptimetracker"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/event"
 	"github.com/ava-labs/libevm/log"
+	"github.com/ava-labs/libevm/metrics"
+
+	"github.com/ava-labs/subnet-evm/warp/messages"
 
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 )
@@ -26,6 +32,10 @@ var (
 	errParsingOffChainMessage         = errors.New("failed to parse off-chain message")
 
 	messageCacheSize = 500
+
+	// pruneBatchSize is the number of indexed messages removed at once while
+	// pruning, bounding the time messages cannot be stored.
+	pruneBatchSize = 1024
 )
 
 type BlockClient interface {
@@ -47,6 +57,26 @@ type Backend interface {
 	// GetMessage retrieves the [unsignedMessage] from the warp backend database if available
 	GetMessage(messageHash ids.ID) (*avalancheWarp.UnsignedMessage, error)
 
+	// IndexMessage indexes [unsignedMessage], sent by the log at [logIndex] of the transaction [txHash]
+	// in the accepted block [blockHash] at [blockNumber]. Blocks must be indexed in order of acceptance.
+	IndexMessage(blockNumber uint64, blockHash common.Hash, txHash common.Hash, logIndex int, unsignedMessage *avalancheWarp.UnsignedMessage) error
+
+	// GetIndexedMessages returns the indexed messages selected by [query] and the cursor of the next page, if any.
+	GetIndexedMessages(query MessageQuery) ([]*IndexedMessage, []byte, error)
+
+	// SubscribeIndexedMessages registers a subscription for the messages indexed from now on.
+	SubscribeIndexedMessages(ch chan<- NewMessageEvent) event.Subscription
+
+	// IndexStoredMessages indexes the messages stored before the message index existed as sent at
+	// [height], so that they are pruned once it is outside of the retention policy, and returns the
+	// number of messages indexed.
+	IndexStoredMessages(height uint64) (int, error)
+
+	// Prune removes the messages sent in accepted blocks below [minHeight], unless they were sent again
+	// at or above it, and returns the number of messages removed. Off-chain messages are never removed.
+	// Pruning stops between batches of messages once [ctx] is done.
+	Prune(ctx context.Context, minHeight uint64) (int, error)
+
 	acp118.Verifier
 }
 
@@ -61,10 +91,20 @@ type backend struct {
 	signatureCache            cache.Cacher[ids.ID, []byte]
 	messageCache              *lru.Cache[ids.ID, *avalancheWarp.UnsignedMessage]
 	offchainAddressedCallMsgs map[ids.ID]*avalancheWarp.UnsignedMessage
+	verifiers                 *messages.Registry
+	index                     *messageIndex
 	stats                     *verifierStats
+
+	// storeLock serializes storing and pruning messages.
+	storeLock      sync.Mutex
+	storedMessages metrics.Gauge
+	storedBytes    metrics.Gauge
+	prunedMessages metrics.Counter
 }
 
 // NewBackend creates a new Backend, and initializes the signature cache and message tracking database.
+// Off-chain addressed call payloads are signed once verified by their verifier in [verifiers], which
+// may be nil. The verifier of [messages.ValidatorUptime] is registered by the backend.
 func NewBackend(
 	networkID uint32,
 	sourceChainID ids.ID,
@@ -74,7 +114,11 @@ func NewBackend(
 	db database.Database,
 	signatureCache cache.Cacher[ids.ID, []byte],
 	offchainMessages [][]byte,
+	verifiers *messages.Registry,
 ) (Backend, error) {
+	if verifiers == nil {
+		verifiers = messages.NewRegistry()
+	}
 	b := &backend{
 		networkID:                 networkID,
 		sourceChainID:             sourceChainID,
@@ -86,10 +130,40 @@ func NewBackend(
 		messageCache:              lru.NewCache[ids.ID, *avalancheWarp.UnsignedMessage](messageCacheSize),
 		stats:                     newVerifierStats(),
 		offchainAddressedCallMsgs: make(map[ids.ID]*avalancheWarp.UnsignedMessage),
+		verifiers:                 verifiers,
+		index:                     newMessageIndex(db),
+		storedMessages:            metrics.NewRegisteredGauge("warp_backend_stored_messages", nil),
+		storedBytes:               metrics.NewRegisteredGauge("warp_backend_stored_bytes", nil),
+		prunedMessages:            metrics.NewRegisteredCounter("warp_backend_pruned_messages", nil),
+	}
+	if err := messages.Register(verifiers, b.verifyUptimeMessage); err != nil {
+		return nil, err
+	}
+	if err := b.initStoredMessages(); err != nil {
+		return nil, err
 	}
 	return b, b.initOffChainMessages(offchainMessages)
 }
 
+// initStoredMessages counts the messages stored in the database.
+func (b *backend) initStoredMessages() error {
+	it := b.db.NewIterator()
+	defer it.Release()
+
+	var count, size int64
+	for it.Next() {
+		// Other keys belong to the message index.
+		if len(it.Key()) != ids.IDLen {
+			continue
+		}
+		count++
+		size += int64(len(it.Value()))
+	}
+	b.storedMessages.Update(count)
+	b.storedBytes.Update(size)
+	return it.Error()
+}
+
 func (b *backend) initOffChainMessages(offchainMessages [][]byte) error {
 	for i, offchainMsg := range offchainMessages {
 		unsignedMsg, err := avalancheWarp.ParseUnsignedMessage(offchainMsg)
@@ -122,7 +196,10 @@ func (b *backend) AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage) err
 	// In the case when a node restarts, and possibly changes its bls key, the cache gets emptied but the database does not.
 	// So to avoid having incorrect signatures saved in the database after a bls key change, we save the full message in the database.
 	// Whereas for the cache, after the node restart, the cache would be emptied so we can directly save the signatures.
-	if err := b.db.Put(messageID[:], unsignedMessage.Bytes()); err != nil {
+	b.storeLock.Lock()
+	err := b.storeMessage(unsignedMessage)
+	b.storeLock.Unlock()
+	if err != nil {
 		return fmt.Errorf("failed to put warp signature in db: %w", err)
 	}
 
@@ -196,6 +273,113 @@ func (b *backend) GetMessage(messageID ids.ID) (*avalancheWarp.UnsignedMessage,
 	return unsignedMessage, nil
 }
 
+// storeMessage puts unsignedMessage in the database, unless it is already
+// stored. It must be called with storeLock held.
+func (b *backend) storeMessage(unsignedMessage *avalancheWarp.UnsignedMessage) error {
+	messageID := unsignedMessage.ID()
+	has, err := b.db.Has(messageID[:])
+	if err != nil || has {
+		return err
+	}
+	messageBytes := unsignedMessage.Bytes()
+	if err := b.db.Put(messageID[:], messageBytes); err != nil {
+		return err
+	}
+	b.storedMessages.Inc(1)
+	b.storedBytes.Inc(int64(len(messageBytes)))
+	return nil
+}
+
+func (b *backend) IndexMessage(blockNumber uint64, blockHash common.Hash, txHash common.Hash, logIndex int, unsignedMessage *avalancheWarp.UnsignedMessage) error {
+	b.storeLock.Lock()
+	defer b.storeLock.Unlock()
+
+	if err := b.index.add(blockNumber, blockHash, txHash, uint32(logIndex), unsignedMessage); err != nil {
+		return fmt.Errorf("failed to index warp message %s: %w", unsignedMessage.ID(), err)
+	}
+	// The message may have been pruned since it was added, if it was sent
+	// before in a pruned block.
+	if err := b.storeMessage(unsignedMessage); err != nil {
+		return fmt.Errorf("failed to put warp message %s in db: %w", unsignedMessage.ID(), err)
+	}
+	return nil
+}
+
+func (b *backend) GetIndexedMessages(query MessageQuery) ([]*IndexedMessage, []byte, error) {
+	return b.index.query(query, b.GetMessage)
+}
+
+func (b *backend) SubscribeIndexedMessages(ch chan<- NewMessageEvent) event.Subscription {
+	return b.index.subscribe(ch)
+}
+
+func (b *backend) IndexStoredMessages(height uint64) (int, error) {
+	b.storeLock.Lock()
+	defer b.storeLock.Unlock()
+
+	it := b.db.NewIterator()
+	defer it.Release()
+
+	var messageIDs []ids.ID
+	for it.Next() {
+		// Other keys belong to the message index.
+		if len(it.Key()) != ids.IDLen {
+			continue
+		}
+		messageID := ids.ID(it.Key())
+		indexed, err := b.index.indexed(messageID)
+		if err != nil {
+			return 0, err
+		}
+		if !indexed {
+			messageIDs = append(messageIDs, messageID)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	if err := b.index.addStored(height, messageIDs); err != nil {
+		return 0, fmt.Errorf("failed to index stored warp messages: %w", err)
+	}
+	return len(messageIDs), nil
+}
+
+func (b *backend) Prune(ctx context.Context, minHeight uint64) (int, error) {
+	var pruned int
+	for {
+		if err := ctx.Err(); err != nil {
+			return pruned, err
+		}
+		b.storeLock.Lock()
+		n, err := b.index.prune(minHeight, pruneBatchSize, func(messageID ids.ID) error {
+			messageBytes, err := b.db.Get(messageID[:])
+			if errors.Is(err, database.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := b.db.Delete(messageID[:]); err != nil {
+				return err
+			}
+			b.messageCache.Evict(messageID)
+			b.signatureCache.Evict(messageID)
+			b.storedMessages.Dec(1)
+			b.storedBytes.Dec(int64(len(messageBytes)))
+			b.prunedMessages.Inc(1)
+			pruned++
+			return nil
+		})
+		b.storeLock.Unlock()
+		if err != nil {
+			return pruned, fmt.Errorf("failed to prune warp messages below height %d: %w", minHeight, err)
+		}
+		if n < pruneBatchSize {
+			return pruned, nil
+		}
+	}
+}
+
 func (b *backend) signMessage(unsignedMessage *avalancheWarp.UnsignedMessage) ([]byte, error) {
 	sig, err := b.warpSigner.Sign(unsignedMessage)
 	if err != nil {
@@ -21,6 +21,10 @@ type Client interface {
 	GetMessageAggregateSignature(ctx context.Context, messageID ids.ID, quorumNum uint64, subnetIDStr string) ([]byte, error)
 	GetBlockSignature(ctx context.Context, blockID ids.ID) ([]byte, error)
 	GetBlockAggregateSignature(ctx context.Context, blockID ids.ID, quorumNum uint64, subnetIDStr string) ([]byte, error)
+	GetMessages(ctx context.Context, filter MessageFilter) (*MessagePage, error)
+	StartMessageAggregation(ctx context.Context, messageID ids.ID, quorumNum uint64, subnetIDStr string) (ids.ID, error)
+	StartBlockAggregation(ctx context.Context, blockID ids.ID, quorumNum uint64, subnetIDStr string) (ids.ID, error)
+	GetAggregation(ctx context.Context, jobID ids.ID) (*AggregationStatus, error)
 }
 
 // client implementation for interacting with EVM [chain]
@@ -78,3 +82,35 @@ func (c *client) GetBlockAggregateSignature(ctx context.Context, blockID ids.ID,
 	}
 	return res, nil
 }
+
+func (c *client) GetMessages(ctx context.Context, filter MessageFilter) (*MessagePage, error) {
+	var res MessagePage
+	if err := c.client.CallContext(ctx, &res, "warp_getMessages", filter); err != nil {
+		return nil, fmt.Errorf("call to warp_getMessages failed. err: %w", err)
+	}
+	return &res, nil
+}
+
+func (c *client) StartMessageAggregation(ctx context.Context, messageID ids.ID, quorumNum uint64, subnetIDStr string) (ids.ID, error) {
+	var res ids.ID
+	if err := c.client.CallContext(ctx, &res, "warp_startMessageAggregation", messageID, quorumNum, subnetIDStr); err != nil {
+		return ids.Empty, fmt.Errorf("call to warp_startMessageAggregation failed. err: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) StartBlockAggregation(ctx context.Context, blockID ids.ID, quorumNum uint64, subnetIDStr string) (ids.ID, error) {
+	var res ids.ID
+	if err := c.client.CallContext(ctx, &res, "warp_startBlockAggregation", blockID, quorumNum, subnetIDStr); err != nil {
+		return ids.Empty, fmt.Errorf("call to warp_startBlockAggregation failed. err: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) GetAggregation(ctx context.Context, jobID ids.ID) (*AggregationStatus, error) {
+	var res AggregationStatus
+	if err := c.client.CallContext(ctx, &res, "warp_getAggregation", jobID); err != nil {
+		return nil, fmt.Errorf("call to warp_getAggregation failed. err: %w", err)
+	}
+	return &res, nil
+}
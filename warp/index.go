@@ -0,0 +1,423 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/event"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const (
+	// MaxIndexedMessages is the maximum number of messages returned by a
+	// single query of the message index.
+	MaxIndexedMessages = 1024
+
+	positionLen = 12 // block height and sequence in the block
+
+	// messageSubscriptionBuffer is the number of indexed messages buffered for
+	// a subscriber before it is dropped.
+	messageSubscriptionBuffer = 1024
+
+	// Block hash, tx hash, log index, message ID and source address
+	recordLen = 2*common.HashLength + 4 + ids.IDLen + common.AddressLength
+)
+
+var (
+	indexPrefix  = []byte("index")
+	heightPrefix = []byte{'h'}
+	senderPrefix = []byte{'s'}
+	latestPrefix = []byte{'m'}
+	storedPrefix = []byte{'o'}
+
+	errInvalidCursor     = errors.New("invalid cursor")
+	errInvalidRange      = errors.New("invalid block range")
+	errLaggingSubscriber = errors.New("subscriber fell behind indexed messages")
+)
+
+// IndexedMessage is a message sent in an accepted block.
+type IndexedMessage struct {
+	BlockNumber   uint64
+	BlockHash     common.Hash
+	TxHash        common.Hash
+	LogIndex      uint32
+	SourceAddress common.Address
+	Message       *avalancheWarp.UnsignedMessage
+}
+
+// MessageQuery selects the indexed messages sent between FromBlock and ToBlock,
+// inclusive, optionally only by SourceAddress. A query resumes from Cursor, as
+// returned with the previous page of messages, if set.
+type MessageQuery struct {
+	FromBlock     uint64
+	ToBlock       uint64
+	SourceAddress *common.Address
+	Cursor        []byte
+	Limit         int
+}
+
+// NewMessageEvent is posted when a message sent in an accepted block is
+// indexed.
+type NewMessageEvent struct{ Message *IndexedMessage }
+
+// messageIndex maps the height of accepted blocks and the source address of
+// the messages sent in them to the messages.
+type messageIndex struct {
+	db database.Database
+
+	// Position of the next message of the block being indexed. Blocks are
+	// indexed in order of acceptance, and a block indexed again after a restart
+	// overwrites its previous entries.
+	lock   sync.Mutex
+	height uint64
+	seq    uint32
+
+	// Subscribers are sent messages without blocking, so that a slow
+	// subscriber does not delay block acceptance.
+	subsLock sync.Mutex
+	subs     map[*messageSubscriber]struct{}
+}
+
+// messageSubscriber buffers the messages sent to a subscription until they
+// are delivered. lagging is closed if the buffer overflows.
+type messageSubscriber struct {
+	queue   chan NewMessageEvent
+	lagging chan struct{}
+}
+
+// newMessageIndex returns the index stored in db. The index is nested in the
+// key space of db, so clearing db clears the index.
+func newMessageIndex(db database.Database) *messageIndex {
+	return &messageIndex{
+		db:   prefixdb.NewNested(indexPrefix, db),
+		subs: make(map[*messageSubscriber]struct{}),
+	}
+}
+
+// position returns the position of the sequence-th message of the block at
+// height.
+func position(height uint64, seq uint32) []byte {
+	pos := make([]byte, positionLen)
+	binary.BigEndian.PutUint64(pos, height)
+	binary.BigEndian.PutUint32(pos[8:], seq)
+	return pos
+}
+
+func heightKey(pos []byte) []byte {
+	return append(append([]byte{}, heightPrefix...), pos...)
+}
+
+func senderKey(address common.Address, pos []byte) []byte {
+	key := append(append([]byte{}, senderPrefix...), address.Bytes()...)
+	return append(key, pos...)
+}
+
+// latestKey maps the ID of a message to the position it was last sent at.
+func latestKey(messageID ids.ID) []byte {
+	return append(append([]byte{}, latestPrefix...), messageID[:]...)
+}
+
+// storedKey indexes a message stored before the index existed at the height it
+// is retained from.
+func storedKey(height uint64, messageID ids.ID) []byte {
+	key := binary.BigEndian.AppendUint64(append([]byte{}, storedPrefix...), height)
+	return append(key, messageID[:]...)
+}
+
+// indexed reports whether the message with messageID was indexed.
+func (i *messageIndex) indexed(messageID ids.ID) (bool, error) {
+	return i.db.Has(latestKey(messageID))
+}
+
+// addStored indexes the messages with messageIDs, stored before the index
+// existed, as last sent at height. They are not returned by queries.
+func (i *messageIndex) addStored(height uint64, messageIDs []ids.ID) error {
+	batch := i.db.NewBatch()
+	for _, messageID := range messageIDs {
+		if err := batch.Put(storedKey(height, messageID), nil); err != nil {
+			return err
+		}
+		if err := batch.Put(latestKey(messageID), position(height, 0)); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// add indexes message, sent by the log at logIndex of the tx with txHash in
+// the accepted block at height.
+func (i *messageIndex) add(height uint64, blockHash common.Hash, txHash common.Hash, logIndex uint32, message *avalancheWarp.UnsignedMessage) error {
+	addressedCall, err := payload.ParseAddressedCall(message.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to parse addressed call: %w", err)
+	}
+	source := common.BytesToAddress(addressedCall.SourceAddress)
+
+	i.lock.Lock()
+	if i.height != height {
+		i.height, i.seq = height, 0
+	}
+	pos := position(height, i.seq)
+	i.seq++
+	i.lock.Unlock()
+
+	messageID := message.ID()
+	record := make([]byte, 0, recordLen)
+	record = append(record, blockHash.Bytes()...)
+	record = append(record, txHash.Bytes()...)
+	record = binary.BigEndian.AppendUint32(record, logIndex)
+	record = append(record, messageID[:]...)
+	record = append(record, source.Bytes()...)
+
+	batch := i.db.NewBatch()
+	if err := batch.Put(heightKey(pos), record); err != nil {
+		return err
+	}
+	if err := batch.Put(senderKey(source, pos), nil); err != nil {
+		return err
+	}
+	if err := batch.Put(latestKey(messageID), pos); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	i.send(NewMessageEvent{Message: &IndexedMessage{
+		BlockNumber:   height,
+		BlockHash:     blockHash,
+		TxHash:        txHash,
+		LogIndex:      logIndex,
+		SourceAddress: source,
+		Message:       message,
+	}})
+	return nil
+}
+
+// send queues ev for each subscriber, dropping the subscribers whose buffer is
+// full.
+func (i *messageIndex) send(ev NewMessageEvent) {
+	i.subsLock.Lock()
+	defer i.subsLock.Unlock()
+
+	for sub := range i.subs {
+		select {
+		case sub.queue <- ev:
+		default:
+			close(sub.lagging)
+			delete(i.subs, sub)
+		}
+	}
+}
+
+// query returns the messages selected by q, in the order they were sent, and
+// the cursor of the next page, if any. getMessage retrieves the message with an
+// indexed message ID.
+func (i *messageIndex) query(q MessageQuery, getMessage func(ids.ID) (*avalancheWarp.UnsignedMessage, error)) ([]*IndexedMessage, []byte, error) {
+	if q.FromBlock > q.ToBlock {
+		return nil, nil, fmt.Errorf("%w: from %d to %d", errInvalidRange, q.FromBlock, q.ToBlock)
+	}
+	limit := q.Limit
+	if limit <= 0 || limit > MaxIndexedMessages {
+		limit = MaxIndexedMessages
+	}
+	start := position(q.FromBlock, 0)
+	if q.Cursor != nil {
+		if len(q.Cursor) != positionLen {
+			return nil, nil, fmt.Errorf("%w: %x", errInvalidCursor, q.Cursor)
+		}
+		start = q.Cursor
+	}
+
+	var prefix []byte
+	if q.SourceAddress != nil {
+		prefix = senderKey(*q.SourceAddress, nil)
+	} else {
+		prefix = heightPrefix
+	}
+	it := i.db.NewIteratorWithStartAndPrefix(append(append([]byte{}, prefix...), start...), prefix)
+	defer it.Release()
+
+	var messages []*IndexedMessage
+	for it.Next() {
+		pos := it.Key()[len(prefix):]
+		if height := binary.BigEndian.Uint64(pos); height > q.ToBlock {
+			break
+		}
+		if len(messages) == limit {
+			return messages, append([]byte{}, pos...), nil
+		}
+		record := it.Value()
+		if q.SourceAddress != nil {
+			var err error
+			if record, err = i.db.Get(heightKey(pos)); err != nil {
+				return nil, nil, fmt.Errorf("failed to get indexed message at %x: %w", pos, err)
+			}
+		}
+		message, err := i.parse(pos, record, getMessage)
+		if err != nil {
+			return nil, nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil, it.Error()
+}
+
+// parse returns the indexed message at pos with record.
+func (*messageIndex) parse(pos []byte, record []byte, getMessage func(ids.ID) (*avalancheWarp.UnsignedMessage, error)) (*IndexedMessage, error) {
+	if len(record) != recordLen {
+		return nil, fmt.Errorf("invalid indexed message record length %d at %x", len(record), pos)
+	}
+	messageID := recordMessageID(record)
+	message, err := getMessage(messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get indexed message %s: %w", messageID, err)
+	}
+	return &IndexedMessage{
+		BlockNumber:   binary.BigEndian.Uint64(pos),
+		BlockHash:     common.BytesToHash(record[:common.HashLength]),
+		TxHash:        common.BytesToHash(record[common.HashLength : 2*common.HashLength]),
+		LogIndex:      binary.BigEndian.Uint32(record[2*common.HashLength:]),
+		SourceAddress: recordSource(record),
+		Message:       message,
+	}, nil
+}
+
+func recordMessageID(record []byte) ids.ID {
+	return ids.ID(record[2*common.HashLength+4:])
+}
+
+func recordSource(record []byte) common.Address {
+	return common.BytesToAddress(record[2*common.HashLength+4+ids.IDLen:])
+}
+
+// prune removes up to limit of the messages sent in blocks below minHeight from
+// the index, including the messages stored before the index existed, and
+// returns the number removed. deleteMessage is called with the ID of each
+// removed message that was not sent again at or above minHeight, before its
+// entries are removed.
+func (i *messageIndex) prune(minHeight uint64, limit int, deleteMessage func(ids.ID) error) (int, error) {
+	type entry struct {
+		messageID ids.ID
+		keys      [][]byte
+	}
+	var entries []entry
+	it := i.db.NewIteratorWithPrefix(heightPrefix)
+	for it.Next() && len(entries) < limit {
+		pos := it.Key()[len(heightPrefix):]
+		if binary.BigEndian.Uint64(pos) >= minHeight {
+			break
+		}
+		record := it.Value()
+		if len(record) != recordLen {
+			it.Release()
+			return 0, fmt.Errorf("invalid indexed message record length %d at %x", len(record), pos)
+		}
+		entries = append(entries, entry{
+			messageID: recordMessageID(record),
+			keys: [][]byte{
+				heightKey(pos),
+				senderKey(recordSource(record), pos),
+			},
+		})
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return 0, err
+	}
+
+	it = i.db.NewIteratorWithPrefix(storedPrefix)
+	for it.Next() && len(entries) < limit {
+		key := it.Key()
+		if len(key) != len(storedPrefix)+8+ids.IDLen {
+			it.Release()
+			return 0, fmt.Errorf("invalid stored message key %x", key)
+		}
+		if binary.BigEndian.Uint64(key[len(storedPrefix):]) >= minHeight {
+			break
+		}
+		entries = append(entries, entry{
+			messageID: ids.ID(key[len(storedPrefix)+8:]),
+			keys:      [][]byte{append([]byte{}, key...)},
+		})
+	}
+	err = it.Error()
+	it.Release()
+	if err != nil {
+		return 0, err
+	}
+
+	retained := position(minHeight, 0)
+	batch := i.db.NewBatch()
+	for _, entry := range entries {
+		latest, err := i.db.Get(latestKey(entry.messageID))
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return 0, err
+		}
+		// Messages sent again in a retained block are kept.
+		if err != nil || bytes.Compare(latest, retained) < 0 {
+			if err := deleteMessage(entry.messageID); err != nil {
+				return 0, err
+			}
+			if err := batch.Delete(latestKey(entry.messageID)); err != nil {
+				return 0, err
+			}
+		}
+		for _, key := range entry.keys {
+			if err := batch.Delete(key); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(entries), batch.Write()
+}
+
+// subscribe registers a subscription for the messages indexed from now on. The
+// subscription fails with errLaggingSubscriber if more than
+// messageSubscriptionBuffer messages are waiting to be received from ch.
+func (i *messageIndex) subscribe(ch chan<- NewMessageEvent) event.Subscription {
+	sub := &messageSubscriber{
+		queue:   make(chan NewMessageEvent, messageSubscriptionBuffer),
+		lagging: make(chan struct{}),
+	}
+	i.subsLock.Lock()
+	i.subs[sub] = struct{}{}
+	i.subsLock.Unlock()
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer func() {
+			i.subsLock.Lock()
+			delete(i.subs, sub)
+			i.subsLock.Unlock()
+		}()
+		for {
+			select {
+			case ev := <-sub.queue:
+				select {
+				case ch <- ev:
+				case <-sub.lagging:
+					return errLaggingSubscriber
+				case <-quit:
+					return nil
+				}
+			case <-sub.lagging:
+				return errLaggingSubscriber
+			case <-quit:
+				return nil
+			}
+		}
+	})
+}
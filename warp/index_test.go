@@ -0,0 +1,176 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/cache/lru"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/libevm/common"
+	"github.com/stretchr/testify/require"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+func TestMessageIndex(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := localsigner.New()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, nil, memdb.New(), lru.NewCache[ids.ID, []byte](500), nil, nil)
+	require.NoError(err)
+
+	newMessages := make(chan NewMessageEvent, 4)
+	sub := backend.SubscribeIndexedMessages(newMessages)
+	defer sub.Unsubscribe()
+
+	var (
+		alice = common.Address{1}
+		bob   = common.Address{2}
+		sent  []*avalancheWarp.UnsignedMessage
+	)
+	// Send messages from alice in blocks 1 and 3, and from bob in blocks 1 and
+	// 2.
+	for i, send := range []struct {
+		height uint64
+		source common.Address
+	}{
+		{height: 1, source: alice},
+		{height: 1, source: bob},
+		{height: 2, source: bob},
+		{height: 3, source: alice},
+	} {
+		addressedCall, err := payload.NewAddressedCall(send.source.Bytes(), []byte{byte(i)})
+		require.NoError(err)
+		message, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		require.NoError(backend.AddMessage(message))
+		require.NoError(backend.IndexMessage(send.height, common.Hash{byte(send.height)}, common.Hash{byte(i)}, i, message))
+		sent = append(sent, message)
+
+		ev := <-newMessages
+		require.Equal(send.height, ev.Message.BlockNumber)
+		require.Equal(send.source, ev.Message.SourceAddress)
+		require.Equal(message, ev.Message.Message)
+	}
+
+	messageIDs := func(messages []*IndexedMessage) []ids.ID {
+		var have []ids.ID
+		for _, message := range messages {
+			have = append(have, message.Message.ID())
+		}
+		return have
+	}
+	tests := []struct {
+		name  string
+		query MessageQuery
+		want  []*avalancheWarp.UnsignedMessage
+	}{
+		{
+			name:  "all",
+			query: MessageQuery{ToBlock: math.MaxUint64},
+			want:  sent,
+		},
+		{
+			name:  "block range",
+			query: MessageQuery{FromBlock: 2, ToBlock: 2},
+			want:  sent[2:3],
+		},
+		{
+			name:  "sender",
+			query: MessageQuery{ToBlock: math.MaxUint64, SourceAddress: &alice},
+			want:  []*avalancheWarp.UnsignedMessage{sent[0], sent[3]},
+		},
+		{
+			name:  "sender and block range",
+			query: MessageQuery{FromBlock: 2, ToBlock: 3, SourceAddress: &bob},
+			want:  sent[2:3],
+		},
+	}
+	for _, test := range tests {
+		messages, cursor, err := backend.GetIndexedMessages(test.query)
+		require.NoError(err, test.name)
+		require.Nil(cursor, test.name)
+
+		var want []ids.ID
+		for _, message := range test.want {
+			want = append(want, message.ID())
+		}
+		require.Equal(want, messageIDs(messages), test.name)
+	}
+
+	// Page through all messages two at a time.
+	var (
+		query = MessageQuery{ToBlock: math.MaxUint64, Limit: 2}
+		paged []*IndexedMessage
+	)
+	for {
+		messages, cursor, err := backend.GetIndexedMessages(query)
+		require.NoError(err)
+		paged = append(paged, messages...)
+		if cursor == nil {
+			break
+		}
+		query.Cursor = cursor
+	}
+	require.Len(paged, len(sent))
+	require.Equal(&IndexedMessage{
+		BlockNumber:   3,
+		BlockHash:     common.Hash{3},
+		TxHash:        common.Hash{3},
+		LogIndex:      3,
+		SourceAddress: alice,
+		Message:       sent[3],
+	}, paged[3])
+
+	_, _, err = backend.GetIndexedMessages(MessageQuery{ToBlock: 1, Cursor: []byte{1}})
+	require.ErrorIs(err, errInvalidCursor)
+	_, _, err = backend.GetIndexedMessages(MessageQuery{FromBlock: 2, ToBlock: 1})
+	require.ErrorIs(err, errInvalidRange)
+}
+
+func TestMessageIndexLaggingSubscriber(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := localsigner.New()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, nil, memdb.New(), lru.NewCache[ids.ID, []byte](500), nil, nil)
+	require.NoError(err)
+
+	// The lagging subscriber never reads its messages.
+	lagging := backend.SubscribeIndexedMessages(make(chan NewMessageEvent))
+	defer lagging.Unsubscribe()
+	newMessages := make(chan NewMessageEvent, messageSubscriptionBuffer+1)
+	sub := backend.SubscribeIndexedMessages(newMessages)
+	defer sub.Unsubscribe()
+
+	// Indexing must not wait for the lagging subscriber.
+	source := common.Address{1}
+	for i := 0; i <= messageSubscriptionBuffer+1; i++ {
+		addressedCall, err := payload.NewAddressedCall(source.Bytes(), []byte{byte(i), byte(i >> 8)})
+		require.NoError(err)
+		message, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		require.NoError(backend.IndexMessage(uint64(i+1), common.Hash{}, common.Hash{}, 0, message))
+
+		if i < messageSubscriptionBuffer {
+			ev := <-newMessages
+			require.Equal(uint64(i+1), ev.Message.BlockNumber)
+		}
+	}
+	require.ErrorIs(<-lagging.Err(), errLaggingSubscriber)
+
+	// The subscriber reading its messages is unaffected.
+	for i := messageSubscriptionBuffer; i <= messageSubscriptionBuffer+1; i++ {
+		ev := <-newMessages
+		require.Equal(uint64(i+1), ev.Message.BlockNumber)
+	}
+}
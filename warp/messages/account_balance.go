@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package messages
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/holiman/uint256"
+)
+
+// AccountBalance is signed when Address has a balance of Balance after the
+// accepted block at Height.
+type AccountBalance struct {
+	Address common.Address `serialize:"true"`
+	Balance [32]byte       `serialize:"true"` // big-endian
+	Height  uint64         `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewAccountBalance creates a new *AccountBalance and initializes it.
+func NewAccountBalance(address common.Address, balance *uint256.Int, height uint64) (*AccountBalance, error) {
+	bhp := &AccountBalance{
+		Address: address,
+		Balance: balance.Bytes32(),
+		Height:  height,
+	}
+	return bhp, initialize(bhp)
+}
+
+// ParseAccountBalance converts a slice of bytes into an initialized AccountBalance.
+func ParseAccountBalance(b []byte) (*AccountBalance, error) {
+	payloadIntf, err := Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	payload, ok := payloadIntf.(*AccountBalance)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errWrongType, payloadIntf)
+	}
+	return payload, nil
+}
+
+// Bytes returns the binary representation of this payload. It assumes that the
+// payload is initialized from either NewAccountBalance or Parse.
+func (b *AccountBalance) Bytes() []byte {
+	return b.bytes
+}
+
+func (b *AccountBalance) initialize(bytes []byte) {
+	b.bytes = bytes
+}
@@ -25,6 +25,8 @@ func init() {
 
 	err := errors.Join(
 		lc.RegisterType(&ValidatorUptime{}),
+		lc.RegisterType(&AccountBalance{}),
+		lc.RegisterType(&StorageSlot{}),
 		Codec.RegisterCodec(CodecVersion, lc),
 	)
 	if err != nil {
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package messages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	ErrUnknownPayload        = errors.New("unknown message type")
+	errDuplicateRegistration = errors.New("verifier already registered")
+)
+
+// Verifier verifies an off-chain payload before it is signed.
+type Verifier interface {
+	// Verify returns nil if payload should be signed.
+	Verify(ctx context.Context, payload Payload) error
+}
+
+// VerifierFunc is a Verifier of payloads of type T.
+type VerifierFunc[T Payload] func(ctx context.Context, payload T) error
+
+// Verify implements Verifier.
+func (f VerifierFunc[T]) Verify(ctx context.Context, payload Payload) error {
+	p, ok := payload.(T)
+	if !ok {
+		return fmt.Errorf("%w: %T", errWrongType, payload)
+	}
+	return f(ctx, p)
+}
+
+// Registry holds the verifiers of the off-chain payload types signed by this
+// node. Payloads of types without a verifier are not signed.
+type Registry struct {
+	lock      sync.RWMutex
+	verifiers map[reflect.Type]Verifier
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		verifiers: make(map[reflect.Type]Verifier),
+	}
+}
+
+// Register sets verifier as the verifier of payloads of type T.
+func Register[T Payload](r *Registry, verifier VerifierFunc[T]) error {
+	typ := reflect.TypeFor[T]()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.verifiers[typ]; ok {
+		return fmt.Errorf("%w: %s", errDuplicateRegistration, typ)
+	}
+	r.verifiers[typ] = verifier
+	return nil
+}
+
+// Verify verifies payload with the verifier of its type.
+func (r *Registry) Verify(ctx context.Context, payload Payload) error {
+	r.lock.RLock()
+	verifier, ok := r.verifiers[reflect.TypeOf(payload)]
+	r.lock.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnknownPayload, payload)
+	}
+	return verifier.Verify(ctx, payload)
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package messages
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/libevm/common"
+)
+
+// StorageSlot is signed when the storage slot Slot of the contract at Address
+// holds Value after the accepted block at Height.
+type StorageSlot struct {
+	Address common.Address `serialize:"true"`
+	Slot    common.Hash    `serialize:"true"`
+	Value   common.Hash    `serialize:"true"`
+	Height  uint64         `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewStorageSlot creates a new *StorageSlot and initializes it.
+func NewStorageSlot(address common.Address, slot common.Hash, value common.Hash, height uint64) (*StorageSlot, error) {
+	bhp := &StorageSlot{
+		Address: address,
+		Slot:    slot,
+		Value:   value,
+		Height:  height,
+	}
+	return bhp, initialize(bhp)
+}
+
+// ParseStorageSlot converts a slice of bytes into an initialized StorageSlot.
+func ParseStorageSlot(b []byte) (*StorageSlot, error) {
+	payloadIntf, err := Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	payload, ok := payloadIntf.(*StorageSlot)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errWrongType, payloadIntf)
+	}
+	return payload, nil
+}
+
+// Bytes returns the binary representation of this payload. It assumes that the
+// payload is initialized from either NewStorageSlot or Parse.
+func (b *StorageSlot) Bytes() []byte {
+	return b.bytes
+}
+
+func (b *StorageSlot) initialize(bytes []byte) {
+	b.bytes = bytes
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/log"
+)
+
+// RetentionPolicy selects the messages sent in accepted blocks that are kept by
+// a Pruner. A message is pruned once its block is more than Blocks blocks
+// behind the last accepted block, or older than Period. A zero value disables
+// the corresponding limit.
+type RetentionPolicy struct {
+	Blocks uint64
+	Period time.Duration
+}
+
+// Enabled reports whether the policy prunes any message.
+func (p RetentionPolicy) Enabled() bool {
+	return p.Blocks > 0 || p.Period > 0
+}
+
+// ChainReader reads the accepted chain.
+type ChainReader interface {
+	LastAcceptedBlock() *types.Block
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// Pruner prunes the messages sent in accepted blocks outside of a retention
+// policy from a Backend.
+type Pruner struct {
+	backend Backend
+	chain   ChainReader
+	policy  RetentionPolicy
+	now     func() time.Time
+}
+
+func NewPruner(backend Backend, chain ChainReader, policy RetentionPolicy) *Pruner {
+	return &Pruner{
+		backend: backend,
+		chain:   chain,
+		policy:  policy,
+		now:     time.Now,
+	}
+}
+
+// Run indexes the messages stored before the message index existed, so that
+// they are pruned too, then prunes messages every interval until ctx is done.
+func (p *Pruner) Run(ctx context.Context, interval time.Duration) {
+	if err := p.indexStoredMessages(); err != nil {
+		log.Error("failed to index stored warp messages", "err", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.Prune(ctx); err != nil {
+				log.Error("failed to prune warp messages", "err", err)
+			}
+		}
+	}
+}
+
+// indexStoredMessages indexes the messages stored before the message index
+// existed as sent in the last accepted block, which they were sent at or
+// before.
+func (p *Pruner) indexStoredMessages() error {
+	height := p.chain.LastAcceptedBlock().NumberU64()
+	indexed, err := p.backend.IndexStoredMessages(height)
+	if indexed > 0 {
+		log.Info("Indexed stored warp messages", "height", height, "indexed", indexed)
+	}
+	return err
+}
+
+// Prune prunes the messages outside of the retention policy and returns the
+// number of messages removed. It stops between batches once ctx is done.
+func (p *Pruner) Prune(ctx context.Context) (int, error) {
+	minHeight := p.minHeight()
+	if minHeight == 0 {
+		return 0, nil
+	}
+	start := time.Now()
+	pruned, err := p.backend.Prune(ctx, minHeight)
+	if pruned > 0 {
+		log.Info("Pruned warp messages", "minHeight", minHeight, "pruned", pruned, "elapsed", time.Since(start))
+	}
+	return pruned, err
+}
+
+// minHeight returns the height of the oldest block whose messages are
+// retained.
+func (p *Pruner) minHeight() uint64 {
+	last := p.chain.LastAcceptedBlock().NumberU64()
+	var minHeight uint64
+	if p.policy.Blocks > 0 && last >= p.policy.Blocks {
+		minHeight = last - p.policy.Blocks + 1
+	}
+	if p.policy.Period > 0 {
+		cutoff := p.now().Add(-p.policy.Period).Unix()
+		if cutoff <= 0 {
+			return minHeight
+		}
+		// Block times are non-decreasing, so search for the first block at or
+		// after the cutoff. Missing headers are retained.
+		minHeight += uint64(sort.Search(int(last-minHeight+1), func(i int) bool {
+			header := p.chain.GetHeaderByNumber(minHeight + uint64(i))
+			return header == nil || header.Time >= uint64(cutoff)
+		}))
+	}
+	return minHeight
+}
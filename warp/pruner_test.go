@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache/lru"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/stretchr/testify/require"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// testChain is a chain of blocks accepted every 10 seconds.
+type testChain struct {
+	last uint64
+}
+
+func (c *testChain) LastAcceptedBlock() *types.Block {
+	return types.NewBlockWithHeader(c.GetHeaderByNumber(c.last))
+}
+
+func (c *testChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number > c.last {
+		return nil
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(number), Time: 10 * number}
+}
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+
+	newMessage := func(b byte) *avalancheWarp.UnsignedMessage {
+		addressedCall, err := payload.NewAddressedCall(common.Address{b}.Bytes(), []byte{b})
+		require.NoError(err)
+		message, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		return message
+	}
+	var (
+		resent   = newMessage(1)
+		pruned   = newMessage(2)
+		latest   = newMessage(3)
+		offchain = newMessage(4)
+	)
+
+	sk, err := localsigner.New()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	b, err := NewBackend(networkID, sourceChainID, warpSigner, nil, nil, memdb.New(), lru.NewCache[ids.ID, []byte](500), [][]byte{offchain.Bytes()}, nil)
+	require.NoError(err)
+
+	for i, send := range []struct {
+		height  uint64
+		message *avalancheWarp.UnsignedMessage
+	}{
+		{height: 1, message: resent},
+		{height: 2, message: pruned},
+		{height: 3, message: resent},
+		{height: 4, message: latest},
+	} {
+		require.NoError(b.AddMessage(send.message))
+		require.NoError(b.IndexMessage(send.height, common.Hash{byte(send.height)}, common.Hash{byte(i)}, i, send.message))
+	}
+	stats := b.(*backend)
+	require.Equal(int64(3), stats.storedMessages.Snapshot().Value())
+
+	// Only the messages of blocks 3 and 4 are retained.
+	pruner := NewPruner(b, &testChain{last: 4}, RetentionPolicy{Blocks: 2})
+	n, err := pruner.Prune(context.Background())
+	require.NoError(err)
+	require.Equal(1, n)
+
+	_, err = b.GetMessage(pruned.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+	for _, message := range []*avalancheWarp.UnsignedMessage{resent, latest, offchain} {
+		got, err := b.GetMessage(message.ID())
+		require.NoError(err)
+		require.Equal(message.Bytes(), got.Bytes())
+	}
+	indexed, _, err := b.GetIndexedMessages(MessageQuery{ToBlock: math.MaxUint64})
+	require.NoError(err)
+	require.Len(indexed, 2)
+	require.Equal(uint64(3), indexed[0].BlockNumber)
+	require.Equal(uint64(4), indexed[1].BlockNumber)
+	require.Equal(int64(2), stats.storedMessages.Snapshot().Value())
+	require.Equal(int64(len(resent.Bytes())+len(latest.Bytes())), stats.storedBytes.Snapshot().Value())
+
+	// Pruning again is a no-op.
+	n, err = pruner.Prune(context.Background())
+	require.NoError(err)
+	require.Zero(n)
+
+	// A message sent again after its block was pruned is stored again.
+	require.NoError(b.IndexMessage(5, common.Hash{5}, common.Hash{5}, 0, pruned))
+	_, err = b.GetMessage(pruned.ID())
+	require.NoError(err)
+}
+
+func TestPruneStoredMessages(t *testing.T) {
+	require := require.New(t)
+
+	newMessage := func(b byte) *avalancheWarp.UnsignedMessage {
+		addressedCall, err := payload.NewAddressedCall(common.Address{b}.Bytes(), []byte{b})
+		require.NoError(err)
+		message, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		return message
+	}
+	var (
+		stored = newMessage(1)
+		resent = newMessage(2)
+	)
+
+	sk, err := localsigner.New()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	b, err := NewBackend(networkID, sourceChainID, warpSigner, nil, nil, memdb.New(), lru.NewCache[ids.ID, []byte](500), nil, nil)
+	require.NoError(err)
+
+	// Messages stored before the index existed are indexed once.
+	require.NoError(b.AddMessage(stored))
+	require.NoError(b.AddMessage(resent))
+	chain := &testChain{last: 4}
+	pruner := NewPruner(b, chain, RetentionPolicy{Blocks: 2})
+	require.NoError(pruner.indexStoredMessages())
+	n, err := b.IndexStoredMessages(chain.last)
+	require.NoError(err)
+	require.Zero(n)
+	indexed, _, err := b.GetIndexedMessages(MessageQuery{ToBlock: math.MaxUint64})
+	require.NoError(err)
+	require.Empty(indexed)
+
+	// They are retained as long as the block they were indexed at.
+	n, err = pruner.Prune(context.Background())
+	require.NoError(err)
+	require.Zero(n)
+
+	require.NoError(b.IndexMessage(5, common.Hash{5}, common.Hash{5}, 0, resent))
+	chain.last = 6
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = pruner.Prune(ctx)
+	require.ErrorIs(err, context.Canceled)
+	n, err = pruner.Prune(context.Background())
+	require.NoError(err)
+	require.Equal(1, n)
+
+	_, err = b.GetMessage(stored.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = b.GetMessage(resent.ID())
+	require.NoError(err)
+}
+
+func TestPrunerMinHeight(t *testing.T) {
+	chain := &testChain{last: 10}
+	now := time.Unix(100, 0) // Time of block 10
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+		want   uint64
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:   "blocks",
+			policy: RetentionPolicy{Blocks: 3},
+			want:   8,
+		},
+		{
+			name:   "more blocks than accepted",
+			policy: RetentionPolicy{Blocks: 20},
+		},
+		{
+			name:   "period",
+			policy: RetentionPolicy{Period: 35 * time.Second},
+			want:   7,
+		},
+		{
+			name:   "period longer than chain",
+			policy: RetentionPolicy{Period: time.Hour},
+		},
+		{
+			name:   "blocks before period",
+			policy: RetentionPolicy{Blocks: 2, Period: 35 * time.Second},
+			want:   9,
+		},
+		{
+			name:   "period before blocks",
+			policy: RetentionPolicy{Blocks: 5, Period: 15 * time.Second},
+			want:   9,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pruner := NewPruner(nil, chain, test.policy)
+			pruner.now = func() time.Time { return now }
+			require.Equal(t, test.want, pruner.minHeight())
+		})
+	}
+}
@@ -5,34 +5,81 @@ package warp
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"math"
+	"sync"
+	"time"
 
+	"github.com/ava-labs/avalanchego/cache/lru"
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/network/p2p/acp118"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/event"
 	"github.com/ava-labs/libevm/log"
 
+	"github.com/ava-labs/subnet-evm/rpc"
+
 	warpprecompile "github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 )
 
-var errNoValidators = errors.New("cannot aggregate signatures from subnet with no validators")
+const (
+	// maxAggregationJobs is the number of aggregation jobs whose status is
+	// retained. The least recently accessed jobs are dropped beyond it, and
+	// cancelled if they are still running.
+	maxAggregationJobs = 1024
+
+	// maxRunningAggregationJobs is the number of aggregation jobs that may run
+	// at the same time.
+	maxRunningAggregationJobs = 16
+
+	// aggregationJobTimeout bounds the time an aggregation job waits for
+	// validators to sign.
+	aggregationJobTimeout = 5 * time.Minute
+)
+
+var (
+	errNoValidators     = errors.New("cannot aggregate signatures from subnet with no validators")
+	errUnknownJob       = errors.New("unknown aggregation job")
+	errTooManyJobs      = errors.New("too many running aggregation jobs")
+	errQuorumNotReached = errors.New("aggregation finished below quorum")
+)
 
 // API introduces snowman specific functionality to the evm
 type API struct {
 	chainContext        *snow.Context
 	backend             Backend
-	signatureAggregator *acp118.SignatureAggregator
+	signatureAggregator *SignatureAggregator
+	jobs                *lru.Cache[ids.ID, *aggregationJob]
+
+	// runningLock guards running, the IDs of the running aggregation jobs by
+	// their message, quorum and subnet.
+	runningLock sync.Mutex
+	running     map[aggregationKey]ids.ID
 }
 
-func NewAPI(chainCtx *snow.Context, backend Backend, signatureAggregator *acp118.SignatureAggregator) *API {
+// aggregationKey identifies the aggregations of the same message, with the
+// same quorum and validators, which share a single job while it runs.
+type aggregationKey struct {
+	messageID ids.ID
+	quorumNum uint64
+	subnetID  string
+}
+
+func NewAPI(chainCtx *snow.Context, backend Backend, signatureAggregator *SignatureAggregator) *API {
 	return &API{
 		backend:             backend,
 		chainContext:        chainCtx,
 		signatureAggregator: signatureAggregator,
+		jobs: lru.NewCacheWithOnEvict(maxAggregationJobs, func(_ ids.ID, job *aggregationJob) {
+			job.cancel()
+		}),
+		running: make(map[aggregationKey]ids.ID),
 	}
 }
 
@@ -90,27 +137,29 @@ func (a *API) GetBlockAggregateSignature(ctx context.Context, blockID ids.ID, qu
 	return a.aggregateSignatures(ctx, unsignedMessage, quorumNum, subnetIDStr)
 }
 
-func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.UnsignedMessage, quorumNum uint64, subnetIDStr string) (hexutil.Bytes, error) {
+// validatorSet returns the current validator set of the subnet with
+// subnetIDStr, or of the subnet of this chain if it is empty.
+func (a *API) validatorSet(ctx context.Context, subnetIDStr string) (validators.WarpSet, error) {
 	subnetID := a.chainContext.SubnetID
 	if len(subnetIDStr) > 0 {
 		sid, err := ids.FromString(subnetIDStr)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse subnetID: %q", subnetIDStr)
+			return validators.WarpSet{}, fmt.Errorf("failed to parse subnetID: %q", subnetIDStr)
 		}
 		subnetID = sid
 	}
 	validatorState := a.chainContext.ValidatorState
 	pChainHeight, err := validatorState.GetCurrentHeight(ctx)
 	if err != nil {
-		return nil, err
+		return validators.WarpSet{}, err
 	}
 
 	validatorSet, err := validatorState.GetWarpValidatorSet(ctx, pChainHeight, subnetID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get validator set: %w", err)
+		return validators.WarpSet{}, fmt.Errorf("failed to get validator set: %w", err)
 	}
 	if len(validatorSet.Validators) == 0 {
-		return nil, fmt.Errorf("%w (SubnetID: %s, Height: %d)", errNoValidators, subnetID, pChainHeight)
+		return validators.WarpSet{}, fmt.Errorf("%w (SubnetID: %s, Height: %d)", errNoValidators, subnetID, pChainHeight)
 	}
 
 	log.Debug("Fetching signature",
@@ -119,17 +168,21 @@ func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.Uns
 		"numValidators", len(validatorSet.Validators),
 		"totalWeight", validatorSet.TotalWeight,
 	)
-	warpMessage := &warp.Message{
-		UnsignedMessage: *unsignedMessage,
-		Signature:       &warp.BitSetSignature{},
+	return validatorSet, nil
+}
+
+func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.UnsignedMessage, quorumNum uint64, subnetIDStr string) (hexutil.Bytes, error) {
+	validatorSet, err := a.validatorSet(ctx, subnetIDStr)
+	if err != nil {
+		return nil, err
 	}
-	signedMessage, _, _, err := a.signatureAggregator.AggregateSignatures(
+	signedMessage, _, err := a.signatureAggregator.Aggregate(
 		ctx,
-		warpMessage,
-		nil,
-		validatorSet.Validators,
+		unsignedMessage,
+		validatorSet,
 		quorumNum,
 		warpprecompile.WarpQuorumDenominator,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -139,3 +192,310 @@ func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.Uns
 	// gotchas that could impact signed messages becoming invalid.
 	return hexutil.Bytes(signedMessage.Bytes()), nil
 }
+
+// AggregationStatus is the status of an aggregation job started by
+// StartMessageAggregation or StartBlockAggregation. SignedMessage is set once
+// the job is done, unless it failed with Error, including when the signed
+// weight did not reach the quorum.
+type AggregationStatus struct {
+	JobID         ids.ID         `json:"jobID"`
+	Done          bool           `json:"done"`
+	SignedWeight  hexutil.Uint64 `json:"signedWeight"`
+	TotalWeight   hexutil.Uint64 `json:"totalWeight"`
+	SignedMessage hexutil.Bytes  `json:"signedMessage,omitempty"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// aggregationJob tracks the status of an aggregation running in the
+// background and posts each update to its subscribers.
+type aggregationJob struct {
+	cancel context.CancelFunc
+
+	lock   sync.Mutex
+	status AggregationStatus
+	feed   event.Feed
+}
+
+func (j *aggregationJob) update(update func(*AggregationStatus)) {
+	j.lock.Lock()
+	update(&j.status)
+	status := j.status
+	j.lock.Unlock()
+
+	j.feed.Send(status)
+}
+
+func (j *aggregationJob) current() AggregationStatus {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.status
+}
+
+// subscribe registers a subscription for the updates of the job and returns
+// its current status.
+func (j *aggregationJob) subscribe(ch chan<- AggregationStatus) (event.Subscription, AggregationStatus) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.feed.Subscribe(ch), j.status
+}
+
+// StartMessageAggregation starts aggregating the signatures of the message with
+// [messageID] in the background and returns the ID of the job. If the same
+// aggregation is already running, the ID of its job is returned instead.
+func (a *API) StartMessageAggregation(ctx context.Context, messageID ids.ID, quorumNum uint64, subnetIDStr string) (ids.ID, error) {
+	unsignedMessage, err := a.backend.GetMessage(messageID)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return a.startAggregation(ctx, unsignedMessage, quorumNum, subnetIDStr)
+}
+
+// StartBlockAggregation starts aggregating the signatures of the block with
+// [blockID] in the background and returns the ID of the job. If the same
+// aggregation is already running, the ID of its job is returned instead.
+func (a *API) StartBlockAggregation(ctx context.Context, blockID ids.ID, quorumNum uint64, subnetIDStr string) (ids.ID, error) {
+	blockHashPayload, err := payload.NewHash(blockID)
+	if err != nil {
+		return ids.Empty, err
+	}
+	unsignedMessage, err := warp.NewUnsignedMessage(a.chainContext.NetworkID, a.chainContext.ChainID, blockHashPayload.Bytes())
+	if err != nil {
+		return ids.Empty, err
+	}
+	return a.startAggregation(ctx, unsignedMessage, quorumNum, subnetIDStr)
+}
+
+func (a *API) startAggregation(ctx context.Context, unsignedMessage *warp.UnsignedMessage, quorumNum uint64, subnetIDStr string) (ids.ID, error) {
+	validatorSet, err := a.validatorSet(ctx, subnetIDStr)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	key := aggregationKey{messageID: unsignedMessage.ID(), quorumNum: quorumNum, subnetID: subnetIDStr}
+	a.runningLock.Lock()
+	defer a.runningLock.Unlock()
+
+	if jobID, ok := a.running[key]; ok {
+		// The job may have been dropped from the retained jobs, in which case
+		// it was cancelled and a new one is started.
+		if _, ok := a.jobs.Get(jobID); ok {
+			return jobID, nil
+		}
+	}
+	if len(a.running) >= maxRunningAggregationJobs {
+		return ids.Empty, fmt.Errorf("%w: %d", errTooManyJobs, len(a.running))
+	}
+	var jobID ids.ID
+	if _, err := rand.Read(jobID[:]); err != nil {
+		return ids.Empty, err
+	}
+	jobCtx, cancel := context.WithTimeout(context.Background(), aggregationJobTimeout)
+	job := &aggregationJob{
+		cancel: cancel,
+		status: AggregationStatus{
+			JobID:       jobID,
+			TotalWeight: hexutil.Uint64(validatorSet.TotalWeight),
+		},
+	}
+	a.jobs.Put(jobID, job)
+	a.running[key] = jobID
+
+	go func() {
+		defer cancel()
+		defer func() {
+			a.runningLock.Lock()
+			if a.running[key] == jobID {
+				delete(a.running, key)
+			}
+			a.runningLock.Unlock()
+		}()
+
+		signedMessage, progress, err := a.signatureAggregator.Aggregate(
+			jobCtx,
+			unsignedMessage,
+			validatorSet,
+			quorumNum,
+			warpprecompile.WarpQuorumDenominator,
+			func(progress AggregationProgress) {
+				job.update(func(status *AggregationStatus) {
+					status.SignedWeight = hexutil.Uint64(progress.SignedWeight)
+				})
+			},
+		)
+		if err == nil {
+			if weightErr := warp.VerifyWeight(progress.SignedWeight, progress.TotalWeight, quorumNum, warpprecompile.WarpQuorumDenominator); weightErr != nil {
+				err = fmt.Errorf("%w: %w", errQuorumNotReached, weightErr)
+				if ctxErr := jobCtx.Err(); ctxErr != nil {
+					err = fmt.Errorf("%w: %w", err, ctxErr)
+				}
+			}
+		}
+		job.update(func(status *AggregationStatus) {
+			status.Done = true
+			status.SignedWeight = hexutil.Uint64(progress.SignedWeight)
+			if err != nil {
+				status.Error = err.Error()
+				return
+			}
+			status.SignedMessage = signedMessage.Bytes()
+		})
+	}()
+	return jobID, nil
+}
+
+// GetAggregation returns the status of the aggregation job with [jobID].
+func (a *API) GetAggregation(_ context.Context, jobID ids.ID) (*AggregationStatus, error) {
+	job, ok := a.jobs.Get(jobID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownJob, jobID)
+	}
+	status := job.current()
+	return &status, nil
+}
+
+// AggregationProgress creates a subscription that fires with the status of the
+// aggregation job with [jobID] when subscribed and each time it changes, until
+// the job is done.
+func (a *API) AggregationProgress(ctx context.Context, jobID ids.ID) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	job, ok := a.jobs.Get(jobID)
+	if !ok {
+		return &rpc.Subscription{}, fmt.Errorf("%w: %s", errUnknownJob, jobID)
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		updates := make(chan AggregationStatus)
+		updatesSub, status := job.subscribe(updates)
+		defer updatesSub.Unsubscribe()
+
+		for {
+			notifier.Notify(rpcSub.ID, status)
+			if status.Done {
+				return
+			}
+			select {
+			case status = <-updates:
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// MessageFilter selects the messages sent in accepted blocks between FromBlock
+// and ToBlock, inclusive, optionally only by SourceAddress. Cursor resumes a
+// query from the cursor returned with the previous page of messages.
+type MessageFilter struct {
+	FromBlock     *hexutil.Uint64 `json:"fromBlock,omitempty"`
+	ToBlock       *hexutil.Uint64 `json:"toBlock,omitempty"`
+	SourceAddress *common.Address `json:"sourceAddress,omitempty"`
+	Cursor        hexutil.Bytes   `json:"cursor,omitempty"`
+	Limit         hexutil.Uint64  `json:"limit,omitempty"`
+}
+
+// RPCMessage is a message sent in an accepted block.
+type RPCMessage struct {
+	MessageID     ids.ID         `json:"messageID"`
+	BlockNumber   hexutil.Uint64 `json:"blockNumber"`
+	BlockHash     common.Hash    `json:"blockHash"`
+	TxHash        common.Hash    `json:"transactionHash"`
+	LogIndex      hexutil.Uint   `json:"logIndex"`
+	SourceAddress common.Address `json:"sourceAddress"`
+	Message       hexutil.Bytes  `json:"message"`
+}
+
+func newRPCMessage(message *IndexedMessage) *RPCMessage {
+	return &RPCMessage{
+		MessageID:     message.Message.ID(),
+		BlockNumber:   hexutil.Uint64(message.BlockNumber),
+		BlockHash:     message.BlockHash,
+		TxHash:        message.TxHash,
+		LogIndex:      hexutil.Uint(message.LogIndex),
+		SourceAddress: message.SourceAddress,
+		Message:       message.Message.Bytes(),
+	}
+}
+
+// MessagePage is a page of messages returned by GetMessages. Cursor is set if
+// more messages match the filter.
+type MessagePage struct {
+	Messages []*RPCMessage `json:"messages"`
+	Cursor   hexutil.Bytes `json:"cursor,omitempty"`
+}
+
+// GetMessages returns the messages sent in accepted blocks selected by filter,
+// in the order they were sent, up to the filter limit or MaxIndexedMessages.
+func (a *API) GetMessages(_ context.Context, filter MessageFilter) (*MessagePage, error) {
+	query := MessageQuery{
+		ToBlock:       math.MaxUint64,
+		SourceAddress: filter.SourceAddress,
+		Cursor:        filter.Cursor,
+		Limit:         int(min(filter.Limit, MaxIndexedMessages)),
+	}
+	if filter.FromBlock != nil {
+		query.FromBlock = uint64(*filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		query.ToBlock = uint64(*filter.ToBlock)
+	}
+	messages, cursor, err := a.backend.GetIndexedMessages(query)
+	if err != nil {
+		return nil, err
+	}
+	page := &MessagePage{
+		Messages: make([]*RPCMessage, len(messages)),
+		Cursor:   cursor,
+	}
+	for i, message := range messages {
+		page.Messages[i] = newRPCMessage(message)
+	}
+	return page, nil
+}
+
+// Messages creates a subscription that fires for each message sent in an
+// accepted block, optionally only by the source address of filter.
+func (a *API) Messages(ctx context.Context, filter *MessageFilter) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		messages := make(chan NewMessageEvent, 16)
+		messagesSub := a.backend.SubscribeIndexedMessages(messages)
+		defer messagesSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-messages:
+				if filter != nil && filter.SourceAddress != nil && *filter.SourceAddress != ev.Message.SourceAddress {
+					continue
+				}
+				notifier.Notify(rpcSub.ID, newRPCMessage(ev.Message))
+			case err := <-messagesSub.Err():
+				// The subscriber could not keep up with the indexed messages.
+				log.Debug("Closing warp messages subscription", "id", rpcSub.ID, "err", err)
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
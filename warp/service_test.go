@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/snow/validators/validatorstest"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/stretchr/testify/require"
+)
+
+// silentRequester forwards signature requests to the nodes with a signer and
+// never responds for the others.
+type silentRequester struct {
+	testRequester
+}
+
+func (r *silentRequester) AppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID], appRequestBytes []byte, onResponse p2p.AppResponseCallback) error {
+	signing := set.NewSet[ids.NodeID](nodeIDs.Len())
+	for nodeID := range nodeIDs {
+		if _, ok := r.signers[nodeID]; ok {
+			signing.Add(nodeID)
+		}
+	}
+	return r.testRequester.AppRequest(ctx, signing, appRequestBytes, onResponse)
+}
+
+func TestStartAggregation(t *testing.T) {
+	require := require.New(t)
+
+	signer, err := localsigner.New()
+	require.NoError(err)
+	silentSigner, err := localsigner.New()
+	require.NoError(err)
+	var (
+		nodeID       = ids.GenerateTestNodeID()
+		silentNodeID = ids.GenerateTestNodeID()
+	)
+	validatorSet, err := validators.FlattenValidatorSet(map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID:       {NodeID: nodeID, PublicKey: signer.PublicKey(), Weight: 1},
+		silentNodeID: {NodeID: silentNodeID, PublicKey: silentSigner.PublicKey(), Weight: 1},
+	})
+	require.NoError(err)
+
+	chainCtx := &snow.Context{
+		NetworkID: 1,
+		ChainID:   ids.GenerateTestID(),
+		SubnetID:  ids.GenerateTestID(),
+		ValidatorState: &validatorstest.State{
+			GetCurrentHeightF: func(context.Context) (uint64, error) {
+				return 1, nil
+			},
+			GetWarpValidatorSetF: func(context.Context, uint64, ids.ID) (validators.WarpSet, error) {
+				return validatorSet, nil
+			},
+		},
+	}
+	requester := &silentRequester{testRequester{signers: map[ids.NodeID]bls.Signer{nodeID: signer}}}
+	api := NewAPI(chainCtx, nil, NewSignatureAggregator(requester))
+	defer api.jobs.Flush()
+
+	// Starting the same aggregation again returns the running job.
+	ctx := context.Background()
+	jobID, err := api.StartBlockAggregation(ctx, ids.GenerateTestID(), 100, "")
+	require.NoError(err)
+	job, ok := api.jobs.Get(jobID)
+	require.True(ok)
+	require.Eventually(func() bool {
+		return job.current().SignedWeight == 1
+	}, time.Second, 10*time.Millisecond)
+
+	blockID := ids.GenerateTestID()
+	otherJobID, err := api.StartBlockAggregation(ctx, blockID, 100, "")
+	require.NoError(err)
+	sameJobID, err := api.StartBlockAggregation(ctx, blockID, 100, "")
+	require.NoError(err)
+	require.Equal(otherJobID, sameJobID)
+
+	// Other aggregations are refused once too many jobs are running.
+	for i := 2; i < maxRunningAggregationJobs; i++ {
+		_, err := api.StartBlockAggregation(ctx, ids.GenerateTestID(), 100, "")
+		require.NoError(err)
+	}
+	_, err = api.StartBlockAggregation(ctx, ids.GenerateTestID(), 100, "")
+	require.ErrorIs(err, errTooManyJobs)
+
+	// Dropping a job cancels it, reporting that it did not reach the quorum.
+	api.jobs.Evict(jobID)
+	require.Eventually(func() bool {
+		return job.current().Done
+	}, time.Second, 10*time.Millisecond)
+	status := job.current()
+	require.Contains(status.Error, errQuorumNotReached.Error())
+	require.Contains(status.Error, context.Canceled.Error())
+	require.Nil(status.SignedMessage)
+
+	// The cancelled job no longer counts against the running jobs.
+	require.Eventually(func() bool {
+		_, err := api.StartBlockAggregation(ctx, ids.GenerateTestID(), 100, "")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
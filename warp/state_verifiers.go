@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/libevm/stateconf"
+	"github.com/holiman/uint256"
+
+	"github.com/ava-labs/subnet-evm/warp/messages"
+)
+
+// StateReader reads the state after an accepted block.
+type StateReader interface {
+	GetBalance(common.Address) *uint256.Int
+	GetState(common.Address, common.Hash, ...stateconf.StateDBStateOption) common.Hash
+}
+
+// StateClient provides the accepted state attested by off-chain messages.
+type StateClient interface {
+	// AcceptedStateAt returns the state after the accepted block at height.
+	AcceptedStateAt(height uint64) (StateReader, error)
+}
+
+// RegisterStateVerifiers registers verifiers of the AccountBalance and
+// StorageSlot payloads in registry, signing them only if they match the
+// accepted state of client.
+func RegisterStateVerifiers(registry *messages.Registry, client StateClient) error {
+	return errors.Join(
+		messages.Register(registry, func(_ context.Context, msg *messages.AccountBalance) error {
+			state, err := client.AcceptedStateAt(msg.Height)
+			if err != nil {
+				return fmt.Errorf("failed to get state at height %d: %w", msg.Height, err)
+			}
+			balance := state.GetBalance(msg.Address)
+			if balance.Bytes32() != msg.Balance {
+				return fmt.Errorf("balance of %s at height %d is %s, not %s", msg.Address, msg.Height, balance, new(uint256.Int).SetBytes32(msg.Balance[:]))
+			}
+			return nil
+		}),
+		messages.Register(registry, func(_ context.Context, msg *messages.StorageSlot) error {
+			state, err := client.AcceptedStateAt(msg.Height)
+			if err != nil {
+				return fmt.Errorf("failed to get state at height %d: %w", msg.Height, err)
+			}
+			if value := state.GetState(msg.Address, msg.Slot); value != msg.Value {
+				return fmt.Errorf("storage slot %s of %s at height %d is %s, not %s", msg.Slot, msg.Address, msg.Height, value, msg.Value)
+			}
+			return nil
+		}),
+	)
+}
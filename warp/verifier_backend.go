@@ -5,6 +5,7 @@ package warp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -46,7 +47,7 @@ func (b *backend) Verify(ctx context.Context, unsignedMessage *avalancheWarp.Uns
 
 	switch p := parsed.(type) {
 	case *payload.AddressedCall:
-		return b.verifyOffchainAddressedCall(p)
+		return b.verifyOffchainAddressedCall(ctx, p)
 	case *payload.Hash:
 		return b.verifyBlockMessage(ctx, p)
 	default:
@@ -75,7 +76,7 @@ func (b *backend) verifyBlockMessage(ctx context.Context, blockHashPayload *payl
 }
 
 // verifyOffchainAddressedCall verifies the addressed call message
-func (b *backend) verifyOffchainAddressedCall(addressedCall *payload.AddressedCall) *common.AppError {
+func (b *backend) verifyOffchainAddressedCall(ctx context.Context, addressedCall *payload.AddressedCall) *common.AppError {
 	// Further, parse the payload to see if it is a known type.
 	parsed, err := messages.Parse(addressedCall.Payload)
 	if err != nil {
@@ -93,39 +94,38 @@ func (b *backend) verifyOffchainAddressedCall(addressedCall *payload.AddressedCa
 		}
 	}
 
-	switch p := parsed.(type) {
-	case *messages.ValidatorUptime:
-		if err := b.verifyUptimeMessage(p); err != nil {
+	if err := b.verifiers.Verify(ctx, parsed); err != nil {
+		if errors.Is(err, messages.ErrUnknownPayload) {
+			b.stats.IncMessageParseFail()
+			return &common.AppError{
+				Code:    ParseErrCode,
+				Message: err.Error(),
+			}
+		}
+		if _, ok := parsed.(*messages.ValidatorUptime); ok {
 			b.stats.IncUptimeValidationFail()
-			return err
+		} else {
+			b.stats.IncAddressedCallValidationFail()
 		}
-	default:
-		b.stats.IncMessageParseFail()
 		return &common.AppError{
-			Code:    ParseErrCode,
-			Message: fmt.Sprintf("unknown message type: %T", p),
+			Code:    VerifyErrCode,
+			Message: err.Error(),
 		}
 	}
 
 	return nil
 }
 
-func (b *backend) verifyUptimeMessage(uptimeMsg *messages.ValidatorUptime) *common.AppError {
+func (b *backend) verifyUptimeMessage(_ context.Context, uptimeMsg *messages.ValidatorUptime) error {
 	currentUptime, _, err := b.uptimeTracker.GetUptime(uptimeMsg.ValidationID)
 	if err != nil {
-		return &common.AppError{
-			Code:    VerifyErrCode,
-			Message: "failed to get uptime: " + err.Error(),
-		}
+		return fmt.Errorf("failed to get uptime: %w", err)
 	}
 
 	currentUptimeSeconds := uint64(currentUptime.Seconds())
 	// verify the current uptime against the total uptime in the message
 	if currentUptimeSeconds < uptimeMsg.TotalUptime {
-		return &common.AppError{
-			Code:    VerifyErrCode,
-			Message: fmt.Sprintf("current uptime %d is less than queried uptime %d for validationID %s", currentUptimeSeconds, uptimeMsg.TotalUptime, uptimeMsg.ValidationID),
-		}
+		return fmt.Errorf("current uptime %d is less than queried uptime %d for validationID %s", currentUptimeSeconds, uptimeMsg.TotalUptime, uptimeMsg.ValidationID)
 	}
 
 	return nil
@@ -22,6 +22,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/evm/metrics/metricstest"
 	"github.com/ava-labs/avalanchego/vms/evm/uptimetracker"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/libevm/libevm/stateconf"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/ava-labs/subnet-evm/warp/warptest"
 
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	ethcommon "github.com/ava-labs/libevm/common"
 )
 
 func TestAddressedCallSignatures(t *testing.T) {
@@ -115,6 +118,7 @@ func TestAddressedCallSignatures(t *testing.T) {
 					database,
 					sigCache,
 					[][]byte{offchainMessage.Bytes()},
+					nil,
 				)
 				require.NoError(t, err)
 				handler := acp118.NewCachedHandler(sigCache, warpBackend, snowCtx.WarpSigner)
@@ -221,6 +225,7 @@ func TestBlockSignatures(t *testing.T) {
 					database,
 					sigCache,
 					nil,
+					nil,
 				)
 				require.NoError(t, err)
 				handler := acp118.NewCachedHandler(sigCache, warpBackend, snowCtx.WarpSigner)
@@ -322,6 +327,7 @@ func TestUptimeSignatures(t *testing.T) {
 			database,
 			sigCache,
 			nil,
+			nil,
 		)
 		require.NoError(t, err)
 		handler := acp118.NewCachedHandler(sigCache, warpBackend, snowCtx.WarpSigner)
@@ -365,3 +371,129 @@ func TestUptimeSignatures(t *testing.T) {
 		require.Equal(t, expectedSignature, response.Signature)
 	}
 }
+
+type testStateClient struct {
+	height   uint64
+	balances map[ethcommon.Address]*uint256.Int
+	slots    map[ethcommon.Address]map[ethcommon.Hash]ethcommon.Hash
+}
+
+func (c *testStateClient) AcceptedStateAt(height uint64) (StateReader, error) {
+	if height > c.height {
+		return nil, fmt.Errorf("height %d is above last accepted height %d", height, c.height)
+	}
+	return c, nil
+}
+
+func (c *testStateClient) GetBalance(addr ethcommon.Address) *uint256.Int {
+	if balance, ok := c.balances[addr]; ok {
+		return balance
+	}
+	return new(uint256.Int)
+}
+
+func (c *testStateClient) GetState(addr ethcommon.Address, slot ethcommon.Hash, _ ...stateconf.StateDBStateOption) ethcommon.Hash {
+	return c.slots[addr][slot]
+}
+
+func TestStateAttestationSignatures(t *testing.T) {
+	snowCtx := utilstest.NewTestSnowContext(t)
+
+	var (
+		account  = ethcommon.Address{1}
+		contract = ethcommon.Address{2}
+		slot     = ethcommon.Hash{3}
+		value    = ethcommon.Hash{4}
+	)
+	client := &testStateClient{
+		height:   10,
+		balances: map[ethcommon.Address]*uint256.Int{account: uint256.NewInt(100)},
+		slots:    map[ethcommon.Address]map[ethcommon.Hash]ethcommon.Hash{contract: {slot: value}},
+	}
+	newPayload := func(p messages.Payload, err error) messages.Payload {
+		require.NoError(t, err)
+		return p
+	}
+
+	tests := []struct {
+		name       string
+		payload    messages.Payload
+		attestable bool
+		err        *common.AppError
+	}{
+		{
+			name:       "balance",
+			payload:    newPayload(messages.NewAccountBalance(account, uint256.NewInt(100), 10)),
+			attestable: true,
+		},
+		{
+			name:       "balance mismatch",
+			payload:    newPayload(messages.NewAccountBalance(account, uint256.NewInt(99), 10)),
+			attestable: true,
+			err:        &common.AppError{Code: VerifyErrCode},
+		},
+		{
+			name:       "balance above last accepted",
+			payload:    newPayload(messages.NewAccountBalance(account, uint256.NewInt(100), 11)),
+			attestable: true,
+			err:        &common.AppError{Code: VerifyErrCode},
+		},
+		{
+			name:       "storage slot",
+			payload:    newPayload(messages.NewStorageSlot(contract, slot, value, 10)),
+			attestable: true,
+		},
+		{
+			name:       "storage slot mismatch",
+			payload:    newPayload(messages.NewStorageSlot(contract, slot, ethcommon.Hash{}, 10)),
+			attestable: true,
+			err:        &common.AppError{Code: VerifyErrCode},
+		},
+		{
+			name:    "attestations disabled",
+			payload: newPayload(messages.NewStorageSlot(contract, slot, value, 10)),
+			err:     &common.AppError{Code: ParseErrCode},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verifiers := messages.NewRegistry()
+			if test.attestable {
+				require.NoError(t, RegisterStateVerifiers(verifiers, client))
+			}
+			sigCache := &cache.Empty[ids.ID, []byte]{}
+			warpBackend, err := NewBackend(
+				snowCtx.NetworkID,
+				snowCtx.ChainID,
+				snowCtx.WarpSigner,
+				warptest.EmptyBlockClient,
+				nil,
+				memdb.New(),
+				sigCache,
+				nil,
+				verifiers,
+			)
+			require.NoError(t, err)
+			handler := acp118.NewCachedHandler(sigCache, warpBackend, snowCtx.WarpSigner)
+
+			addressedCall, err := payload.NewAddressedCall(nil, test.payload.Bytes())
+			require.NoError(t, err)
+			unsignedMessage, err := avalancheWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, addressedCall.Bytes())
+			require.NoError(t, err)
+			protoBytes, err := proto.Marshal(&sdk.SignatureRequest{Message: unsignedMessage.Bytes()})
+			require.NoError(t, err)
+
+			responseBytes, appErr := handler.AppRequest(t.Context(), ids.GenerateTestNodeID(), time.Time{}, protoBytes)
+			if test.err != nil {
+				require.ErrorIs(t, appErr, test.err)
+				return
+			}
+			require.Nil(t, appErr)
+			expectedSignature, err := snowCtx.WarpSigner.Sign(unsignedMessage)
+			require.NoError(t, err)
+			response := &sdk.SignatureResponse{}
+			require.NoError(t, proto.Unmarshal(responseBytes, response))
+			require.Equal(t, expectedSignature, response.Signature)
+		})
+	}
+}